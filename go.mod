@@ -20,6 +20,7 @@ require (
 	github.com/lib/pq v1.10.9
 	github.com/mailgun/mailgun-go/v5 v5.8.1
 	github.com/robfig/cron/v3 v3.0.1
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/testcontainers/testcontainers-go/modules/compose v0.40.0
 	google.golang.org/api v0.231.0
 )
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/supanova-rp/supanova-server/internal/config"
 	"github.com/supanova-rp/supanova-server/internal/handlers"
@@ -11,15 +12,30 @@ import (
 	"github.com/supanova-rp/supanova-server/internal/store"
 )
 
+// How long shutdown waits for user imports running in the background to finish
+const importShutdownTimeout = 30 * time.Second
+
 type Dependencies struct {
-	Store         *store.Store
-	ObjectStorage handlers.ObjectStorage
-	EmailService  handlers.EmailService
-	AuthProvider  auth.AuthProvider
+	Store               *store.Store
+	ObjectStorage       handlers.ObjectStorage
+	EmailService        handlers.EmailService
+	AuthProvider        auth.AuthProvider
+	CertificateRenderer handlers.CertificateRenderer
 }
 
 func Run(ctx context.Context, cfg *config.App, deps Dependencies) (err error) {
 	h := handlers.NewHandlers(
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
+		deps.Store,
 		deps.Store,
 		deps.Store,
 		deps.Store,
@@ -30,6 +46,7 @@ func Run(ctx context.Context, cfg *config.App, deps Dependencies) (err error) {
 		deps.ObjectStorage,
 		deps.EmailService,
 		deps.AuthProvider,
+		deps.CertificateRenderer,
 	)
 
 	svr := server.New(h, deps.AuthProvider, cfg)
@@ -47,12 +64,18 @@ func Run(ctx context.Context, cfg *config.App, deps Dependencies) (err error) {
 		err = svrErr
 	}
 
-	deps.EmailService.StopRetry(ctx)
+	deps.EmailService.StopDispatcher(ctx)
 
 	shutdownErr := svr.Stop()
 	if shutdownErr != nil {
 		slog.Error("server shutdown error", slog.Any("error", shutdownErr))
 	}
 
+	importsCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), importShutdownTimeout)
+	defer cancel()
+	if !h.WaitForImports(importsCtx) {
+		slog.Error("user imports still running at shutdown")
+	}
+
 	return err
 }
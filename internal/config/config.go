@@ -6,7 +6,9 @@ import (
 	"log/slog"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,21 +16,26 @@ import (
 type Role string
 
 const (
-	AdminRole  Role = "admin"
-	UserRole   Role = "user"
-	APIVersion      = "v2"
+	AdminRole      Role = "admin"
+	ManagerRole    Role = "manager"
+	InstructorRole Role = "instructor"
+	AuditorRole    Role = "auditor"
+	UserRole       Role = "user"
+	APIVersion          = "v2"
 )
 
 type App struct {
-	Port                    string
-	DatabaseURL             string
-	LogLevel                slog.Level
-	Environment             Environment
-	AWS                     *AWS
-	AuthProviderCredentials string
-	ClientURLs              []string
-	EmailService            *EmailService
-	Metrics                 *Metrics
+	Port         string
+	DatabaseURL  string
+	LogLevel     slog.Level
+	Environment  Environment
+	AWS          *AWS
+	Auth         *Auth
+	ClientURLs   []string
+	EmailService *EmailService
+	Reminders    *Reminders
+	Certificates *Certificates
+	Metrics      *Metrics
 }
 
 type Environment string
@@ -45,6 +52,45 @@ var validEnvironments = []Environment{
 	EnvironmentTest,
 }
 
+type AuthProviderName string
+
+const (
+	FirebaseAuthProvider AuthProviderName = "firebase"
+	// LocalAuthProvider issues and verifies its own tokens, for running without Firebase in
+	// development and testing
+	LocalAuthProvider AuthProviderName = "local"
+)
+
+// minSigningKeyLength is the shortest signing key accepted for local auth tokens, in bytes
+const minSigningKeyLength = 32
+
+const (
+	// defaultTokenCacheSize is used when TOKEN_CACHE_SIZE isn't set
+	defaultTokenCacheSize = 10000
+	// defaultRevocationCheckInterval is used when TOKEN_REVOCATION_CHECK_INTERVAL isn't set
+	defaultRevocationCheckInterval = 5 * time.Minute
+)
+
+// Default cron schedules, used when their environment variables aren't set
+const (
+	defaultEmailDispatchCronSchedule         = "@every 10s"
+	defaultOverdueEnrolmentCronSchedule      = "0 7 * * *"
+	defaultManagerDigestCronSchedule         = "0 8 * * 1"
+	defaultEnrolmentNotificationCronSchedule = "*/15 * * * *"
+	defaultLearnerReminderCronSchedule       = "0 9 * * *"
+)
+
+type Auth struct {
+	Provider            AuthProviderName
+	FirebaseCredentials string
+	LocalSigningKey     string
+	// TokenCacheSize is the most verified Firebase tokens kept in memory
+	TokenCacheSize int
+	// RevocationCheckInterval is how long a cached token is trusted before it's verified with
+	// Firebase again, to pick up revoked tokens and disabled users
+	RevocationCheckInterval time.Duration
+}
+
 type AWS struct {
 	Region       string
 	AccessKey    string
@@ -55,13 +101,66 @@ type AWS struct {
 	CDNKeyName   string
 }
 
+type EmailProviderName string
+
+const (
+	MailgunEmailProvider EmailProviderName = "mailgun"
+	// SMTPEmailProvider sends through any SMTP server, e.g. a local SMTP catcher in development
+	SMTPEmailProvider EmailProviderName = "smtp"
+)
+
 type EmailService struct {
-	SendingKey                   string
-	Domain                       string
-	Sender                       string
-	Recipient                    string
-	CourseCompletionTemplateName string
-	CronSchedule                 string
+	Provider  EmailProviderName
+	Sender    string
+	Recipient string
+	// TemplatesDir has templates replacing the built-in ones with the same file name, if it's set
+	TemplatesDir string
+	// Template names are optional, Mailgun hosted templates used instead of the built-in ones
+	CourseCompletionTemplateName       string
+	OverdueEnrolmentTemplateName       string
+	InvitationTemplateName             string
+	ManagerDigestTemplateName          string
+	ProgressResetTemplateName          string
+	EnrolmentTemplateName              string
+	NotStartedReminderTemplateName     string
+	CompletionConfirmationTemplateName string
+	CertificateExpiryTemplateName      string
+	// DispatchCronSchedule is how often queued emails are sent, e.g. @every 10s
+	DispatchCronSchedule string
+	// Only the selected provider's settings are set
+	Mailgun *Mailgun
+	SMTP    *SMTP
+}
+
+type Mailgun struct {
+	SendingKey string
+	Domain     string
+	// WebhookSigningKey verifies delivery webhooks from Mailgun, they're rejected if it isn't set
+	WebhookSigningKey string
+}
+
+type SMTP struct {
+	Host string
+	Port int
+	// Username and Password are optional, local SMTP catchers don't need them
+	Username string
+	Password string
+}
+
+type Reminders struct {
+	OverdueCronSchedule       string
+	ManagerDigestCronSchedule string
+	// EnrolmentCronSchedule emails learners about new enrolments, so should run often
+	EnrolmentCronSchedule string
+	// LearnerReminderCronSchedule reminds learners about courses they haven't started and
+	// certificates that are about to expire
+	LearnerReminderCronSchedule string
+}
+
+type Certificates struct {
+	// VerifyURL is the client page certificates are verified on, a certificate's verification ID
+	// is appended to it for the QR code printed on the certificate. There's no QR code if it's unset.
+	VerifyURL string
 }
 
 var logLevelMap = map[string]slog.Level{
@@ -81,26 +180,19 @@ func ParseEnv() (*App, error) {
 	_ = godotenv.Load()
 
 	envVars := map[string]string{
-		"SERVER_PORT":                     "",
-		"DATABASE_URL":                    "",
-		"LOG_LEVEL":                       "",
-		"AWS_REGION":                      "",
-		"AWS_ACCESS_KEY_ID":               "",
-		"AWS_SECRET_ACCESS_KEY":           "",
-		"AWS_BUCKET_NAME":                 "",
-		"CLOUDFRONT_DOMAIN":               "",
-		"CLOUDFRONT_KEY_PAIR_ID":          "",
-		"CLOUDFRONT_KEY_NAME":             "",
-		"ENVIRONMENT":                     "",
-		"FIREBASE_CREDENTIALS":            "",
-		"CLIENT_URLS":                     "",
-		"MAILGUN_SENDING_KEY":             "",
-		"MAILGUN_DOMAIN":                  "",
-		"MAILGUN_SENDER":                  "",
-		"MAILGUN_RECIPIENT":               "",
-		"COURSE_COMPLETION_TEMPLATE_NAME": "",
-		"EMAIL_FAILURE_CRON_SCHEDULE":     "",
-		"METRICS_PORT":                    "",
+		"SERVER_PORT":            "",
+		"DATABASE_URL":           "",
+		"LOG_LEVEL":              "",
+		"AWS_REGION":             "",
+		"AWS_ACCESS_KEY_ID":      "",
+		"AWS_SECRET_ACCESS_KEY":  "",
+		"AWS_BUCKET_NAME":        "",
+		"CLOUDFRONT_DOMAIN":      "",
+		"CLOUDFRONT_KEY_PAIR_ID": "",
+		"CLOUDFRONT_KEY_NAME":    "",
+		"ENVIRONMENT":            "",
+		"CLIENT_URLS":            "",
+		"METRICS_PORT":           "",
 	}
 
 	for key := range envVars {
@@ -121,6 +213,32 @@ func ParseEnv() (*App, error) {
 		return nil, errors.New("ENVIRONMENT should be one of development|production|test")
 	}
 
+	// Deployments from before there was a choice of auth provider only set FIREBASE_CREDENTIALS
+	authProvider := AuthProviderName(os.Getenv("AUTH_PROVIDER"))
+	if authProvider == "" {
+		authProvider = FirebaseAuthProvider
+	}
+
+	authCfg, err := parseAuth(environment, authProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deployments from before there was a choice of email provider use Mailgun
+	emailProvider := EmailProviderName(getEnv("EMAIL_PROVIDER", "", string(MailgunEmailProvider)))
+	emailCfg, err := parseEmailProvider(emailProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := getEnv("EMAIL_SENDER", "MAILGUN_SENDER", "")
+	recipient := getEnv("EMAIL_RECIPIENT", "MAILGUN_RECIPIENT", "")
+	if sender == "" || recipient == "" {
+		return nil, errors.New("EMAIL_SENDER and EMAIL_RECIPIENT environment variables are not set")
+	}
+
+	dispatchSchedule := getEnv("EMAIL_DISPATCH_CRON_SCHEDULE", "EMAIL_FAILURE_CRON_SCHEDULE", defaultEmailDispatchCronSchedule)
+
 	clientURLsRaw := strings.Split(envVars["CLIENT_URLS"], ",")
 	clientURLs := make([]string, 0, len(clientURLsRaw))
 	for _, url := range clientURLsRaw {
@@ -143,19 +261,148 @@ func ParseEnv() (*App, error) {
 			CDNKeyPairID: envVars["CLOUDFRONT_KEY_PAIR_ID"],
 			CDNKeyName:   envVars["CLOUDFRONT_KEY_NAME"],
 		},
-		Environment:             environment,
-		AuthProviderCredentials: envVars["FIREBASE_CREDENTIALS"],
-		ClientURLs:              clientURLs,
+		Environment: environment,
+		Auth:        authCfg,
+		ClientURLs:  clientURLs,
 		EmailService: &EmailService{
-			SendingKey:                   envVars["MAILGUN_SENDING_KEY"],
-			Domain:                       envVars["MAILGUN_DOMAIN"],
-			CourseCompletionTemplateName: envVars["COURSE_COMPLETION_TEMPLATE_NAME"],
-			CronSchedule:                 envVars["EMAIL_FAILURE_CRON_SCHEDULE"],
-			Sender:                       envVars["MAILGUN_SENDER"],
-			Recipient:                    envVars["MAILGUN_RECIPIENT"],
+			Provider:                           emailCfg.Provider,
+			Mailgun:                            emailCfg.Mailgun,
+			SMTP:                               emailCfg.SMTP,
+			TemplatesDir:                       os.Getenv("EMAIL_TEMPLATES_DIR"),
+			CourseCompletionTemplateName:       os.Getenv("COURSE_COMPLETION_TEMPLATE_NAME"),
+			OverdueEnrolmentTemplateName:       os.Getenv("OVERDUE_ENROLMENT_TEMPLATE_NAME"),
+			InvitationTemplateName:             os.Getenv("INVITATION_TEMPLATE_NAME"),
+			ManagerDigestTemplateName:          os.Getenv("MANAGER_DIGEST_TEMPLATE_NAME"),
+			ProgressResetTemplateName:          os.Getenv("PROGRESS_RESET_TEMPLATE_NAME"),
+			EnrolmentTemplateName:              os.Getenv("ENROLMENT_TEMPLATE_NAME"),
+			NotStartedReminderTemplateName:     os.Getenv("NOT_STARTED_REMINDER_TEMPLATE_NAME"),
+			CompletionConfirmationTemplateName: os.Getenv("COMPLETION_CONFIRMATION_TEMPLATE_NAME"),
+			CertificateExpiryTemplateName:      os.Getenv("CERTIFICATE_EXPIRY_TEMPLATE_NAME"),
+			DispatchCronSchedule:               dispatchSchedule,
+			Sender:                             sender,
+			Recipient:                          recipient,
+		},
+		Reminders: &Reminders{
+			OverdueCronSchedule:         getEnv("OVERDUE_ENROLMENT_CRON_SCHEDULE", "", defaultOverdueEnrolmentCronSchedule),
+			ManagerDigestCronSchedule:   getEnv("MANAGER_DIGEST_CRON_SCHEDULE", "", defaultManagerDigestCronSchedule),
+			EnrolmentCronSchedule:       getEnv("ENROLMENT_NOTIFICATION_CRON_SCHEDULE", "", defaultEnrolmentNotificationCronSchedule),
+			LearnerReminderCronSchedule: getEnv("LEARNER_REMINDER_CRON_SCHEDULE", "", defaultLearnerReminderCronSchedule),
+		},
+		Certificates: &Certificates{
+			VerifyURL: os.Getenv("CERTIFICATE_VERIFY_URL"),
 		},
 		Metrics: &Metrics{
 			Port: envVars["METRICS_PORT"],
 		},
 	}, nil
 }
+
+// getEnv returns the environment variable, or the variable it was renamed from if it isn't set,
+// or defaultValue if neither is set
+func getEnv(key, oldKey, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	if oldKey != "" {
+		if value := os.Getenv(oldKey); value != "" {
+			return value
+		}
+	}
+
+	return defaultValue
+}
+
+// parseAuth reads the settings of the selected auth provider, which are only required when that
+// provider is used. The local provider can't be used in production.
+func parseAuth(environment Environment, provider AuthProviderName) (*Auth, error) {
+	switch provider {
+	case FirebaseAuthProvider:
+		credentials := os.Getenv("FIREBASE_CREDENTIALS")
+		if credentials == "" {
+			return nil, errors.New("FIREBASE_CREDENTIALS environment variable is not set")
+		}
+
+		cacheSize := defaultTokenCacheSize
+		if value := os.Getenv("TOKEN_CACHE_SIZE"); value != "" {
+			size, err := strconv.Atoi(value)
+			if err != nil || size <= 0 {
+				return nil, errors.New("TOKEN_CACHE_SIZE should be a positive number")
+			}
+			cacheSize = size
+		}
+
+		checkInterval := defaultRevocationCheckInterval
+		if value := os.Getenv("TOKEN_REVOCATION_CHECK_INTERVAL"); value != "" {
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval <= 0 {
+				return nil, errors.New("TOKEN_REVOCATION_CHECK_INTERVAL should be a positive duration, e.g. 5m")
+			}
+			checkInterval = interval
+		}
+
+		return &Auth{
+			Provider:                provider,
+			FirebaseCredentials:     credentials,
+			TokenCacheSize:          cacheSize,
+			RevocationCheckInterval: checkInterval,
+		}, nil
+	case LocalAuthProvider:
+		if environment == EnvironmentProduction {
+			return nil, errors.New("AUTH_PROVIDER local can't be used in production")
+		}
+
+		signingKey := os.Getenv("LOCAL_AUTH_SIGNING_KEY")
+		if len(signingKey) < minSigningKeyLength {
+			return nil, fmt.Errorf("LOCAL_AUTH_SIGNING_KEY should be at least %d characters", minSigningKeyLength)
+		}
+
+		return &Auth{Provider: provider, LocalSigningKey: signingKey}, nil
+	default:
+		return nil, errors.New("AUTH_PROVIDER should be one of firebase|local")
+	}
+}
+
+// parseEmailProvider reads the settings of the selected email provider, which are only required
+// when that provider is used
+func parseEmailProvider(provider EmailProviderName) (*EmailService, error) {
+	switch provider {
+	case MailgunEmailProvider:
+		sendingKey := os.Getenv("MAILGUN_SENDING_KEY")
+		domain := os.Getenv("MAILGUN_DOMAIN")
+		if sendingKey == "" || domain == "" {
+			return nil, errors.New("MAILGUN_SENDING_KEY and MAILGUN_DOMAIN environment variables are required for mailgun")
+		}
+
+		return &EmailService{
+			Provider: provider,
+			Mailgun: &Mailgun{
+				SendingKey:        sendingKey,
+				Domain:            domain,
+				WebhookSigningKey: os.Getenv("MAILGUN_WEBHOOK_SIGNING_KEY"),
+			},
+		}, nil
+	case SMTPEmailProvider:
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			return nil, errors.New("SMTP_HOST environment variable is not set")
+		}
+
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil || port <= 0 {
+			return nil, errors.New("SMTP_PORT should be a port number")
+		}
+
+		return &EmailService{
+			Provider: provider,
+			SMTP: &SMTP{
+				Host:     host,
+				Port:     port,
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+			},
+		}, nil
+	default:
+		return nil, errors.New("EMAIL_PROVIDER should be one of mailgun|smtp")
+	}
+}
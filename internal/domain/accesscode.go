@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate moq -out ../handlers/mocks/accesscode_mock.go -pkg mocks . AccessCodeRepository
+
+type AccessCodeRepository interface {
+	AddAccessCode(ctx context.Context, params AddAccessCodeParams) (*AccessCode, error)
+	GetAccessCodes(ctx context.Context, courseID *uuid.UUID) ([]AccessCode, error)
+	RevokeAccessCode(ctx context.Context, id uuid.UUID) error
+	GetAccessCodeRedemptions(ctx context.Context, id uuid.UUID) ([]AccessCodeRedemption, error)
+	RedeemAccessCode(ctx context.Context, params RedeemAccessCodeParams) (*RedeemAccessCodeResult, error)
+}
+
+var (
+	ErrAccessCodeExpired = errors.New("access code has expired")
+	ErrAccessCodeRevoked = errors.New("access code has been revoked")
+	ErrAccessCodeUsedUp  = errors.New("access code has reached its maximum number of uses")
+)
+
+type AccessCode struct {
+	ID        uuid.UUID  `json:"id"`
+	Code      string     `json:"code"`
+	CourseID  uuid.UUID  `json:"courseId"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	MaxUses   *int       `json:"maxUses"`
+	Uses      int        `json:"uses"`
+	CreatedBy string     `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+}
+
+// Validate returns why the code can't be redeemed at the given time, or nil if it can
+func (c *AccessCode) Validate(now time.Time) error {
+	switch {
+	case c.RevokedAt != nil:
+		return ErrAccessCodeRevoked
+	case c.ExpiresAt != nil && !now.Before(*c.ExpiresAt):
+		return ErrAccessCodeExpired
+	case c.MaxUses != nil && c.Uses >= *c.MaxUses:
+		return ErrAccessCodeUsedUp
+	default:
+		return nil
+	}
+}
+
+type AddAccessCodeParams struct {
+	Code      string
+	CourseID  uuid.UUID
+	ExpiresAt *time.Time
+	MaxUses   *int
+	CreatedBy string
+}
+
+type AccessCodeRedemption struct {
+	UserID     string    `json:"userId"`
+	UserName   string    `json:"userName"`
+	UserEmail  string    `json:"userEmail"`
+	RedeemedAt time.Time `json:"redeemedAt"`
+}
+
+type RedeemAccessCodeParams struct {
+	Code   string
+	UserID string
+}
+
+// RedeemAccessCodeResult is returned for every successful redemption. Redeeming the same code
+// twice doesn't count as another use, AlreadyRedeemed is set instead.
+type RedeemAccessCodeResult struct {
+	CourseID        uuid.UUID `json:"courseId"`
+	AlreadyRedeemed bool      `json:"alreadyRedeemed"`
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate moq -out ../handlers/mocks/apikey_mock.go -pkg mocks . APIKeyRepository
+
+type APIKeyRepository interface {
+	AddAPIKey(ctx context.Context, params AddAPIKeyParams) (*APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	SetAPIKeyLastUsed(ctx context.Context, id uuid.UUID) error
+}
+
+// APIKeyPrefix starts every API key, so the auth middleware can tell keys apart from user tokens
+const APIKeyPrefix = "snk_"
+
+var (
+	ErrAPIKeyExpired = errors.New("API key has expired")
+	ErrAPIKeyRevoked = errors.New("API key has been revoked")
+)
+
+// APIKey lets another system call the API with the scopes the key was created with. Prefix is the
+// start of the key, so admins can tell keys apart without the key being stored.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
+
+// Validate returns why the key can't be used at the given time, or nil if it can
+func (k *APIKey) Validate(now time.Time) error {
+	switch {
+	case k.RevokedAt != nil:
+		return ErrAPIKeyRevoked
+	case k.ExpiresAt != nil && !now.Before(*k.ExpiresAt):
+		return ErrAPIKeyExpired
+	default:
+		return nil
+	}
+}
+
+type AddAPIKeyParams struct {
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	CreatedBy string
+	ExpiresAt *time.Time
+}
+
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// HashAPIKey returns the hash keys are stored and looked up by. Keys are long and random so they
+// don't need a slow password hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
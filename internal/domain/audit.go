@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+//go:generate moq -out ../handlers/mocks/audit_mock.go -pkg mocks . AuditRepository
+
+type AuditRepository interface {
+	AddAuditEntry(ctx context.Context, params AddAuditEntryParams) error
+	// WithAuditEntry makes a change and adds its audit entry in one transaction, so the change is
+	// rolled back if it can't be audited. Repository calls change makes with the context it's given
+	// join the transaction. It returns the entry, or nil if nothing changed.
+	WithAuditEntry(ctx context.Context, change func(ctx context.Context) (*AddAuditEntryParams, error)) error
+	ListAuditEntries(ctx context.Context, params ListAuditEntriesParams) (*Page[AuditEntry], error)
+	VerifyAuditLog(ctx context.Context) (*AuditVerification, error)
+}
+
+type AuditAction string
+
+const (
+	AuditActionAddCourse              AuditAction = "course.add"
+	AuditActionEditCourse             AuditAction = "course.edit"
+	AuditActionDeleteCourse           AuditAction = "course.delete"
+	AuditActionEnrol                  AuditAction = "enrolment.add"
+	AuditActionDisenrol               AuditAction = "enrolment.remove"
+	AuditActionSetEnrolmentDueDate    AuditAction = "enrolment.due_date"
+	AuditActionResetProgress          AuditAction = "progress.reset"
+	AuditActionResetQuizProgress      AuditAction = "quiz_progress.reset"
+	AuditActionRegisterUser           AuditAction = "user.register"
+	AuditActionRevokeCertificate      AuditAction = "certificate.revoke"
+	AuditActionSetCertificateValidity AuditAction = "course.certificate_validity"
+	AuditActionRetryEmail             AuditAction = "email.retry"
+	AuditActionDiscardEmail           AuditAction = "email.discard"
+	AuditActionBulkUpdateEnrolments   AuditAction = "enrolment.bulk_update"
+	AuditActionImportUser             AuditAction = "user.import"
+	AuditActionUpdateUser             AuditAction = "user.update"
+	AuditActionDeactivateUser         AuditAction = "user.deactivate"
+	AuditActionReactivateUser         AuditAction = "user.reactivate"
+	AuditActionDeleteUser             AuditAction = "user.delete"
+	AuditActionEraseUser              AuditAction = "user.erase"
+	AuditActionSetUserRole            AuditAction = "user.role"
+	AuditActionSetLineManager         AuditAction = "user.line_manager"
+	AuditActionAssignInstructors      AuditAction = "course.instructors_assign"
+	AuditActionUnassignInstructors    AuditAction = "course.instructors_unassign"
+	AuditActionAddAPIKey              AuditAction = "api_key.add"
+	AuditActionRevokeAPIKey           AuditAction = "api_key.revoke"
+	AuditActionAddGroup               AuditAction = "group.add"
+	AuditActionUpdateGroup            AuditAction = "group.update"
+	AuditActionDeleteGroup            AuditAction = "group.delete"
+	AuditActionAddGroupMembers        AuditAction = "group.members_add"
+	AuditActionRemoveGroupMembers     AuditAction = "group.members_remove"
+	AuditActionAddGroupManagers       AuditAction = "group.managers_add"
+	AuditActionRemoveGroupManagers    AuditAction = "group.managers_remove"
+	AuditActionAssignGroupCourse      AuditAction = "group.course_assign"
+	AuditActionUnassignGroupCourse    AuditAction = "group.course_unassign"
+	AuditActionAddAccessCode          AuditAction = "access_code.add"
+	AuditActionRevokeAccessCode       AuditAction = "access_code.revoke"
+)
+
+const (
+	AuditTargetCourse      = "course"
+	AuditTargetUser        = "user"
+	AuditTargetCertificate = "certificate"
+	AuditTargetEmail       = "email"
+	AuditTargetGroup       = "group"
+	AuditTargetAPIKey      = "api_key"
+	AuditTargetAccessCode  = "access_code"
+)
+
+// AuditEntry records an admin action, with the state of the target before and after it. Hash
+// covers the entry and PrevHash, the hash of the entry before it, so changing or removing an entry
+// is detected when the log is verified.
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	ActorID    string          `json:"actorId"`
+	Action     AuditAction     `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetId"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	PrevHash   string          `json:"prevHash"`
+	Hash       string          `json:"hash"`
+}
+
+type AddAuditEntryParams struct {
+	ActorID    string
+	Action     AuditAction
+	TargetType string
+	TargetID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+}
+
+// ListAuditEntriesParams filters the audit log, empty filters are ignored. Entries are sorted by
+// when they were added.
+type ListAuditEntriesParams struct {
+	PageParams
+	ActorID     string
+	Action      string
+	TargetType  string
+	TargetID    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// AuditVerification is the result of checking the audit log's hash chain. FirstInvalidID is the
+// first entry that was changed, or that follows a removed entry.
+type AuditVerification struct {
+	Valid          bool   `json:"valid"`
+	EntriesChecked int64  `json:"entriesChecked"`
+	FirstInvalidID *int64 `json:"firstInvalidId"`
+}
+
+// auditHashInput fixes the fields, and their order, that an entry's hash covers
+type auditHashInput struct {
+	PrevHash   string          `json:"prevHash"`
+	ActorID    string          `json:"actorId"`
+	Action     AuditAction     `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   string          `json:"targetId"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  string          `json:"createdAt"`
+}
+
+// AuditHash returns the hash of an entry chained from the entry before it. The first entry's
+// previous hash is empty. CreatedAt must already be truncated to what Postgres stores.
+func AuditHash(entry *AuditEntry) (string, error) {
+	b, err := json.Marshal(auditHashInput{
+		PrevHash:   entry.PrevHash,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     entry.Before,
+		After:      entry.After,
+		CreatedAt:  entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -2,6 +2,8 @@ package domain
 
 import "context"
 
+//go:generate moq -out ../handlers/mocks/auth_mock.go -pkg mocks . AuthRepository
+
 type AuthRepository interface {
 	RegisterUser(context.Context, RegisterParams) (*User, error)
 }
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate moq -out ../handlers/mocks/certificate_mock.go -pkg mocks . CertificateRepository
+
+type CertificateRepository interface {
+	AddCertificate(ctx context.Context, params AddCertificateParams) (*Certificate, error)
+	GetLatestCertificate(ctx context.Context, params GetLatestCertificateParams) (*Certificate, error)
+	GetCertificateByVerificationID(ctx context.Context, verificationID string) (*Certificate, error)
+	DeleteCertificate(ctx context.Context, id uuid.UUID) error
+	RevokeCertificate(ctx context.Context, params RevokeCertificateParams) error
+	SetCertificateValidity(ctx context.Context, params SetCertificateValidityParams) error
+	GetExpiringCertificates(ctx context.Context, expiresWithinDays int) ([]ExpiringCertificate, error)
+	SetCertificateExpiryNotified(ctx context.Context, id uuid.UUID, notification *OutboxEmail) error
+}
+
+type CertificateStatus string
+
+const (
+	CertificateStatusValid   CertificateStatus = "valid"
+	CertificateStatusExpired CertificateStatus = "expired"
+	CertificateStatusRevoked CertificateStatus = "revoked"
+)
+
+// Certificate is issued to a learner each time they complete a course, the PDF is kept in object
+// storage under StorageKey. Anyone with the VerificationID can check the certificate is genuine.
+type Certificate struct {
+	ID              uuid.UUID  `json:"id"`
+	Number          string     `json:"number"`
+	UserID          string     `json:"userId"`
+	UserName        string     `json:"userName"`
+	CourseID        uuid.UUID  `json:"courseId"`
+	CourseTitle     string     `json:"courseTitle"`
+	CompletionTitle string     `json:"completionTitle"`
+	StorageKey      string     `json:"-"`
+	VerificationID  string     `json:"verificationId"`
+	IssuedAt        time.Time  `json:"issuedAt"`
+	ExpiresAt       *time.Time `json:"expiresAt"`
+	RevokedAt       *time.Time `json:"revokedAt"`
+	RevokeReason    string     `json:"revokeReason,omitempty"`
+}
+
+// Status returns whether the certificate is valid at the given time
+func (c *Certificate) Status(now time.Time) CertificateStatus {
+	switch {
+	case c.RevokedAt != nil:
+		return CertificateStatusRevoked
+	case c.ExpiresAt != nil && !now.Before(*c.ExpiresAt):
+		return CertificateStatusExpired
+	default:
+		return CertificateStatusValid
+	}
+}
+
+// CertificateVerification is what's shown publicly to confirm a certificate, without the holder's
+// user ID or the reason it was revoked
+type CertificateVerification struct {
+	Number      string            `json:"number"`
+	HolderName  string            `json:"holderName"`
+	CourseTitle string            `json:"courseTitle"`
+	IssuedAt    time.Time         `json:"issuedAt"`
+	ExpiresAt   *time.Time        `json:"expiresAt"`
+	Revoked     bool              `json:"revoked"`
+	RevokedAt   *time.Time        `json:"revokedAt"`
+	Status      CertificateStatus `json:"status"`
+}
+
+func (c *Certificate) Verification(now time.Time) *CertificateVerification {
+	return &CertificateVerification{
+		Number:      c.Number,
+		HolderName:  c.UserName,
+		CourseTitle: c.CourseTitle,
+		IssuedAt:    c.IssuedAt,
+		ExpiresAt:   c.ExpiresAt,
+		Revoked:     c.RevokedAt != nil,
+		RevokedAt:   c.RevokedAt,
+		Status:      c.Status(now),
+	}
+}
+
+// ExpiringCertificate is a learner's current certificate for a course that expires soon
+type ExpiringCertificate struct {
+	ID          uuid.UUID
+	Number      string
+	UserID      string
+	UserName    string
+	UserEmail   string
+	CourseID    uuid.UUID
+	CourseTitle string
+	ExpiresAt   time.Time
+}
+
+type AddCertificateParams struct {
+	ID             uuid.UUID
+	UserID         string
+	CourseID       uuid.UUID
+	StorageKey     string
+	VerificationID string
+}
+
+type RevokeCertificateParams struct {
+	ID        uuid.UUID
+	Reason    string
+	RevokedBy string
+}
+
+// SetCertificateValidityParams set how many months a course's certificates are valid for, they
+// never expire if ValidityMonths is nil. Certificates already issued keep their expiry.
+type SetCertificateValidityParams struct {
+	CourseID       uuid.UUID
+	ValidityMonths *int
+}
+
+type GetLatestCertificateParams struct {
+	UserID   string
+	CourseID uuid.UUID
+}
+
+// CertificateNumber formats the sequential number of a certificate as it's printed on it
+func CertificateNumber(number int64) string {
+	return fmt.Sprintf("SN-%06d", number)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -14,8 +15,9 @@ import (
 type CourseRepository interface {
 	GetCourse(context.Context, pgtype.UUID) (*Course, error)
 	GetAllCourses(context.Context) ([]*AllCourseLegacy, error)
+	ListCourses(ctx context.Context, params PageParams) (*Page[*AllCourseLegacy], error)
 	GetCoursesOverview(context.Context) ([]CourseOverview, error)
-	GetAssignedCourseTitles(context.Context, string) ([]CourseOverview, error)
+	GetAssignedCourseTitles(context.Context, string) ([]AssignedCourseOverview, error)
 	AddCourse(context.Context, *AddCourseParams) (*Course, error)
 	EditCourse(context.Context, *EditCourseParams) (*Course, error)
 	DeleteCourse(context.Context, uuid.UUID) error
@@ -183,6 +185,12 @@ type CourseOverview struct {
 	Description string    `json:"description"`
 }
 
+type AssignedCourseOverview struct {
+	CourseOverview
+	DueDate *time.Time      `json:"dueDate"`
+	Status  EnrolmentStatus `json:"status"`
+}
+
 type CourseMaterial struct {
 	ID         uuid.UUID `json:"id"`
 	Name       string    `json:"name"`
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate moq -out ../handlers/mocks/failedemail_mock.go -pkg mocks . FailedEmailRepository
+
+type FailedEmailRepository interface {
+	ListFailedEmails(ctx context.Context, params ListFailedEmailsParams) (*Page[FailedEmail], error)
+	RetryFailedEmail(ctx context.Context, id uuid.UUID) error
+	DiscardFailedEmail(ctx context.Context, id uuid.UUID) error
+	GetEmailAttempts(ctx context.Context, id uuid.UUID) ([]EmailAttempt, error)
+}
+
+//go:generate moq -out ../handlers/mocks/emailevent_mock.go -pkg mocks . EmailEventRepository
+
+type EmailEventRepository interface {
+	RecordEmailEvent(ctx context.Context, event *EmailEvent) error
+}
+
+// OutboxEmail is an email queued in the email outbox, it's sent by the email dispatcher once the
+// transaction queueing it commits. TemplateParams are the email's params as JSON.
+type OutboxEmail struct {
+	EmailName      string
+	TemplateName   string
+	TemplateParams []byte
+}
+
+type EmailStatus string
+
+const (
+	EmailStatusPending EmailStatus = "pending"
+	EmailStatusDead    EmailStatus = "dead"
+)
+
+// FailedEmail is an email that failed to send. Pending emails are retried at NextAttemptAt, dead
+// emails failed too many times and are only sent again if an admin retries them.
+type FailedEmail struct {
+	ID             uuid.UUID       `json:"id"`
+	EmailName      string          `json:"emailName"`
+	TemplateName   string          `json:"templateName"`
+	TemplateParams json.RawMessage `json:"templateParams"`
+	Status         EmailStatus     `json:"status"`
+	Attempts       int             `json:"attempts"`
+	Error          string          `json:"error"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// EmailAttempt is an attempt to send an email, Error is empty if it was sent
+type EmailAttempt struct {
+	AttemptedAt time.Time `json:"attemptedAt"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// ListFailedEmailsParams filter failed emails by status, an empty status includes both. Search
+// matches the email name.
+type ListFailedEmailsParams struct {
+	PageParams
+	Status EmailStatus
+}
+
+type EmailEventType string
+
+const (
+	EmailEventDelivered EmailEventType = "delivered"
+	// EmailEventDeferred is a temporary failure, the provider tries to deliver the email again
+	EmailEventDeferred   EmailEventType = "deferred"
+	EmailEventBounced    EmailEventType = "bounced"
+	EmailEventComplained EmailEventType = "complained"
+)
+
+// EmailEvent is a delivery event for a sent email reported by the email provider. MessageID is the
+// ID the provider gave the message when it was sent.
+type EmailEvent struct {
+	ProviderEventID string
+	MessageID       string
+	Type            EmailEventType
+	Recipient       string
+	Reason          string
+	OccurredAt      time.Time
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+//go:generate moq -out ../handlers/mocks/emailpreference_mock.go -pkg mocks . EmailPreferenceRepository
+
+type EmailPreferenceRepository interface {
+	GetEmailOptOuts(ctx context.Context, userID string) ([]string, error)
+	SetEmailOptOut(ctx context.Context, params SetEmailOptOutParams) error
+}
+
+// EmailPreferences list the optional emails a learner can opt out of, and whether they have
+type EmailPreferences struct {
+	Emails []EmailPreference `json:"emails"`
+}
+
+type EmailPreference struct {
+	EmailName string `json:"emailName"`
+	OptedOut  bool   `json:"optedOut"`
+}
+
+type SetEmailOptOutParams struct {
+	UserID    string
+	EmailName string
+	OptOut    bool
+}
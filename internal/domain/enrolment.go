@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,16 +11,26 @@ import (
 
 type EnrolmentRepository interface {
 	GetUsersAndAssignedCourses(context.Context) ([]UserWithAssignedCourses, error)
+	ListUsersAndAssignedCourses(ctx context.Context, params PageParams) (*Page[UserWithAssignedCourses], error)
 	IsEnrolled(ctx context.Context, params IsEnrolledParams) (bool, error)
 	EnrolInCourse(ctx context.Context, params EnrolInCourseParams) error
 	DisenrolInCourse(ctx context.Context, params DisenrolInCourseParams) error
+	SetEnrolmentDueDate(ctx context.Context, params SetEnrolmentDueDateParams) (*EnrolmentDueDateChange, error)
+	GetOverdueEnrolments(context.Context) ([]OverdueEnrolment, error)
+	SetEnrolmentOverdue(ctx context.Context, enrolmentID uuid.UUID, notification *OutboxEmail) error
+	GetUnnotifiedEnrolments(context.Context) ([]EnrolmentNotice, error)
+	SetEnrolmentNotified(ctx context.Context, enrolmentID uuid.UUID, notification *OutboxEmail) error
+	GetNotStartedEnrolments(ctx context.Context, enrolledDays int) ([]EnrolmentNotice, error)
+	SetEnrolmentReminded(ctx context.Context, enrolmentID uuid.UUID, reminder *OutboxEmail) error
+	BulkUpdateEnrolments(ctx context.Context, params BulkUpdateEnrolmentsParams) ([]BulkEnrolmentResult, error)
 }
 
 type UserWithAssignedCourses struct {
-	ID        string      `json:"id"`
-	Name      string      `json:"name"`
-	Email     string      `json:"email"`
-	CourseIDs []uuid.UUID `json:"courseIds"`
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Email     string         `json:"email"`
+	CourseIDs []uuid.UUID    `json:"courseIds"`
+	Groups    []GroupSummary `json:"groups"`
 }
 
 type IsEnrolledParams struct {
@@ -27,12 +38,117 @@ type IsEnrolledParams struct {
 	CourseID uuid.UUID
 }
 
+// EnrolInCourseParams sets an optional due date on the enrolment, either absolute (DueAt)
+// or a number of days after the enrolment date (DueInDays)
 type EnrolInCourseParams struct {
-	UserID   string
-	CourseID uuid.UUID
+	UserID     string
+	CourseID   uuid.UUID
+	EnrolledBy string
+	DueAt      *time.Time
+	DueInDays  *int
 }
 
 type DisenrolInCourseParams struct {
 	UserID   string
 	CourseID uuid.UUID
 }
+
+// SetEnrolmentDueDateParams behaves like EnrolInCourseParams, with DueInDays relative to the
+// original enrolment date. Leaving both unset removes the due date.
+type SetEnrolmentDueDateParams struct {
+	UserID    string
+	CourseID  uuid.UUID
+	DueAt     *time.Time
+	DueInDays *int
+}
+
+// EnrolmentDueDateChange is an enrolment's due date before and after it was set, either of which
+// can be nil
+type EnrolmentDueDateChange struct {
+	PreviousDueDate *time.Time
+	DueDate         *time.Time
+}
+
+// MaxBulkEnrolmentItems limits the number of user/course pairs in a single bulk update
+const MaxBulkEnrolmentItems = 1000
+
+type BulkEnrolmentAction string
+
+const (
+	BulkEnrolmentActionEnrol    BulkEnrolmentAction = "enrol"
+	BulkEnrolmentActionDisenrol BulkEnrolmentAction = "disenrol"
+)
+
+// BulkUpdateEnrolmentsParams applies Action to every combination of UserIDs and CourseIDs.
+// The due date fields are only used when enrolling.
+type BulkUpdateEnrolmentsParams struct {
+	UserIDs    []string
+	CourseIDs  []uuid.UUID
+	Action     BulkEnrolmentAction
+	EnrolledBy string
+	DueAt      *time.Time
+	DueInDays  *int
+}
+
+type BulkEnrolmentResultStatus string
+
+const (
+	BulkEnrolmentResultEnrolled        BulkEnrolmentResultStatus = "enrolled"
+	BulkEnrolmentResultAlreadyEnrolled BulkEnrolmentResultStatus = "already_enrolled"
+	BulkEnrolmentResultDisenrolled     BulkEnrolmentResultStatus = "disenrolled"
+	BulkEnrolmentResultNotEnrolled     BulkEnrolmentResultStatus = "not_enrolled"
+	BulkEnrolmentResultUserNotFound    BulkEnrolmentResultStatus = "user_not_found"
+	BulkEnrolmentResultCourseNotFound  BulkEnrolmentResultStatus = "course_not_found"
+)
+
+type BulkEnrolmentResult struct {
+	UserID   string                    `json:"userId"`
+	CourseID uuid.UUID                 `json:"courseId"`
+	Status   BulkEnrolmentResultStatus `json:"status"`
+}
+
+type OverdueEnrolment struct {
+	ID          uuid.UUID
+	UserID      string
+	UserName    string
+	UserEmail   string
+	CourseID    uuid.UUID
+	CourseTitle string
+	DueAt       time.Time
+}
+
+// EnrolmentNotice is an enrolment the learner is emailed about, when they're enrolled and when
+// they haven't started the course
+type EnrolmentNotice struct {
+	ID          uuid.UUID
+	UserID      string
+	UserName    string
+	UserEmail   string
+	CourseID    uuid.UUID
+	CourseTitle string
+	EnrolledAt  time.Time
+	DueAt       *time.Time
+}
+
+type EnrolmentStatus string
+
+const (
+	EnrolmentStatusNotStarted EnrolmentStatus = "not_started"
+	EnrolmentStatusInProgress EnrolmentStatus = "in_progress"
+	EnrolmentStatusCompleted  EnrolmentStatus = "completed"
+	EnrolmentStatusOverdue    EnrolmentStatus = "overdue"
+)
+
+// EnrolmentStatusFrom works out the status of an enrolment, a completed course is never overdue
+func EnrolmentStatusFrom(started, completed bool, dueAt *time.Time, now time.Time) EnrolmentStatus {
+	switch {
+	case completed:
+		return EnrolmentStatusCompleted
+	case dueAt != nil && dueAt.Before(now):
+		return EnrolmentStatusOverdue
+	case started:
+		return EnrolmentStatusInProgress
+	default:
+		return EnrolmentStatusNotStarted
+	}
+}
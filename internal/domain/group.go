@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+//go:generate moq -out ../handlers/mocks/group_mock.go -pkg mocks . GroupRepository
+
+type GroupRepository interface {
+	GetGroups(context.Context) ([]Group, error)
+	AddGroup(ctx context.Context, name string) (*Group, error)
+	UpdateGroup(ctx context.Context, params UpdateGroupParams) error
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+	AddGroupMembers(ctx context.Context, params GroupMembersParams) error
+	RemoveGroupMembers(ctx context.Context, params GroupMembersParams) error
+	AssignCourseToGroup(ctx context.Context, params AssignCourseToGroupParams) error
+	UnassignCourseFromGroup(ctx context.Context, params UnassignCourseFromGroupParams) error
+}
+
+type Group struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	MemberCount int64       `json:"memberCount"`
+	CourseIDs   []uuid.UUID `json:"courseIds"`
+}
+
+// GroupSummary is the group membership shown alongside users in admin lists
+type GroupSummary struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type UpdateGroupParams struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type GroupMembersParams struct {
+	GroupID uuid.UUID
+	UserIDs []string
+}
+
+// AssignCourseToGroupParams enrols every current and future member of the group in the course.
+// DueInDays sets each member's due date relative to when they are enrolled.
+type AssignCourseToGroupParams struct {
+	GroupID    uuid.UUID
+	CourseID   uuid.UUID
+	DueInDays  *int
+	AssignedBy string
+}
+
+type UnassignCourseFromGroupParams struct {
+	GroupID  uuid.UUID
+	CourseID uuid.UUID
+}
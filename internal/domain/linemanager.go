@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate moq -out ../handlers/mocks/linemanager_mock.go -pkg mocks . LineManagerRepository
+
+type LineManagerRepository interface {
+	// SetLineManager returns the ID of the user's previous line manager, empty if they had none
+	SetLineManager(ctx context.Context, params SetLineManagerParams) (string, error)
+	// RemoveLineManager returns the ID of the line manager removed, empty if the user had none
+	RemoveLineManager(ctx context.Context, userID string) (string, error)
+	GetTeamEnrolments(ctx context.Context, managerID string) ([]TeamEnrolment, error)
+	GetManagerDigests(ctx context.Context, dueWithinDays int) ([]ManagerDigest, error)
+}
+
+type SetLineManagerParams struct {
+	UserID    string
+	ManagerID string
+}
+
+// TeamEnrolment is a course enrolment of one of a line manager's direct reports
+type TeamEnrolment struct {
+	UserID      string          `json:"userId"`
+	UserName    string          `json:"name"`
+	Email       string          `json:"email"`
+	CourseID    uuid.UUID       `json:"courseId"`
+	CourseTitle string          `json:"courseTitle"`
+	EnrolledAt  time.Time       `json:"enrolledAt"`
+	DueDate     *time.Time      `json:"dueDate,omitempty"`
+	Status      EnrolmentStatus `json:"status"`
+}
+
+// TeamCompliance summarises the training status of a line manager's direct reports
+type TeamCompliance struct {
+	Counts     map[EnrolmentStatus]int `json:"counts"`
+	Enrolments []TeamEnrolment         `json:"enrolments"`
+}
+
+// ManagerDigest lists the overdue and soon to be due training of a line manager's direct reports
+type ManagerDigest struct {
+	ManagerID    string
+	ManagerName  string
+	ManagerEmail string
+	Enrolments   []DigestEnrolment
+}
+
+type DigestEnrolment struct {
+	UserName    string
+	CourseTitle string
+	DueAt       time.Time
+}
+
+func TeamComplianceFrom(enrolments []TeamEnrolment) *TeamCompliance {
+	counts := map[EnrolmentStatus]int{
+		EnrolmentStatusNotStarted: 0,
+		EnrolmentStatusInProgress: 0,
+		EnrolmentStatusCompleted:  0,
+		EnrolmentStatusOverdue:    0,
+	}
+
+	for _, enrolment := range enrolments {
+		counts[enrolment.Status]++
+	}
+
+	return &TeamCompliance{
+		Counts:     counts,
+		Enrolments: enrolments,
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// PageParams are the shared inputs for cursor-based pagination on list endpoints.
+// SortBy is resource specific, filters that don't apply to a resource are ignored.
+type PageParams struct {
+	Cursor   *Cursor
+	Limit    int
+	SortBy   string
+	SortDir  SortDirection
+	Search   string
+	CourseID *uuid.UUID
+	GroupID  *uuid.UUID
+	// Deactivated filters users by whether they have been deactivated, nil includes both
+	Deactivated *bool
+	// ManagerID filters users to the direct reports of a line manager
+	ManagerID *string
+}
+
+// Cursor points at the last item of the previous page. Key is the value of the
+// sort column for that item and ID breaks ties between items with the same key.
+type Cursor struct {
+	Key string `json:"k"`
+	ID  string `json:"id"`
+}
+
+// Page is the envelope returned by every paginated list endpoint.
+// NextCursor is nil when there are no more items.
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	TotalCount int64   `json:"totalCount"`
+	NextCursor *string `json:"nextCursor"`
+}
+
+func (c Cursor) Encode() string {
+	// Marshalling a struct of strings can't fail
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cursor: %w", err)
+	}
+
+	if cursor.ID == "" {
+		return nil, errors.New("cursor is missing an ID")
+	}
+
+	return &cursor, nil
+}
+
+func (p PageParams) Descending() bool {
+	return p.SortDir == SortDescending
+}
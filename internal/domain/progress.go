@@ -12,10 +12,10 @@ type ProgressRepository interface {
 	GetProgress(context.Context, GetProgressParams) (*Progress, error)
 	UpdateProgress(context.Context, UpdateProgressParams) error
 	GetAllProgress(context.Context) ([]*FullProgress, error)
-	HasCompletedCourse(context.Context, HasCompletedCourseParams) (bool, error)
+	ListProgress(ctx context.Context, params PageParams) (*Page[*FullProgress], error)
 	SetCourseCompleted(context.Context, SetCourseCompletedParams) error
 	SetIntroCompleted(context.Context, SetIntroCompletedParams) error
-	ResetProgress(context.Context, ResetProgressParams) error
+	ResetProgress(context.Context, ResetProgressParams) (*ProgressReset, error)
 }
 
 type GetProgressParams struct {
@@ -29,14 +29,13 @@ type UpdateProgressParams struct {
 	SectionID uuid.UUID
 }
 
-type HasCompletedCourseParams struct {
-	UserID   string
-	CourseID uuid.UUID
-}
-
+// SetCourseCompletedParams queue the Emails about the completion in the same transaction. Emails
+// is only called if the course wasn't already completed, with a context that joins the transaction,
+// so anything issued for the completion is only issued once.
 type SetCourseCompletedParams struct {
 	UserID   string
 	CourseID uuid.UUID
+	Emails   func(ctx context.Context) ([]*OutboxEmail, error)
 }
 
 type SetIntroCompletedParams struct {
@@ -44,9 +43,32 @@ type SetIntroCompletedParams struct {
 	CourseID uuid.UUID
 }
 
+// ResetProgressParams reset the course progress and quiz attempts of UserIDs, or of everyone
+// enrolled on the course if Cohort is set. The previous progress is archived with the reason.
+// Emails is called with the reset for the emails about it, which are queued in its transaction.
 type ResetProgressParams struct {
-	UserID   string
+	UserIDs  []string
+	Cohort   bool
 	CourseID uuid.UUID
+	Reason   string
+	ResetBy  string
+	Emails   func(reset *ProgressReset) ([]*OutboxEmail, error)
+}
+
+// ProgressReset is an admin reset of learners' progress, of a whole course or only one of its
+// quizzes. Users are the learners whose progress was reset.
+type ProgressReset struct {
+	ID          uuid.UUID   `json:"id"`
+	CourseID    uuid.UUID   `json:"courseId"`
+	CourseTitle string      `json:"courseTitle"`
+	QuizID      *uuid.UUID  `json:"quizId"`
+	Users       []ResetUser `json:"users"`
+}
+
+type ResetUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
 }
 
 type Progress struct {
@@ -58,6 +80,7 @@ type FullProgress struct {
 	UserID   string              `json:"userID"`
 	UserName string              `json:"name"`
 	Email    string              `json:"email"`
+	Groups   []GroupSummary      `json:"groups,omitempty"`
 	Progress []*FullUserProgress `json:"progress"`
 }
 
@@ -15,11 +15,23 @@ type QuizRepository interface {
 	SetQuizState(context.Context, SetQuizStateParams) error
 	GetQuizAttemptsByUserID(context.Context, string) ([]*QuizAttempts, error)
 	GetAllQuizSections(context.Context) ([]*QuizSection, error)
-	ResetQuizProgress(ctx context.Context, userID string, quizID uuid.UUID) error
+	ListQuizSections(ctx context.Context, params PageParams) (*Page[*QuizSection], error)
+	ResetQuizProgress(ctx context.Context, params ResetQuizProgressParams) (*ProgressReset, error)
 	GetQuizState(ctx context.Context, userID string, quizID uuid.UUID) (*QuizState, error)
 	GetQuizQuestions(ctx context.Context, sectionIDs []uuid.UUID) ([]*QuizQuestionLegacy, error)
 }
 
+// ResetQuizProgressParams reset the answers and attempts of a quiz like ResetProgressParams, the
+// cohort being everyone enrolled on the quiz's course
+type ResetQuizProgressParams struct {
+	UserIDs []string
+	Cohort  bool
+	QuizID  uuid.UUID
+	Reason  string
+	ResetBy string
+	Emails  func(reset *ProgressReset) ([]*OutboxEmail, error)
+}
+
 type SaveQuizAttemptParams struct {
 	UserID  string
 	QuizID  uuid.UUID
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+)
+
+//go:generate moq -out ../handlers/mocks/role_mock.go -pkg mocks . RoleRepository
+
+type RoleRepository interface {
+	GetUserRole(ctx context.Context, userID string) (config.Role, error)
+	GetUserRoles(context.Context) ([]UserRole, error)
+	SetUserRole(ctx context.Context, params SetUserRoleParams) error
+	GetRoleScope(ctx context.Context, userID string) (*RoleScope, error)
+	IsGroupManager(ctx context.Context, params IsGroupManagerParams) (bool, error)
+	AddGroupManagers(ctx context.Context, params GroupMembersParams) error
+	RemoveGroupManagers(ctx context.Context, params GroupMembersParams) error
+	IsCourseInstructor(ctx context.Context, params IsCourseInstructorParams) (bool, error)
+	AssignCourseInstructors(ctx context.Context, params CourseInstructorsParams) error
+	UnassignCourseInstructors(ctx context.Context, params CourseInstructorsParams) error
+}
+
+// UserRole is a role granted to a user on top of the default learner role
+type UserRole struct {
+	UserID    string      `json:"userId"`
+	Name      string      `json:"name"`
+	Email     string      `json:"email"`
+	Role      config.Role `json:"role"`
+	GrantedBy string      `json:"grantedBy,omitempty"`
+	GrantedAt time.Time   `json:"grantedAt"`
+	RoleScope
+}
+
+// RoleScope limits what managers and instructors can do to the groups they manage and the courses
+// they are instructors on
+type RoleScope struct {
+	ManagedGroupIDs     []uuid.UUID `json:"managedGroupIds"`
+	InstructedCourseIDs []uuid.UUID `json:"instructedCourseIds"`
+}
+
+// SetUserRoleParams grants a role to a user, setting the user role removes any role granted before
+type SetUserRoleParams struct {
+	UserID    string
+	Role      config.Role
+	GrantedBy string
+}
+
+type IsGroupManagerParams struct {
+	UserID  string
+	GroupID uuid.UUID
+}
+
+type IsCourseInstructorParams struct {
+	UserID   string
+	CourseID uuid.UUID
+}
+
+type CourseInstructorsParams struct {
+	CourseID uuid.UUID
+	UserIDs  []string
+}
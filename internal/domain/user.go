@@ -1,15 +1,49 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 //go:generate moq -out ../handlers/mocks/user_mock.go -pkg mocks . UserRepository
 
 type UserRepository interface {
 	GetUser(context.Context, string) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	ListUsers(ctx context.Context, params PageParams) (*Page[User], error)
+	UpdateUser(ctx context.Context, params UpdateUserParams) (*User, error)
+	SetUserDeactivated(ctx context.Context, params SetUserDeactivatedParams) error
+	DeleteUser(ctx context.Context, id string) error
+	ExportUserData(ctx context.Context, id string) (*UserDataExport, error)
+	EraseUser(ctx context.Context, id string) (*ErasedUser, error)
 }
 
 type User struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
+	// EmailBouncedAt is when an email to the user bounced, if their address is bouncing. It's only
+	// set when listing users.
+	EmailBouncedAt *time.Time `json:"emailBouncedAt,omitempty"`
+}
+
+type UserStatus string
+
+const (
+	UserStatusActive      UserStatus = "active"
+	UserStatusDeactivated UserStatus = "deactivated"
+)
+
+type UpdateUserParams struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+// SetUserDeactivatedParams deactivates or reactivates a user. Deactivated users can't log in
+// but their enrolments and progress are kept.
+type SetUserDeactivatedParams struct {
+	ID          string
+	Deactivated bool
 }
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDataExport is everything held about a user, returned for subject access requests
+type UserDataExport struct {
+	ExportedAt            time.Time                      `json:"exportedAt"`
+	User                  User                           `json:"user"`
+	Enrolments            []UserDataEnrolment            `json:"enrolments"`
+	Progress              []UserDataProgress             `json:"progress"`
+	QuizStates            []UserDataQuizState            `json:"quizStates"`
+	QuizAttempts          []UserDataQuizAttempt          `json:"quizAttempts"`
+	Groups                []UserDataGroup                `json:"groups"`
+	AccessCodeRedemptions []UserDataAccessCodeRedemption `json:"accessCodeRedemptions"`
+	Emails                []UserDataEmail                `json:"emails"`
+}
+
+type UserDataEnrolment struct {
+	CourseID    uuid.UUID  `json:"courseId"`
+	CourseTitle string     `json:"courseTitle"`
+	EnrolledAt  time.Time  `json:"enrolledAt"`
+	EnrolledBy  string     `json:"enrolledBy,omitempty"`
+	DueAt       *time.Time `json:"dueAt"`
+	OverdueAt   *time.Time `json:"overdueAt"`
+}
+
+type UserDataProgress struct {
+	CourseID            uuid.UUID   `json:"courseId"`
+	CourseTitle         string      `json:"courseTitle"`
+	CompletedSectionIDs []uuid.UUID `json:"completedSectionIds"`
+	CompletedIntro      bool        `json:"completedIntro"`
+	CompletedCourse     bool        `json:"completedCourse"`
+}
+
+type UserDataQuizState struct {
+	QuizID      uuid.UUID       `json:"quizId"`
+	QuizState   json.RawMessage `json:"quizState"`
+	QuizAnswers json.RawMessage `json:"quizAnswers"`
+	Attempts    int             `json:"attempts"`
+}
+
+type UserDataQuizAttempt struct {
+	QuizID        uuid.UUID       `json:"quizId"`
+	AttemptNumber int             `json:"attemptNumber"`
+	Answers       json.RawMessage `json:"answers"`
+}
+
+type UserDataGroup struct {
+	GroupID   uuid.UUID `json:"groupId"`
+	GroupName string    `json:"groupName"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+type UserDataAccessCodeRedemption struct {
+	Code       string    `json:"code"`
+	CourseID   uuid.UUID `json:"courseId"`
+	RedeemedAt time.Time `json:"redeemedAt"`
+}
+
+type UserDataEmail struct {
+	ID             uuid.UUID       `json:"id"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	EmailName      string          `json:"emailName"`
+	TemplateParams json.RawMessage `json:"templateParams"`
+	Status         string          `json:"status"`
+	Error          string          `json:"error,omitempty"`
+	SentAt         *time.Time      `json:"sentAt"`
+}
+
+// ErasedUser is the anonymised placeholder left after erasing a user. It keeps the user's
+// completed courses for reporting, everything else about the user is deleted.
+type ErasedUser struct {
+	ID                  string `json:"id"`
+	RetainedCompletions int64  `json:"retainedCompletions"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:generate moq -out ../handlers/mocks/userimport_mock.go -pkg mocks . UserImportRepository
+
+type UserImportRepository interface {
+	AddUserImport(ctx context.Context, params AddUserImportParams) (uuid.UUID, error)
+	CompleteUserImport(ctx context.Context, params CompleteUserImportParams) error
+	GetUserImport(ctx context.Context, id uuid.UUID) (*UserImport, error)
+}
+
+type UserImportStatus string
+
+const (
+	UserImportStatusRunning   UserImportStatus = "running"
+	UserImportStatusCompleted UserImportStatus = "completed"
+)
+
+// UserImport is a CSV import of users that runs in the background. Report is the result of each
+// row, it's saved when the import starts and replaced when the import completes.
+type UserImport struct {
+	ID          uuid.UUID
+	Status      UserImportStatus
+	Report      json.RawMessage
+	CreatedBy   string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+type AddUserImportParams struct {
+	Report    json.RawMessage
+	CreatedBy string
+}
+
+type CompleteUserImportParams struct {
+	ID     uuid.UUID
+	Report json.RawMessage
+}
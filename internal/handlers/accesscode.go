@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	stdErrors "errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const (
+	accessCodeResource            = "access code"
+	accessCodesResource           = "access codes"
+	accessCodeRedemptionsResource = "access code redemptions"
+	accessCodeLength              = 10
+)
+
+type AddAccessCodeParams struct {
+	CourseID  string     `json:"courseId" validate:"required"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	MaxUses   *int       `json:"maxUses" validate:"omitempty,min=1"`
+}
+
+func (h *Handlers) AddAccessCode(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	adminID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params AddAccessCodeParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(time.Now()) {
+		return httpError(http.StatusBadRequest, errors.Validation, nil)
+	}
+
+	code, err := utils.RandomCode(accessCodeLength)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Creating(accessCodeResource), err)
+	}
+
+	var accessCode *domain.AccessCode
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		accessCode, err = h.AccessCode.AddAccessCode(ctx, domain.AddAccessCodeParams{
+			Code:      code,
+			CourseID:  courseID,
+			ExpiresAt: params.ExpiresAt,
+			MaxUses:   params.MaxUses,
+			CreatedBy: adminID,
+		})
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(accessCodeResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionAddAccessCode, domain.AuditTargetAccessCode, accessCode.ID.String(), nil, accessCode)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, accessCode)
+}
+
+type GetAccessCodesParams struct {
+	CourseID string `json:"courseId"`
+}
+
+func (h *Handlers) GetAccessCodes(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params GetAccessCodesParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	var courseID *uuid.UUID
+	if params.CourseID != "" {
+		id, err := uuid.Parse(params.CourseID)
+		if err != nil {
+			return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+		}
+		courseID = &id
+	}
+
+	accessCodes, err := h.AccessCode.GetAccessCodes(ctx, courseID)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(accessCodesResource), err)
+	}
+
+	return e.JSON(http.StatusOK, accessCodes)
+}
+
+type AccessCodeParams struct {
+	ID string `json:"id" validate:"required"`
+}
+
+func (h *Handlers) RevokeAccessCode(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params AccessCodeParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(params.ID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.AccessCode.RevokeAccessCode(ctx, id); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(accessCodeResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(accessCodeResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionRevokeAccessCode, domain.AuditTargetAccessCode, params.ID, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// GetAccessCodeRedemptions returns who redeemed the access code and when
+func (h *Handlers) GetAccessCodeRedemptions(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params AccessCodeParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(params.ID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	redemptions, err := h.AccessCode.GetAccessCodeRedemptions(ctx, id)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(accessCodeRedemptionsResource), err)
+	}
+
+	return e.JSON(http.StatusOK, redemptions)
+}
+
+type RedeemAccessCodeParams struct {
+	Code string `json:"code" validate:"required,max=50"`
+}
+
+// RedeemAccessCode enrols the current user in the course the access code was generated for
+func (h *Handlers) RedeemAccessCode(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params RedeemAccessCodeParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(params.Code))
+
+	result, err := h.AccessCode.RedeemAccessCode(ctx, domain.RedeemAccessCodeParams{
+		Code:   code,
+		UserID: userID,
+	})
+	if err != nil {
+		slog.InfoContext(ctx, "access code redemption failed", slog.String("user_id", userID), slog.Any("error", err))
+
+		switch {
+		case errors.IsNotFoundErr(err):
+			return httpError(http.StatusNotFound, errors.NotFound(accessCodeResource), err)
+		case stdErrors.Is(err, domain.ErrAccessCodeExpired),
+			stdErrors.Is(err, domain.ErrAccessCodeRevoked),
+			stdErrors.Is(err, domain.ErrAccessCodeUsedUp):
+			return httpError(http.StatusGone, err.Error(), err)
+		default:
+			return httpError(http.StatusInternalServerError, errors.Updating(accessCodeResource), err)
+		}
+	}
+
+	slog.InfoContext(
+		ctx,
+		"access code redeemed",
+		slog.String("user_id", userID),
+		slog.String("course_id", result.CourseID.String()),
+		slog.Bool("already_redeemed", result.AlreadyRedeemed),
+	)
+
+	return e.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,256 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+)
+
+func TestAddAccessCode_HappyPath(t *testing.T) {
+	t.Run("generates access code for course", func(t *testing.T) {
+		maxUses := 20
+		expiresAt := time.Now().Add(7 * 24 * time.Hour).UTC()
+
+		mockAccessCodeRepo := &mocks.AccessCodeRepositoryMock{
+			AddAccessCodeFunc: func(ctx context.Context, params domain.AddAccessCodeParams) (*domain.AccessCode, error) {
+				return &domain.AccessCode{
+					ID:        uuid.New(),
+					Code:      params.Code,
+					CourseID:  params.CourseID,
+					ExpiresAt: params.ExpiresAt,
+					MaxUses:   params.MaxUses,
+					CreatedBy: params.CreatedBy,
+				}, nil
+			},
+		}
+
+		h := &handlers.Handlers{AccessCode: mockAccessCodeRepo, Audit: newAuditMock()}
+
+		req := handlers.AddAccessCodeParams{
+			CourseID:  testhelpers.Course.ID.String(),
+			ExpiresAt: &expiresAt,
+			MaxUses:   &maxUses,
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "access-codes/add")
+
+		err := h.AddAccessCode(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rec.Code)
+		}
+
+		calls := mockAccessCodeRepo.AddAccessCodeCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.AddAccessCodeHandlerName)
+
+		params := calls[0].Params
+		if len(params.Code) != 10 {
+			t.Errorf("expected a 10 character code, got %q", params.Code)
+		}
+
+		expected := domain.AddAccessCodeParams{
+			Code:      params.Code,
+			CourseID:  testhelpers.Course.ID,
+			ExpiresAt: &expiresAt,
+			MaxUses:   &maxUses,
+			CreatedBy: testhelpers.TestUserID,
+		}
+		if diff := cmp.Diff(expected, params); diff != "" {
+			t.Errorf("access code params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestAddAccessCode_UnhappyPath(t *testing.T) {
+	courseID := testhelpers.Course.ID.String()
+	expired := time.Now().Add(-time.Hour)
+	noUses := 0
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.AddAccessCodeParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing course id",
+			reqBody:        handlers.AddAccessCodeParams{},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "validation error - max uses less than one",
+			reqBody:        handlers.AddAccessCodeParams{CourseID: courseID, MaxUses: &noUses},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "validation error - expiry in the past",
+			reqBody:        handlers.AddAccessCodeParams{CourseID: courseID, ExpiresAt: &expired},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "invalid uuid format",
+			reqBody:        handlers.AddAccessCodeParams{CourseID: "invalid-uuid"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.AddAccessCodeParams{CourseID: courseID},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Creating("access code"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				AccessCode: &mocks.AccessCodeRepositoryMock{
+					AddAccessCodeFunc: func(ctx context.Context, params domain.AddAccessCodeParams) (*domain.AccessCode, error) {
+						return nil, tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "access-codes/add")
+			err := h.AddAccessCode(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestRedeemAccessCode_HappyPath(t *testing.T) {
+	t.Run("normalises code and enrols user", func(t *testing.T) {
+		expected := &domain.RedeemAccessCodeResult{CourseID: testhelpers.Course.ID}
+
+		mockAccessCodeRepo := &mocks.AccessCodeRepositoryMock{
+			RedeemAccessCodeFunc: func(
+				ctx context.Context,
+				params domain.RedeemAccessCodeParams,
+			) (*domain.RedeemAccessCodeResult, error) {
+				return expected, nil
+			},
+		}
+
+		h := &handlers.Handlers{AccessCode: mockAccessCodeRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(
+			t,
+			handlers.RedeemAccessCodeParams{Code: " abcd2345xy "},
+			"access-codes/redeem",
+			testhelpers.WithRole(config.UserRole),
+		)
+
+		err := h.RedeemAccessCode(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var actual domain.RedeemAccessCodeResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(expected, &actual); diff != "" {
+			t.Errorf("redeem result mismatch (-want +got):\n%s", diff)
+		}
+
+		calls := mockAccessCodeRepo.RedeemAccessCodeCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.RedeemAccessCodeHandlerName)
+
+		expectedParams := domain.RedeemAccessCodeParams{Code: "ABCD2345XY", UserID: testhelpers.TestUserID}
+		if diff := cmp.Diff(expectedParams, calls[0].Params); diff != "" {
+			t.Errorf("redeem params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestRedeemAccessCode_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.RedeemAccessCodeParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing code",
+			reqBody:        handlers.RedeemAccessCodeParams{},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "code not found",
+			reqBody:        handlers.RedeemAccessCodeParams{Code: "ABCD2345XY"},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("access code"),
+		},
+		{
+			name:           "code expired",
+			reqBody:        handlers.RedeemAccessCodeParams{Code: "ABCD2345XY"},
+			repoErr:        domain.ErrAccessCodeExpired,
+			wantStatus:     http.StatusGone,
+			expectedErrMsg: domain.ErrAccessCodeExpired.Error(),
+		},
+		{
+			name:           "code used up",
+			reqBody:        handlers.RedeemAccessCodeParams{Code: "ABCD2345XY"},
+			repoErr:        domain.ErrAccessCodeUsedUp,
+			wantStatus:     http.StatusGone,
+			expectedErrMsg: domain.ErrAccessCodeUsedUp.Error(),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.RedeemAccessCodeParams{Code: "ABCD2345XY"},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("access code"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				AccessCode: &mocks.AccessCodeRepositoryMock{
+					RedeemAccessCodeFunc: func(
+						ctx context.Context,
+						params domain.RedeemAccessCodeParams,
+					) (*domain.RedeemAccessCodeResult, error) {
+						return nil, tt.repoErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "access-codes/redeem", testhelpers.WithRole(config.UserRole))
+			err := h.RedeemAccessCode(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/middleware"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const (
+	apiKeyResource  = "API key"
+	apiKeysResource = "API keys"
+	// apiKeyBytes is the number of random bytes in a key, 32 bytes can't be guessed
+	apiKeyBytes = 32
+	// apiKeyPrefixLength is how much of the random part of a key is stored to tell keys apart
+	apiKeyPrefixLength = 6
+)
+
+type AddAPIKeyParams struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"omitempty,unique,dive,oneof=courses:view progress:view reports:view"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// AddAPIKeyResponse is the only time the key is returned, only its hash is stored
+type AddAPIKeyResponse struct {
+	Key string `json:"key"`
+	*domain.APIKey
+}
+
+// AddAPIKey creates a key for another system to call the API with. Keys created without scopes can
+// only read reports.
+func (h *Handlers) AddAPIKey(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	adminID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params AddAPIKeyParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(time.Now()) {
+		return httpError(http.StatusBadRequest, errors.Validation, nil)
+	}
+
+	scopes := params.Scopes
+	if len(scopes) == 0 {
+		scopes = utils.Map(middleware.DefaultAPIKeyScopes, func(p middleware.Permission) string { return string(p) })
+	}
+
+	token, err := utils.RandomToken(apiKeyBytes)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Creating(apiKeyResource), err)
+	}
+	key := domain.APIKeyPrefix + token
+
+	var apiKey *domain.APIKey
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		apiKey, err = h.APIKey.AddAPIKey(ctx, domain.AddAPIKeyParams{
+			Name:      params.Name,
+			Prefix:    key[:len(domain.APIKeyPrefix)+apiKeyPrefixLength],
+			KeyHash:   domain.HashAPIKey(key),
+			Scopes:    scopes,
+			CreatedBy: adminID,
+			ExpiresAt: params.ExpiresAt,
+		})
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(apiKeyResource), err)
+		}
+
+		// Only the stored details are recorded, never the key
+		return auditEntry(ctx, domain.AuditActionAddAPIKey, domain.AuditTargetAPIKey, apiKey.ID.String(), nil, apiKey)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, &AddAPIKeyResponse{Key: key, APIKey: apiKey})
+}
+
+func (h *Handlers) GetAPIKeys(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	apiKeys, err := h.APIKey.GetAPIKeys(ctx)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(apiKeysResource), err)
+	}
+
+	return e.JSON(http.StatusOK, apiKeys)
+}
+
+type RevokeAPIKeyParams struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// RevokeAPIKey stops the key being accepted straight away, revoked keys are kept so their use can
+// still be audited
+func (h *Handlers) RevokeAPIKey(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params RevokeAPIKeyParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(params.ID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.APIKey.RevokeAPIKey(ctx, id); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(apiKeyResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(apiKeyResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionRevokeAPIKey, domain.AuditTargetAPIKey, params.ID, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
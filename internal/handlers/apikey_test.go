@@ -0,0 +1,237 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+)
+
+func TestAddAPIKey_HappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		scopes         []string
+		expectedScopes []string
+	}{
+		{
+			name:           "defaults to read-only reporting scopes",
+			expectedScopes: []string{"progress:view", "reports:view"},
+		},
+		{
+			name:           "uses requested scopes",
+			scopes:         []string{"courses:view"},
+			expectedScopes: []string{"courses:view"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiresAt := time.Now().Add(30 * 24 * time.Hour).UTC()
+
+			mockRepo := &mocks.APIKeyRepositoryMock{
+				AddAPIKeyFunc: func(ctx context.Context, params domain.AddAPIKeyParams) (*domain.APIKey, error) {
+					return &domain.APIKey{
+						ID:        uuid.New(),
+						Name:      params.Name,
+						Prefix:    params.Prefix,
+						Scopes:    params.Scopes,
+						CreatedBy: params.CreatedBy,
+						ExpiresAt: params.ExpiresAt,
+					}, nil
+				},
+			}
+
+			auditRepo := newAuditMock()
+			h := &handlers.Handlers{APIKey: mockRepo, Audit: auditRepo}
+
+			req := handlers.AddAPIKeyParams{Name: "HR system", Scopes: tt.scopes, ExpiresAt: &expiresAt}
+			ctx, rec := testhelpers.SetupEchoContext(t, req, "api-keys/add")
+
+			err := h.AddAPIKey(ctx)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if rec.Code != http.StatusCreated {
+				t.Errorf("expected status %d, got %d", http.StatusCreated, rec.Code)
+			}
+
+			calls := mockRepo.AddAPIKeyCalls()
+			testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.AddAPIKeyHandlerName)
+
+			var actual handlers.AddAPIKeyResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if !domain.IsAPIKey(actual.Key) || !strings.HasPrefix(actual.Key, actual.Prefix) {
+				t.Errorf("expected key starting with %q, got %q", actual.Prefix, actual.Key)
+			}
+
+			expected := domain.AddAPIKeyParams{
+				Name:      "HR system",
+				Prefix:    actual.Prefix,
+				KeyHash:   domain.HashAPIKey(actual.Key),
+				Scopes:    tt.expectedScopes,
+				CreatedBy: testhelpers.TestUserID,
+				ExpiresAt: &expiresAt,
+			}
+			if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+				t.Errorf("API key params mismatch (-want +got):\n%s", diff)
+			}
+
+			auditCalls := auditRepo.AddAuditEntryCalls()
+			testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.AddAPIKeyHandlerName)
+			if auditCalls[0].Params.Action != domain.AuditActionAddAPIKey {
+				t.Errorf("expected audit action %s, got %s", domain.AuditActionAddAPIKey, auditCalls[0].Params.Action)
+			}
+			if strings.Contains(string(auditCalls[0].Params.After), actual.Key) {
+				t.Error("expected the key not to be recorded in the audit log")
+			}
+		})
+	}
+}
+
+func TestAddAPIKey_UnhappyPath(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.AddAPIKeyParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing name",
+			reqBody:        handlers.AddAPIKeyParams{},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "validation error - scope that can write",
+			reqBody:        handlers.AddAPIKeyParams{Name: "HR system", Scopes: []string{"learners:manage"}},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "validation error - expiry in the past",
+			reqBody:        handlers.AddAPIKeyParams{Name: "HR system", ExpiresAt: &expired},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.AddAPIKeyParams{Name: "HR system"},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Creating("API key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				APIKey: &mocks.APIKeyRepositoryMock{
+					AddAPIKeyFunc: func(ctx context.Context, params domain.AddAPIKeyParams) (*domain.APIKey, error) {
+						return nil, tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "api-keys/add")
+			err := h.AddAPIKey(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Run("revokes key", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo := &mocks.APIKeyRepositoryMock{
+			RevokeAPIKeyFunc: func(ctx context.Context, id uuid.UUID) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{APIKey: mockRepo, Audit: newAuditMock()}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.RevokeAPIKeyParams{ID: id.String()}, "api-keys/revoke")
+
+		err := h.RevokeAPIKey(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRepo.RevokeAPIKeyCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.RevokeAPIKeyHandlerName)
+		if calls[0].Id != id {
+			t.Errorf("expected key %s to be revoked, got %s", id, calls[0].Id)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.RevokeAPIKeyParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "invalid uuid format",
+			reqBody:        handlers.RevokeAPIKeyParams{ID: "invalid-uuid"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+		},
+		{
+			name:           "key not found",
+			reqBody:        handlers.RevokeAPIKeyParams{ID: uuid.NewString()},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("API key"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.RevokeAPIKeyParams{ID: uuid.NewString()},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("API key"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				APIKey: &mocks.APIKeyRepositoryMock{
+					RevokeAPIKeyFunc: func(ctx context.Context, id uuid.UUID) error {
+						return tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "api-keys/revoke")
+			err := h.RevokeAPIKey(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
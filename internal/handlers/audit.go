@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+)
+
+const (
+	auditLogResource   = "audit log"
+	auditEntryResource = "audit entry"
+)
+
+type ListAuditLogParams struct {
+	PaginationParams
+	ActorID     string     `json:"actorId"`
+	Action      string     `json:"action"`
+	TargetType  string     `json:"targetType"`
+	TargetID    string     `json:"targetId"`
+	CreatedFrom *time.Time `json:"createdFrom"`
+	CreatedTo   *time.Time `json:"createdTo"`
+}
+
+// ListAuditLog lists admin actions in the order they were made, search matches the target and actor IDs
+func (h *Handlers) ListAuditLog(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListAuditLogParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	pageParams, err := params.pageParams("", pageFilters{})
+	if err != nil {
+		return err
+	}
+
+	entries, err := h.Audit.ListAuditEntries(ctx, domain.ListAuditEntriesParams{
+		PageParams:  pageParams,
+		ActorID:     params.ActorID,
+		Action:      params.Action,
+		TargetType:  params.TargetType,
+		TargetID:    params.TargetID,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+	})
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(auditLogResource), err)
+	}
+
+	return e.JSON(http.StatusOK, entries)
+}
+
+// VerifyAuditLog checks that no entry in the audit log has been changed or removed
+func (h *Handlers) VerifyAuditLog(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	verification, err := h.Audit.VerifyAuditLog(ctx)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(auditLogResource), err)
+	}
+
+	return e.JSON(http.StatusOK, verification)
+}
+
+// audited makes an admin action and adds it to the audit log in one transaction, so an action is
+// never made without being audited. change makes the action with the context it's given, so the
+// repository calls join the transaction, and returns its entry from auditEntry. Its errors are
+// returned as they are.
+func (h *Handlers) audited(
+	ctx context.Context,
+	change func(ctx context.Context) (*domain.AddAuditEntryParams, error),
+) error {
+	var changeErr error
+	err := h.Audit.WithAuditEntry(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		entry, err := change(ctx)
+		changeErr = err
+		return entry, err
+	})
+	if err == nil || changeErr != nil {
+		return err
+	}
+
+	slog.ErrorContext(ctx, "failed to record audit entry", slog.Any("error", err))
+
+	return httpError(http.StatusInternalServerError, errors.Creating(auditEntryResource), err)
+}
+
+// auditEntry is the audit log entry of an admin action, with the state of the target before and
+// after it, either of which can be nil
+func auditEntry(
+	ctx context.Context,
+	action domain.AuditAction,
+	targetType, targetID string,
+	before, after any,
+) (*domain.AddAuditEntryParams, error) {
+	// Actions are made by users, not API keys, so there is always an actor
+	actorID, _ := getUserID(ctx)
+
+	entry := &domain.AddAuditEntryParams{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+
+	var err error
+	if entry.Before, err = auditStateFrom(before); err == nil {
+		entry.After, err = auditStateFrom(after)
+	}
+	if err != nil {
+		return nil, httpError(http.StatusInternalServerError, errors.Creating(auditEntryResource), err)
+	}
+
+	return entry, nil
+}
+
+func auditStateFrom(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(state)
+}
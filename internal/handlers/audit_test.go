@@ -0,0 +1,255 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+)
+
+// newAuditMock returns an audit log that records every entry, for handlers of admin actions
+func newAuditMock() *mocks.AuditRepositoryMock {
+	return withAuditEntry(&mocks.AuditRepositoryMock{
+		AddAuditEntryFunc: func(ctx context.Context, params domain.AddAuditEntryParams) error {
+			return nil
+		},
+	})
+}
+
+// withAuditEntry makes the mock's WithAuditEntry make the change then add its entry with
+// AddAuditEntry, as the store does in one transaction
+func withAuditEntry(mock *mocks.AuditRepositoryMock) *mocks.AuditRepositoryMock {
+	mock.WithAuditEntryFunc = func(
+		ctx context.Context,
+		change func(ctx context.Context) (*domain.AddAuditEntryParams, error),
+	) error {
+		entry, err := change(ctx)
+		if err != nil || entry == nil {
+			return err
+		}
+
+		return mock.AddAuditEntry(ctx, *entry)
+	}
+
+	return mock
+}
+
+func TestListAuditLog(t *testing.T) {
+	t.Run("lists entries with filters", func(t *testing.T) {
+		entries := []domain.AuditEntry{
+			{
+				ID:         1,
+				ActorID:    testhelpers.TestUserID,
+				Action:     domain.AuditActionEditCourse,
+				TargetType: domain.AuditTargetCourse,
+				TargetID:   testhelpers.Course.ID.String(),
+				Before:     json.RawMessage(`{"title":"Course"}`),
+				After:      json.RawMessage(`{"title":"Edited Course"}`),
+				CreatedAt:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				Hash:       "hash",
+			},
+		}
+
+		mockRepo := &mocks.AuditRepositoryMock{
+			ListAuditEntriesFunc: func(
+				ctx context.Context,
+				params domain.ListAuditEntriesParams,
+			) (*domain.Page[domain.AuditEntry], error) {
+				return &domain.Page[domain.AuditEntry]{Items: entries, TotalCount: 1}, nil
+			},
+		}
+
+		h := &handlers.Handlers{Audit: mockRepo}
+
+		createdFrom := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+		req := handlers.ListAuditLogParams{
+			PaginationParams: handlers.PaginationParams{SortDir: "desc"},
+			Action:           string(domain.AuditActionEditCourse),
+			TargetType:       domain.AuditTargetCourse,
+			CreatedFrom:      &createdFrom,
+		}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "audit-log")
+
+		err := h.ListAuditLog(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.ListAuditEntriesCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListAuditLogHandlerName)
+
+		expectedParams := domain.ListAuditEntriesParams{
+			PageParams:  domain.PageParams{SortDir: domain.SortDescending},
+			Action:      string(domain.AuditActionEditCourse),
+			TargetType:  domain.AuditTargetCourse,
+			CreatedFrom: &createdFrom,
+		}
+		if diff := cmp.Diff(expectedParams, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+
+		var actual domain.Page[domain.AuditEntry]
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(entries, actual.Items); diff != "" {
+			t.Errorf("entries mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		h := &handlers.Handlers{Audit: &mocks.AuditRepositoryMock{}}
+
+		req := handlers.ListAuditLogParams{PaginationParams: handlers.PaginationParams{Cursor: "not-a-cursor"}}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "audit-log")
+
+		err := h.ListAuditLog(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.InvalidFormat("cursor"))
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Audit: &mocks.AuditRepositoryMock{
+				ListAuditEntriesFunc: func(
+					ctx context.Context,
+					params domain.ListAuditEntriesParams,
+				) (*domain.Page[domain.AuditEntry], error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "audit-log")
+
+		err := h.ListAuditLog(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("audit log"))
+	})
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	t.Run("returns the verification", func(t *testing.T) {
+		invalidID := int64(3)
+		expected := &domain.AuditVerification{EntriesChecked: 2, FirstInvalidID: &invalidID}
+
+		h := &handlers.Handlers{
+			Audit: &mocks.AuditRepositoryMock{
+				VerifyAuditLogFunc: func(ctx context.Context) (*domain.AuditVerification, error) {
+					return expected, nil
+				},
+			},
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, nil, "audit-log/verify")
+
+		err := h.VerifyAuditLog(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var actual domain.AuditVerification
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(*expected, actual); diff != "" {
+			t.Errorf("verification mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Audit: &mocks.AuditRepositoryMock{
+				VerifyAuditLogFunc: func(ctx context.Context) (*domain.AuditVerification, error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "audit-log/verify")
+
+		err := h.VerifyAuditLog(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("audit log"))
+	})
+}
+
+func TestRecordAudit(t *testing.T) {
+	t.Run("records the action with the state before and after", func(t *testing.T) {
+		auditRepo := newAuditMock()
+		h := &handlers.Handlers{
+			Course: &mocks.CourseRepositoryMock{
+				GetCourseFunc: func(ctx context.Context, id pgtype.UUID) (*domain.Course, error) {
+					return testhelpers.Course, nil
+				},
+				DeleteCourseFunc: func(ctx context.Context, id uuid.UUID) error {
+					return nil
+				},
+			},
+			Audit: auditRepo,
+		}
+
+		req := handlers.DeleteCourseParams{CourseID: testhelpers.Course.ID.String()}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "course/delete")
+
+		if err := h.DeleteCourse(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.DeleteCourseHandlerName)
+
+		before, err := json.Marshal(testhelpers.Course)
+		if err != nil {
+			t.Fatalf("failed to marshal course: %v", err)
+		}
+
+		expected := domain.AddAuditEntryParams{
+			ActorID:    testhelpers.TestUserID,
+			Action:     domain.AuditActionDeleteCourse,
+			TargetType: domain.AuditTargetCourse,
+			TargetID:   testhelpers.Course.ID.String(),
+			Before:     before,
+		}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("audit entry mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("failing to record fails the action", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Course: &mocks.CourseRepositoryMock{
+				AddCourseFunc: func(ctx context.Context, params *domain.AddCourseParams) (*domain.Course, error) {
+					return testhelpers.Course, nil
+				},
+			},
+			Audit: withAuditEntry(&mocks.AuditRepositoryMock{
+				AddAuditEntryFunc: func(ctx context.Context, params domain.AddAuditEntryParams) error {
+					return stdErrors.New("db error")
+				},
+			}),
+		}
+
+		req := handlers.AddCourseParams{
+			Title:             "New Course",
+			Description:       "New Description",
+			CompletionTitle:   "Completion Title",
+			CompletionMessage: "Completion Message",
+		}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "course/add")
+
+		err := h.AddCourse(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Creating("audit entry"))
+	})
+}
@@ -1,15 +1,22 @@
 package handlers
 
 import (
+	"context"
+	stdErrors "errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/auth"
 )
 
-const userResource = "user"
+const (
+	userResource        = "user"
+	accessTokenResource = "access token"
+	passwordResource    = "password"
+)
 
 type RegisterParams struct {
 	Name     string `json:"name" validate:"required"`
@@ -30,14 +37,89 @@ func (h *Handlers) Register(e echo.Context) error {
 		return httpError(http.StatusInternalServerError, errors.Creating(userResource), err)
 	}
 
-	user, err := h.Auth.RegisterUser(ctx, domain.RegisterParams{
-		ID:    userID,
-		Name:  params.Name,
-		Email: params.Email,
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		user, err := h.Auth.RegisterUser(ctx, domain.RegisterParams{
+			ID:    userID,
+			Name:  params.Name,
+			Email: params.Email,
+		})
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(userResource), err)
+		}
+
+		// The password isn't recorded
+		return auditEntry(ctx, domain.AuditActionRegisterUser, domain.AuditTargetUser, user.ID, nil, user)
 	})
 	if err != nil {
-		return httpError(http.StatusInternalServerError, errors.Creating(userResource), err)
+		return err
+	}
+
+	return e.JSON(http.StatusOK, map[string]string{"newUserId": userID})
+}
+
+type SignInParams struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type SignInResponse struct {
+	AccessToken string `json:"accessToken"`
+}
+
+// SignIn returns an access token for users of the local auth provider, Firebase users sign in
+// with Firebase from the client
+func (h *Handlers) SignIn(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	provider, ok := h.AuthProvider.(auth.PasswordAuthProvider)
+	if !ok {
+		return httpError(http.StatusNotFound, errors.NotFound("sign in"), nil)
+	}
+
+	var params SignInParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	token, err := provider.SignIn(ctx, params.Email, params.Password)
+	if err != nil {
+		if stdErrors.Is(err, auth.ErrInvalidCredentials) || stdErrors.Is(err, auth.ErrUserDisabled) {
+			return httpError(http.StatusUnauthorized, errors.Unauthorised, err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Getting(accessTokenResource), err)
+	}
+
+	return e.JSON(http.StatusOK, &SignInResponse{AccessToken: token})
+}
+
+type SetPasswordParams struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// SetPassword sets a local auth user's password from a password setup link
+func (h *Handlers) SetPassword(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	provider, ok := h.AuthProvider.(auth.PasswordAuthProvider)
+	if !ok {
+		return httpError(http.StatusNotFound, errors.NotFound("set password"), nil)
+	}
+
+	var params SetPasswordParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	err := provider.SetPassword(ctx, params.Token, params.Password)
+	if err != nil {
+		if stdErrors.Is(err, auth.ErrInvalidToken) || errors.IsNotFoundErr(err) {
+			return httpError(http.StatusUnauthorized, errors.Unauthorised, err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Updating(passwordResource), err)
 	}
 
-	return e.JSON(http.StatusOK, map[string]string{"newUserId": user.ID})
+	return e.NoContent(http.StatusNoContent)
 }
@@ -0,0 +1,206 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/services/auth"
+)
+
+const (
+	testEmail    = "test@example.com"
+	testPassword = "password123"
+)
+
+func TestSignIn(t *testing.T) {
+	t.Run("returns access token", func(t *testing.T) {
+		mockProvider := &mocks.PasswordAuthProviderMock{
+			SignInFunc: func(ctx context.Context, email, password string) (string, error) {
+				return "access-token", nil
+			},
+		}
+
+		h := &handlers.Handlers{AuthProvider: mockProvider}
+
+		req := handlers.SignInParams{Email: testEmail, Password: testPassword}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "auth/sign-in")
+
+		err := h.SignIn(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		calls := mockProvider.SignInCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SignInHandlerName)
+		if calls[0].Email != testEmail || calls[0].Password != testPassword {
+			t.Errorf("expected sign in as %s, got %s", testEmail, calls[0].Email)
+		}
+
+		var actual handlers.SignInResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if actual.AccessToken != "access-token" {
+			t.Errorf("expected access token %q, got %q", "access-token", actual.AccessToken)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.SignInParams
+		providerErr    error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing password",
+			reqBody:        handlers.SignInParams{Email: testEmail},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "invalid credentials",
+			reqBody:        handlers.SignInParams{Email: testEmail, Password: testPassword},
+			providerErr:    auth.ErrInvalidCredentials,
+			wantStatus:     http.StatusUnauthorized,
+			expectedErrMsg: errors.Unauthorised,
+		},
+		{
+			name:           "disabled user",
+			reqBody:        handlers.SignInParams{Email: testEmail, Password: testPassword},
+			providerErr:    auth.ErrUserDisabled,
+			wantStatus:     http.StatusUnauthorized,
+			expectedErrMsg: errors.Unauthorised,
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.SignInParams{Email: testEmail, Password: testPassword},
+			providerErr:    stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Getting("access token"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				AuthProvider: &mocks.PasswordAuthProviderMock{
+					SignInFunc: func(ctx context.Context, email, password string) (string, error) {
+						return "", tt.providerErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "auth/sign-in")
+			err := h.SignIn(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+
+	t.Run("not found for providers without passwords", func(t *testing.T) {
+		h := &handlers.Handlers{AuthProvider: &mocks.AuthProviderMock{}}
+
+		req := handlers.SignInParams{Email: testEmail, Password: testPassword}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "auth/sign-in")
+
+		err := h.SignIn(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusNotFound, errors.NotFound("sign in"))
+	})
+}
+
+func TestSetPassword(t *testing.T) {
+	t.Run("sets password", func(t *testing.T) {
+		mockProvider := &mocks.PasswordAuthProviderMock{
+			SetPasswordFunc: func(ctx context.Context, token, password string) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{AuthProvider: mockProvider}
+
+		req := handlers.SetPasswordParams{Token: "setup-token", Password: testPassword}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "auth/set-password")
+
+		err := h.SetPassword(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockProvider.SetPasswordCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetPasswordHandlerName)
+		if calls[0].Token != "setup-token" {
+			t.Errorf("expected token %q, got %q", "setup-token", calls[0].Token)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.SetPasswordParams
+		providerErr    error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - password too short",
+			reqBody:        handlers.SetPasswordParams{Token: "setup-token", Password: "short"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "invalid token",
+			reqBody:        handlers.SetPasswordParams{Token: "setup-token", Password: testPassword},
+			providerErr:    fmt.Errorf("%w: expired", auth.ErrInvalidToken),
+			wantStatus:     http.StatusUnauthorized,
+			expectedErrMsg: errors.Unauthorised,
+		},
+		{
+			name:           "user not found",
+			reqBody:        handlers.SetPasswordParams{Token: "setup-token", Password: testPassword},
+			providerErr:    pgx.ErrNoRows,
+			wantStatus:     http.StatusUnauthorized,
+			expectedErrMsg: errors.Unauthorised,
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.SetPasswordParams{Token: "setup-token", Password: testPassword},
+			providerErr:    stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("password"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				AuthProvider: &mocks.PasswordAuthProviderMock{
+					SetPasswordFunc: func(ctx context.Context, token, password string) error {
+						return tt.providerErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "auth/set-password")
+			err := h.SetPassword(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
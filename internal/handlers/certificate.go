@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/middleware"
+	"github.com/supanova-rp/supanova-server/internal/services/certificate"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const (
+	certificateResource         = "certificate"
+	certificateValidityResource = "certificate validity"
+)
+
+// verificationIDBytes is the length of a certificate's verification ID before it's encoded, long
+// enough that IDs can't be guessed
+const verificationIDBytes = 16
+
+// GetCertificateParams get the caller's own certificate, or another learner's if UserID is set,
+// which needs permission to view progress
+type GetCertificateParams struct {
+	CourseID string `json:"courseId" validate:"required"`
+	UserID   string `json:"userId"`
+}
+
+// CertificateResponse has a signed URL the certificate's PDF can be downloaded from, which expires
+// after a few hours
+type CertificateResponse struct {
+	*domain.Certificate
+	URL string `json:"url"`
+}
+
+// GetCertificate returns the certificate from the learner's latest completion of the course
+func (h *Handlers) GetCertificate(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params GetCertificateParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	if params.UserID != "" && params.UserID != userID {
+		if !middleware.ContextHasPermission(ctx, middleware.PermissionViewProgress) {
+			return httpError(http.StatusForbidden, errors.Forbidden(certificateResource), nil)
+		}
+		userID = params.UserID
+	}
+
+	cert, err := h.Certificate.GetLatestCertificate(ctx, domain.GetLatestCertificateParams{
+		UserID:   userID,
+		CourseID: courseID,
+	})
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(certificateResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Getting(certificateResource), err)
+	}
+
+	response, err := h.certificateResponse(ctx, cert)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(certificateResource), err)
+	}
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// VerifyCertificate is public so anyone given a certificate can check it's genuine and still valid,
+// it's found by the verification ID printed on the certificate rather than its ID
+func (h *Handlers) VerifyCertificate(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	verificationID := e.Param("verificationId")
+	if verificationID == "" {
+		return httpError(http.StatusBadRequest, errors.Validation, nil)
+	}
+
+	cert, err := h.Certificate.GetCertificateByVerificationID(ctx, verificationID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(certificateResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Getting(certificateResource), err)
+	}
+
+	return e.JSON(http.StatusOK, cert.Verification(time.Now()))
+}
+
+type RevokeCertificateParams struct {
+	CertificateID string `json:"certificateId" validate:"required"`
+	Reason        string `json:"reason" validate:"required,max=500"`
+}
+
+// RevokeCertificate marks a certificate as no longer valid, e.g. if it was issued in error. It's
+// kept so its verification page shows it was revoked.
+func (h *Handlers) RevokeCertificate(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params RevokeCertificateParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(params.CertificateID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		err := h.Certificate.RevokeCertificate(ctx, domain.RevokeCertificateParams{
+			ID:        id,
+			Reason:    params.Reason,
+			RevokedBy: userID,
+		})
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(certificateResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(certificateResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionRevokeCertificate, domain.AuditTargetCertificate, id.String(), nil, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+type SetCertificateValidityParams struct {
+	CourseID       string `json:"courseId" validate:"required"`
+	ValidityMonths *int   `json:"validityMonths" validate:"omitempty,min=1,max=600"`
+}
+
+// SetCertificateValidity sets how long certificates issued for the course are valid for, leaving
+// out validityMonths means they never expire
+func (h *Handlers) SetCertificateValidity(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params SetCertificateValidityParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		err := h.Certificate.SetCertificateValidity(ctx, domain.SetCertificateValidityParams{
+			CourseID:       courseID,
+			ValidityMonths: params.ValidityMonths,
+		})
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound("course"), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(certificateValidityResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionSetCertificateValidity, domain.AuditTargetCourse, params.CourseID, nil, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// issueCertificate renders a learner's completion certificate and stores it. The certificate is
+// added first for its number, and removed again if it can't be stored.
+func (h *Handlers) issueCertificate(
+	ctx context.Context,
+	userID string,
+	courseID uuid.UUID,
+	completedAt time.Time,
+) (*domain.Certificate, error) {
+	id := uuid.New()
+
+	verificationID, err := utils.RandomToken(verificationIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := h.Certificate.AddCertificate(ctx, domain.AddCertificateParams{
+		ID:             id,
+		UserID:         userID,
+		CourseID:       courseID,
+		StorageKey:     getCertificateKey(courseID, id),
+		VerificationID: verificationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.storeCertificate(ctx, cert, completedAt)
+	if err != nil {
+		if deleteErr := h.Certificate.DeleteCertificate(ctx, id); deleteErr != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to delete unstored certificate",
+				slog.Any("error", deleteErr),
+				slog.String("certificate_id", id.String()),
+			)
+		}
+
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+func (h *Handlers) storeCertificate(ctx context.Context, cert *domain.Certificate, completedAt time.Time) error {
+	pdf, err := h.CertificateRenderer.Render(&certificate.Data{
+		Number:          cert.Number,
+		VerificationID:  cert.VerificationID,
+		LearnerName:     cert.UserName,
+		CourseTitle:     cert.CourseTitle,
+		CompletionTitle: cert.CompletionTitle,
+		CompletionDate:  completedAt.In(location).Format("2 January 2006"),
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.ObjectStorage.Upload(ctx, cert.StorageKey, pdf, certificate.ContentType)
+}
+
+func (h *Handlers) certificateResponse(ctx context.Context, cert *domain.Certificate) (*CertificateResponse, error) {
+	url, err := h.ObjectStorage.GetCDNURL(ctx, cert.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertificateResponse{Certificate: cert, URL: url}, nil
+}
+
+func getCertificateKey(courseID, certificateID uuid.UUID) string {
+	return fmt.Sprintf("%s/certificates/%s.pdf", courseID, certificateID)
+}
@@ -0,0 +1,480 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+)
+
+var issuedCertificate = &domain.Certificate{
+	ID:              uuid.New(),
+	Number:          "SN-000001",
+	UserID:          testhelpers.TestUserID,
+	UserName:        "Test User",
+	CourseID:        testhelpers.Course.ID,
+	CourseTitle:     testhelpers.Course.Title,
+	CompletionTitle: testhelpers.Course.CompletionTitle,
+	StorageKey:      "certificate-key",
+	VerificationID:  "verification-id",
+	IssuedAt:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// newCertificateStorageMock stores certificates and signs a URL for their key
+func newCertificateStorageMock() *mocks.ObjectStorageMock {
+	return &mocks.ObjectStorageMock{
+		UploadFunc: func(ctx context.Context, key string, body []byte, contentType string) error {
+			return nil
+		},
+		GetCDNURLFunc: func(ctx context.Context, key string) (string, error) {
+			return "https://cdn.example.com/" + key, nil
+		},
+	}
+}
+
+func TestGetCertificate(t *testing.T) {
+	t.Run("returns the learner's own certificate", func(t *testing.T) {
+		mockRepo := &mocks.CertificateRepositoryMock{
+			GetLatestCertificateFunc: func(
+				ctx context.Context,
+				params domain.GetLatestCertificateParams,
+			) (*domain.Certificate, error) {
+				return issuedCertificate, nil
+			},
+		}
+
+		h := &handlers.Handlers{Certificate: mockRepo, ObjectStorage: newCertificateStorageMock()}
+
+		req := handlers.GetCertificateParams{CourseID: testhelpers.Course.ID.String()}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "certificate", testhelpers.WithRole(config.UserRole))
+
+		if err := h.GetCertificate(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.GetLatestCertificateCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetCertificateHandlerName)
+
+		expectedParams := domain.GetLatestCertificateParams{UserID: testhelpers.TestUserID, CourseID: testhelpers.Course.ID}
+		if diff := cmp.Diff(expectedParams, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+
+		var actual map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if actual["number"] != issuedCertificate.Number || actual["url"] != "https://cdn.example.com/certificate-key" {
+			t.Errorf("unexpected certificate response %v", actual)
+		}
+		if _, ok := actual["StorageKey"]; ok {
+			t.Error("expected the storage key not to be returned")
+		}
+	})
+
+	t.Run("admins can get another learner's certificate", func(t *testing.T) {
+		mockRepo := &mocks.CertificateRepositoryMock{
+			GetLatestCertificateFunc: func(
+				ctx context.Context,
+				params domain.GetLatestCertificateParams,
+			) (*domain.Certificate, error) {
+				return issuedCertificate, nil
+			},
+		}
+
+		h := &handlers.Handlers{Certificate: mockRepo, ObjectStorage: newCertificateStorageMock()}
+
+		req := handlers.GetCertificateParams{CourseID: testhelpers.Course.ID.String(), UserID: "other-user"}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "certificate")
+
+		if err := h.GetCertificate(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.GetLatestCertificateCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetCertificateHandlerName)
+
+		if calls[0].Params.UserID != "other-user" {
+			t.Errorf("expected the other learner's certificate, got %s", calls[0].Params.UserID)
+		}
+	})
+}
+
+func TestGetCertificate_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		reqBody        handlers.GetCertificateParams
+		opts           []testhelpers.EchoTestOption
+		setup          func() *handlers.Handlers
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	tests := []testCase{
+		{
+			name:           "validation - missing courseId",
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Certificate: &mocks.CertificateRepositoryMock{}}
+			},
+		},
+		{
+			name:           "learners can't get another learner's certificate",
+			reqBody:        handlers.GetCertificateParams{CourseID: testhelpers.Course.ID.String(), UserID: "other-user"},
+			opts:           []testhelpers.EchoTestOption{testhelpers.WithRole(config.UserRole)},
+			wantStatus:     http.StatusForbidden,
+			expectedErrMsg: errors.Forbidden("certificate"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Certificate: &mocks.CertificateRepositoryMock{}}
+			},
+		},
+		{
+			name:           "not found",
+			reqBody:        handlers.GetCertificateParams{CourseID: testhelpers.Course.ID.String()},
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("certificate"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Certificate: &mocks.CertificateRepositoryMock{
+						GetLatestCertificateFunc: func(
+							ctx context.Context,
+							params domain.GetLatestCertificateParams,
+						) (*domain.Certificate, error) {
+							return nil, pgx.ErrNoRows
+						},
+					},
+				}
+			},
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.GetCertificateParams{CourseID: testhelpers.Course.ID.String()},
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Getting("certificate"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Certificate: &mocks.CertificateRepositoryMock{
+						GetLatestCertificateFunc: func(
+							ctx context.Context,
+							params domain.GetLatestCertificateParams,
+						) (*domain.Certificate, error) {
+							return nil, stdErrors.New("db error")
+						},
+					},
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.setup()
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "certificate", tt.opts...)
+			err := h.GetCertificate(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	t.Run("returns the certificate's public details", func(t *testing.T) {
+		mockRepo := &mocks.CertificateRepositoryMock{
+			GetCertificateByVerificationIDFunc: func(ctx context.Context, verificationID string) (*domain.Certificate, error) {
+				return issuedCertificate, nil
+			},
+		}
+
+		h := &handlers.Handlers{Certificate: mockRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, nil, "certificates/verify/verification-id")
+		ctx.SetParamNames("verificationId")
+		ctx.SetParamValues("verification-id")
+
+		if err := h.VerifyCertificate(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.GetCertificateByVerificationIDCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.VerifyCertificateHandlerName)
+
+		if calls[0].VerificationID != "verification-id" {
+			t.Errorf("expected verification ID verification-id, got %s", calls[0].VerificationID)
+		}
+
+		var actual map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if actual["holderName"] != issuedCertificate.UserName || actual["status"] != string(domain.CertificateStatusValid) {
+			t.Errorf("unexpected verification response %v", actual)
+		}
+		if _, ok := actual["userId"]; ok {
+			t.Error("expected the holder's user ID not to be returned")
+		}
+	})
+
+	t.Run("shows revoked certificates as revoked", func(t *testing.T) {
+		revokedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		expiresAt := revokedAt
+		revoked := *issuedCertificate
+		revoked.RevokedAt = &revokedAt
+		revoked.RevokeReason = "Issued in error"
+		revoked.ExpiresAt = &expiresAt
+
+		h := &handlers.Handlers{
+			Certificate: &mocks.CertificateRepositoryMock{
+				GetCertificateByVerificationIDFunc: func(ctx context.Context, verificationID string) (*domain.Certificate, error) {
+					return &revoked, nil
+				},
+			},
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, nil, "certificates/verify/verification-id")
+		ctx.SetParamNames("verificationId")
+		ctx.SetParamValues("verification-id")
+
+		if err := h.VerifyCertificate(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var actual domain.CertificateVerification
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		// Revoked takes priority over expired
+		if !actual.Revoked || actual.Status != domain.CertificateStatusRevoked {
+			t.Errorf("expected the certificate to be revoked, got %+v", actual)
+		}
+		if bytes.Contains(rec.Body.Bytes(), []byte(revoked.RevokeReason)) {
+			t.Error("expected the revoke reason not to be returned")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Certificate: &mocks.CertificateRepositoryMock{
+				GetCertificateByVerificationIDFunc: func(ctx context.Context, verificationID string) (*domain.Certificate, error) {
+					return nil, pgx.ErrNoRows
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "certificates/verify/unknown")
+		ctx.SetParamNames("verificationId")
+		ctx.SetParamValues("unknown")
+
+		err := h.VerifyCertificate(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusNotFound, errors.NotFound("certificate"))
+	})
+}
+
+func TestRevokeCertificate(t *testing.T) {
+	t.Run("revokes the certificate and records it", func(t *testing.T) {
+		mockRepo := &mocks.CertificateRepositoryMock{
+			RevokeCertificateFunc: func(ctx context.Context, params domain.RevokeCertificateParams) error {
+				return nil
+			},
+		}
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{Certificate: mockRepo, Audit: auditRepo}
+
+		req := handlers.RevokeCertificateParams{CertificateID: issuedCertificate.ID.String(), Reason: "Issued in error"}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "certificates/revoke")
+
+		if err := h.RevokeCertificate(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRepo.RevokeCertificateCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.RevokeCertificateHandlerName)
+
+		expectedParams := domain.RevokeCertificateParams{
+			ID:        issuedCertificate.ID,
+			Reason:    "Issued in error",
+			RevokedBy: testhelpers.TestUserID,
+		}
+		if diff := cmp.Diff(expectedParams, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+
+		entries := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(entries), 1, testhelpers.RevokeCertificateHandlerName)
+
+		if entries[0].Params.Action != domain.AuditActionRevokeCertificate ||
+			entries[0].Params.TargetID != issuedCertificate.ID.String() {
+			t.Errorf("unexpected audit entry %+v", entries[0].Params)
+		}
+	})
+}
+
+func TestRevokeCertificate_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		reqBody        handlers.RevokeCertificateParams
+		setup          func() *handlers.Handlers
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	tests := []testCase{
+		{
+			name:           "validation - missing reason",
+			reqBody:        handlers.RevokeCertificateParams{CertificateID: issuedCertificate.ID.String()},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Certificate: &mocks.CertificateRepositoryMock{}}
+			},
+		},
+		{
+			name:           "invalid certificate id",
+			reqBody:        handlers.RevokeCertificateParams{CertificateID: "invalid", Reason: "Issued in error"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Certificate: &mocks.CertificateRepositoryMock{}}
+			},
+		},
+		{
+			name:           "not found",
+			reqBody:        handlers.RevokeCertificateParams{CertificateID: issuedCertificate.ID.String(), Reason: "Issued in error"},
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("certificate"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Certificate: &mocks.CertificateRepositoryMock{
+						RevokeCertificateFunc: func(ctx context.Context, params domain.RevokeCertificateParams) error {
+							return pgx.ErrNoRows
+						},
+					},
+					Audit: newAuditMock(),
+				}
+			},
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.RevokeCertificateParams{CertificateID: issuedCertificate.ID.String(), Reason: "Issued in error"},
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("certificate"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Certificate: &mocks.CertificateRepositoryMock{
+						RevokeCertificateFunc: func(ctx context.Context, params domain.RevokeCertificateParams) error {
+							return stdErrors.New("db error")
+						},
+					},
+					Audit: newAuditMock(),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.setup()
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "certificates/revoke")
+			err := h.RevokeCertificate(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestSetCertificateValidity(t *testing.T) {
+	t.Run("sets the course's certificate validity", func(t *testing.T) {
+		mockRepo := &mocks.CertificateRepositoryMock{
+			SetCertificateValidityFunc: func(ctx context.Context, params domain.SetCertificateValidityParams) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{Certificate: mockRepo, Audit: newAuditMock()}
+
+		months := 12
+		req := handlers.SetCertificateValidityParams{CourseID: testhelpers.Course.ID.String(), ValidityMonths: &months}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "courses/certificate-validity")
+
+		if err := h.SetCertificateValidity(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.SetCertificateValidityCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetCertificateValidityHandlerName)
+
+		expectedParams := domain.SetCertificateValidityParams{CourseID: testhelpers.Course.ID, ValidityMonths: &months}
+		if diff := cmp.Diff(expectedParams, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestSetCertificateValidity_UnhappyPath(t *testing.T) {
+	months := 0
+
+	type testCase struct {
+		name           string
+		reqBody        handlers.SetCertificateValidityParams
+		setup          func() *handlers.Handlers
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	tests := []testCase{
+		{
+			name:           "validation - validity too short",
+			reqBody:        handlers.SetCertificateValidityParams{CourseID: testhelpers.Course.ID.String(), ValidityMonths: &months},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Certificate: &mocks.CertificateRepositoryMock{}}
+			},
+		},
+		{
+			name:           "course not found",
+			reqBody:        handlers.SetCertificateValidityParams{CourseID: testhelpers.Course.ID.String()},
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("course"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Certificate: &mocks.CertificateRepositoryMock{
+						SetCertificateValidityFunc: func(ctx context.Context, params domain.SetCertificateValidityParams) error {
+							return pgx.ErrNoRows
+						},
+					},
+					Audit: newAuditMock(),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.setup()
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "courses/certificate-validity")
+			err := h.SetCertificateValidity(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
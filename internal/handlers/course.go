@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -146,9 +147,18 @@ func (h *Handlers) AddCourse(e echo.Context) error {
 		return err
 	}
 
-	course, err := h.Course.AddCourse(ctx, addCourseParamsFrom(&req))
+	var course *domain.Course
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		course, err = h.Course.AddCourse(ctx, addCourseParamsFrom(&req))
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(courseResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionAddCourse, domain.AuditTargetCourse, course.ID.String(), nil, course)
+	})
 	if err != nil {
-		return httpError(http.StatusInternalServerError, errors.Creating(courseResource), err)
+		return err
 	}
 
 	return e.JSON(http.StatusCreated, course)
@@ -320,9 +330,27 @@ func (h *Handlers) EditCourse(e echo.Context) error {
 		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
 	}
 
-	course, err := h.Course.EditCourse(ctx, params)
+	if err := h.checkCanEditCourse(ctx, params.CourseID); err != nil {
+		return err
+	}
+
+	before, err := h.getCourseForAudit(ctx, params.CourseID)
 	if err != nil {
-		return httpError(http.StatusInternalServerError, errors.Updating(courseResource), err)
+		return err
+	}
+
+	var course *domain.Course
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		course, err = h.Course.EditCourse(ctx, params)
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(courseResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionEditCourse, domain.AuditTargetCourse, req.CourseID, before, course)
+	})
+	if err != nil {
+		return err
 	}
 
 	return e.JSON(http.StatusOK, course)
@@ -503,13 +531,39 @@ func (h *Handlers) DeleteCourse(e echo.Context) error {
 		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
 	}
 
-	if err := h.Course.DeleteCourse(ctx, courseID); err != nil {
-		return httpError(http.StatusInternalServerError, errors.Deleting(courseResource), err)
+	before, err := h.getCourseForAudit(ctx, courseID)
+	if err != nil {
+		return err
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Course.DeleteCourse(ctx, courseID); err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(courseResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionDeleteCourse, domain.AuditTargetCourse, params.CourseID, before, nil)
+	})
+	if err != nil {
+		return err
 	}
 
 	return e.JSON(http.StatusOK, params.CourseID)
 }
 
+// getCourseForAudit returns the course as it was before it's changed, for the audit log
+func (h *Handlers) getCourseForAudit(ctx context.Context, courseID uuid.UUID) (*domain.Course, error) {
+	course, err := h.Course.GetCourse(ctx, utils.PGUUIDFromUUID(courseID))
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return nil, httpError(http.StatusNotFound, errors.NotFound(courseResource), err)
+		}
+
+		return nil, httpError(http.StatusInternalServerError, errors.Getting(courseResource), err)
+	}
+
+	return course, nil
+}
+
 func (h *Handlers) GetCourses(e echo.Context) error {
 	ctx := e.Request().Context()
 
@@ -521,6 +575,32 @@ func (h *Handlers) GetCourses(e echo.Context) error {
 	return e.JSON(http.StatusOK, courses)
 }
 
+type ListCoursesParams struct {
+	PaginationParams
+}
+
+func (h *Handlers) ListCourses(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListCoursesParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	// Courses can only be sorted by title
+	pageParams, err := params.pageParams("", pageFilters{})
+	if err != nil {
+		return err
+	}
+
+	courses, err := h.Course.ListCourses(ctx, pageParams)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(coursesResource), err)
+	}
+
+	return e.JSON(http.StatusOK, courses)
+}
+
 func (h *Handlers) GetCoursesOverview(e echo.Context) error {
 	ctx := e.Request().Context()
 
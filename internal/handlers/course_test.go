@@ -6,6 +6,7 @@ import (
 	stdErrors "errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
@@ -238,7 +239,7 @@ func TestAddCourse_HappyPath(t *testing.T) {
 			},
 		}
 
-		h := &handlers.Handlers{Course: mockRepo}
+		h := &handlers.Handlers{Course: mockRepo, Audit: newAuditMock()}
 
 		reqBody := handlers.AddCourseParams{
 			Title:             "New Course",
@@ -328,6 +329,7 @@ func TestAddCourse_UnhappyPath(t *testing.T) {
 							return nil, stdErrors.New("database connection failed")
 						},
 					},
+					Audit: newAuditMock(),
 				}
 			},
 		},
@@ -455,21 +457,29 @@ func TestGetCoursesOverview_HappyPath(t *testing.T) {
 
 func TestGetAssignedCourseTitles_HappyPath(t *testing.T) {
 	t.Run("returns assigned course titles successfully", func(t *testing.T) {
-		expected := []domain.CourseOverview{
+		dueDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		expected := []domain.AssignedCourseOverview{
 			{
-				ID:          testhelpers.Course.ID,
-				Title:       testhelpers.Course.Title,
-				Description: testhelpers.Course.Description,
+				CourseOverview: domain.CourseOverview{
+					ID:          testhelpers.Course.ID,
+					Title:       testhelpers.Course.Title,
+					Description: testhelpers.Course.Description,
+				},
+				DueDate: &dueDate,
+				Status:  domain.EnrolmentStatusOverdue,
 			},
 			{
-				ID:          uuid.New(),
-				Title:       "Course 2",
-				Description: "Description 2",
+				CourseOverview: domain.CourseOverview{
+					ID:          uuid.New(),
+					Title:       "Course 2",
+					Description: "Description 2",
+				},
+				Status: domain.EnrolmentStatusNotStarted,
 			},
 		}
 
 		mockRepo := &mocks.CourseRepositoryMock{
-			GetAssignedCourseTitlesFunc: func(ctx context.Context, userID string) ([]domain.CourseOverview, error) {
+			GetAssignedCourseTitlesFunc: func(ctx context.Context, userID string) ([]domain.AssignedCourseOverview, error) {
 				return expected, nil
 			},
 		}
@@ -487,7 +497,7 @@ func TestGetAssignedCourseTitles_HappyPath(t *testing.T) {
 			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
 		}
 
-		var actual []domain.CourseOverview
+		var actual []domain.AssignedCourseOverview
 		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
 			t.Fatalf("failed to unmarshal response: %v", err)
 		}
@@ -501,8 +511,8 @@ func TestGetAssignedCourseTitles_HappyPath(t *testing.T) {
 
 	t.Run("returns empty slice when no courses assigned", func(t *testing.T) {
 		mockRepo := &mocks.CourseRepositoryMock{
-			GetAssignedCourseTitlesFunc: func(ctx context.Context, userID string) ([]domain.CourseOverview, error) {
-				return []domain.CourseOverview{}, nil
+			GetAssignedCourseTitlesFunc: func(ctx context.Context, userID string) ([]domain.AssignedCourseOverview, error) {
+				return []domain.AssignedCourseOverview{}, nil
 			},
 		}
 
@@ -519,7 +529,7 @@ func TestGetAssignedCourseTitles_HappyPath(t *testing.T) {
 			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
 		}
 
-		var actual []domain.CourseOverview
+		var actual []domain.AssignedCourseOverview
 		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
 			t.Fatalf("failed to unmarshal response: %v", err)
 		}
@@ -558,7 +568,7 @@ func TestGetAssignedCourseTitles_UnhappyPath(t *testing.T) {
 			setup: func() *handlers.Handlers {
 				return &handlers.Handlers{
 					Course: &mocks.CourseRepositoryMock{
-						GetAssignedCourseTitlesFunc: func(ctx context.Context, userID string) ([]domain.CourseOverview, error) {
+						GetAssignedCourseTitlesFunc: func(ctx context.Context, userID string) ([]domain.AssignedCourseOverview, error) {
 							return nil, stdErrors.New("database connection failed")
 						},
 					},
@@ -749,12 +759,16 @@ func TestEditCourse_HappyPath(t *testing.T) {
 		expected := testhelpers.Course
 
 		mockRepo := &mocks.CourseRepositoryMock{
+			GetCourseFunc: func(_ context.Context, _ pgtype.UUID) (*domain.Course, error) {
+				return expected, nil
+			},
 			EditCourseFunc: func(_ context.Context, _ *domain.EditCourseParams) (*domain.Course, error) {
 				return expected, nil
 			},
 		}
+		auditRepo := newAuditMock()
 
-		h := &handlers.Handlers{Course: mockRepo}
+		h := &handlers.Handlers{Course: mockRepo, Audit: auditRepo}
 
 		ctx, rec := testhelpers.SetupEchoContext(t, validEditCourseRequest(), "edit-course")
 
@@ -777,6 +791,7 @@ func TestEditCourse_HappyPath(t *testing.T) {
 		}
 
 		testhelpers.AssertRepoCalls(t, len(mockRepo.EditCourseCalls()), 1, testhelpers.EditCourseHandlerName)
+		testhelpers.AssertRepoCalls(t, len(auditRepo.AddAuditEntryCalls()), 1, testhelpers.EditCourseHandlerName)
 	})
 }
 
@@ -895,14 +910,30 @@ func TestEditCourse_UnhappyPath(t *testing.T) {
 		}
 		h := &handlers.Handlers{
 			Course: &mocks.CourseRepositoryMock{
+				GetCourseFunc: func(_ context.Context, _ pgtype.UUID) (*domain.Course, error) {
+					return testhelpers.Course, nil
+				},
 				EditCourseFunc: func(_ context.Context, _ *domain.EditCourseParams) (*domain.Course, error) {
 					return nil, stdErrors.New("database connection failed")
 				},
 			},
+			Audit: newAuditMock(),
 		}
 		ctx, _ := testhelpers.SetupEchoContext(t, req, "edit-course")
 		testhelpers.AssertHTTPError(t, h.EditCourse(ctx), http.StatusInternalServerError, errors.Updating("course"))
 	})
+
+	t.Run("course not found", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Course: &mocks.CourseRepositoryMock{
+				GetCourseFunc: func(_ context.Context, _ pgtype.UUID) (*domain.Course, error) {
+					return nil, pgx.ErrNoRows
+				},
+			},
+		}
+		ctx, _ := testhelpers.SetupEchoContext(t, validEditCourseRequest(), "edit-course")
+		testhelpers.AssertHTTPError(t, h.EditCourse(ctx), http.StatusNotFound, errors.NotFound("course"))
+	})
 }
 
 func validEditCourseRequest() handlers.EditCourseRequest {
@@ -1006,3 +1037,112 @@ func validEditCourseRequest() handlers.EditCourseRequest {
 		DeletedMaterialIDs: []string{deletedMaterialID},
 	}
 }
+
+func TestListCourses_HappyPath(t *testing.T) {
+	t.Run("returns the page of courses matching the search", func(t *testing.T) {
+		expected := &domain.Page[*domain.AllCourseLegacy]{
+			Items: []*domain.AllCourseLegacy{
+				{
+					ID:                testhelpers.Course.ID,
+					Title:             testhelpers.Course.Title,
+					Description:       testhelpers.Course.Description,
+					CompletionTitle:   testhelpers.Course.CompletionTitle,
+					CompletionMessage: testhelpers.Course.CompletionMessage,
+					Sections:          []domain.CourseSection{},
+					Materials:         []domain.CourseMaterial{},
+				},
+			},
+			TotalCount: 1,
+		}
+
+		mockRepo := &mocks.CourseRepositoryMock{
+			ListCoursesFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.AllCourseLegacy], error) {
+				return expected, nil
+			},
+		}
+
+		h := &handlers.Handlers{Course: mockRepo}
+
+		reqParams := handlers.ListCoursesParams{
+			PaginationParams: handlers.PaginationParams{Search: "100%_test", SortDir: "desc"},
+		}
+		ctx, rec := testhelpers.SetupEchoContext(t, reqParams, "courses/list")
+
+		err := h.ListCourses(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var actual *domain.Page[*domain.AllCourseLegacy]
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("courses mismatch (-want +got):\n%s", diff)
+		}
+
+		calls := mockRepo.ListCoursesCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListCoursesHandlerName)
+
+		if calls[0].Params.Search != "100%_test" {
+			t.Errorf("expected search %q, got %q", "100%_test", calls[0].Params.Search)
+		}
+
+		if !calls[0].Params.Descending() {
+			t.Error("expected descending sort")
+		}
+	})
+}
+
+func TestListCourses_UnhappyPath(t *testing.T) {
+	t.Run("invalid sort direction", func(t *testing.T) {
+		mockRepo := &mocks.CourseRepositoryMock{}
+		h := &handlers.Handlers{Course: mockRepo}
+
+		reqParams := handlers.ListCoursesParams{
+			PaginationParams: handlers.PaginationParams{SortDir: "sideways"},
+		}
+		ctx, _ := testhelpers.SetupEchoContext(t, reqParams, "courses/list")
+
+		err := h.ListCourses(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.Validation)
+		testhelpers.AssertRepoCalls(t, len(mockRepo.ListCoursesCalls()), 0, testhelpers.ListCoursesHandlerName)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockRepo := &mocks.CourseRepositoryMock{}
+		h := &handlers.Handlers{Course: mockRepo}
+
+		reqParams := handlers.ListCoursesParams{
+			PaginationParams: handlers.PaginationParams{Cursor: "not-a-cursor"},
+		}
+		ctx, _ := testhelpers.SetupEchoContext(t, reqParams, "courses/list")
+
+		err := h.ListCourses(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.InvalidFormat("cursor"))
+		testhelpers.AssertRepoCalls(t, len(mockRepo.ListCoursesCalls()), 0, testhelpers.ListCoursesHandlerName)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Course: &mocks.CourseRepositoryMock{
+				ListCoursesFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.AllCourseLegacy], error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "courses/list")
+
+		err := h.ListCourses(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("courses"))
+	})
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+)
+
+const (
+	emailResource           = "email"
+	emailPreferenceResource = "email preference"
+	failedEmailResource     = "failed email"
+	emailAttemptResource    = "email attempts"
+)
+
+// PreviewEmailParams choose the email to preview by name, e.g. course-completion. Variables replace
+// the email's sample template variables.
+type PreviewEmailParams struct {
+	EmailName string            `json:"emailName" validate:"required"`
+	Variables map[string]string `json:"variables"`
+}
+
+// PreviewEmail renders an email from its templates without sending it, so changes to the templates
+// can be checked
+func (h *Handlers) PreviewEmail(e echo.Context) error {
+	var params PreviewEmailParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	rendered, err := h.EmailService.Preview(params.EmailName, params.Variables)
+	if err != nil {
+		if stdErrors.Is(err, email.ErrUnknownEmail) {
+			return httpError(http.StatusNotFound, errors.NotFound(emailResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Getting(emailResource), err)
+	}
+
+	return e.JSON(http.StatusOK, rendered)
+}
+
+// SetEmailPreferenceParams opt the learner out of, or back in to, an optional email by name, e.g.
+// enrolment
+type SetEmailPreferenceParams struct {
+	EmailName string `json:"emailName" validate:"required"`
+	OptOut    bool   `json:"optOut"`
+}
+
+// GetEmailPreferences lists the emails the learner can opt out of, and whether they have. Emails
+// needed for compliance, like overdue training, aren't listed as they're always sent.
+func (h *Handlers) GetEmailPreferences(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	optOuts, err := h.EmailPreference.GetEmailOptOuts(ctx, userID)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(emailPreferenceResource), err)
+	}
+
+	preferences := &domain.EmailPreferences{Emails: make([]domain.EmailPreference, 0, len(email.OptionalEmails))}
+	for _, emailName := range email.OptionalEmails {
+		preferences.Emails = append(preferences.Emails, domain.EmailPreference{
+			EmailName: emailName,
+			OptedOut:  slices.Contains(optOuts, emailName),
+		})
+	}
+
+	return e.JSON(http.StatusOK, preferences)
+}
+
+func (h *Handlers) SetEmailPreference(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params SetEmailPreferenceParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	if !slices.Contains(email.OptionalEmails, params.EmailName) {
+		return httpError(http.StatusBadRequest, "only optional emails can be opted out of", nil)
+	}
+
+	err := h.EmailPreference.SetEmailOptOut(ctx, domain.SetEmailOptOutParams{
+		UserID:    userID,
+		EmailName: params.EmailName,
+		OptOut:    params.OptOut,
+	})
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Updating(emailPreferenceResource), err)
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+type ListFailedEmailsParams struct {
+	PaginationParams
+	Status string `json:"status" validate:"omitempty,oneof=pending dead"`
+}
+
+// ListFailedEmails lists emails that failed to send, oldest first, with their last error and how
+// many times they've been tried. Search matches the email name, e.g. course-completion.
+func (h *Handlers) ListFailedEmails(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListFailedEmailsParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	pageParams, err := params.pageParams("", pageFilters{})
+	if err != nil {
+		return err
+	}
+
+	failed, err := h.FailedEmail.ListFailedEmails(ctx, domain.ListFailedEmailsParams{
+		PageParams: pageParams,
+		Status:     domain.EmailStatus(params.Status),
+	})
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(failedEmailResource), err)
+	}
+
+	return e.JSON(http.StatusOK, failed)
+}
+
+type FailedEmailParams struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// GetEmailAttempts lists each attempt to send a failed email, oldest first, with the error it
+// failed with
+func (h *Handlers) GetEmailAttempts(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params FailedEmailParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(params.ID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	attempts, err := h.FailedEmail.GetEmailAttempts(ctx, id)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(emailAttemptResource), err)
+	}
+
+	return e.JSON(http.StatusOK, attempts)
+}
+
+// RetryFailedEmail sends a dead-lettered email again with the next dispatch. Failed emails that
+// haven't been dead-lettered are already retried by the dispatcher.
+func (h *Handlers) RetryFailedEmail(e echo.Context) error {
+	return h.updateFailedEmail(e, domain.AuditActionRetryEmail, h.FailedEmail.RetryFailedEmail)
+}
+
+// DiscardFailedEmail stops a failed email from being retried, e.g. if it's no longer relevant
+func (h *Handlers) DiscardFailedEmail(e echo.Context) error {
+	return h.updateFailedEmail(e, domain.AuditActionDiscardEmail, h.FailedEmail.DiscardFailedEmail)
+}
+
+func (h *Handlers) updateFailedEmail(
+	e echo.Context,
+	action domain.AuditAction,
+	update func(ctx context.Context, id uuid.UUID) error,
+) error {
+	ctx := e.Request().Context()
+
+	var params FailedEmailParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(params.ID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := update(ctx, id); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(failedEmailResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(failedEmailResource), err)
+		}
+
+		return auditEntry(ctx, action, domain.AuditTargetEmail, id.String(), nil, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,507 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+)
+
+func TestPreviewEmail(t *testing.T) {
+	t.Run("renders the email", func(t *testing.T) {
+		rendered := &email.RenderedEmail{
+			Subject: "Your Test Course progress has been reset",
+			Text:    "Hi User A,\n",
+			HTML:    "<p>Hi User A,</p>",
+		}
+
+		mockEmailService := &mocks.EmailServiceMock{
+			PreviewFunc: func(emailName string, variables map[string]string) (*email.RenderedEmail, error) {
+				return rendered, nil
+			},
+		}
+
+		h := &handlers.Handlers{EmailService: mockEmailService}
+
+		req := handlers.PreviewEmailParams{
+			EmailName: "progress-reset",
+			Variables: map[string]string{"user_name": "User A"},
+		}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "admin/emails/preview")
+
+		if err := h.PreviewEmail(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockEmailService.PreviewCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.PreviewEmailHandlerName)
+
+		if calls[0].EmailName != req.EmailName || calls[0].Variables["user_name"] != "User A" {
+			t.Errorf("unexpected preview call %+v", calls[0])
+		}
+
+		var actual email.RenderedEmail
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(rendered, &actual); diff != "" {
+			t.Errorf("email mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestPreviewEmail_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		reqBody        handlers.PreviewEmailParams
+		previewErr     error
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	tests := []testCase{
+		{
+			name:           "validation - missing emailName",
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "unknown email",
+			reqBody:        handlers.PreviewEmailParams{EmailName: "unknown"},
+			previewErr:     fmt.Errorf("%w: unknown", email.ErrUnknownEmail),
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("email"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.PreviewEmailParams{EmailName: "progress-reset"},
+			previewErr:     stdErrors.New("template error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Getting("email"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				EmailService: &mocks.EmailServiceMock{
+					PreviewFunc: func(emailName string, variables map[string]string) (*email.RenderedEmail, error) {
+						return nil, tt.previewErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "admin/emails/preview")
+			err := h.PreviewEmail(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestGetEmailPreferences(t *testing.T) {
+	t.Run("lists the optional emails", func(t *testing.T) {
+		mockRepo := &mocks.EmailPreferenceRepositoryMock{
+			GetEmailOptOutsFunc: func(ctx context.Context, userID string) ([]string, error) {
+				return []string{"not-started-reminder"}, nil
+			},
+		}
+
+		h := &handlers.Handlers{EmailPreference: mockRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, nil, "email-preferences")
+
+		if err := h.GetEmailPreferences(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.GetEmailOptOutsCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetEmailPreferencesHandlerName)
+
+		if calls[0].UserID != testhelpers.TestUserID {
+			t.Errorf("expected opt outs of %s, got %s", testhelpers.TestUserID, calls[0].UserID)
+		}
+
+		var actual domain.EmailPreferences
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		expected := domain.EmailPreferences{Emails: []domain.EmailPreference{
+			{EmailName: "enrolment", OptedOut: false},
+			{EmailName: "not-started-reminder", OptedOut: true},
+			{EmailName: "completion-confirmation", OptedOut: false},
+		}}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("preferences mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			EmailPreference: &mocks.EmailPreferenceRepositoryMock{
+				GetEmailOptOutsFunc: func(ctx context.Context, userID string) ([]string, error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "email-preferences")
+		err := h.GetEmailPreferences(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("email preference"))
+	})
+}
+
+func TestSetEmailPreference(t *testing.T) {
+	t.Run("opts out of the email", func(t *testing.T) {
+		mockRepo := &mocks.EmailPreferenceRepositoryMock{
+			SetEmailOptOutFunc: func(ctx context.Context, params domain.SetEmailOptOutParams) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{EmailPreference: mockRepo}
+
+		req := handlers.SetEmailPreferenceParams{EmailName: "enrolment", OptOut: true}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "email-preferences")
+
+		if err := h.SetEmailPreference(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRepo.SetEmailOptOutCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetEmailPreferenceHandlerName)
+
+		expected := domain.SetEmailOptOutParams{UserID: testhelpers.TestUserID, EmailName: "enrolment", OptOut: true}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestSetEmailPreference_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		reqBody        handlers.SetEmailPreferenceParams
+		setErr         error
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	tests := []testCase{
+		{
+			name:           "validation - missing emailName",
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "compliance email can't be opted out of",
+			reqBody:        handlers.SetEmailPreferenceParams{EmailName: "overdue-enrolment", OptOut: true},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: "only optional emails can be opted out of",
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.SetEmailPreferenceParams{EmailName: "enrolment", OptOut: true},
+			setErr:         stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("email preference"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.EmailPreferenceRepositoryMock{
+				SetEmailOptOutFunc: func(ctx context.Context, params domain.SetEmailOptOutParams) error {
+					return tt.setErr
+				},
+			}
+			h := &handlers.Handlers{EmailPreference: mockRepo}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "email-preferences")
+			err := h.SetEmailPreference(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+
+			if tt.setErr == nil {
+				testhelpers.AssertRepoCalls(t, len(mockRepo.SetEmailOptOutCalls()), 0, testhelpers.SetEmailPreferenceHandlerName)
+			}
+		})
+	}
+}
+
+func TestListFailedEmails(t *testing.T) {
+	t.Run("lists failed emails by status", func(t *testing.T) {
+		failed := []domain.FailedEmail{
+			{
+				ID:             uuid.New(),
+				EmailName:      "course-completion",
+				TemplateName:   "course-completion",
+				TemplateParams: json.RawMessage(`{"user_name":"User A"}`),
+				Status:         domain.EmailStatusDead,
+				Attempts:       8,
+				Error:          "connection refused",
+				CreatedAt:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		}
+
+		mockRepo := &mocks.FailedEmailRepositoryMock{
+			ListFailedEmailsFunc: func(
+				ctx context.Context,
+				params domain.ListFailedEmailsParams,
+			) (*domain.Page[domain.FailedEmail], error) {
+				return &domain.Page[domain.FailedEmail]{Items: failed, TotalCount: 1}, nil
+			},
+		}
+
+		h := &handlers.Handlers{FailedEmail: mockRepo}
+
+		req := handlers.ListFailedEmailsParams{Status: "dead"}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "admin/emails/failed")
+
+		if err := h.ListFailedEmails(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.ListFailedEmailsCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListFailedEmailsHandlerName)
+
+		if calls[0].Params.Status != domain.EmailStatusDead {
+			t.Errorf("expected status %s, got %s", domain.EmailStatusDead, calls[0].Params.Status)
+		}
+
+		var actual domain.Page[domain.FailedEmail]
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(failed, actual.Items); diff != "" {
+			t.Errorf("failed emails mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("validation - unknown status", func(t *testing.T) {
+		h := &handlers.Handlers{FailedEmail: &mocks.FailedEmailRepositoryMock{}}
+
+		req := handlers.ListFailedEmailsParams{Status: "sent"}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "admin/emails/failed")
+
+		err := h.ListFailedEmails(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.Validation)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			FailedEmail: &mocks.FailedEmailRepositoryMock{
+				ListFailedEmailsFunc: func(
+					ctx context.Context,
+					params domain.ListFailedEmailsParams,
+				) (*domain.Page[domain.FailedEmail], error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, handlers.ListFailedEmailsParams{}, "admin/emails/failed")
+		err := h.ListFailedEmails(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("failed email"))
+	})
+}
+
+func TestGetEmailAttempts(t *testing.T) {
+	t.Run("lists the email's attempts", func(t *testing.T) {
+		id := uuid.New()
+		attempts := []domain.EmailAttempt{
+			{AttemptedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Error: "connection refused"},
+			{AttemptedAt: time.Date(2026, 1, 2, 3, 5, 5, 0, time.UTC), Error: "connection refused"},
+		}
+
+		mockRepo := &mocks.FailedEmailRepositoryMock{
+			GetEmailAttemptsFunc: func(ctx context.Context, id uuid.UUID) ([]domain.EmailAttempt, error) {
+				return attempts, nil
+			},
+		}
+
+		h := &handlers.Handlers{FailedEmail: mockRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.FailedEmailParams{ID: id.String()}, "admin/emails/failed/attempts")
+
+		if err := h.GetEmailAttempts(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.GetEmailAttemptsCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetEmailAttemptsHandlerName)
+
+		if calls[0].Id != id {
+			t.Errorf("expected attempts of %s, got %s", id, calls[0].Id)
+		}
+
+		var actual []domain.EmailAttempt
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(attempts, actual); diff != "" {
+			t.Errorf("attempts mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			FailedEmail: &mocks.FailedEmailRepositoryMock{
+				GetEmailAttemptsFunc: func(ctx context.Context, id uuid.UUID) ([]domain.EmailAttempt, error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, handlers.FailedEmailParams{ID: uuid.New().String()}, "admin/emails/failed/attempts")
+		err := h.GetEmailAttempts(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("email attempts"))
+	})
+}
+
+func TestRetryFailedEmail(t *testing.T) {
+	t.Run("retries the email and records it", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo := &mocks.FailedEmailRepositoryMock{
+			RetryFailedEmailFunc: func(ctx context.Context, id uuid.UUID) error {
+				return nil
+			},
+		}
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{FailedEmail: mockRepo, Audit: auditRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.FailedEmailParams{ID: id.String()}, "admin/emails/failed/retry")
+
+		if err := h.RetryFailedEmail(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRepo.RetryFailedEmailCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.RetryFailedEmailHandlerName)
+
+		if calls[0].Id != id {
+			t.Errorf("expected email %s to be retried, got %s", id, calls[0].Id)
+		}
+
+		entries := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(entries), 1, testhelpers.RetryFailedEmailHandlerName)
+
+		if entries[0].Params.Action != domain.AuditActionRetryEmail || entries[0].Params.TargetID != id.String() {
+			t.Errorf("unexpected audit entry %+v", entries[0].Params)
+		}
+	})
+}
+
+func TestDiscardFailedEmail(t *testing.T) {
+	t.Run("discards the email and records it", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo := &mocks.FailedEmailRepositoryMock{
+			DiscardFailedEmailFunc: func(ctx context.Context, id uuid.UUID) error {
+				return nil
+			},
+		}
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{FailedEmail: mockRepo, Audit: auditRepo}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, handlers.FailedEmailParams{ID: id.String()}, "admin/emails/failed/discard")
+
+		if err := h.DiscardFailedEmail(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.DiscardFailedEmailCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.DiscardFailedEmailHandlerName)
+
+		if calls[0].Id != id {
+			t.Errorf("expected email %s to be discarded, got %s", id, calls[0].Id)
+		}
+
+		entries := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(entries), 1, testhelpers.DiscardFailedEmailHandlerName)
+
+		if entries[0].Params.Action != domain.AuditActionDiscardEmail {
+			t.Errorf("unexpected audit entry %+v", entries[0].Params)
+		}
+	})
+}
+
+func TestDiscardFailedEmail_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		reqBody        handlers.FailedEmailParams
+		discardErr     error
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	tests := []testCase{
+		{
+			name:           "validation - missing id",
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "invalid id",
+			reqBody:        handlers.FailedEmailParams{ID: "invalid"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+		},
+		{
+			name:           "not found",
+			reqBody:        handlers.FailedEmailParams{ID: uuid.New().String()},
+			discardErr:     pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("failed email"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.FailedEmailParams{ID: uuid.New().String()},
+			discardErr:     stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("failed email"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				FailedEmail: &mocks.FailedEmailRepositoryMock{
+					DiscardFailedEmailFunc: func(ctx context.Context, id uuid.UUID) error {
+						return tt.discardErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "admin/emails/failed/discard")
+			err := h.DiscardFailedEmail(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
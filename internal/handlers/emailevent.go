@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	stdErrors "errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+)
+
+const (
+	emailEventResource   = "email event"
+	emailWebhookResource = "email webhook"
+	// maxWebhookBodyBytes is the largest webhook read, delivery events are a few KB
+	maxWebhookBodyBytes = 1 << 20
+)
+
+// EmailWebhook records delivery, bounce and complaint events sent by the email provider's webhook.
+// It's public, so webhooks are only accepted if they're signed by the provider. Other events are
+// acknowledged without being recorded, so the provider doesn't retry them.
+func (h *Handlers) EmailWebhook(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	body, err := io.ReadAll(io.LimitReader(e.Request().Body, maxWebhookBodyBytes))
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidRequestBody, err)
+	}
+
+	event, err := h.EmailService.ParseWebhook(body)
+	if err != nil {
+		switch {
+		case stdErrors.Is(err, email.ErrWebhookNotConfigured):
+			return httpError(http.StatusNotFound, errors.NotFound(emailWebhookResource), err)
+		case stdErrors.Is(err, email.ErrInvalidWebhookSignature):
+			return httpError(http.StatusUnauthorized, errors.Unauthorised, err)
+		default:
+			return httpError(http.StatusBadRequest, errors.InvalidFormat(emailWebhookResource), err)
+		}
+	}
+
+	if event == nil {
+		return e.NoContent(http.StatusOK)
+	}
+
+	if err := h.EmailEvent.RecordEmailEvent(ctx, event); err != nil {
+		return httpError(http.StatusInternalServerError, errors.Creating(emailEventResource), err)
+	}
+
+	slog.DebugContext(
+		ctx,
+		"email event recorded",
+		slog.String("event", string(event.Type)),
+		slog.String("message_id", event.MessageID),
+	)
+
+	return e.NoContent(http.StatusOK)
+}
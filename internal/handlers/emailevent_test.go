@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+)
+
+var bouncedEvent = &domain.EmailEvent{
+	ProviderEventID: "event-1",
+	MessageID:       "20260102030405.1@example.com",
+	Type:            domain.EmailEventBounced,
+	Recipient:       "user@example.com",
+	Reason:          "No such mailbox",
+	OccurredAt:      time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+}
+
+var webhookBody = map[string]any{
+	"signature":  map[string]string{"timestamp": "1767323045", "token": "token", "signature": "signature"},
+	"event-data": map[string]any{"event": "failed", "severity": "permanent"},
+}
+
+func TestEmailWebhook(t *testing.T) {
+	t.Run("records the event", func(t *testing.T) {
+		mockEmailService := &mocks.EmailServiceMock{
+			ParseWebhookFunc: func(body []byte) (*domain.EmailEvent, error) {
+				return bouncedEvent, nil
+			},
+		}
+		mockRepo := &mocks.EmailEventRepositoryMock{
+			RecordEmailEventFunc: func(ctx context.Context, event *domain.EmailEvent) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{EmailService: mockEmailService, EmailEvent: mockRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, webhookBody, "webhooks/email")
+
+		if err := h.EmailWebhook(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		parseCalls := mockEmailService.ParseWebhookCalls()
+		testhelpers.AssertRepoCalls(t, len(parseCalls), 1, testhelpers.EmailWebhookHandlerName)
+
+		expectedBody, err := json.Marshal(webhookBody)
+		if err != nil {
+			t.Fatalf("failed to marshal webhook body: %v", err)
+		}
+
+		if string(parseCalls[0].Body) != string(expectedBody) {
+			t.Errorf("expected webhook body %s, got %s", expectedBody, parseCalls[0].Body)
+		}
+
+		calls := mockRepo.RecordEmailEventCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.EmailWebhookHandlerName)
+
+		if diff := cmp.Diff(bouncedEvent, calls[0].Event); diff != "" {
+			t.Errorf("event mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("acknowledges events that aren't about delivery", func(t *testing.T) {
+		mockRepo := &mocks.EmailEventRepositoryMock{}
+
+		h := &handlers.Handlers{
+			EmailService: &mocks.EmailServiceMock{
+				ParseWebhookFunc: func(body []byte) (*domain.EmailEvent, error) {
+					return nil, nil
+				},
+			},
+			EmailEvent: mockRepo,
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, webhookBody, "webhooks/email")
+
+		if err := h.EmailWebhook(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		if calls := mockRepo.RecordEmailEventCalls(); len(calls) != 0 {
+			t.Errorf("expected no events to be recorded, got %d", len(calls))
+		}
+	})
+}
+
+func TestEmailWebhook_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		parseErr       error
+		recordErr      error
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	tests := []testCase{
+		{
+			name:           "webhooks not configured",
+			parseErr:       email.ErrWebhookNotConfigured,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("email webhook"),
+		},
+		{
+			name:           "invalid signature",
+			parseErr:       email.ErrInvalidWebhookSignature,
+			wantStatus:     http.StatusUnauthorized,
+			expectedErrMsg: errors.Unauthorised,
+		},
+		{
+			name:           "invalid webhook",
+			parseErr:       fmt.Errorf("%w: unsupported event", email.ErrInvalidWebhook),
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidFormat("email webhook"),
+		},
+		{
+			name:           "internal server error",
+			recordErr:      stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Creating("email event"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				EmailService: &mocks.EmailServiceMock{
+					ParseWebhookFunc: func(body []byte) (*domain.EmailEvent, error) {
+						if tt.parseErr != nil {
+							return nil, tt.parseErr
+						}
+
+						return bouncedEvent, nil
+					},
+				},
+				EmailEvent: &mocks.EmailEventRepositoryMock{
+					RecordEmailEventFunc: func(ctx context.Context, event *domain.EmailEvent) error {
+						return tt.recordErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, webhookBody, "webhooks/email")
+			err := h.EmailWebhook(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -10,6 +11,7 @@ import (
 	"github.com/supanova-rp/supanova-server/internal/config"
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/utils"
 )
 
 const (
@@ -28,15 +30,51 @@ func (h *Handlers) GetUsersAndAssignedCourses(e echo.Context) error {
 	return e.JSON(http.StatusOK, usersToCourses)
 }
 
+type ListUsersAndAssignedCoursesParams struct {
+	PaginationParams
+	SortBy   string `json:"sortBy" validate:"omitempty,oneof=name email"`
+	CourseID string `json:"courseId"`
+	GroupID  string `json:"groupId"`
+}
+
+func (h *Handlers) ListUsersAndAssignedCourses(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListUsersAndAssignedCoursesParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	pageParams, err := params.pageParams(params.SortBy, pageFilters{CourseID: params.CourseID, GroupID: params.GroupID})
+	if err != nil {
+		return err
+	}
+
+	usersToCourses, err := h.Enrolment.ListUsersAndAssignedCourses(ctx, pageParams)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(usersWithAssignedCoursesResource), err)
+	}
+
+	return e.JSON(http.StatusOK, usersToCourses)
+}
+
 type UpdateCourseEnrolmentParams struct {
 	UserID     string `json:"user_id" validate:"required"`
 	CourseID   string `json:"course_id" validate:"required"`
 	IsEnrolled bool   `json:"isAssigned"`
+	// Optional due date when enrolling, either absolute or a number of days after enrolment
+	DueDate   *time.Time `json:"dueDate"`
+	DueInDays *int       `json:"dueInDays" validate:"omitempty,min=1,excluded_with=DueDate"`
 }
 
 func (h *Handlers) UpdateCourseEnrolment(e echo.Context) error {
 	ctx := e.Request().Context()
 
+	adminID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
 	var params UpdateCourseEnrolmentParams
 	if err := bindAndValidate(e, &params); err != nil {
 		return err
@@ -47,22 +85,211 @@ func (h *Handlers) UpdateCourseEnrolment(e echo.Context) error {
 		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
 	}
 
-	if params.IsEnrolled {
-		err := h.Enrolment.DisenrolInCourse(ctx, domain.DisenrolInCourseParams{
-			UserID:   params.UserID,
-			CourseID: courseID,
+	before := enrolmentAuditState{CourseID: params.CourseID, Enrolled: params.IsEnrolled}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if params.IsEnrolled {
+			err := h.Enrolment.DisenrolInCourse(ctx, domain.DisenrolInCourseParams{
+				UserID:   params.UserID,
+				CourseID: courseID,
+			})
+			if err != nil {
+				return nil, httpError(http.StatusInternalServerError, errors.Deleting(enrolmentResource), err)
+			}
+
+			after := enrolmentAuditState{CourseID: params.CourseID}
+			return auditEntry(ctx, domain.AuditActionDisenrol, domain.AuditTargetUser, params.UserID, before, after)
+		}
+
+		err := h.Enrolment.EnrolInCourse(ctx, domain.EnrolInCourseParams{
+			UserID:     params.UserID,
+			CourseID:   courseID,
+			EnrolledBy: adminID,
+			DueAt:      params.DueDate,
+			DueInDays:  params.DueInDays,
 		})
 		if err != nil {
-			return httpError(http.StatusInternalServerError, errors.Deleting(enrolmentResource), err)
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(enrolmentResource), err)
 		}
-	} else {
-		err := h.Enrolment.EnrolInCourse(ctx, domain.EnrolInCourseParams{
-			UserID:   params.UserID,
-			CourseID: courseID,
+
+		after := enrolmentAuditState{
+			CourseID:  params.CourseID,
+			Enrolled:  true,
+			DueDate:   params.DueDate,
+			DueInDays: params.DueInDays,
+		}
+		return auditEntry(ctx, domain.AuditActionEnrol, domain.AuditTargetUser, params.UserID, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// enrolmentAuditState is a user's enrolment in a course, as recorded in the audit log
+type enrolmentAuditState struct {
+	CourseID  string     `json:"courseId"`
+	Enrolled  bool       `json:"enrolled"`
+	DueDate   *time.Time `json:"dueDate,omitempty"`
+	DueInDays *int       `json:"dueInDays,omitempty"`
+}
+
+type BulkUpdateEnrolmentsParams struct {
+	UserIDs   []string `json:"userIds" validate:"required,min=1,dive,required"`
+	CourseIDs []string `json:"courseIds" validate:"required,min=1,dive,required"`
+	Action    string   `json:"action" validate:"required,oneof=enrol disenrol"`
+	// Optional due date when enrolling, either absolute or a number of days after enrolment
+	DueDate   *time.Time `json:"dueDate"`
+	DueInDays *int       `json:"dueInDays" validate:"omitempty,min=1,excluded_with=DueDate"`
+}
+
+// BulkUpdateEnrolments enrols or disenrols every combination of the given users and courses in one
+// transaction. It is idempotent, the status of each user/course pair is returned in the results.
+func (h *Handlers) BulkUpdateEnrolments(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	adminID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params BulkUpdateEnrolmentsParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	userIDs := utils.Unique(params.UserIDs)
+	courseIDs := make([]uuid.UUID, 0, len(params.CourseIDs))
+	for _, id := range utils.Unique(params.CourseIDs) {
+		courseID, err := uuid.Parse(id)
+		if err != nil {
+			return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+		}
+		courseIDs = append(courseIDs, courseID)
+	}
+
+	if len(userIDs)*len(courseIDs) > domain.MaxBulkEnrolmentItems {
+		return httpError(http.StatusBadRequest, errors.TooMany("enrolments", domain.MaxBulkEnrolmentItems), nil)
+	}
+
+	var results []domain.BulkEnrolmentResult
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		results, err = h.Enrolment.BulkUpdateEnrolments(ctx, domain.BulkUpdateEnrolmentsParams{
+			UserIDs:    userIDs,
+			CourseIDs:  courseIDs,
+			Action:     domain.BulkEnrolmentAction(params.Action),
+			EnrolledBy: adminID,
+			DueAt:      params.DueDate,
+			DueInDays:  params.DueInDays,
+		})
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Updating("enrolments"), err)
+		}
+
+		return nil, h.recordBulkEnrolmentAudit(ctx, &params, results)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, results)
+}
+
+// bulkEnrolmentAuditState is what the audit log records for each course in a bulk update, only the
+// users whose enrolment changed are listed
+type bulkEnrolmentAuditState struct {
+	Action    string     `json:"action"`
+	UserIDs   []string   `json:"userIds"`
+	DueDate   *time.Time `json:"dueDate,omitempty"`
+	DueInDays *int       `json:"dueInDays,omitempty"`
+}
+
+// recordBulkEnrolmentAudit records a bulk update against each course, in the update's transaction.
+// Courses where no enrolment changed aren't recorded.
+func (h *Handlers) recordBulkEnrolmentAudit(
+	ctx context.Context,
+	params *BulkUpdateEnrolmentsParams,
+	results []domain.BulkEnrolmentResult,
+) error {
+	var courseIDs []uuid.UUID
+	changed := map[uuid.UUID][]string{}
+
+	for _, result := range results {
+		if result.Status != domain.BulkEnrolmentResultEnrolled && result.Status != domain.BulkEnrolmentResultDisenrolled {
+			continue
+		}
+
+		if _, ok := changed[result.CourseID]; !ok {
+			courseIDs = append(courseIDs, result.CourseID)
+		}
+		changed[result.CourseID] = append(changed[result.CourseID], result.UserID)
+	}
+
+	for _, courseID := range courseIDs {
+		after := bulkEnrolmentAuditState{
+			Action:    params.Action,
+			UserIDs:   changed[courseID],
+			DueDate:   params.DueDate,
+			DueInDays: params.DueInDays,
+		}
+
+		entry, err := auditEntry(ctx, domain.AuditActionBulkUpdateEnrolments, domain.AuditTargetCourse, courseID.String(), nil, after)
+		if err != nil {
+			return err
+		}
+
+		if err := h.Audit.AddAuditEntry(ctx, *entry); err != nil {
+			return httpError(http.StatusInternalServerError, errors.Creating(auditEntryResource), err)
+		}
+	}
+
+	return nil
+}
+
+type SetEnrolmentDueDateParams struct {
+	UserID   string `json:"userId" validate:"required"`
+	CourseID string `json:"courseId" validate:"required"`
+	// Omit both to remove the due date. dueInDays is relative to the original enrolment date
+	DueDate   *time.Time `json:"dueDate"`
+	DueInDays *int       `json:"dueInDays" validate:"omitempty,min=1,excluded_with=DueDate"`
+}
+
+func (h *Handlers) SetEnrolmentDueDate(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params SetEnrolmentDueDateParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		change, err := h.Enrolment.SetEnrolmentDueDate(ctx, domain.SetEnrolmentDueDateParams{
+			UserID:    params.UserID,
+			CourseID:  courseID,
+			DueAt:     params.DueDate,
+			DueInDays: params.DueInDays,
 		})
 		if err != nil {
-			return httpError(http.StatusInternalServerError, errors.Creating(enrolmentResource), err)
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(enrolmentResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(enrolmentResource), err)
 		}
+
+		before := enrolmentAuditState{CourseID: params.CourseID, Enrolled: true, DueDate: change.PreviousDueDate}
+		after := enrolmentAuditState{CourseID: params.CourseID, Enrolled: true, DueDate: change.DueDate}
+		return auditEntry(ctx, domain.AuditActionSetEnrolmentDueDate, domain.AuditTargetUser, params.UserID, before, after)
+	})
+	if err != nil {
+		return err
 	}
 
 	return e.NoContent(http.StatusNoContent)
@@ -6,9 +6,11 @@ import (
 	stdErrors "errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers"
@@ -121,6 +123,7 @@ func TestGetUsersAndAssignedCourses_UnhappyPath(t *testing.T) {
 func TestUpdateCourseEnrolment_HappyPath(t *testing.T) {
 	t.Run("enrols user successfully when IsEnrolled is false", func(t *testing.T) {
 		courseID := testhelpers.Course.ID.String()
+		dueInDays := 30
 
 		mockEnrolmentRepo := &mocks.EnrolmentRepositoryMock{
 			EnrolInCourseFunc: func(ctx context.Context, params domain.EnrolInCourseParams) error {
@@ -128,12 +131,15 @@ func TestUpdateCourseEnrolment_HappyPath(t *testing.T) {
 			},
 		}
 
-		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo}
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo, Audit: auditRepo}
 
 		req := handlers.UpdateCourseEnrolmentParams{
 			UserID:     testhelpers.TestUserID,
 			CourseID:   courseID,
 			IsEnrolled: false,
+			DueInDays:  &dueInDays,
 		}
 
 		ctx, rec := testhelpers.SetupEchoContext(t, req, "enrolment")
@@ -148,6 +154,26 @@ func TestUpdateCourseEnrolment_HappyPath(t *testing.T) {
 
 		testhelpers.AssertRepoCalls(t, len(mockEnrolmentRepo.EnrolInCourseCalls()), 1, testhelpers.EnrolUserInCourseHandlerName)
 		testhelpers.AssertRepoCalls(t, len(mockEnrolmentRepo.DisenrolInCourseCalls()), 0, testhelpers.DisenrolUserInCourseHandlerName)
+
+		expected := domain.EnrolInCourseParams{
+			UserID:     testhelpers.TestUserID,
+			CourseID:   testhelpers.Course.ID,
+			EnrolledBy: testhelpers.TestUserID,
+			DueInDays:  &dueInDays,
+		}
+		if diff := cmp.Diff(expected, mockEnrolmentRepo.EnrolInCourseCalls()[0].Params); diff != "" {
+			t.Errorf("enrol params mismatch (-want +got):\n%s", diff)
+		}
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.EnrolUserInCourseHandlerName)
+		if auditCalls[0].Params.Action != domain.AuditActionEnrol {
+			t.Errorf("expected action %s, got %s", domain.AuditActionEnrol, auditCalls[0].Params.Action)
+		}
+		expectedAfter := `{"courseId":"` + courseID + `","enrolled":true,"dueInDays":30}`
+		if string(auditCalls[0].Params.After) != expectedAfter {
+			t.Errorf("expected state after %s, got %s", expectedAfter, auditCalls[0].Params.After)
+		}
 	})
 
 	t.Run("disenrols user successfully when IsEnrolled is true", func(t *testing.T) {
@@ -159,7 +185,7 @@ func TestUpdateCourseEnrolment_HappyPath(t *testing.T) {
 			},
 		}
 
-		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo}
+		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo, Audit: newAuditMock()}
 
 		req := handlers.UpdateCourseEnrolmentParams{
 			UserID:     testhelpers.TestUserID,
@@ -192,6 +218,8 @@ func TestUpdateCourseEnrolment_UnhappyPath(t *testing.T) {
 	}
 
 	courseID := testhelpers.Course.ID.String()
+	dueDate := time.Now().Add(24 * time.Hour)
+	dueInDays := 30
 
 	tests := []testCase{
 		{
@@ -219,6 +247,21 @@ func TestUpdateCourseEnrolment_UnhappyPath(t *testing.T) {
 				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
 			},
 		},
+		{
+			name: "validation error - both due date and due in days",
+			reqBody: handlers.UpdateCourseEnrolmentParams{
+				UserID:     testhelpers.TestUserID,
+				CourseID:   courseID,
+				IsEnrolled: false,
+				DueDate:    &dueDate,
+				DueInDays:  &dueInDays,
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
+			},
+		},
 		{
 			name: "internal server error",
 			reqBody: handlers.UpdateCourseEnrolmentParams{
@@ -235,6 +278,7 @@ func TestUpdateCourseEnrolment_UnhappyPath(t *testing.T) {
 							return stdErrors.New("db error")
 						},
 					},
+					Audit: newAuditMock(),
 				}
 			},
 		},
@@ -249,3 +293,486 @@ func TestUpdateCourseEnrolment_UnhappyPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBulkUpdateEnrolments_HappyPath(t *testing.T) {
+	t.Run("returns per item results and removes duplicate ids", func(t *testing.T) {
+		courseID := testhelpers.Course.ID
+		expected := []domain.BulkEnrolmentResult{
+			{UserID: "user-1", CourseID: courseID, Status: domain.BulkEnrolmentResultEnrolled},
+			{UserID: "user-2", CourseID: courseID, Status: domain.BulkEnrolmentResultAlreadyEnrolled},
+			{UserID: "user-3", CourseID: courseID, Status: domain.BulkEnrolmentResultUserNotFound},
+		}
+
+		mockEnrolmentRepo := &mocks.EnrolmentRepositoryMock{
+			BulkUpdateEnrolmentsFunc: func(
+				ctx context.Context,
+				params domain.BulkUpdateEnrolmentsParams,
+			) ([]domain.BulkEnrolmentResult, error) {
+				return expected, nil
+			},
+		}
+
+		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo, Audit: newAuditMock()}
+
+		req := handlers.BulkUpdateEnrolmentsParams{
+			UserIDs:   []string{"user-1", "user-2", "user-3", "user-1"},
+			CourseIDs: []string{courseID.String(), courseID.String()},
+			Action:    "enrol",
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "enrolments/bulk")
+		err := h.BulkUpdateEnrolments(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var actual []domain.BulkEnrolmentResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("bulk enrolment results mismatch (-want +got):\n%s", diff)
+		}
+
+		calls := mockEnrolmentRepo.BulkUpdateEnrolmentsCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.BulkUpdateEnrolmentsHandlerName)
+
+		expectedParams := domain.BulkUpdateEnrolmentsParams{
+			UserIDs:    []string{"user-1", "user-2", "user-3"},
+			CourseIDs:  []uuid.UUID{courseID},
+			Action:     domain.BulkEnrolmentActionEnrol,
+			EnrolledBy: testhelpers.TestUserID,
+		}
+		if diff := cmp.Diff(expectedParams, calls[0].Params); diff != "" {
+			t.Errorf("bulk enrolment params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestBulkUpdateEnrolments_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		reqBody        handlers.BulkUpdateEnrolmentsParams
+		setup          func() *handlers.Handlers
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	courseID := testhelpers.Course.ID.String()
+
+	tooManyUsers := make([]string, domain.MaxBulkEnrolmentItems+1)
+	for i := range tooManyUsers {
+		tooManyUsers[i] = uuid.NewString()
+	}
+
+	tests := []testCase{
+		{
+			name: "validation error - invalid action",
+			reqBody: handlers.BulkUpdateEnrolmentsParams{
+				UserIDs:   []string{testhelpers.TestUserID},
+				CourseIDs: []string{courseID},
+				Action:    "assign",
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
+			},
+		},
+		{
+			name: "validation error - missing user ids",
+			reqBody: handlers.BulkUpdateEnrolmentsParams{
+				CourseIDs: []string{courseID},
+				Action:    "enrol",
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
+			},
+		},
+		{
+			name: "validation error - invalid uuid format",
+			reqBody: handlers.BulkUpdateEnrolmentsParams{
+				UserIDs:   []string{testhelpers.TestUserID},
+				CourseIDs: []string{courseID, "invalid-uuid"},
+				Action:    "disenrol",
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
+			},
+		},
+		{
+			name: "too many enrolments",
+			reqBody: handlers.BulkUpdateEnrolmentsParams{
+				UserIDs:   tooManyUsers,
+				CourseIDs: []string{courseID},
+				Action:    "enrol",
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.TooMany("enrolments", domain.MaxBulkEnrolmentItems),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
+			},
+		},
+		{
+			name: "internal server error",
+			reqBody: handlers.BulkUpdateEnrolmentsParams{
+				UserIDs:   []string{testhelpers.TestUserID},
+				CourseIDs: []string{courseID},
+				Action:    "enrol",
+			},
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("enrolments"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Enrolment: &mocks.EnrolmentRepositoryMock{
+						BulkUpdateEnrolmentsFunc: func(
+							ctx context.Context,
+							params domain.BulkUpdateEnrolmentsParams,
+						) ([]domain.BulkEnrolmentResult, error) {
+							return nil, stdErrors.New("db error")
+						},
+					},
+					Audit: newAuditMock(),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.setup()
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "enrolments/bulk")
+			err := h.BulkUpdateEnrolments(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestSetEnrolmentDueDate_HappyPath(t *testing.T) {
+	t.Run("sets due date successfully", func(t *testing.T) {
+		dueDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		previousDueDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+		mockEnrolmentRepo := &mocks.EnrolmentRepositoryMock{
+			SetEnrolmentDueDateFunc: func(
+				ctx context.Context,
+				params domain.SetEnrolmentDueDateParams,
+			) (*domain.EnrolmentDueDateChange, error) {
+				return &domain.EnrolmentDueDateChange{PreviousDueDate: &previousDueDate, DueDate: &dueDate}, nil
+			},
+		}
+
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo, Audit: auditRepo}
+
+		req := handlers.SetEnrolmentDueDateParams{
+			UserID:   testhelpers.TestUserID,
+			CourseID: testhelpers.Course.ID.String(),
+			DueDate:  &dueDate,
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "enrolment/due-date")
+		err := h.SetEnrolmentDueDate(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockEnrolmentRepo.SetEnrolmentDueDateCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetEnrolmentDueDateHandlerName)
+
+		expected := domain.SetEnrolmentDueDateParams{
+			UserID:   testhelpers.TestUserID,
+			CourseID: testhelpers.Course.ID,
+			DueAt:    &dueDate,
+		}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("due date params mismatch (-want +got):\n%s", diff)
+		}
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.SetEnrolmentDueDateHandlerName)
+		if auditCalls[0].Params.Action != domain.AuditActionSetEnrolmentDueDate {
+			t.Errorf("expected action %s, got %s", domain.AuditActionSetEnrolmentDueDate, auditCalls[0].Params.Action)
+		}
+		courseID := testhelpers.Course.ID.String()
+		expectedBefore := `{"courseId":"` + courseID + `","enrolled":true,"dueDate":"2025-12-01T00:00:00Z"}`
+		expectedAfter := `{"courseId":"` + courseID + `","enrolled":true,"dueDate":"2026-01-01T00:00:00Z"}`
+		if string(auditCalls[0].Params.Before) != expectedBefore || string(auditCalls[0].Params.After) != expectedAfter {
+			t.Errorf("expected state %s to %s, got %s to %s",
+				expectedBefore, expectedAfter, auditCalls[0].Params.Before, auditCalls[0].Params.After)
+		}
+	})
+}
+
+func TestSetEnrolmentDueDate_UnhappyPath(t *testing.T) {
+	type testCase struct {
+		name           string
+		reqBody        handlers.SetEnrolmentDueDateParams
+		setup          func() *handlers.Handlers
+		wantStatus     int
+		expectedErrMsg string
+	}
+
+	courseID := testhelpers.Course.ID.String()
+	dueInDays := 30
+
+	tests := []testCase{
+		{
+			name: "validation error - due in days less than one",
+			reqBody: handlers.SetEnrolmentDueDateParams{
+				UserID:    testhelpers.TestUserID,
+				CourseID:  courseID,
+				DueInDays: new(int),
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
+			},
+		},
+		{
+			name: "validation error - invalid uuid format",
+			reqBody: handlers.SetEnrolmentDueDateParams{
+				UserID:   testhelpers.TestUserID,
+				CourseID: "invalid-uuid",
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Enrolment: &mocks.EnrolmentRepositoryMock{}}
+			},
+		},
+		{
+			name: "enrolment not found",
+			reqBody: handlers.SetEnrolmentDueDateParams{
+				UserID:    testhelpers.TestUserID,
+				CourseID:  courseID,
+				DueInDays: &dueInDays,
+			},
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("enrolment"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Enrolment: &mocks.EnrolmentRepositoryMock{
+						SetEnrolmentDueDateFunc: func(
+							ctx context.Context,
+							params domain.SetEnrolmentDueDateParams,
+						) (*domain.EnrolmentDueDateChange, error) {
+							return nil, pgx.ErrNoRows
+						},
+					},
+					Audit: newAuditMock(),
+				}
+			},
+		},
+		{
+			name: "internal server error",
+			reqBody: handlers.SetEnrolmentDueDateParams{
+				UserID:    testhelpers.TestUserID,
+				CourseID:  courseID,
+				DueInDays: &dueInDays,
+			},
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("enrolment"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Enrolment: &mocks.EnrolmentRepositoryMock{
+						SetEnrolmentDueDateFunc: func(
+							ctx context.Context,
+							params domain.SetEnrolmentDueDateParams,
+						) (*domain.EnrolmentDueDateChange, error) {
+							return nil, stdErrors.New("db error")
+						},
+					},
+					Audit: newAuditMock(),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.setup()
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "enrolment/due-date")
+			err := h.SetEnrolmentDueDate(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestListUsersAndAssignedCourses_HappyPath(t *testing.T) {
+	t.Run("returns a page of users with assigned courses", func(t *testing.T) {
+		courseID := uuid.New()
+		groupID := uuid.New()
+		nextCursor := domain.Cursor{Key: "bob", ID: "user-2"}.Encode()
+
+		expected := &domain.Page[domain.UserWithAssignedCourses]{
+			Items: []domain.UserWithAssignedCourses{
+				{
+					ID:        "user-2",
+					Name:      "Bob",
+					Email:     "bob@example.com",
+					CourseIDs: []uuid.UUID{courseID},
+					Groups:    []domain.GroupSummary{{ID: groupID, Name: "Site B"}},
+				},
+			},
+			TotalCount: 3,
+			NextCursor: &nextCursor,
+		}
+
+		mockEnrolmentRepo := &mocks.EnrolmentRepositoryMock{
+			ListUsersAndAssignedCoursesFunc: func(
+				ctx context.Context,
+				params domain.PageParams,
+			) (*domain.Page[domain.UserWithAssignedCourses], error) {
+				return expected, nil
+			},
+		}
+
+		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo}
+
+		reqParams := handlers.ListUsersAndAssignedCoursesParams{
+			PaginationParams: handlers.PaginationParams{
+				Cursor:  domain.Cursor{Key: "alice", ID: "user-1"}.Encode(),
+				Limit:   1,
+				SortDir: "desc",
+				Search:  "b",
+			},
+			SortBy:   "email",
+			CourseID: courseID.String(),
+			GroupID:  groupID.String(),
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, reqParams, "users-to-courses/list")
+
+		err := h.ListUsersAndAssignedCourses(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var actual domain.Page[domain.UserWithAssignedCourses]
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(expected, &actual); diff != "" {
+			t.Errorf("page mismatch (-want +got):\n%s", diff)
+		}
+
+		calls := mockEnrolmentRepo.ListUsersAndAssignedCoursesCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListUsersAndAssignedCoursesHandlerName)
+
+		expectedParams := domain.PageParams{
+			Cursor:   &domain.Cursor{Key: "alice", ID: "user-1"},
+			Limit:    1,
+			SortBy:   "email",
+			SortDir:  domain.SortDescending,
+			Search:   "b",
+			CourseID: &courseID,
+			GroupID:  &groupID,
+		}
+		if diff := cmp.Diff(expectedParams, calls[0].Params); diff != "" {
+			t.Errorf("page params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestListUsersAndAssignedCourses_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       handlers.ListUsersAndAssignedCoursesParams
+		expectedCode int
+		expectedMsg  string
+	}{
+		{
+			name: "invalid cursor",
+			params: handlers.ListUsersAndAssignedCoursesParams{
+				PaginationParams: handlers.PaginationParams{Cursor: "not-a-cursor"},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  errors.InvalidFormat("cursor"),
+		},
+		{
+			name:         "invalid course ID",
+			params:       handlers.ListUsersAndAssignedCoursesParams{CourseID: "invalid-uuid"},
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  errors.InvalidUUID,
+		},
+		{
+			name:         "invalid group ID",
+			params:       handlers.ListUsersAndAssignedCoursesParams{GroupID: "invalid-uuid"},
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  errors.InvalidUUID,
+		},
+		{
+			name:         "unsupported sort field",
+			params:       handlers.ListUsersAndAssignedCoursesParams{SortBy: "password"},
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  errors.Validation,
+		},
+		{
+			name: "limit too large",
+			params: handlers.ListUsersAndAssignedCoursesParams{
+				PaginationParams: handlers.PaginationParams{Limit: 1000},
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedMsg:  errors.Validation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEnrolmentRepo := &mocks.EnrolmentRepositoryMock{}
+			h := &handlers.Handlers{Enrolment: mockEnrolmentRepo}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.params, "users-to-courses/list")
+
+			err := h.ListUsersAndAssignedCourses(ctx)
+
+			testhelpers.AssertHTTPError(t, err, tt.expectedCode, tt.expectedMsg)
+			testhelpers.AssertRepoCalls(
+				t,
+				len(mockEnrolmentRepo.ListUsersAndAssignedCoursesCalls()),
+				0,
+				testhelpers.ListUsersAndAssignedCoursesHandlerName,
+			)
+		})
+	}
+
+	t.Run("internal server error", func(t *testing.T) {
+		mockEnrolmentRepo := &mocks.EnrolmentRepositoryMock{
+			ListUsersAndAssignedCoursesFunc: func(
+				ctx context.Context,
+				params domain.PageParams,
+			) (*domain.Page[domain.UserWithAssignedCourses], error) {
+				return nil, stdErrors.New("db error")
+			},
+		}
+
+		h := &handlers.Handlers{Enrolment: mockEnrolmentRepo}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, struct{}{}, "users-to-courses/list")
+
+		err := h.ListUsersAndAssignedCourses(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("users with assigned courses"))
+	})
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const uniqueViolationCode = "23505"
+
 const (
 	InvalidUUID        = "invalid uuid format"
 	Validation         = "validation failed"
@@ -42,14 +45,32 @@ func IsNotFoundErr(err error) bool {
 	return stdErrors.Is(err, pgx.ErrNoRows)
 }
 
+func AlreadyExists(resource string) string {
+	return fmt.Sprintf("%s already exists", resource)
+}
+
+// IsUniqueViolationErr reports whether err was caused by a unique constraint violation
+func IsUniqueViolationErr(err error) bool {
+	var pgErr *pgconn.PgError
+	return stdErrors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
 func InvalidFormat(resource string) string {
 	return fmt.Sprintf("Invalid %s format", resource)
 }
 
+func TooMany(resource string, maximum int) string {
+	return fmt.Sprintf("Too many %s, the maximum is %d", resource, maximum)
+}
+
 func Forbidden(resource string) string {
 	return fmt.Sprintf("No permissions for %s", resource)
 }
 
+func OwnAccount(action string) string {
+	return fmt.Sprintf("Cannot %s your own account", action)
+}
+
 func Wrap(text string) error {
 	return stdErrors.New(text)
 }
@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const (
+	groupResource        = "group"
+	groupsResource       = "groups"
+	groupMembersResource = "group members"
+	groupCourseResource  = "group course"
+)
+
+func (h *Handlers) GetGroups(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	groups, err := h.Group.GetGroups(ctx)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(groupsResource), err)
+	}
+
+	return e.JSON(http.StatusOK, groups)
+}
+
+type AddGroupParams struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+func (h *Handlers) AddGroup(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params AddGroupParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	var group *domain.Group
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		group, err = h.Group.AddGroup(ctx, params.Name)
+		if err != nil {
+			if errors.IsUniqueViolationErr(err) {
+				return nil, httpError(http.StatusConflict, errors.AlreadyExists(groupResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(groupResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionAddGroup, domain.AuditTargetGroup, group.ID.String(), nil, group)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusCreated, group)
+}
+
+type UpdateGroupParams struct {
+	GroupID string `json:"groupId" validate:"required"`
+	Name    string `json:"name" validate:"required,max=100"`
+}
+
+func (h *Handlers) UpdateGroup(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params UpdateGroupParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	groupID, err := uuid.Parse(params.GroupID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		err := h.Group.UpdateGroup(ctx, domain.UpdateGroupParams{ID: groupID, Name: params.Name})
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(groupResource), err)
+			}
+			if errors.IsUniqueViolationErr(err) {
+				return nil, httpError(http.StatusConflict, errors.AlreadyExists(groupResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(groupResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionUpdateGroup, domain.AuditTargetGroup, params.GroupID, nil, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+type DeleteGroupParams struct {
+	GroupID string `json:"groupId" validate:"required"`
+}
+
+// DeleteGroup removes the group, enrolments made through the group are kept
+func (h *Handlers) DeleteGroup(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params DeleteGroupParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	groupID, err := uuid.Parse(params.GroupID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Group.DeleteGroup(ctx, groupID); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(groupResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(groupResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionDeleteGroup, domain.AuditTargetGroup, params.GroupID, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+type GroupMembersParams struct {
+	GroupID string   `json:"groupId" validate:"required"`
+	UserIDs []string `json:"userIds" validate:"required,min=1,max=1000,dive,required"`
+}
+
+// AddGroupMembers adds users to a group and enrols them in the group's courses. Unknown users are skipped.
+func (h *Handlers) AddGroupMembers(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	params, err := groupMembersParamsFrom(e)
+	if err != nil {
+		return err
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Group.AddGroupMembers(ctx, params); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(groupResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(groupMembersResource), err)
+		}
+
+		return groupMembersAuditEntry(ctx, domain.AuditActionAddGroupMembers, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// RemoveGroupMembers removes users from a group, their existing enrolments are kept
+func (h *Handlers) RemoveGroupMembers(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	params, err := groupMembersParamsFrom(e)
+	if err != nil {
+		return err
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Group.RemoveGroupMembers(ctx, params); err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(groupMembersResource), err)
+		}
+
+		return groupMembersAuditEntry(ctx, domain.AuditActionRemoveGroupMembers, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// groupMembersAuditState is the users added to or removed from a group, as recorded in the audit log
+type groupMembersAuditState struct {
+	UserIDs []string `json:"userIds"`
+}
+
+// groupMembersAuditEntry records a change to a group's members or managers against the group
+func groupMembersAuditEntry(
+	ctx context.Context,
+	action domain.AuditAction,
+	params domain.GroupMembersParams,
+) (*domain.AddAuditEntryParams, error) {
+	after := groupMembersAuditState{UserIDs: params.UserIDs}
+	return auditEntry(ctx, action, domain.AuditTargetGroup, params.GroupID.String(), nil, after)
+}
+
+func groupMembersParamsFrom(e echo.Context) (domain.GroupMembersParams, error) {
+	var params GroupMembersParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return domain.GroupMembersParams{}, err
+	}
+
+	groupID, err := uuid.Parse(params.GroupID)
+	if err != nil {
+		return domain.GroupMembersParams{}, httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	return domain.GroupMembersParams{
+		GroupID: groupID,
+		UserIDs: utils.Unique(params.UserIDs),
+	}, nil
+}
+
+type AssignCourseToGroupParams struct {
+	GroupID  string `json:"groupId" validate:"required"`
+	CourseID string `json:"courseId" validate:"required"`
+	// Optional number of days each member has to complete the course, from when they are enrolled
+	DueInDays *int `json:"dueInDays" validate:"omitempty,min=1"`
+}
+
+// AssignCourseToGroup enrols all current members of the group in the course, future members
+// are enrolled when they join the group
+func (h *Handlers) AssignCourseToGroup(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	adminID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params AssignCourseToGroupParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	groupID, err := uuid.Parse(params.GroupID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		err := h.Group.AssignCourseToGroup(ctx, domain.AssignCourseToGroupParams{
+			GroupID:    groupID,
+			CourseID:   courseID,
+			DueInDays:  params.DueInDays,
+			AssignedBy: adminID,
+		})
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound("group or course"), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(groupCourseResource), err)
+		}
+
+		after := groupCourseAuditState{CourseID: params.CourseID, Assigned: true, DueInDays: params.DueInDays}
+		return auditEntry(ctx, domain.AuditActionAssignGroupCourse, domain.AuditTargetGroup, params.GroupID, nil, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// groupCourseAuditState is a course's assignment to a group, as recorded in the audit log
+type groupCourseAuditState struct {
+	CourseID  string `json:"courseId"`
+	Assigned  bool   `json:"assigned"`
+	DueInDays *int   `json:"dueInDays,omitempty"`
+}
+
+type UnassignCourseFromGroupParams struct {
+	GroupID  string `json:"groupId" validate:"required"`
+	CourseID string `json:"courseId" validate:"required"`
+}
+
+// UnassignCourseFromGroup stops new members being enrolled in the course, existing enrolments are kept
+func (h *Handlers) UnassignCourseFromGroup(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params UnassignCourseFromGroupParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	groupID, err := uuid.Parse(params.GroupID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		err := h.Group.UnassignCourseFromGroup(ctx, domain.UnassignCourseFromGroupParams{
+			GroupID:  groupID,
+			CourseID: courseID,
+		})
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(groupCourseResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(groupCourseResource), err)
+		}
+
+		after := groupCourseAuditState{CourseID: params.CourseID}
+		return auditEntry(ctx, domain.AuditActionUnassignGroupCourse, domain.AuditTargetGroup, params.GroupID, nil, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,311 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+)
+
+func TestAddGroup_HappyPath(t *testing.T) {
+	t.Run("creates group successfully", func(t *testing.T) {
+		expected := &domain.Group{
+			ID:        uuid.New(),
+			Name:      "Nuclear Medicine",
+			CourseIDs: []uuid.UUID{},
+		}
+
+		mockGroupRepo := &mocks.GroupRepositoryMock{
+			AddGroupFunc: func(ctx context.Context, name string) (*domain.Group, error) {
+				return expected, nil
+			},
+		}
+
+		auditRepo := newAuditMock()
+		h := &handlers.Handlers{Group: mockGroupRepo, Audit: auditRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.AddGroupParams{Name: expected.Name}, "groups/add")
+
+		err := h.AddGroup(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rec.Code)
+		}
+
+		var actual domain.Group
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(expected, &actual); diff != "" {
+			t.Errorf("group mismatch (-want +got):\n%s", diff)
+		}
+
+		testhelpers.AssertRepoCalls(t, len(mockGroupRepo.AddGroupCalls()), 1, testhelpers.AddGroupHandlerName)
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.AddGroupHandlerName)
+		if auditCalls[0].Params.Action != domain.AuditActionAddGroup || auditCalls[0].Params.TargetID != expected.ID.String() {
+			t.Errorf("expected %s audit entry for group %s, got %+v", domain.AuditActionAddGroup, expected.ID, auditCalls[0].Params)
+		}
+	})
+}
+
+func TestAddGroup_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.AddGroupParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing name",
+			reqBody:        handlers.AddGroupParams{},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "group name already exists",
+			reqBody:        handlers.AddGroupParams{Name: "Site B"},
+			repoErr:        &pgconn.PgError{Code: "23505"},
+			wantStatus:     http.StatusConflict,
+			expectedErrMsg: errors.AlreadyExists("group"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.AddGroupParams{Name: "Site B"},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Creating("group"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				Group: &mocks.GroupRepositoryMock{
+					AddGroupFunc: func(ctx context.Context, name string) (*domain.Group, error) {
+						return nil, tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "groups/add")
+			err := h.AddGroup(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestAddGroupMembers_HappyPath(t *testing.T) {
+	t.Run("adds unique members to group", func(t *testing.T) {
+		groupID := uuid.New()
+
+		mockGroupRepo := &mocks.GroupRepositoryMock{
+			AddGroupMembersFunc: func(ctx context.Context, params domain.GroupMembersParams) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{Group: mockGroupRepo, Audit: newAuditMock()}
+
+		req := handlers.GroupMembersParams{
+			GroupID: groupID.String(),
+			UserIDs: []string{"user-1", "user-2", "user-1"},
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "groups/members/add")
+
+		err := h.AddGroupMembers(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockGroupRepo.AddGroupMembersCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.AddGroupMembersHandlerName)
+
+		expected := domain.GroupMembersParams{GroupID: groupID, UserIDs: []string{"user-1", "user-2"}}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("group members params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestAddGroupMembers_UnhappyPath(t *testing.T) {
+	groupID := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.GroupMembersParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing user ids",
+			reqBody:        handlers.GroupMembersParams{GroupID: groupID},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "invalid uuid format",
+			reqBody:        handlers.GroupMembersParams{GroupID: "invalid-uuid", UserIDs: []string{"user-1"}},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+		},
+		{
+			name:           "group not found",
+			reqBody:        handlers.GroupMembersParams{GroupID: groupID, UserIDs: []string{"user-1"}},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("group"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.GroupMembersParams{GroupID: groupID, UserIDs: []string{"user-1"}},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Creating("group members"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				Group: &mocks.GroupRepositoryMock{
+					AddGroupMembersFunc: func(ctx context.Context, params domain.GroupMembersParams) error {
+						return tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "groups/members/add")
+			err := h.AddGroupMembers(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestAssignCourseToGroup_HappyPath(t *testing.T) {
+	t.Run("assigns course to group with due date", func(t *testing.T) {
+		groupID := uuid.New()
+		dueInDays := 14
+
+		mockGroupRepo := &mocks.GroupRepositoryMock{
+			AssignCourseToGroupFunc: func(ctx context.Context, params domain.AssignCourseToGroupParams) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{Group: mockGroupRepo, Audit: newAuditMock()}
+
+		req := handlers.AssignCourseToGroupParams{
+			GroupID:   groupID.String(),
+			CourseID:  testhelpers.Course.ID.String(),
+			DueInDays: &dueInDays,
+		}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "groups/courses/assign")
+
+		err := h.AssignCourseToGroup(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockGroupRepo.AssignCourseToGroupCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.AssignCourseToGroupHandlerName)
+
+		expected := domain.AssignCourseToGroupParams{
+			GroupID:    groupID,
+			CourseID:   testhelpers.Course.ID,
+			DueInDays:  &dueInDays,
+			AssignedBy: testhelpers.TestUserID,
+		}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("assign course params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestAssignCourseToGroup_UnhappyPath(t *testing.T) {
+	groupID := uuid.New().String()
+	courseID := testhelpers.Course.ID.String()
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.AssignCourseToGroupParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing course id",
+			reqBody:        handlers.AssignCourseToGroupParams{GroupID: groupID},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "invalid uuid format",
+			reqBody:        handlers.AssignCourseToGroupParams{GroupID: groupID, CourseID: "invalid-uuid"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+		},
+		{
+			name:           "group or course not found",
+			reqBody:        handlers.AssignCourseToGroupParams{GroupID: groupID, CourseID: courseID},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("group or course"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.AssignCourseToGroupParams{GroupID: groupID, CourseID: courseID},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Creating("group course"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				Group: &mocks.GroupRepositoryMock{
+					AssignCourseToGroupFunc: func(ctx context.Context, params domain.AssignCourseToGroupParams) error {
+						return tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "groups/courses/assign")
+			err := h.AssignCourseToGroup(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
@@ -2,24 +2,41 @@ package handlers
 
 import (
 	"context"
+	"sync"
 
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/services/auth"
+	"github.com/supanova-rp/supanova-server/internal/services/certificate"
 	"github.com/supanova-rp/supanova-server/internal/services/email"
 )
 
 type Handlers struct {
-	System    domain.SystemRepository
-	Course    domain.CourseRepository
-	Progress  domain.ProgressRepository
-	Enrolment domain.EnrolmentRepository
-	User      domain.UserRepository
-	Auth      domain.AuthRepository
-	Quiz      domain.QuizRepository
+	System          domain.SystemRepository
+	Course          domain.CourseRepository
+	Progress        domain.ProgressRepository
+	Enrolment       domain.EnrolmentRepository
+	User            domain.UserRepository
+	Auth            domain.AuthRepository
+	Quiz            domain.QuizRepository
+	Group           domain.GroupRepository
+	AccessCode      domain.AccessCodeRepository
+	Role            domain.RoleRepository
+	LineManager     domain.LineManagerRepository
+	APIKey          domain.APIKeyRepository
+	Audit           domain.AuditRepository
+	Certificate     domain.CertificateRepository
+	EmailPreference domain.EmailPreferenceRepository
+	FailedEmail     domain.FailedEmailRepository
+	EmailEvent      domain.EmailEventRepository
+	UserImport      domain.UserImportRepository
 
-	ObjectStorage ObjectStorage
-	EmailService  EmailService
-	AuthProvider  auth.AuthProvider
+	ObjectStorage       ObjectStorage
+	EmailService        EmailService
+	AuthProvider        auth.AuthProvider
+	CertificateRenderer CertificateRenderer
+
+	// imports tracks user imports running in the background, so shutdown can wait for them
+	imports sync.WaitGroup
 }
 
 //go:generate moq -out ../handlers/mocks/objectstorage_mock.go -pkg mocks . ObjectStorage
@@ -27,16 +44,25 @@ type Handlers struct {
 type ObjectStorage interface {
 	GenerateUploadURL(ctx context.Context, key string, contentType *string) (string, error)
 	GetCDNURL(ctx context.Context, key string) (string, error)
+	Upload(ctx context.Context, key string, body []byte, contentType string) error
 }
 
 //go:generate moq -out ../handlers/mocks/emailservice_mock.go -pkg mocks . EmailService
 
 type EmailService interface {
 	Send(ctx context.Context, params email.EmailParams, templateName, emailName string) error
-	SetupRetry() (context.CancelFunc, error)
 	GetTemplateNames() *email.TemplateNames
 	GetEmailNames() *email.EmailNames
-	StopRetry(ctx context.Context)
+	Preview(emailName string, variables map[string]string) (*email.RenderedEmail, error)
+	StopDispatcher(ctx context.Context)
+	ParseWebhook(body []byte) (*domain.EmailEvent, error)
+}
+
+//go:generate moq -out ../handlers/mocks/certificaterenderer_mock.go -pkg mocks . CertificateRenderer
+
+type CertificateRenderer interface {
+	Render(data *certificate.Data) ([]byte, error)
+	VerificationURL(verificationID string) string
 }
 
 func NewHandlers(
@@ -47,20 +73,44 @@ func NewHandlers(
 	user domain.UserRepository,
 	authentication domain.AuthRepository,
 	quiz domain.QuizRepository,
+	group domain.GroupRepository,
+	accessCode domain.AccessCodeRepository,
+	role domain.RoleRepository,
+	lineManager domain.LineManagerRepository,
+	apiKey domain.APIKeyRepository,
+	audit domain.AuditRepository,
+	certificate domain.CertificateRepository,
+	emailPreference domain.EmailPreferenceRepository,
+	failedEmail domain.FailedEmailRepository,
+	emailEvent domain.EmailEventRepository,
+	userImport domain.UserImportRepository,
 	objectStorage ObjectStorage,
 	emailService EmailService,
 	authProvider auth.AuthProvider,
+	certificateRenderer CertificateRenderer,
 ) *Handlers {
 	return &Handlers{
-		System:        system,
-		Course:        course,
-		Progress:      progress,
-		Enrolment:     enrolment,
-		User:          user,
-		Auth:          authentication,
-		Quiz:          quiz,
-		ObjectStorage: objectStorage,
-		EmailService:  emailService,
-		AuthProvider:  authProvider,
+		System:              system,
+		Course:              course,
+		Progress:            progress,
+		Enrolment:           enrolment,
+		User:                user,
+		Auth:                authentication,
+		Quiz:                quiz,
+		Group:               group,
+		AccessCode:          accessCode,
+		Role:                role,
+		LineManager:         lineManager,
+		APIKey:              apiKey,
+		Audit:               audit,
+		Certificate:         certificate,
+		EmailPreference:     emailPreference,
+		FailedEmail:         failedEmail,
+		EmailEvent:          emailEvent,
+		UserImport:          userImport,
+		ObjectStorage:       objectStorage,
+		EmailService:        emailService,
+		AuthProvider:        authProvider,
+		CertificateRenderer: certificateRenderer,
 	}
 }
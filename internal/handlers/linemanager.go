@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+)
+
+const (
+	lineManagerResource    = "line manager"
+	teamComplianceResource = "team compliance"
+)
+
+type SetLineManagerParams struct {
+	UserID    string `json:"userId" validate:"required"`
+	ManagerID string `json:"managerId" validate:"omitempty,nefield=UserID"`
+}
+
+// SetLineManager replaces the user's line manager, an empty manager ID removes it
+func (h *Handlers) SetLineManager(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params SetLineManagerParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		previousManagerID, err := h.setLineManager(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		// Removing a line manager from a user without one changes nothing
+		if previousManagerID == params.ManagerID {
+			return nil, nil
+		}
+
+		before := lineManagerAuditState{ManagerID: previousManagerID}
+		after := lineManagerAuditState{ManagerID: params.ManagerID}
+		return auditEntry(ctx, domain.AuditActionSetLineManager, domain.AuditTargetUser, params.UserID, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// setLineManager sets or removes the user's line manager and returns the previous one
+func (h *Handlers) setLineManager(ctx context.Context, params *SetLineManagerParams) (string, error) {
+	if params.ManagerID == "" {
+		previousManagerID, err := h.LineManager.RemoveLineManager(ctx, params.UserID)
+		if err != nil {
+			return "", httpError(http.StatusInternalServerError, errors.Deleting(lineManagerResource), err)
+		}
+
+		return previousManagerID, nil
+	}
+
+	previousManagerID, err := h.LineManager.SetLineManager(ctx, domain.SetLineManagerParams{
+		UserID:    params.UserID,
+		ManagerID: params.ManagerID,
+	})
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return "", httpError(http.StatusNotFound, errors.NotFound(userResource), err)
+		}
+
+		return "", httpError(http.StatusInternalServerError, errors.Updating(lineManagerResource), err)
+	}
+
+	return previousManagerID, nil
+}
+
+// lineManagerAuditState is a user's line manager as recorded in the audit log, empty if they have none
+type lineManagerAuditState struct {
+	ManagerID string `json:"managerId,omitempty"`
+}
+
+type ListTeamProgressParams struct {
+	PaginationParams
+	SortBy   string `json:"sortBy" validate:"omitempty,oneof=name email"`
+	CourseID string `json:"courseId"`
+}
+
+// ListTeamProgress lists progress for the current user's direct reports
+func (h *Handlers) ListTeamProgress(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListTeamProgressParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	pageParams, err := params.pageParams(params.SortBy, pageFilters{CourseID: params.CourseID})
+	if err != nil {
+		return err
+	}
+
+	managerID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+	pageParams.ManagerID = &managerID
+
+	progress, err := h.Progress.ListProgress(ctx, pageParams)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(progressResource), err)
+	}
+
+	return e.JSON(http.StatusOK, progress)
+}
+
+// GetTeamCompliance returns the status of every enrolment of the current user's direct reports,
+// with a count for each status
+func (h *Handlers) GetTeamCompliance(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	managerID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	enrolments, err := h.LineManager.GetTeamEnrolments(ctx, managerID)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(teamComplianceResource), err)
+	}
+
+	return e.JSON(http.StatusOK, domain.TeamComplianceFrom(enrolments))
+}
@@ -0,0 +1,307 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+)
+
+func TestSetLineManager_HappyPath(t *testing.T) {
+	t.Run("sets line manager", func(t *testing.T) {
+		mockRepo := &mocks.LineManagerRepositoryMock{
+			SetLineManagerFunc: func(ctx context.Context, params domain.SetLineManagerParams) (string, error) {
+				return "manager-0", nil
+			},
+		}
+
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{LineManager: mockRepo, Audit: auditRepo}
+
+		req := handlers.SetLineManagerParams{UserID: testhelpers.User.ID, ManagerID: "manager-1"}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/manager/set")
+
+		err := h.SetLineManager(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRepo.SetLineManagerCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetLineManagerHandlerName)
+
+		expected := domain.SetLineManagerParams{UserID: testhelpers.User.ID, ManagerID: "manager-1"}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.SetLineManagerHandlerName)
+		entry := auditCalls[0].Params
+		if entry.Action != domain.AuditActionSetLineManager || entry.TargetID != testhelpers.User.ID {
+			t.Errorf("expected %s audit entry for user %s, got %+v", domain.AuditActionSetLineManager, testhelpers.User.ID, entry)
+		}
+		if string(entry.Before) != `{"managerId":"manager-0"}` || string(entry.After) != `{"managerId":"manager-1"}` {
+			t.Errorf("expected line manager manager-0 to manager-1, got %s to %s", entry.Before, entry.After)
+		}
+	})
+
+	t.Run("removes line manager when manager id is empty", func(t *testing.T) {
+		mockRepo := &mocks.LineManagerRepositoryMock{
+			RemoveLineManagerFunc: func(ctx context.Context, userID string) (string, error) {
+				return "manager-1", nil
+			},
+		}
+
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{LineManager: mockRepo, Audit: auditRepo}
+
+		req := handlers.SetLineManagerParams{UserID: testhelpers.User.ID}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/manager/set")
+
+		err := h.SetLineManager(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRepo.RemoveLineManagerCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetLineManagerHandlerName)
+		if calls[0].UserID != testhelpers.User.ID {
+			t.Errorf("expected user %s, got %s", testhelpers.User.ID, calls[0].UserID)
+		}
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.SetLineManagerHandlerName)
+		if string(auditCalls[0].Params.Before) != `{"managerId":"manager-1"}` || string(auditCalls[0].Params.After) != `{}` {
+			t.Errorf("expected line manager manager-1 to be removed, got %s to %s", auditCalls[0].Params.Before, auditCalls[0].Params.After)
+		}
+	})
+
+	t.Run("doesn't audit removing a line manager from a user without one", func(t *testing.T) {
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{
+			LineManager: &mocks.LineManagerRepositoryMock{
+				RemoveLineManagerFunc: func(ctx context.Context, userID string) (string, error) {
+					return "", nil
+				},
+			},
+			Audit: auditRepo,
+		}
+
+		req := handlers.SetLineManagerParams{UserID: testhelpers.User.ID}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/manager/set")
+
+		if err := h.SetLineManager(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		testhelpers.AssertRepoCalls(t, len(auditRepo.AddAuditEntryCalls()), 0, testhelpers.SetLineManagerHandlerName)
+	})
+}
+
+func TestSetLineManager_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.SetLineManagerParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing user id",
+			reqBody:        handlers.SetLineManagerParams{ManagerID: "manager-1"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "validation error - own line manager",
+			reqBody:        handlers.SetLineManagerParams{UserID: testhelpers.User.ID, ManagerID: testhelpers.User.ID},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "user not found",
+			reqBody:        handlers.SetLineManagerParams{UserID: testhelpers.User.ID, ManagerID: "manager-1"},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.SetLineManagerParams{UserID: testhelpers.User.ID, ManagerID: "manager-1"},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("line manager"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				LineManager: &mocks.LineManagerRepositoryMock{
+					SetLineManagerFunc: func(ctx context.Context, params domain.SetLineManagerParams) (string, error) {
+						return "", tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/manager/set")
+			err := h.SetLineManager(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestListTeamProgress(t *testing.T) {
+	t.Run("lists progress for the current user's team", func(t *testing.T) {
+		mockRepo := &mocks.ProgressRepositoryMock{
+			ListProgressFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+				return &domain.Page[*domain.FullProgress]{Items: []*domain.FullProgress{}}, nil
+			},
+		}
+
+		h := &handlers.Handlers{Progress: mockRepo}
+
+		reqParams := handlers.ListTeamProgressParams{CourseID: testhelpers.Course.ID.String()}
+		ctx, rec := testhelpers.SetupEchoContext(t, reqParams, "team/progress")
+
+		err := h.ListTeamProgress(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		calls := mockRepo.ListProgressCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListTeamProgressHandlerName)
+
+		params := calls[0].Params
+		if params.ManagerID == nil || *params.ManagerID != testhelpers.TestUserID {
+			t.Errorf("expected progress scoped to %s, got %v", testhelpers.TestUserID, params.ManagerID)
+		}
+		if params.CourseID == nil || *params.CourseID != testhelpers.Course.ID {
+			t.Errorf("expected course filter %s, got %v", testhelpers.Course.ID, params.CourseID)
+		}
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Progress: &mocks.ProgressRepositoryMock{
+				ListProgressFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "team/progress")
+
+		err := h.ListTeamProgress(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("user progress"))
+	})
+}
+
+func TestGetTeamCompliance(t *testing.T) {
+	t.Run("returns enrolments with status counts", func(t *testing.T) {
+		dueDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		enrolments := []domain.TeamEnrolment{
+			{
+				UserID:      "user-1",
+				UserName:    "User A",
+				CourseID:    testhelpers.Course.ID,
+				CourseTitle: testhelpers.Course.Title,
+				DueDate:     &dueDate,
+				Status:      domain.EnrolmentStatusOverdue,
+			},
+			{
+				UserID:      "user-2",
+				UserName:    "User B",
+				CourseID:    testhelpers.Course.ID,
+				CourseTitle: testhelpers.Course.Title,
+				Status:      domain.EnrolmentStatusCompleted,
+			},
+		}
+
+		mockRepo := &mocks.LineManagerRepositoryMock{
+			GetTeamEnrolmentsFunc: func(ctx context.Context, managerID string) ([]domain.TeamEnrolment, error) {
+				return enrolments, nil
+			},
+		}
+
+		h := &handlers.Handlers{LineManager: mockRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, nil, "team/compliance")
+
+		err := h.GetTeamCompliance(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRepo.GetTeamEnrolmentsCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetTeamComplianceHandlerName)
+		if calls[0].ManagerID != testhelpers.TestUserID {
+			t.Errorf("expected team of %s, got %s", testhelpers.TestUserID, calls[0].ManagerID)
+		}
+
+		var actual domain.TeamCompliance
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		expected := domain.TeamCompliance{
+			Counts: map[domain.EnrolmentStatus]int{
+				domain.EnrolmentStatusNotStarted: 0,
+				domain.EnrolmentStatusInProgress: 0,
+				domain.EnrolmentStatusCompleted:  1,
+				domain.EnrolmentStatusOverdue:    1,
+			},
+			Enrolments: enrolments,
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("compliance mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			LineManager: &mocks.LineManagerRepositoryMock{
+				GetTeamEnrolmentsFunc: func(ctx context.Context, managerID string) ([]domain.TeamEnrolment, error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "team/compliance")
+
+		err := h.GetTeamCompliance(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("team compliance"))
+	})
+}
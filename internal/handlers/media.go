@@ -26,6 +26,15 @@ func (h *Handlers) GetVideoUploadURL(e echo.Context) error {
 		return err
 	}
 
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	if err := h.checkCanEditCourse(ctx, courseID); err != nil {
+		return err
+	}
+
 	videoKey := getVideoKey(params)
 	URL, err := h.ObjectStorage.GenerateUploadURL(ctx, videoKey, nil)
 	if err != nil {
@@ -113,6 +122,15 @@ func (h *Handlers) GetMaterialUploadURL(e echo.Context) error {
 		return err
 	}
 
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	if err := h.checkCanEditCourse(ctx, courseID); err != nil {
+		return err
+	}
+
 	materialKey := getMaterialKey(params.CourseID, params.StorageKey)
 	contentType := "application/pdf"
 	URL, err := h.ObjectStorage.GenerateUploadURL(ctx, materialKey, &contentType)
@@ -213,6 +213,55 @@ func TestGetVideoUploadURL_UnhappyPath(t *testing.T) {
 	}
 }
 
+func TestGetVideoUploadURL_Instructor(t *testing.T) {
+	tests := []struct {
+		name         string
+		isInstructor bool
+		wantErr      bool
+	}{
+		{name: "instructor assigned to course", isInstructor: true},
+		{name: "instructor not assigned to course", isInstructor: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo := &mocks.RoleRepositoryMock{
+				IsCourseInstructorFunc: func(ctx context.Context, params domain.IsCourseInstructorParams) (bool, error) {
+					return tt.isInstructor, nil
+				},
+			}
+			mockObjectStorage := &mocks.ObjectStorageMock{
+				GenerateUploadURLFunc: func(ctx context.Context, key string, contentType *string) (string, error) {
+					return "https://myuploadurl.com", nil
+				},
+			}
+
+			h := &handlers.Handlers{Role: mockRoleRepo, ObjectStorage: mockObjectStorage}
+
+			ctx, _ := testhelpers.SetupEchoContext(
+				t, testhelpers.VideoURLParams, "get-video-upload-url", testhelpers.WithRole(config.InstructorRole),
+			)
+			err := h.GetVideoUploadURL(ctx)
+
+			calls := mockRoleRepo.IsCourseInstructorCalls()
+			testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetVideoUploadURLHandlerName)
+			if calls[0].Params.CourseID.String() != testhelpers.VideoURLParams.CourseID {
+				t.Errorf("expected course %s, got %s", testhelpers.VideoURLParams.CourseID, calls[0].Params.CourseID)
+			}
+
+			if tt.wantErr {
+				testhelpers.AssertHTTPError(t, err, http.StatusForbidden, errors.Forbidden("course"))
+				testhelpers.AssertRepoCalls(t, len(mockObjectStorage.GenerateUploadURLCalls()), 0, testhelpers.GetVideoUploadURLHandlerName)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
 func TestGetMaterialUploadURL_HappyPath(t *testing.T) {
 	expected := &domain.VideoUploadURL{UploadURL: "https://s3uploadurl.com"}
 
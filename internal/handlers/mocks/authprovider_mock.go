@@ -22,9 +22,21 @@ var _ auth.AuthProvider = &AuthProviderMock{}
 //			CreateUserFunc: func(ctx context.Context, email string, password string, name string) (string, error) {
 //				panic("mock out the CreateUser method")
 //			},
+//			DeleteUserFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			GetPasswordSetupLinkFunc: func(ctx context.Context, email string) (string, error) {
+//				panic("mock out the GetPasswordSetupLink method")
+//			},
 //			GetUserFromIDTokenFunc: func(ctx context.Context, token string) (*auth.User, error) {
 //				panic("mock out the GetUserFromIDToken method")
 //			},
+//			SetUserDisabledFunc: func(ctx context.Context, id string, disabled bool) error {
+//				panic("mock out the SetUserDisabled method")
+//			},
+//			UpdateUserFunc: func(ctx context.Context, id string, email string, name string) error {
+//				panic("mock out the UpdateUser method")
+//			},
 //		}
 //
 //		// use mockedAuthProvider in code that requires auth.AuthProvider
@@ -35,9 +47,21 @@ type AuthProviderMock struct {
 	// CreateUserFunc mocks the CreateUser method.
 	CreateUserFunc func(ctx context.Context, email string, password string, name string) (string, error)
 
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, id string) error
+
+	// GetPasswordSetupLinkFunc mocks the GetPasswordSetupLink method.
+	GetPasswordSetupLinkFunc func(ctx context.Context, email string) (string, error)
+
 	// GetUserFromIDTokenFunc mocks the GetUserFromIDToken method.
 	GetUserFromIDTokenFunc func(ctx context.Context, token string) (*auth.User, error)
 
+	// SetUserDisabledFunc mocks the SetUserDisabled method.
+	SetUserDisabledFunc func(ctx context.Context, id string, disabled bool) error
+
+	// UpdateUserFunc mocks the UpdateUser method.
+	UpdateUserFunc func(ctx context.Context, id string, email string, name string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateUser holds details about calls to the CreateUser method.
@@ -51,6 +75,20 @@ type AuthProviderMock struct {
 			// Name is the name argument value.
 			Name string
 		}
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// GetPasswordSetupLink holds details about calls to the GetPasswordSetupLink method.
+		GetPasswordSetupLink []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
 		// GetUserFromIDToken holds details about calls to the GetUserFromIDToken method.
 		GetUserFromIDToken []struct {
 			// Ctx is the ctx argument value.
@@ -58,9 +96,33 @@ type AuthProviderMock struct {
 			// Token is the token argument value.
 			Token string
 		}
+		// SetUserDisabled holds details about calls to the SetUserDisabled method.
+		SetUserDisabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+			// Disabled is the disabled argument value.
+			Disabled bool
+		}
+		// UpdateUser holds details about calls to the UpdateUser method.
+		UpdateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+			// Email is the email argument value.
+			Email string
+			// Name is the name argument value.
+			Name string
+		}
 	}
-	lockCreateUser         sync.RWMutex
-	lockGetUserFromIDToken sync.RWMutex
+	lockCreateUser           sync.RWMutex
+	lockDeleteUser           sync.RWMutex
+	lockGetPasswordSetupLink sync.RWMutex
+	lockGetUserFromIDToken   sync.RWMutex
+	lockSetUserDisabled      sync.RWMutex
+	lockUpdateUser           sync.RWMutex
 }
 
 // CreateUser calls CreateUserFunc.
@@ -107,6 +169,78 @@ func (mock *AuthProviderMock) CreateUserCalls() []struct {
 	return calls
 }
 
+// DeleteUser calls DeleteUserFunc.
+func (mock *AuthProviderMock) DeleteUser(ctx context.Context, id string) error {
+	if mock.DeleteUserFunc == nil {
+		panic("AuthProviderMock.DeleteUserFunc: method is nil but AuthProvider.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, id)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedAuthProvider.DeleteUserCalls())
+func (mock *AuthProviderMock) DeleteUserCalls() []struct {
+	Ctx context.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  string
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// GetPasswordSetupLink calls GetPasswordSetupLinkFunc.
+func (mock *AuthProviderMock) GetPasswordSetupLink(ctx context.Context, email string) (string, error) {
+	if mock.GetPasswordSetupLinkFunc == nil {
+		panic("AuthProviderMock.GetPasswordSetupLinkFunc: method is nil but AuthProvider.GetPasswordSetupLink was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockGetPasswordSetupLink.Lock()
+	mock.calls.GetPasswordSetupLink = append(mock.calls.GetPasswordSetupLink, callInfo)
+	mock.lockGetPasswordSetupLink.Unlock()
+	return mock.GetPasswordSetupLinkFunc(ctx, email)
+}
+
+// GetPasswordSetupLinkCalls gets all the calls that were made to GetPasswordSetupLink.
+// Check the length with:
+//
+//	len(mockedAuthProvider.GetPasswordSetupLinkCalls())
+func (mock *AuthProviderMock) GetPasswordSetupLinkCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockGetPasswordSetupLink.RLock()
+	calls = mock.calls.GetPasswordSetupLink
+	mock.lockGetPasswordSetupLink.RUnlock()
+	return calls
+}
+
 // GetUserFromIDToken calls GetUserFromIDTokenFunc.
 func (mock *AuthProviderMock) GetUserFromIDToken(ctx context.Context, token string) (*auth.User, error) {
 	if mock.GetUserFromIDTokenFunc == nil {
@@ -142,3 +276,87 @@ func (mock *AuthProviderMock) GetUserFromIDTokenCalls() []struct {
 	mock.lockGetUserFromIDToken.RUnlock()
 	return calls
 }
+
+// SetUserDisabled calls SetUserDisabledFunc.
+func (mock *AuthProviderMock) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	if mock.SetUserDisabledFunc == nil {
+		panic("AuthProviderMock.SetUserDisabledFunc: method is nil but AuthProvider.SetUserDisabled was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Id       string
+		Disabled bool
+	}{
+		Ctx:      ctx,
+		Id:       id,
+		Disabled: disabled,
+	}
+	mock.lockSetUserDisabled.Lock()
+	mock.calls.SetUserDisabled = append(mock.calls.SetUserDisabled, callInfo)
+	mock.lockSetUserDisabled.Unlock()
+	return mock.SetUserDisabledFunc(ctx, id, disabled)
+}
+
+// SetUserDisabledCalls gets all the calls that were made to SetUserDisabled.
+// Check the length with:
+//
+//	len(mockedAuthProvider.SetUserDisabledCalls())
+func (mock *AuthProviderMock) SetUserDisabledCalls() []struct {
+	Ctx      context.Context
+	Id       string
+	Disabled bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Id       string
+		Disabled bool
+	}
+	mock.lockSetUserDisabled.RLock()
+	calls = mock.calls.SetUserDisabled
+	mock.lockSetUserDisabled.RUnlock()
+	return calls
+}
+
+// UpdateUser calls UpdateUserFunc.
+func (mock *AuthProviderMock) UpdateUser(ctx context.Context, id string, email string, name string) error {
+	if mock.UpdateUserFunc == nil {
+		panic("AuthProviderMock.UpdateUserFunc: method is nil but AuthProvider.UpdateUser was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Id    string
+		Email string
+		Name  string
+	}{
+		Ctx:   ctx,
+		Id:    id,
+		Email: email,
+		Name:  name,
+	}
+	mock.lockUpdateUser.Lock()
+	mock.calls.UpdateUser = append(mock.calls.UpdateUser, callInfo)
+	mock.lockUpdateUser.Unlock()
+	return mock.UpdateUserFunc(ctx, id, email, name)
+}
+
+// UpdateUserCalls gets all the calls that were made to UpdateUser.
+// Check the length with:
+//
+//	len(mockedAuthProvider.UpdateUserCalls())
+func (mock *AuthProviderMock) UpdateUserCalls() []struct {
+	Ctx   context.Context
+	Id    string
+	Email string
+	Name  string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Id    string
+		Email string
+		Name  string
+	}
+	mock.lockUpdateUser.RLock()
+	calls = mock.calls.UpdateUser
+	mock.lockUpdateUser.RUnlock()
+	return calls
+}
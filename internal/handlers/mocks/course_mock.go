@@ -33,7 +33,7 @@ var _ domain.CourseRepository = &CourseRepositoryMock{}
 //			GetAllCoursesFunc: func(contextMoqParam context.Context) ([]*domain.AllCourseLegacy, error) {
 //				panic("mock out the GetAllCourses method")
 //			},
-//			GetAssignedCourseTitlesFunc: func(contextMoqParam context.Context, s string) ([]domain.CourseOverview, error) {
+//			GetAssignedCourseTitlesFunc: func(contextMoqParam context.Context, s string) ([]domain.AssignedCourseOverview, error) {
 //				panic("mock out the GetAssignedCourseTitles method")
 //			},
 //			GetCourseFunc: func(contextMoqParam context.Context, uUID pgtype.UUID) (*domain.Course, error) {
@@ -45,6 +45,9 @@ var _ domain.CourseRepository = &CourseRepositoryMock{}
 //			GetCoursesOverviewFunc: func(contextMoqParam context.Context) ([]domain.CourseOverview, error) {
 //				panic("mock out the GetCoursesOverview method")
 //			},
+//			ListCoursesFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.AllCourseLegacy], error) {
+//				panic("mock out the ListCourses method")
+//			},
 //		}
 //
 //		// use mockedCourseRepository in code that requires domain.CourseRepository
@@ -65,7 +68,7 @@ type CourseRepositoryMock struct {
 	GetAllCoursesFunc func(contextMoqParam context.Context) ([]*domain.AllCourseLegacy, error)
 
 	// GetAssignedCourseTitlesFunc mocks the GetAssignedCourseTitles method.
-	GetAssignedCourseTitlesFunc func(contextMoqParam context.Context, s string) ([]domain.CourseOverview, error)
+	GetAssignedCourseTitlesFunc func(contextMoqParam context.Context, s string) ([]domain.AssignedCourseOverview, error)
 
 	// GetCourseFunc mocks the GetCourse method.
 	GetCourseFunc func(contextMoqParam context.Context, uUID pgtype.UUID) (*domain.Course, error)
@@ -76,6 +79,9 @@ type CourseRepositoryMock struct {
 	// GetCoursesOverviewFunc mocks the GetCoursesOverview method.
 	GetCoursesOverviewFunc func(contextMoqParam context.Context) ([]domain.CourseOverview, error)
 
+	// ListCoursesFunc mocks the ListCourses method.
+	ListCoursesFunc func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.AllCourseLegacy], error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// AddCourse holds details about calls to the AddCourse method.
@@ -130,6 +136,13 @@ type CourseRepositoryMock struct {
 			// ContextMoqParam is the contextMoqParam argument value.
 			ContextMoqParam context.Context
 		}
+		// ListCourses holds details about calls to the ListCourses method.
+		ListCourses []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.PageParams
+		}
 	}
 	lockAddCourse               sync.RWMutex
 	lockDeleteCourse            sync.RWMutex
@@ -139,6 +152,7 @@ type CourseRepositoryMock struct {
 	lockGetCourse               sync.RWMutex
 	lockGetCourseMaterials      sync.RWMutex
 	lockGetCoursesOverview      sync.RWMutex
+	lockListCourses             sync.RWMutex
 }
 
 // AddCourse calls AddCourseFunc.
@@ -282,7 +296,7 @@ func (mock *CourseRepositoryMock) GetAllCoursesCalls() []struct {
 }
 
 // GetAssignedCourseTitles calls GetAssignedCourseTitlesFunc.
-func (mock *CourseRepositoryMock) GetAssignedCourseTitles(contextMoqParam context.Context, s string) ([]domain.CourseOverview, error) {
+func (mock *CourseRepositoryMock) GetAssignedCourseTitles(contextMoqParam context.Context, s string) ([]domain.AssignedCourseOverview, error) {
 	if mock.GetAssignedCourseTitlesFunc == nil {
 		panic("CourseRepositoryMock.GetAssignedCourseTitlesFunc: method is nil but CourseRepository.GetAssignedCourseTitles was just called")
 	}
@@ -420,3 +434,39 @@ func (mock *CourseRepositoryMock) GetCoursesOverviewCalls() []struct {
 	mock.lockGetCoursesOverview.RUnlock()
 	return calls
 }
+
+// ListCourses calls ListCoursesFunc.
+func (mock *CourseRepositoryMock) ListCourses(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.AllCourseLegacy], error) {
+	if mock.ListCoursesFunc == nil {
+		panic("CourseRepositoryMock.ListCoursesFunc: method is nil but CourseRepository.ListCourses was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockListCourses.Lock()
+	mock.calls.ListCourses = append(mock.calls.ListCourses, callInfo)
+	mock.lockListCourses.Unlock()
+	return mock.ListCoursesFunc(ctx, params)
+}
+
+// ListCoursesCalls gets all the calls that were made to ListCourses.
+// Check the length with:
+//
+//	len(mockedCourseRepository.ListCoursesCalls())
+func (mock *CourseRepositoryMock) ListCoursesCalls() []struct {
+	Ctx    context.Context
+	Params domain.PageParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}
+	mock.lockListCourses.RLock()
+	calls = mock.calls.ListCourses
+	mock.lockListCourses.RUnlock()
+	return calls
+}
@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"context"
+	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers"
 	"github.com/supanova-rp/supanova-server/internal/services/email"
 	"sync"
@@ -26,14 +27,17 @@ var _ handlers.EmailService = &EmailServiceMock{}
 //			GetTemplateNamesFunc: func() *email.TemplateNames {
 //				panic("mock out the GetTemplateNames method")
 //			},
+//			ParseWebhookFunc: func(body []byte) (*domain.EmailEvent, error) {
+//				panic("mock out the ParseWebhook method")
+//			},
+//			PreviewFunc: func(emailName string, variables map[string]string) (*email.RenderedEmail, error) {
+//				panic("mock out the Preview method")
+//			},
 //			SendFunc: func(ctx context.Context, params email.EmailParams, templateName string, emailName string) error {
 //				panic("mock out the Send method")
 //			},
-//			SetupRetryFunc: func() (context.CancelFunc, error) {
-//				panic("mock out the SetupRetry method")
-//			},
-//			StopRetryFunc: func(ctx context.Context)  {
-//				panic("mock out the StopRetry method")
+//			StopDispatcherFunc: func(ctx context.Context)  {
+//				panic("mock out the StopDispatcher method")
 //			},
 //		}
 //
@@ -48,14 +52,17 @@ type EmailServiceMock struct {
 	// GetTemplateNamesFunc mocks the GetTemplateNames method.
 	GetTemplateNamesFunc func() *email.TemplateNames
 
+	// ParseWebhookFunc mocks the ParseWebhook method.
+	ParseWebhookFunc func(body []byte) (*domain.EmailEvent, error)
+
+	// PreviewFunc mocks the Preview method.
+	PreviewFunc func(emailName string, variables map[string]string) (*email.RenderedEmail, error)
+
 	// SendFunc mocks the Send method.
 	SendFunc func(ctx context.Context, params email.EmailParams, templateName string, emailName string) error
 
-	// SetupRetryFunc mocks the SetupRetry method.
-	SetupRetryFunc func() (context.CancelFunc, error)
-
-	// StopRetryFunc mocks the StopRetry method.
-	StopRetryFunc func(ctx context.Context)
+	// StopDispatcherFunc mocks the StopDispatcher method.
+	StopDispatcherFunc func(ctx context.Context)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -65,6 +72,18 @@ type EmailServiceMock struct {
 		// GetTemplateNames holds details about calls to the GetTemplateNames method.
 		GetTemplateNames []struct {
 		}
+		// ParseWebhook holds details about calls to the ParseWebhook method.
+		ParseWebhook []struct {
+			// Body is the body argument value.
+			Body []byte
+		}
+		// Preview holds details about calls to the Preview method.
+		Preview []struct {
+			// EmailName is the emailName argument value.
+			EmailName string
+			// Variables is the variables argument value.
+			Variables map[string]string
+		}
 		// Send holds details about calls to the Send method.
 		Send []struct {
 			// Ctx is the ctx argument value.
@@ -76,20 +95,18 @@ type EmailServiceMock struct {
 			// EmailName is the emailName argument value.
 			EmailName string
 		}
-		// SetupRetry holds details about calls to the SetupRetry method.
-		SetupRetry []struct {
-		}
-		// StopRetry holds details about calls to the StopRetry method.
-		StopRetry []struct {
+		// StopDispatcher holds details about calls to the StopDispatcher method.
+		StopDispatcher []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
 	}
 	lockGetEmailNames    sync.RWMutex
 	lockGetTemplateNames sync.RWMutex
+	lockParseWebhook     sync.RWMutex
+	lockPreview          sync.RWMutex
 	lockSend             sync.RWMutex
-	lockSetupRetry       sync.RWMutex
-	lockStopRetry        sync.RWMutex
+	lockStopDispatcher   sync.RWMutex
 }
 
 // GetEmailNames calls GetEmailNamesFunc.
@@ -146,6 +163,74 @@ func (mock *EmailServiceMock) GetTemplateNamesCalls() []struct {
 	return calls
 }
 
+// ParseWebhook calls ParseWebhookFunc.
+func (mock *EmailServiceMock) ParseWebhook(body []byte) (*domain.EmailEvent, error) {
+	if mock.ParseWebhookFunc == nil {
+		panic("EmailServiceMock.ParseWebhookFunc: method is nil but EmailService.ParseWebhook was just called")
+	}
+	callInfo := struct {
+		Body []byte
+	}{
+		Body: body,
+	}
+	mock.lockParseWebhook.Lock()
+	mock.calls.ParseWebhook = append(mock.calls.ParseWebhook, callInfo)
+	mock.lockParseWebhook.Unlock()
+	return mock.ParseWebhookFunc(body)
+}
+
+// ParseWebhookCalls gets all the calls that were made to ParseWebhook.
+// Check the length with:
+//
+//	len(mockedEmailService.ParseWebhookCalls())
+func (mock *EmailServiceMock) ParseWebhookCalls() []struct {
+	Body []byte
+} {
+	var calls []struct {
+		Body []byte
+	}
+	mock.lockParseWebhook.RLock()
+	calls = mock.calls.ParseWebhook
+	mock.lockParseWebhook.RUnlock()
+	return calls
+}
+
+// Preview calls PreviewFunc.
+func (mock *EmailServiceMock) Preview(emailName string, variables map[string]string) (*email.RenderedEmail, error) {
+	if mock.PreviewFunc == nil {
+		panic("EmailServiceMock.PreviewFunc: method is nil but EmailService.Preview was just called")
+	}
+	callInfo := struct {
+		EmailName string
+		Variables map[string]string
+	}{
+		EmailName: emailName,
+		Variables: variables,
+	}
+	mock.lockPreview.Lock()
+	mock.calls.Preview = append(mock.calls.Preview, callInfo)
+	mock.lockPreview.Unlock()
+	return mock.PreviewFunc(emailName, variables)
+}
+
+// PreviewCalls gets all the calls that were made to Preview.
+// Check the length with:
+//
+//	len(mockedEmailService.PreviewCalls())
+func (mock *EmailServiceMock) PreviewCalls() []struct {
+	EmailName string
+	Variables map[string]string
+} {
+	var calls []struct {
+		EmailName string
+		Variables map[string]string
+	}
+	mock.lockPreview.RLock()
+	calls = mock.calls.Preview
+	mock.lockPreview.RUnlock()
+	return calls
+}
+
 // Send calls SendFunc.
 func (mock *EmailServiceMock) Send(ctx context.Context, params email.EmailParams, templateName string, emailName string) error {
 	if mock.SendFunc == nil {
@@ -190,61 +275,34 @@ func (mock *EmailServiceMock) SendCalls() []struct {
 	return calls
 }
 
-// SetupRetry calls SetupRetryFunc.
-func (mock *EmailServiceMock) SetupRetry() (context.CancelFunc, error) {
-	if mock.SetupRetryFunc == nil {
-		panic("EmailServiceMock.SetupRetryFunc: method is nil but EmailService.SetupRetry was just called")
-	}
-	callInfo := struct {
-	}{}
-	mock.lockSetupRetry.Lock()
-	mock.calls.SetupRetry = append(mock.calls.SetupRetry, callInfo)
-	mock.lockSetupRetry.Unlock()
-	return mock.SetupRetryFunc()
-}
-
-// SetupRetryCalls gets all the calls that were made to SetupRetry.
-// Check the length with:
-//
-//	len(mockedEmailService.SetupRetryCalls())
-func (mock *EmailServiceMock) SetupRetryCalls() []struct {
-} {
-	var calls []struct {
-	}
-	mock.lockSetupRetry.RLock()
-	calls = mock.calls.SetupRetry
-	mock.lockSetupRetry.RUnlock()
-	return calls
-}
-
-// StopRetry calls StopRetryFunc.
-func (mock *EmailServiceMock) StopRetry(ctx context.Context) {
-	if mock.StopRetryFunc == nil {
-		panic("EmailServiceMock.StopRetryFunc: method is nil but EmailService.StopRetry was just called")
+// StopDispatcher calls StopDispatcherFunc.
+func (mock *EmailServiceMock) StopDispatcher(ctx context.Context) {
+	if mock.StopDispatcherFunc == nil {
+		panic("EmailServiceMock.StopDispatcherFunc: method is nil but EmailService.StopDispatcher was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
 	}{
 		Ctx: ctx,
 	}
-	mock.lockStopRetry.Lock()
-	mock.calls.StopRetry = append(mock.calls.StopRetry, callInfo)
-	mock.lockStopRetry.Unlock()
-	mock.StopRetryFunc(ctx)
+	mock.lockStopDispatcher.Lock()
+	mock.calls.StopDispatcher = append(mock.calls.StopDispatcher, callInfo)
+	mock.lockStopDispatcher.Unlock()
+	mock.StopDispatcherFunc(ctx)
 }
 
-// StopRetryCalls gets all the calls that were made to StopRetry.
+// StopDispatcherCalls gets all the calls that were made to StopDispatcher.
 // Check the length with:
 //
-//	len(mockedEmailService.StopRetryCalls())
-func (mock *EmailServiceMock) StopRetryCalls() []struct {
+//	len(mockedEmailService.StopDispatcherCalls())
+func (mock *EmailServiceMock) StopDispatcherCalls() []struct {
 	Ctx context.Context
 } {
 	var calls []struct {
 		Ctx context.Context
 	}
-	mock.lockStopRetry.RLock()
-	calls = mock.calls.StopRetry
-	mock.lockStopRetry.RUnlock()
+	mock.lockStopDispatcher.RLock()
+	calls = mock.calls.StopDispatcher
+	mock.lockStopDispatcher.RUnlock()
 	return calls
 }
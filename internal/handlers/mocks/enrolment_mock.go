@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"context"
+	"github.com/google/uuid"
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"sync"
 )
@@ -19,18 +20,45 @@ var _ domain.EnrolmentRepository = &EnrolmentRepositoryMock{}
 //
 //		// make and configure a mocked domain.EnrolmentRepository
 //		mockedEnrolmentRepository := &EnrolmentRepositoryMock{
+//			BulkUpdateEnrolmentsFunc: func(ctx context.Context, params domain.BulkUpdateEnrolmentsParams) ([]domain.BulkEnrolmentResult, error) {
+//				panic("mock out the BulkUpdateEnrolments method")
+//			},
 //			DisenrolInCourseFunc: func(ctx context.Context, params domain.DisenrolInCourseParams) error {
 //				panic("mock out the DisenrolInCourse method")
 //			},
 //			EnrolInCourseFunc: func(ctx context.Context, params domain.EnrolInCourseParams) error {
 //				panic("mock out the EnrolInCourse method")
 //			},
+//			GetNotStartedEnrolmentsFunc: func(ctx context.Context, enrolledDays int) ([]domain.EnrolmentNotice, error) {
+//				panic("mock out the GetNotStartedEnrolments method")
+//			},
+//			GetOverdueEnrolmentsFunc: func(contextMoqParam context.Context) ([]domain.OverdueEnrolment, error) {
+//				panic("mock out the GetOverdueEnrolments method")
+//			},
+//			GetUnnotifiedEnrolmentsFunc: func(contextMoqParam context.Context) ([]domain.EnrolmentNotice, error) {
+//				panic("mock out the GetUnnotifiedEnrolments method")
+//			},
 //			GetUsersAndAssignedCoursesFunc: func(contextMoqParam context.Context) ([]domain.UserWithAssignedCourses, error) {
 //				panic("mock out the GetUsersAndAssignedCourses method")
 //			},
 //			IsEnrolledFunc: func(ctx context.Context, params domain.IsEnrolledParams) (bool, error) {
 //				panic("mock out the IsEnrolled method")
 //			},
+//			ListUsersAndAssignedCoursesFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[domain.UserWithAssignedCourses], error) {
+//				panic("mock out the ListUsersAndAssignedCourses method")
+//			},
+//			SetEnrolmentDueDateFunc: func(ctx context.Context, params domain.SetEnrolmentDueDateParams) (*domain.EnrolmentDueDateChange, error) {
+//				panic("mock out the SetEnrolmentDueDate method")
+//			},
+//			SetEnrolmentNotifiedFunc: func(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+//				panic("mock out the SetEnrolmentNotified method")
+//			},
+//			SetEnrolmentOverdueFunc: func(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+//				panic("mock out the SetEnrolmentOverdue method")
+//			},
+//			SetEnrolmentRemindedFunc: func(ctx context.Context, enrolmentID uuid.UUID, reminder *domain.OutboxEmail) error {
+//				panic("mock out the SetEnrolmentReminded method")
+//			},
 //		}
 //
 //		// use mockedEnrolmentRepository in code that requires domain.EnrolmentRepository
@@ -38,20 +66,54 @@ var _ domain.EnrolmentRepository = &EnrolmentRepositoryMock{}
 //
 //	}
 type EnrolmentRepositoryMock struct {
+	// BulkUpdateEnrolmentsFunc mocks the BulkUpdateEnrolments method.
+	BulkUpdateEnrolmentsFunc func(ctx context.Context, params domain.BulkUpdateEnrolmentsParams) ([]domain.BulkEnrolmentResult, error)
+
 	// DisenrolInCourseFunc mocks the DisenrolInCourse method.
 	DisenrolInCourseFunc func(ctx context.Context, params domain.DisenrolInCourseParams) error
 
 	// EnrolInCourseFunc mocks the EnrolInCourse method.
 	EnrolInCourseFunc func(ctx context.Context, params domain.EnrolInCourseParams) error
 
+	// GetNotStartedEnrolmentsFunc mocks the GetNotStartedEnrolments method.
+	GetNotStartedEnrolmentsFunc func(ctx context.Context, enrolledDays int) ([]domain.EnrolmentNotice, error)
+
+	// GetOverdueEnrolmentsFunc mocks the GetOverdueEnrolments method.
+	GetOverdueEnrolmentsFunc func(contextMoqParam context.Context) ([]domain.OverdueEnrolment, error)
+
+	// GetUnnotifiedEnrolmentsFunc mocks the GetUnnotifiedEnrolments method.
+	GetUnnotifiedEnrolmentsFunc func(contextMoqParam context.Context) ([]domain.EnrolmentNotice, error)
+
 	// GetUsersAndAssignedCoursesFunc mocks the GetUsersAndAssignedCourses method.
 	GetUsersAndAssignedCoursesFunc func(contextMoqParam context.Context) ([]domain.UserWithAssignedCourses, error)
 
 	// IsEnrolledFunc mocks the IsEnrolled method.
 	IsEnrolledFunc func(ctx context.Context, params domain.IsEnrolledParams) (bool, error)
 
+	// ListUsersAndAssignedCoursesFunc mocks the ListUsersAndAssignedCourses method.
+	ListUsersAndAssignedCoursesFunc func(ctx context.Context, params domain.PageParams) (*domain.Page[domain.UserWithAssignedCourses], error)
+
+	// SetEnrolmentDueDateFunc mocks the SetEnrolmentDueDate method.
+	SetEnrolmentDueDateFunc func(ctx context.Context, params domain.SetEnrolmentDueDateParams) (*domain.EnrolmentDueDateChange, error)
+
+	// SetEnrolmentNotifiedFunc mocks the SetEnrolmentNotified method.
+	SetEnrolmentNotifiedFunc func(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error
+
+	// SetEnrolmentOverdueFunc mocks the SetEnrolmentOverdue method.
+	SetEnrolmentOverdueFunc func(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error
+
+	// SetEnrolmentRemindedFunc mocks the SetEnrolmentReminded method.
+	SetEnrolmentRemindedFunc func(ctx context.Context, enrolmentID uuid.UUID, reminder *domain.OutboxEmail) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// BulkUpdateEnrolments holds details about calls to the BulkUpdateEnrolments method.
+		BulkUpdateEnrolments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.BulkUpdateEnrolmentsParams
+		}
 		// DisenrolInCourse holds details about calls to the DisenrolInCourse method.
 		DisenrolInCourse []struct {
 			// Ctx is the ctx argument value.
@@ -66,6 +128,23 @@ type EnrolmentRepositoryMock struct {
 			// Params is the params argument value.
 			Params domain.EnrolInCourseParams
 		}
+		// GetNotStartedEnrolments holds details about calls to the GetNotStartedEnrolments method.
+		GetNotStartedEnrolments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EnrolledDays is the enrolledDays argument value.
+			EnrolledDays int
+		}
+		// GetOverdueEnrolments holds details about calls to the GetOverdueEnrolments method.
+		GetOverdueEnrolments []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
+		// GetUnnotifiedEnrolments holds details about calls to the GetUnnotifiedEnrolments method.
+		GetUnnotifiedEnrolments []struct {
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
 		// GetUsersAndAssignedCourses holds details about calls to the GetUsersAndAssignedCourses method.
 		GetUsersAndAssignedCourses []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -78,11 +157,97 @@ type EnrolmentRepositoryMock struct {
 			// Params is the params argument value.
 			Params domain.IsEnrolledParams
 		}
+		// ListUsersAndAssignedCourses holds details about calls to the ListUsersAndAssignedCourses method.
+		ListUsersAndAssignedCourses []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.PageParams
+		}
+		// SetEnrolmentDueDate holds details about calls to the SetEnrolmentDueDate method.
+		SetEnrolmentDueDate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.SetEnrolmentDueDateParams
+		}
+		// SetEnrolmentNotified holds details about calls to the SetEnrolmentNotified method.
+		SetEnrolmentNotified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EnrolmentID is the enrolmentID argument value.
+			EnrolmentID uuid.UUID
+			// Notification is the notification argument value.
+			Notification *domain.OutboxEmail
+		}
+		// SetEnrolmentOverdue holds details about calls to the SetEnrolmentOverdue method.
+		SetEnrolmentOverdue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EnrolmentID is the enrolmentID argument value.
+			EnrolmentID uuid.UUID
+			// Notification is the notification argument value.
+			Notification *domain.OutboxEmail
+		}
+		// SetEnrolmentReminded holds details about calls to the SetEnrolmentReminded method.
+		SetEnrolmentReminded []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EnrolmentID is the enrolmentID argument value.
+			EnrolmentID uuid.UUID
+			// Reminder is the reminder argument value.
+			Reminder *domain.OutboxEmail
+		}
 	}
-	lockDisenrolInCourse           sync.RWMutex
-	lockEnrolInCourse              sync.RWMutex
-	lockGetUsersAndAssignedCourses sync.RWMutex
-	lockIsEnrolled                 sync.RWMutex
+	lockBulkUpdateEnrolments        sync.RWMutex
+	lockDisenrolInCourse            sync.RWMutex
+	lockEnrolInCourse               sync.RWMutex
+	lockGetNotStartedEnrolments     sync.RWMutex
+	lockGetOverdueEnrolments        sync.RWMutex
+	lockGetUnnotifiedEnrolments     sync.RWMutex
+	lockGetUsersAndAssignedCourses  sync.RWMutex
+	lockIsEnrolled                  sync.RWMutex
+	lockListUsersAndAssignedCourses sync.RWMutex
+	lockSetEnrolmentDueDate         sync.RWMutex
+	lockSetEnrolmentNotified        sync.RWMutex
+	lockSetEnrolmentOverdue         sync.RWMutex
+	lockSetEnrolmentReminded        sync.RWMutex
+}
+
+// BulkUpdateEnrolments calls BulkUpdateEnrolmentsFunc.
+func (mock *EnrolmentRepositoryMock) BulkUpdateEnrolments(ctx context.Context, params domain.BulkUpdateEnrolmentsParams) ([]domain.BulkEnrolmentResult, error) {
+	if mock.BulkUpdateEnrolmentsFunc == nil {
+		panic("EnrolmentRepositoryMock.BulkUpdateEnrolmentsFunc: method is nil but EnrolmentRepository.BulkUpdateEnrolments was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.BulkUpdateEnrolmentsParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockBulkUpdateEnrolments.Lock()
+	mock.calls.BulkUpdateEnrolments = append(mock.calls.BulkUpdateEnrolments, callInfo)
+	mock.lockBulkUpdateEnrolments.Unlock()
+	return mock.BulkUpdateEnrolmentsFunc(ctx, params)
+}
+
+// BulkUpdateEnrolmentsCalls gets all the calls that were made to BulkUpdateEnrolments.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.BulkUpdateEnrolmentsCalls())
+func (mock *EnrolmentRepositoryMock) BulkUpdateEnrolmentsCalls() []struct {
+	Ctx    context.Context
+	Params domain.BulkUpdateEnrolmentsParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.BulkUpdateEnrolmentsParams
+	}
+	mock.lockBulkUpdateEnrolments.RLock()
+	calls = mock.calls.BulkUpdateEnrolments
+	mock.lockBulkUpdateEnrolments.RUnlock()
+	return calls
 }
 
 // DisenrolInCourse calls DisenrolInCourseFunc.
@@ -157,6 +322,106 @@ func (mock *EnrolmentRepositoryMock) EnrolInCourseCalls() []struct {
 	return calls
 }
 
+// GetNotStartedEnrolments calls GetNotStartedEnrolmentsFunc.
+func (mock *EnrolmentRepositoryMock) GetNotStartedEnrolments(ctx context.Context, enrolledDays int) ([]domain.EnrolmentNotice, error) {
+	if mock.GetNotStartedEnrolmentsFunc == nil {
+		panic("EnrolmentRepositoryMock.GetNotStartedEnrolmentsFunc: method is nil but EnrolmentRepository.GetNotStartedEnrolments was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		EnrolledDays int
+	}{
+		Ctx:          ctx,
+		EnrolledDays: enrolledDays,
+	}
+	mock.lockGetNotStartedEnrolments.Lock()
+	mock.calls.GetNotStartedEnrolments = append(mock.calls.GetNotStartedEnrolments, callInfo)
+	mock.lockGetNotStartedEnrolments.Unlock()
+	return mock.GetNotStartedEnrolmentsFunc(ctx, enrolledDays)
+}
+
+// GetNotStartedEnrolmentsCalls gets all the calls that were made to GetNotStartedEnrolments.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.GetNotStartedEnrolmentsCalls())
+func (mock *EnrolmentRepositoryMock) GetNotStartedEnrolmentsCalls() []struct {
+	Ctx          context.Context
+	EnrolledDays int
+} {
+	var calls []struct {
+		Ctx          context.Context
+		EnrolledDays int
+	}
+	mock.lockGetNotStartedEnrolments.RLock()
+	calls = mock.calls.GetNotStartedEnrolments
+	mock.lockGetNotStartedEnrolments.RUnlock()
+	return calls
+}
+
+// GetOverdueEnrolments calls GetOverdueEnrolmentsFunc.
+func (mock *EnrolmentRepositoryMock) GetOverdueEnrolments(contextMoqParam context.Context) ([]domain.OverdueEnrolment, error) {
+	if mock.GetOverdueEnrolmentsFunc == nil {
+		panic("EnrolmentRepositoryMock.GetOverdueEnrolmentsFunc: method is nil but EnrolmentRepository.GetOverdueEnrolments was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
+	mock.lockGetOverdueEnrolments.Lock()
+	mock.calls.GetOverdueEnrolments = append(mock.calls.GetOverdueEnrolments, callInfo)
+	mock.lockGetOverdueEnrolments.Unlock()
+	return mock.GetOverdueEnrolmentsFunc(contextMoqParam)
+}
+
+// GetOverdueEnrolmentsCalls gets all the calls that were made to GetOverdueEnrolments.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.GetOverdueEnrolmentsCalls())
+func (mock *EnrolmentRepositoryMock) GetOverdueEnrolmentsCalls() []struct {
+	ContextMoqParam context.Context
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+	}
+	mock.lockGetOverdueEnrolments.RLock()
+	calls = mock.calls.GetOverdueEnrolments
+	mock.lockGetOverdueEnrolments.RUnlock()
+	return calls
+}
+
+// GetUnnotifiedEnrolments calls GetUnnotifiedEnrolmentsFunc.
+func (mock *EnrolmentRepositoryMock) GetUnnotifiedEnrolments(contextMoqParam context.Context) ([]domain.EnrolmentNotice, error) {
+	if mock.GetUnnotifiedEnrolmentsFunc == nil {
+		panic("EnrolmentRepositoryMock.GetUnnotifiedEnrolmentsFunc: method is nil but EnrolmentRepository.GetUnnotifiedEnrolments was just called")
+	}
+	callInfo := struct {
+		ContextMoqParam context.Context
+	}{
+		ContextMoqParam: contextMoqParam,
+	}
+	mock.lockGetUnnotifiedEnrolments.Lock()
+	mock.calls.GetUnnotifiedEnrolments = append(mock.calls.GetUnnotifiedEnrolments, callInfo)
+	mock.lockGetUnnotifiedEnrolments.Unlock()
+	return mock.GetUnnotifiedEnrolmentsFunc(contextMoqParam)
+}
+
+// GetUnnotifiedEnrolmentsCalls gets all the calls that were made to GetUnnotifiedEnrolments.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.GetUnnotifiedEnrolmentsCalls())
+func (mock *EnrolmentRepositoryMock) GetUnnotifiedEnrolmentsCalls() []struct {
+	ContextMoqParam context.Context
+} {
+	var calls []struct {
+		ContextMoqParam context.Context
+	}
+	mock.lockGetUnnotifiedEnrolments.RLock()
+	calls = mock.calls.GetUnnotifiedEnrolments
+	mock.lockGetUnnotifiedEnrolments.RUnlock()
+	return calls
+}
+
 // GetUsersAndAssignedCourses calls GetUsersAndAssignedCoursesFunc.
 func (mock *EnrolmentRepositoryMock) GetUsersAndAssignedCourses(contextMoqParam context.Context) ([]domain.UserWithAssignedCourses, error) {
 	if mock.GetUsersAndAssignedCoursesFunc == nil {
@@ -224,3 +489,195 @@ func (mock *EnrolmentRepositoryMock) IsEnrolledCalls() []struct {
 	mock.lockIsEnrolled.RUnlock()
 	return calls
 }
+
+// ListUsersAndAssignedCourses calls ListUsersAndAssignedCoursesFunc.
+func (mock *EnrolmentRepositoryMock) ListUsersAndAssignedCourses(ctx context.Context, params domain.PageParams) (*domain.Page[domain.UserWithAssignedCourses], error) {
+	if mock.ListUsersAndAssignedCoursesFunc == nil {
+		panic("EnrolmentRepositoryMock.ListUsersAndAssignedCoursesFunc: method is nil but EnrolmentRepository.ListUsersAndAssignedCourses was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockListUsersAndAssignedCourses.Lock()
+	mock.calls.ListUsersAndAssignedCourses = append(mock.calls.ListUsersAndAssignedCourses, callInfo)
+	mock.lockListUsersAndAssignedCourses.Unlock()
+	return mock.ListUsersAndAssignedCoursesFunc(ctx, params)
+}
+
+// ListUsersAndAssignedCoursesCalls gets all the calls that were made to ListUsersAndAssignedCourses.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.ListUsersAndAssignedCoursesCalls())
+func (mock *EnrolmentRepositoryMock) ListUsersAndAssignedCoursesCalls() []struct {
+	Ctx    context.Context
+	Params domain.PageParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}
+	mock.lockListUsersAndAssignedCourses.RLock()
+	calls = mock.calls.ListUsersAndAssignedCourses
+	mock.lockListUsersAndAssignedCourses.RUnlock()
+	return calls
+}
+
+// SetEnrolmentDueDate calls SetEnrolmentDueDateFunc.
+func (mock *EnrolmentRepositoryMock) SetEnrolmentDueDate(ctx context.Context, params domain.SetEnrolmentDueDateParams) (*domain.EnrolmentDueDateChange, error) {
+	if mock.SetEnrolmentDueDateFunc == nil {
+		panic("EnrolmentRepositoryMock.SetEnrolmentDueDateFunc: method is nil but EnrolmentRepository.SetEnrolmentDueDate was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.SetEnrolmentDueDateParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockSetEnrolmentDueDate.Lock()
+	mock.calls.SetEnrolmentDueDate = append(mock.calls.SetEnrolmentDueDate, callInfo)
+	mock.lockSetEnrolmentDueDate.Unlock()
+	return mock.SetEnrolmentDueDateFunc(ctx, params)
+}
+
+// SetEnrolmentDueDateCalls gets all the calls that were made to SetEnrolmentDueDate.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.SetEnrolmentDueDateCalls())
+func (mock *EnrolmentRepositoryMock) SetEnrolmentDueDateCalls() []struct {
+	Ctx    context.Context
+	Params domain.SetEnrolmentDueDateParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.SetEnrolmentDueDateParams
+	}
+	mock.lockSetEnrolmentDueDate.RLock()
+	calls = mock.calls.SetEnrolmentDueDate
+	mock.lockSetEnrolmentDueDate.RUnlock()
+	return calls
+}
+
+// SetEnrolmentNotified calls SetEnrolmentNotifiedFunc.
+func (mock *EnrolmentRepositoryMock) SetEnrolmentNotified(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+	if mock.SetEnrolmentNotifiedFunc == nil {
+		panic("EnrolmentRepositoryMock.SetEnrolmentNotifiedFunc: method is nil but EnrolmentRepository.SetEnrolmentNotified was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		EnrolmentID  uuid.UUID
+		Notification *domain.OutboxEmail
+	}{
+		Ctx:          ctx,
+		EnrolmentID:  enrolmentID,
+		Notification: notification,
+	}
+	mock.lockSetEnrolmentNotified.Lock()
+	mock.calls.SetEnrolmentNotified = append(mock.calls.SetEnrolmentNotified, callInfo)
+	mock.lockSetEnrolmentNotified.Unlock()
+	return mock.SetEnrolmentNotifiedFunc(ctx, enrolmentID, notification)
+}
+
+// SetEnrolmentNotifiedCalls gets all the calls that were made to SetEnrolmentNotified.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.SetEnrolmentNotifiedCalls())
+func (mock *EnrolmentRepositoryMock) SetEnrolmentNotifiedCalls() []struct {
+	Ctx          context.Context
+	EnrolmentID  uuid.UUID
+	Notification *domain.OutboxEmail
+} {
+	var calls []struct {
+		Ctx          context.Context
+		EnrolmentID  uuid.UUID
+		Notification *domain.OutboxEmail
+	}
+	mock.lockSetEnrolmentNotified.RLock()
+	calls = mock.calls.SetEnrolmentNotified
+	mock.lockSetEnrolmentNotified.RUnlock()
+	return calls
+}
+
+// SetEnrolmentOverdue calls SetEnrolmentOverdueFunc.
+func (mock *EnrolmentRepositoryMock) SetEnrolmentOverdue(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+	if mock.SetEnrolmentOverdueFunc == nil {
+		panic("EnrolmentRepositoryMock.SetEnrolmentOverdueFunc: method is nil but EnrolmentRepository.SetEnrolmentOverdue was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		EnrolmentID  uuid.UUID
+		Notification *domain.OutboxEmail
+	}{
+		Ctx:          ctx,
+		EnrolmentID:  enrolmentID,
+		Notification: notification,
+	}
+	mock.lockSetEnrolmentOverdue.Lock()
+	mock.calls.SetEnrolmentOverdue = append(mock.calls.SetEnrolmentOverdue, callInfo)
+	mock.lockSetEnrolmentOverdue.Unlock()
+	return mock.SetEnrolmentOverdueFunc(ctx, enrolmentID, notification)
+}
+
+// SetEnrolmentOverdueCalls gets all the calls that were made to SetEnrolmentOverdue.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.SetEnrolmentOverdueCalls())
+func (mock *EnrolmentRepositoryMock) SetEnrolmentOverdueCalls() []struct {
+	Ctx          context.Context
+	EnrolmentID  uuid.UUID
+	Notification *domain.OutboxEmail
+} {
+	var calls []struct {
+		Ctx          context.Context
+		EnrolmentID  uuid.UUID
+		Notification *domain.OutboxEmail
+	}
+	mock.lockSetEnrolmentOverdue.RLock()
+	calls = mock.calls.SetEnrolmentOverdue
+	mock.lockSetEnrolmentOverdue.RUnlock()
+	return calls
+}
+
+// SetEnrolmentReminded calls SetEnrolmentRemindedFunc.
+func (mock *EnrolmentRepositoryMock) SetEnrolmentReminded(ctx context.Context, enrolmentID uuid.UUID, reminder *domain.OutboxEmail) error {
+	if mock.SetEnrolmentRemindedFunc == nil {
+		panic("EnrolmentRepositoryMock.SetEnrolmentRemindedFunc: method is nil but EnrolmentRepository.SetEnrolmentReminded was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		EnrolmentID uuid.UUID
+		Reminder    *domain.OutboxEmail
+	}{
+		Ctx:         ctx,
+		EnrolmentID: enrolmentID,
+		Reminder:    reminder,
+	}
+	mock.lockSetEnrolmentReminded.Lock()
+	mock.calls.SetEnrolmentReminded = append(mock.calls.SetEnrolmentReminded, callInfo)
+	mock.lockSetEnrolmentReminded.Unlock()
+	return mock.SetEnrolmentRemindedFunc(ctx, enrolmentID, reminder)
+}
+
+// SetEnrolmentRemindedCalls gets all the calls that were made to SetEnrolmentReminded.
+// Check the length with:
+//
+//	len(mockedEnrolmentRepository.SetEnrolmentRemindedCalls())
+func (mock *EnrolmentRepositoryMock) SetEnrolmentRemindedCalls() []struct {
+	Ctx         context.Context
+	EnrolmentID uuid.UUID
+	Reminder    *domain.OutboxEmail
+} {
+	var calls []struct {
+		Ctx         context.Context
+		EnrolmentID uuid.UUID
+		Reminder    *domain.OutboxEmail
+	}
+	mock.lockSetEnrolmentReminded.RLock()
+	calls = mock.calls.SetEnrolmentReminded
+	mock.lockSetEnrolmentReminded.RUnlock()
+	return calls
+}
@@ -25,6 +25,9 @@ var _ handlers.ObjectStorage = &ObjectStorageMock{}
 //			GetCDNURLFunc: func(ctx context.Context, key string) (string, error) {
 //				panic("mock out the GetCDNURL method")
 //			},
+//			UploadFunc: func(ctx context.Context, key string, body []byte, contentType string) error {
+//				panic("mock out the Upload method")
+//			},
 //		}
 //
 //		// use mockedObjectStorage in code that requires handlers.ObjectStorage
@@ -38,6 +41,9 @@ type ObjectStorageMock struct {
 	// GetCDNURLFunc mocks the GetCDNURL method.
 	GetCDNURLFunc func(ctx context.Context, key string) (string, error)
 
+	// UploadFunc mocks the Upload method.
+	UploadFunc func(ctx context.Context, key string, body []byte, contentType string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GenerateUploadURL holds details about calls to the GenerateUploadURL method.
@@ -56,9 +62,21 @@ type ObjectStorageMock struct {
 			// Key is the key argument value.
 			Key string
 		}
+		// Upload holds details about calls to the Upload method.
+		Upload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Body is the body argument value.
+			Body []byte
+			// ContentType is the contentType argument value.
+			ContentType string
+		}
 	}
 	lockGenerateUploadURL sync.RWMutex
 	lockGetCDNURL         sync.RWMutex
+	lockUpload            sync.RWMutex
 }
 
 // GenerateUploadURL calls GenerateUploadURLFunc.
@@ -136,3 +154,47 @@ func (mock *ObjectStorageMock) GetCDNURLCalls() []struct {
 	mock.lockGetCDNURL.RUnlock()
 	return calls
 }
+
+// Upload calls UploadFunc.
+func (mock *ObjectStorageMock) Upload(ctx context.Context, key string, body []byte, contentType string) error {
+	if mock.UploadFunc == nil {
+		panic("ObjectStorageMock.UploadFunc: method is nil but ObjectStorage.Upload was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Key         string
+		Body        []byte
+		ContentType string
+	}{
+		Ctx:         ctx,
+		Key:         key,
+		Body:        body,
+		ContentType: contentType,
+	}
+	mock.lockUpload.Lock()
+	mock.calls.Upload = append(mock.calls.Upload, callInfo)
+	mock.lockUpload.Unlock()
+	return mock.UploadFunc(ctx, key, body, contentType)
+}
+
+// UploadCalls gets all the calls that were made to Upload.
+// Check the length with:
+//
+//	len(mockedObjectStorage.UploadCalls())
+func (mock *ObjectStorageMock) UploadCalls() []struct {
+	Ctx         context.Context
+	Key         string
+	Body        []byte
+	ContentType string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Key         string
+		Body        []byte
+		ContentType string
+	}
+	mock.lockUpload.RLock()
+	calls = mock.calls.Upload
+	mock.lockUpload.RUnlock()
+	return calls
+}
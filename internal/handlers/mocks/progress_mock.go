@@ -25,10 +25,10 @@ var _ domain.ProgressRepository = &ProgressRepositoryMock{}
 //			GetProgressFunc: func(contextMoqParam context.Context, getProgressParams domain.GetProgressParams) (*domain.Progress, error) {
 //				panic("mock out the GetProgress method")
 //			},
-//			HasCompletedCourseFunc: func(contextMoqParam context.Context, hasCompletedCourseParams domain.HasCompletedCourseParams) (bool, error) {
-//				panic("mock out the HasCompletedCourse method")
+//			ListProgressFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+//				panic("mock out the ListProgress method")
 //			},
-//			ResetProgressFunc: func(contextMoqParam context.Context, resetProgressParams domain.ResetProgressParams) error {
+//			ResetProgressFunc: func(contextMoqParam context.Context, resetProgressParams domain.ResetProgressParams) (*domain.ProgressReset, error) {
 //				panic("mock out the ResetProgress method")
 //			},
 //			SetCourseCompletedFunc: func(contextMoqParam context.Context, setCourseCompletedParams domain.SetCourseCompletedParams) error {
@@ -53,11 +53,11 @@ type ProgressRepositoryMock struct {
 	// GetProgressFunc mocks the GetProgress method.
 	GetProgressFunc func(contextMoqParam context.Context, getProgressParams domain.GetProgressParams) (*domain.Progress, error)
 
-	// HasCompletedCourseFunc mocks the HasCompletedCourse method.
-	HasCompletedCourseFunc func(contextMoqParam context.Context, hasCompletedCourseParams domain.HasCompletedCourseParams) (bool, error)
+	// ListProgressFunc mocks the ListProgress method.
+	ListProgressFunc func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error)
 
 	// ResetProgressFunc mocks the ResetProgress method.
-	ResetProgressFunc func(contextMoqParam context.Context, resetProgressParams domain.ResetProgressParams) error
+	ResetProgressFunc func(contextMoqParam context.Context, resetProgressParams domain.ResetProgressParams) (*domain.ProgressReset, error)
 
 	// SetCourseCompletedFunc mocks the SetCourseCompleted method.
 	SetCourseCompletedFunc func(contextMoqParam context.Context, setCourseCompletedParams domain.SetCourseCompletedParams) error
@@ -82,12 +82,12 @@ type ProgressRepositoryMock struct {
 			// GetProgressParams is the getProgressParams argument value.
 			GetProgressParams domain.GetProgressParams
 		}
-		// HasCompletedCourse holds details about calls to the HasCompletedCourse method.
-		HasCompletedCourse []struct {
-			// ContextMoqParam is the contextMoqParam argument value.
-			ContextMoqParam context.Context
-			// HasCompletedCourseParams is the hasCompletedCourseParams argument value.
-			HasCompletedCourseParams domain.HasCompletedCourseParams
+		// ListProgress holds details about calls to the ListProgress method.
+		ListProgress []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.PageParams
 		}
 		// ResetProgress holds details about calls to the ResetProgress method.
 		ResetProgress []struct {
@@ -120,7 +120,7 @@ type ProgressRepositoryMock struct {
 	}
 	lockGetAllProgress     sync.RWMutex
 	lockGetProgress        sync.RWMutex
-	lockHasCompletedCourse sync.RWMutex
+	lockListProgress       sync.RWMutex
 	lockResetProgress      sync.RWMutex
 	lockSetCourseCompleted sync.RWMutex
 	lockSetIntroCompleted  sync.RWMutex
@@ -195,44 +195,44 @@ func (mock *ProgressRepositoryMock) GetProgressCalls() []struct {
 	return calls
 }
 
-// HasCompletedCourse calls HasCompletedCourseFunc.
-func (mock *ProgressRepositoryMock) HasCompletedCourse(contextMoqParam context.Context, hasCompletedCourseParams domain.HasCompletedCourseParams) (bool, error) {
-	if mock.HasCompletedCourseFunc == nil {
-		panic("ProgressRepositoryMock.HasCompletedCourseFunc: method is nil but ProgressRepository.HasCompletedCourse was just called")
+// ListProgress calls ListProgressFunc.
+func (mock *ProgressRepositoryMock) ListProgress(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+	if mock.ListProgressFunc == nil {
+		panic("ProgressRepositoryMock.ListProgressFunc: method is nil but ProgressRepository.ListProgress was just called")
 	}
 	callInfo := struct {
-		ContextMoqParam          context.Context
-		HasCompletedCourseParams domain.HasCompletedCourseParams
+		Ctx    context.Context
+		Params domain.PageParams
 	}{
-		ContextMoqParam:          contextMoqParam,
-		HasCompletedCourseParams: hasCompletedCourseParams,
+		Ctx:    ctx,
+		Params: params,
 	}
-	mock.lockHasCompletedCourse.Lock()
-	mock.calls.HasCompletedCourse = append(mock.calls.HasCompletedCourse, callInfo)
-	mock.lockHasCompletedCourse.Unlock()
-	return mock.HasCompletedCourseFunc(contextMoqParam, hasCompletedCourseParams)
+	mock.lockListProgress.Lock()
+	mock.calls.ListProgress = append(mock.calls.ListProgress, callInfo)
+	mock.lockListProgress.Unlock()
+	return mock.ListProgressFunc(ctx, params)
 }
 
-// HasCompletedCourseCalls gets all the calls that were made to HasCompletedCourse.
+// ListProgressCalls gets all the calls that were made to ListProgress.
 // Check the length with:
 //
-//	len(mockedProgressRepository.HasCompletedCourseCalls())
-func (mock *ProgressRepositoryMock) HasCompletedCourseCalls() []struct {
-	ContextMoqParam          context.Context
-	HasCompletedCourseParams domain.HasCompletedCourseParams
+//	len(mockedProgressRepository.ListProgressCalls())
+func (mock *ProgressRepositoryMock) ListProgressCalls() []struct {
+	Ctx    context.Context
+	Params domain.PageParams
 } {
 	var calls []struct {
-		ContextMoqParam          context.Context
-		HasCompletedCourseParams domain.HasCompletedCourseParams
+		Ctx    context.Context
+		Params domain.PageParams
 	}
-	mock.lockHasCompletedCourse.RLock()
-	calls = mock.calls.HasCompletedCourse
-	mock.lockHasCompletedCourse.RUnlock()
+	mock.lockListProgress.RLock()
+	calls = mock.calls.ListProgress
+	mock.lockListProgress.RUnlock()
 	return calls
 }
 
 // ResetProgress calls ResetProgressFunc.
-func (mock *ProgressRepositoryMock) ResetProgress(contextMoqParam context.Context, resetProgressParams domain.ResetProgressParams) error {
+func (mock *ProgressRepositoryMock) ResetProgress(contextMoqParam context.Context, resetProgressParams domain.ResetProgressParams) (*domain.ProgressReset, error) {
 	if mock.ResetProgressFunc == nil {
 		panic("ProgressRepositoryMock.ResetProgressFunc: method is nil but ProgressRepository.ResetProgress was just called")
 	}
@@ -32,7 +32,10 @@ var _ domain.QuizRepository = &QuizRepositoryMock{}
 //			GetQuizStateFunc: func(ctx context.Context, userID string, quizID uuid.UUID) (*domain.QuizState, error) {
 //				panic("mock out the GetQuizState method")
 //			},
-//			ResetQuizProgressFunc: func(ctx context.Context, userID string, quizID uuid.UUID) error {
+//			ListQuizSectionsFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.QuizSection], error) {
+//				panic("mock out the ListQuizSections method")
+//			},
+//			ResetQuizProgressFunc: func(ctx context.Context, params domain.ResetQuizProgressParams) (*domain.ProgressReset, error) {
 //				panic("mock out the ResetQuizProgress method")
 //			},
 //			SaveQuizAttemptFunc: func(contextMoqParam context.Context, saveQuizAttemptParams domain.SaveQuizAttemptParams) error {
@@ -63,8 +66,11 @@ type QuizRepositoryMock struct {
 	// GetQuizStateFunc mocks the GetQuizState method.
 	GetQuizStateFunc func(ctx context.Context, userID string, quizID uuid.UUID) (*domain.QuizState, error)
 
+	// ListQuizSectionsFunc mocks the ListQuizSections method.
+	ListQuizSectionsFunc func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.QuizSection], error)
+
 	// ResetQuizProgressFunc mocks the ResetQuizProgress method.
-	ResetQuizProgressFunc func(ctx context.Context, userID string, quizID uuid.UUID) error
+	ResetQuizProgressFunc func(ctx context.Context, params domain.ResetQuizProgressParams) (*domain.ProgressReset, error)
 
 	// SaveQuizAttemptFunc mocks the SaveQuizAttempt method.
 	SaveQuizAttemptFunc func(contextMoqParam context.Context, saveQuizAttemptParams domain.SaveQuizAttemptParams) error
@@ -105,14 +111,19 @@ type QuizRepositoryMock struct {
 			// QuizID is the quizID argument value.
 			QuizID uuid.UUID
 		}
+		// ListQuizSections holds details about calls to the ListQuizSections method.
+		ListQuizSections []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.PageParams
+		}
 		// ResetQuizProgress holds details about calls to the ResetQuizProgress method.
 		ResetQuizProgress []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// UserID is the userID argument value.
-			UserID string
-			// QuizID is the quizID argument value.
-			QuizID uuid.UUID
+			// Params is the params argument value.
+			Params domain.ResetQuizProgressParams
 		}
 		// SaveQuizAttempt holds details about calls to the SaveQuizAttempt method.
 		SaveQuizAttempt []struct {
@@ -140,6 +151,7 @@ type QuizRepositoryMock struct {
 	lockGetQuizAttemptsByUserID sync.RWMutex
 	lockGetQuizQuestions        sync.RWMutex
 	lockGetQuizState            sync.RWMutex
+	lockListQuizSections        sync.RWMutex
 	lockResetQuizProgress       sync.RWMutex
 	lockSaveQuizAttempt         sync.RWMutex
 	lockSetQuizState            sync.RWMutex
@@ -290,24 +302,58 @@ func (mock *QuizRepositoryMock) GetQuizStateCalls() []struct {
 	return calls
 }
 
+// ListQuizSections calls ListQuizSectionsFunc.
+func (mock *QuizRepositoryMock) ListQuizSections(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.QuizSection], error) {
+	if mock.ListQuizSectionsFunc == nil {
+		panic("QuizRepositoryMock.ListQuizSectionsFunc: method is nil but QuizRepository.ListQuizSections was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockListQuizSections.Lock()
+	mock.calls.ListQuizSections = append(mock.calls.ListQuizSections, callInfo)
+	mock.lockListQuizSections.Unlock()
+	return mock.ListQuizSectionsFunc(ctx, params)
+}
+
+// ListQuizSectionsCalls gets all the calls that were made to ListQuizSections.
+// Check the length with:
+//
+//	len(mockedQuizRepository.ListQuizSectionsCalls())
+func (mock *QuizRepositoryMock) ListQuizSectionsCalls() []struct {
+	Ctx    context.Context
+	Params domain.PageParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}
+	mock.lockListQuizSections.RLock()
+	calls = mock.calls.ListQuizSections
+	mock.lockListQuizSections.RUnlock()
+	return calls
+}
+
 // ResetQuizProgress calls ResetQuizProgressFunc.
-func (mock *QuizRepositoryMock) ResetQuizProgress(ctx context.Context, userID string, quizID uuid.UUID) error {
+func (mock *QuizRepositoryMock) ResetQuizProgress(ctx context.Context, params domain.ResetQuizProgressParams) (*domain.ProgressReset, error) {
 	if mock.ResetQuizProgressFunc == nil {
 		panic("QuizRepositoryMock.ResetQuizProgressFunc: method is nil but QuizRepository.ResetQuizProgress was just called")
 	}
 	callInfo := struct {
 		Ctx    context.Context
-		UserID string
-		QuizID uuid.UUID
+		Params domain.ResetQuizProgressParams
 	}{
 		Ctx:    ctx,
-		UserID: userID,
-		QuizID: quizID,
+		Params: params,
 	}
 	mock.lockResetQuizProgress.Lock()
 	mock.calls.ResetQuizProgress = append(mock.calls.ResetQuizProgress, callInfo)
 	mock.lockResetQuizProgress.Unlock()
-	return mock.ResetQuizProgressFunc(ctx, userID, quizID)
+	return mock.ResetQuizProgressFunc(ctx, params)
 }
 
 // ResetQuizProgressCalls gets all the calls that were made to ResetQuizProgress.
@@ -316,13 +362,11 @@ func (mock *QuizRepositoryMock) ResetQuizProgress(ctx context.Context, userID st
 //	len(mockedQuizRepository.ResetQuizProgressCalls())
 func (mock *QuizRepositoryMock) ResetQuizProgressCalls() []struct {
 	Ctx    context.Context
-	UserID string
-	QuizID uuid.UUID
+	Params domain.ResetQuizProgressParams
 } {
 	var calls []struct {
 		Ctx    context.Context
-		UserID string
-		QuizID uuid.UUID
+		Params domain.ResetQuizProgressParams
 	}
 	mock.lockResetQuizProgress.RLock()
 	calls = mock.calls.ResetQuizProgress
@@ -19,9 +19,30 @@ var _ domain.UserRepository = &UserRepositoryMock{}
 //
 //		// make and configure a mocked domain.UserRepository
 //		mockedUserRepository := &UserRepositoryMock{
+//			DeleteUserFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteUser method")
+//			},
+//			EraseUserFunc: func(ctx context.Context, id string) (*domain.ErasedUser, error) {
+//				panic("mock out the EraseUser method")
+//			},
+//			ExportUserDataFunc: func(ctx context.Context, id string) (*domain.UserDataExport, error) {
+//				panic("mock out the ExportUserData method")
+//			},
 //			GetUserFunc: func(contextMoqParam context.Context, s string) (*domain.User, error) {
 //				panic("mock out the GetUser method")
 //			},
+//			GetUserByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+//				panic("mock out the GetUserByEmail method")
+//			},
+//			ListUsersFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[domain.User], error) {
+//				panic("mock out the ListUsers method")
+//			},
+//			SetUserDeactivatedFunc: func(ctx context.Context, params domain.SetUserDeactivatedParams) error {
+//				panic("mock out the SetUserDeactivated method")
+//			},
+//			UpdateUserFunc: func(ctx context.Context, params domain.UpdateUserParams) (*domain.User, error) {
+//				panic("mock out the UpdateUser method")
+//			},
 //		}
 //
 //		// use mockedUserRepository in code that requires domain.UserRepository
@@ -29,11 +50,53 @@ var _ domain.UserRepository = &UserRepositoryMock{}
 //
 //	}
 type UserRepositoryMock struct {
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, id string) error
+
+	// EraseUserFunc mocks the EraseUser method.
+	EraseUserFunc func(ctx context.Context, id string) (*domain.ErasedUser, error)
+
+	// ExportUserDataFunc mocks the ExportUserData method.
+	ExportUserDataFunc func(ctx context.Context, id string) (*domain.UserDataExport, error)
+
 	// GetUserFunc mocks the GetUser method.
 	GetUserFunc func(contextMoqParam context.Context, s string) (*domain.User, error)
 
+	// GetUserByEmailFunc mocks the GetUserByEmail method.
+	GetUserByEmailFunc func(ctx context.Context, email string) (*domain.User, error)
+
+	// ListUsersFunc mocks the ListUsers method.
+	ListUsersFunc func(ctx context.Context, params domain.PageParams) (*domain.Page[domain.User], error)
+
+	// SetUserDeactivatedFunc mocks the SetUserDeactivated method.
+	SetUserDeactivatedFunc func(ctx context.Context, params domain.SetUserDeactivatedParams) error
+
+	// UpdateUserFunc mocks the UpdateUser method.
+	UpdateUserFunc func(ctx context.Context, params domain.UpdateUserParams) (*domain.User, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// DeleteUser holds details about calls to the DeleteUser method.
+		DeleteUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// EraseUser holds details about calls to the EraseUser method.
+		EraseUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+		}
+		// ExportUserData holds details about calls to the ExportUserData method.
+		ExportUserData []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Id is the id argument value.
+			Id string
+		}
 		// GetUser holds details about calls to the GetUser method.
 		GetUser []struct {
 			// ContextMoqParam is the contextMoqParam argument value.
@@ -41,8 +104,151 @@ type UserRepositoryMock struct {
 			// S is the s argument value.
 			S string
 		}
+		// GetUserByEmail holds details about calls to the GetUserByEmail method.
+		GetUserByEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
+		// ListUsers holds details about calls to the ListUsers method.
+		ListUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.PageParams
+		}
+		// SetUserDeactivated holds details about calls to the SetUserDeactivated method.
+		SetUserDeactivated []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.SetUserDeactivatedParams
+		}
+		// UpdateUser holds details about calls to the UpdateUser method.
+		UpdateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Params is the params argument value.
+			Params domain.UpdateUserParams
+		}
+	}
+	lockDeleteUser         sync.RWMutex
+	lockEraseUser          sync.RWMutex
+	lockExportUserData     sync.RWMutex
+	lockGetUser            sync.RWMutex
+	lockGetUserByEmail     sync.RWMutex
+	lockListUsers          sync.RWMutex
+	lockSetUserDeactivated sync.RWMutex
+	lockUpdateUser         sync.RWMutex
+}
+
+// DeleteUser calls DeleteUserFunc.
+func (mock *UserRepositoryMock) DeleteUser(ctx context.Context, id string) error {
+	if mock.DeleteUserFunc == nil {
+		panic("UserRepositoryMock.DeleteUserFunc: method is nil but UserRepository.DeleteUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockDeleteUser.Lock()
+	mock.calls.DeleteUser = append(mock.calls.DeleteUser, callInfo)
+	mock.lockDeleteUser.Unlock()
+	return mock.DeleteUserFunc(ctx, id)
+}
+
+// DeleteUserCalls gets all the calls that were made to DeleteUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.DeleteUserCalls())
+func (mock *UserRepositoryMock) DeleteUserCalls() []struct {
+	Ctx context.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  string
+	}
+	mock.lockDeleteUser.RLock()
+	calls = mock.calls.DeleteUser
+	mock.lockDeleteUser.RUnlock()
+	return calls
+}
+
+// EraseUser calls EraseUserFunc.
+func (mock *UserRepositoryMock) EraseUser(ctx context.Context, id string) (*domain.ErasedUser, error) {
+	if mock.EraseUserFunc == nil {
+		panic("UserRepositoryMock.EraseUserFunc: method is nil but UserRepository.EraseUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockEraseUser.Lock()
+	mock.calls.EraseUser = append(mock.calls.EraseUser, callInfo)
+	mock.lockEraseUser.Unlock()
+	return mock.EraseUserFunc(ctx, id)
+}
+
+// EraseUserCalls gets all the calls that were made to EraseUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.EraseUserCalls())
+func (mock *UserRepositoryMock) EraseUserCalls() []struct {
+	Ctx context.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  string
 	}
-	lockGetUser sync.RWMutex
+	mock.lockEraseUser.RLock()
+	calls = mock.calls.EraseUser
+	mock.lockEraseUser.RUnlock()
+	return calls
+}
+
+// ExportUserData calls ExportUserDataFunc.
+func (mock *UserRepositoryMock) ExportUserData(ctx context.Context, id string) (*domain.UserDataExport, error) {
+	if mock.ExportUserDataFunc == nil {
+		panic("UserRepositoryMock.ExportUserDataFunc: method is nil but UserRepository.ExportUserData was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Id  string
+	}{
+		Ctx: ctx,
+		Id:  id,
+	}
+	mock.lockExportUserData.Lock()
+	mock.calls.ExportUserData = append(mock.calls.ExportUserData, callInfo)
+	mock.lockExportUserData.Unlock()
+	return mock.ExportUserDataFunc(ctx, id)
+}
+
+// ExportUserDataCalls gets all the calls that were made to ExportUserData.
+// Check the length with:
+//
+//	len(mockedUserRepository.ExportUserDataCalls())
+func (mock *UserRepositoryMock) ExportUserDataCalls() []struct {
+	Ctx context.Context
+	Id  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Id  string
+	}
+	mock.lockExportUserData.RLock()
+	calls = mock.calls.ExportUserData
+	mock.lockExportUserData.RUnlock()
+	return calls
 }
 
 // GetUser calls GetUserFunc.
@@ -80,3 +286,147 @@ func (mock *UserRepositoryMock) GetUserCalls() []struct {
 	mock.lockGetUser.RUnlock()
 	return calls
 }
+
+// GetUserByEmail calls GetUserByEmailFunc.
+func (mock *UserRepositoryMock) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if mock.GetUserByEmailFunc == nil {
+		panic("UserRepositoryMock.GetUserByEmailFunc: method is nil but UserRepository.GetUserByEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockGetUserByEmail.Lock()
+	mock.calls.GetUserByEmail = append(mock.calls.GetUserByEmail, callInfo)
+	mock.lockGetUserByEmail.Unlock()
+	return mock.GetUserByEmailFunc(ctx, email)
+}
+
+// GetUserByEmailCalls gets all the calls that were made to GetUserByEmail.
+// Check the length with:
+//
+//	len(mockedUserRepository.GetUserByEmailCalls())
+func (mock *UserRepositoryMock) GetUserByEmailCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockGetUserByEmail.RLock()
+	calls = mock.calls.GetUserByEmail
+	mock.lockGetUserByEmail.RUnlock()
+	return calls
+}
+
+// ListUsers calls ListUsersFunc.
+func (mock *UserRepositoryMock) ListUsers(ctx context.Context, params domain.PageParams) (*domain.Page[domain.User], error) {
+	if mock.ListUsersFunc == nil {
+		panic("UserRepositoryMock.ListUsersFunc: method is nil but UserRepository.ListUsers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockListUsers.Lock()
+	mock.calls.ListUsers = append(mock.calls.ListUsers, callInfo)
+	mock.lockListUsers.Unlock()
+	return mock.ListUsersFunc(ctx, params)
+}
+
+// ListUsersCalls gets all the calls that were made to ListUsers.
+// Check the length with:
+//
+//	len(mockedUserRepository.ListUsersCalls())
+func (mock *UserRepositoryMock) ListUsersCalls() []struct {
+	Ctx    context.Context
+	Params domain.PageParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.PageParams
+	}
+	mock.lockListUsers.RLock()
+	calls = mock.calls.ListUsers
+	mock.lockListUsers.RUnlock()
+	return calls
+}
+
+// SetUserDeactivated calls SetUserDeactivatedFunc.
+func (mock *UserRepositoryMock) SetUserDeactivated(ctx context.Context, params domain.SetUserDeactivatedParams) error {
+	if mock.SetUserDeactivatedFunc == nil {
+		panic("UserRepositoryMock.SetUserDeactivatedFunc: method is nil but UserRepository.SetUserDeactivated was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.SetUserDeactivatedParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockSetUserDeactivated.Lock()
+	mock.calls.SetUserDeactivated = append(mock.calls.SetUserDeactivated, callInfo)
+	mock.lockSetUserDeactivated.Unlock()
+	return mock.SetUserDeactivatedFunc(ctx, params)
+}
+
+// SetUserDeactivatedCalls gets all the calls that were made to SetUserDeactivated.
+// Check the length with:
+//
+//	len(mockedUserRepository.SetUserDeactivatedCalls())
+func (mock *UserRepositoryMock) SetUserDeactivatedCalls() []struct {
+	Ctx    context.Context
+	Params domain.SetUserDeactivatedParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.SetUserDeactivatedParams
+	}
+	mock.lockSetUserDeactivated.RLock()
+	calls = mock.calls.SetUserDeactivated
+	mock.lockSetUserDeactivated.RUnlock()
+	return calls
+}
+
+// UpdateUser calls UpdateUserFunc.
+func (mock *UserRepositoryMock) UpdateUser(ctx context.Context, params domain.UpdateUserParams) (*domain.User, error) {
+	if mock.UpdateUserFunc == nil {
+		panic("UserRepositoryMock.UpdateUserFunc: method is nil but UserRepository.UpdateUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Params domain.UpdateUserParams
+	}{
+		Ctx:    ctx,
+		Params: params,
+	}
+	mock.lockUpdateUser.Lock()
+	mock.calls.UpdateUser = append(mock.calls.UpdateUser, callInfo)
+	mock.lockUpdateUser.Unlock()
+	return mock.UpdateUserFunc(ctx, params)
+}
+
+// UpdateUserCalls gets all the calls that were made to UpdateUser.
+// Check the length with:
+//
+//	len(mockedUserRepository.UpdateUserCalls())
+func (mock *UserRepositoryMock) UpdateUserCalls() []struct {
+	Ctx    context.Context
+	Params domain.UpdateUserParams
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Params domain.UpdateUserParams
+	}
+	mock.lockUpdateUser.RLock()
+	calls = mock.calls.UpdateUser
+	mock.lockUpdateUser.RUnlock()
+	return calls
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+)
+
+// PaginationParams are embedded in the params of every paginated list endpoint.
+// Cursor is the nextCursor returned by the previous page, omitted for the first page.
+type PaginationParams struct {
+	Cursor  string `json:"cursor"`
+	Limit   int    `json:"limit" validate:"omitempty,min=1,max=200"`
+	SortDir string `json:"sortDir" validate:"omitempty,oneof=asc desc"`
+	Search  string `json:"search" validate:"max=200"`
+}
+
+// pageFilters are the optional filters of a list endpoint, as passed in the request
+type pageFilters struct {
+	CourseID string
+	GroupID  string
+	Status   string
+}
+
+func (p *PaginationParams) pageParams(sortBy string, filters pageFilters) (domain.PageParams, error) {
+	params := domain.PageParams{
+		Limit:   p.Limit,
+		SortBy:  sortBy,
+		SortDir: domain.SortDirection(p.SortDir),
+		Search:  p.Search,
+	}
+
+	if p.Cursor != "" {
+		cursor, err := domain.DecodeCursor(p.Cursor)
+		if err != nil {
+			return domain.PageParams{}, httpError(http.StatusBadRequest, errors.InvalidFormat("cursor"), err)
+		}
+		params.Cursor = cursor
+	}
+
+	if filters.CourseID != "" {
+		id, err := uuid.Parse(filters.CourseID)
+		if err != nil {
+			return domain.PageParams{}, httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+		}
+		params.CourseID = &id
+	}
+
+	if filters.GroupID != "" {
+		id, err := uuid.Parse(filters.GroupID)
+		if err != nil {
+			return domain.PageParams{}, httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+		}
+		params.GroupID = &id
+	}
+
+	if filters.Status != "" {
+		deactivated := filters.Status == string(domain.UserStatusDeactivated)
+		params.Deactivated = &deactivated
+	}
+
+	return params, nil
+}
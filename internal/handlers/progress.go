@@ -123,71 +123,102 @@ func (h *Handlers) SetCourseCompleted(e echo.Context) error {
 		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
 	}
 
-	prevCompleted, err := h.Progress.HasCompletedCourse(ctx, domain.HasCompletedCourseParams{
-		UserID:   userID,
-		CourseID: courseID,
-	})
-	if err != nil {
-		return httpError(http.StatusInternalServerError, errors.Updating(progressResource), err)
-	}
+	// Only called for the request that completes the course, so completing it again is a no-op. The
+	// certificate is issued in the completion's transaction, so it's rolled back with it.
+	completionEmails := func(ctx context.Context) ([]*domain.OutboxEmail, error) {
+		user, err := h.User.GetUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The certificate is issued first so the completion emails link to it
+		completedAt := time.Now().In(location)
+		cert := h.issueCompletionCertificate(ctx, userID, courseID, completedAt)
 
-	if prevCompleted {
-		return e.NoContent(http.StatusNoContent)
+		return h.completionEmails(user, params.CourseName, completedAt, cert)
 	}
 
 	err = h.Progress.SetCourseCompleted(ctx, domain.SetCourseCompletedParams{
 		UserID:   userID,
 		CourseID: courseID,
+		Emails:   completionEmails,
 	})
 	if err != nil {
 		return httpError(http.StatusInternalServerError, errors.Updating(progressResource), err)
 	}
 
-	user, err := h.User.GetUser(ctx, userID)
+	return e.NoContent(http.StatusNoContent)
+}
+
+// issueCompletionCertificate issues the learner's certificate for completing the course. The
+// course is still completed if the certificate can't be issued, so it returns nil on failure.
+func (h *Handlers) issueCompletionCertificate(
+	ctx context.Context,
+	userID string,
+	courseID uuid.UUID,
+	completedAt time.Time,
+) *domain.Certificate {
+	cert, err := h.issueCertificate(ctx, userID, courseID, completedAt)
 	if err != nil {
-		return httpError(http.StatusInternalServerError, errors.Updating(progressResource), err)
+		slog.ErrorContext(
+			ctx,
+			"failed to issue certificate",
+			slog.Any("error", err),
+			slog.String("course_id", courseID.String()),
+			slog.String("user_id", userID),
+		)
+		return nil
 	}
 
-	emailParams := &email.CourseCompletionParams{
+	return cert
+}
+
+// completionEmails tell the admin that the learner completed the course and confirm it to the
+// learner, linking to the certificate if one was issued. They link to its verification page rather
+// than a signed download URL, which would expire before the emails are read.
+func (h *Handlers) completionEmails(
+	user *domain.User,
+	courseName string,
+	completedAt time.Time,
+	cert *domain.Certificate,
+) ([]*domain.OutboxEmail, error) {
+	completionTimestamp := completedAt.Format("02/01/2006 15:04:05")
+	adminParams := &email.CourseCompletionParams{
 		UserName:            user.Name,
 		UserEmail:           user.Email,
-		CourseName:          params.CourseName,
-		CompletionTimestamp: time.Now().In(location).Format("02/01/2006 15:04:05"),
+		CourseName:          courseName,
+		CompletionTimestamp: completionTimestamp,
+	}
+	learnerParams := &email.CompletionConfirmationParams{
+		UserID:              user.ID,
+		UserName:            user.Name,
+		UserEmail:           user.Email,
+		CourseName:          courseName,
+		CompletionTimestamp: completionTimestamp,
 	}
 
-	go func() {
-		emailName := h.EmailService.GetEmailNames().CourseCompletion
-		templateName := h.EmailService.GetTemplateNames().CourseCompletion
+	if cert != nil {
+		certificateURL := h.CertificateRenderer.VerificationURL(cert.VerificationID)
+		adminParams.CertificateNumber = cert.Number
+		adminParams.CertificateURL = certificateURL
+		learnerParams.CertificateNumber = cert.Number
+		learnerParams.CertificateURL = certificateURL
+	}
 
-		err = h.EmailService.Send(
-			context.WithoutCancel(ctx),
-			emailParams,
-			templateName,
-			emailName,
-		)
-		if err != nil {
-			slog.ErrorContext(
-				ctx,
-				"failed to send email",
-				slog.Any("error", err),
-				slog.String("email_name", emailName),
-				slog.String("template_name", templateName),
-				slog.String("course_id", params.CourseID),
-				slog.String("user_id", userID),
-			)
-		} else {
-			slog.InfoContext(
-				ctx,
-				"course completion email sent",
-				slog.String("email_name", emailName),
-				slog.String("template_name", templateName),
-				slog.String("course_id", params.CourseID),
-				slog.String("user_id", userID),
-			)
-		}
-	}()
+	emailNames := h.EmailService.GetEmailNames()
+	templateNames := h.EmailService.GetTemplateNames()
 
-	return e.NoContent(http.StatusNoContent)
+	adminEmail, err := email.NewOutboxEmail(adminParams, templateNames.CourseCompletion, emailNames.CourseCompletion)
+	if err != nil {
+		return nil, err
+	}
+
+	learnerEmail, err := email.NewOutboxEmail(learnerParams, templateNames.CompletionConfirmation, emailNames.CompletionConfirmation)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*domain.OutboxEmail{adminEmail, learnerEmail}, nil
 }
 
 type SetIntroCompletedParams struct {
@@ -232,13 +263,16 @@ func (h *Handlers) SetIntroCompleted(e echo.Context) error {
 }
 
 type ResetProgressParams struct {
+	ResetTargetParams
 	CourseID string `json:"courseId" validate:"required"`
 }
 
+// ResetProgress resets the course progress and quiz attempts of learners, archiving what they had
+// done, and lets them know why
 func (h *Handlers) ResetProgress(e echo.Context) error {
 	ctx := e.Request().Context()
 
-	userID, ok := getUserID(ctx)
+	adminID, ok := getUserID(ctx)
 	if !ok {
 		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
 	}
@@ -248,20 +282,42 @@ func (h *Handlers) ResetProgress(e echo.Context) error {
 		return err
 	}
 
+	userIDs, err := params.userIDs()
+	if err != nil {
+		return err
+	}
+
 	courseID, err := uuid.Parse(params.CourseID)
 	if err != nil {
 		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
 	}
 
-	err = h.Progress.ResetProgress(ctx, domain.ResetProgressParams{
-		UserID:   userID,
-		CourseID: courseID,
-	})
+	reset, err := h.resetProgressAudited(ctx, domain.AuditActionResetProgress, params.Reason,
+		func(ctx context.Context) (*domain.ProgressReset, error) {
+			reset, err := h.Progress.ResetProgress(ctx, domain.ResetProgressParams{
+				UserIDs:  userIDs,
+				Cohort:   params.Cohort,
+				CourseID: courseID,
+				Reason:   params.Reason,
+				ResetBy:  adminID,
+				Emails:   h.progressResetEmails(params.Reason),
+			})
+			if err != nil {
+				if errors.IsNotFoundErr(err) {
+					return nil, httpError(http.StatusNotFound, errors.NotFound(progressResetTargetResource), err)
+				}
+
+				return nil, httpError(http.StatusInternalServerError, errors.Updating(progressResource), err)
+			}
+
+			return reset, nil
+		},
+	)
 	if err != nil {
-		return httpError(http.StatusInternalServerError, errors.Updating(progressResource), err)
+		return err
 	}
 
-	return e.NoContent(http.StatusNoContent)
+	return e.JSON(http.StatusOK, reset)
 }
 
 func (h *Handlers) GetAllProgress(e echo.Context) error {
@@ -278,3 +334,35 @@ func (h *Handlers) GetAllProgress(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, progress)
 }
+
+type ListProgressParams struct {
+	PaginationParams
+	SortBy   string `json:"sortBy" validate:"omitempty,oneof=name email"`
+	CourseID string `json:"courseId"`
+	GroupID  string `json:"groupId"`
+}
+
+func (h *Handlers) ListProgress(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListProgressParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	pageParams, err := params.pageParams(params.SortBy, pageFilters{CourseID: params.CourseID, GroupID: params.GroupID})
+	if err != nil {
+		return err
+	}
+
+	if err := h.checkCanViewGroupProgress(ctx, pageParams.GroupID); err != nil {
+		return err
+	}
+
+	progress, err := h.Progress.ListProgress(ctx, pageParams)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(progressResource), err)
+	}
+
+	return e.JSON(http.StatusOK, progress)
+}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	stdErrors "errors"
@@ -8,15 +9,19 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/supanova-rp/supanova-server/internal/config"
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers"
 	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
 	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
 	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/services/certificate"
 	"github.com/supanova-rp/supanova-server/internal/services/email"
+	"github.com/supanova-rp/supanova-server/internal/utils"
 )
 
 func TestGetProgress_HappyPath(t *testing.T) {
@@ -380,20 +385,23 @@ func TestSetIntroCompleted_UnhappyPath(t *testing.T) {
 }
 
 func TestSetCourseCompleted_HappyPath(t *testing.T) {
-	t.Run("already completed course - no update", func(t *testing.T) {
+	t.Run("already completed course - nothing issued", func(t *testing.T) {
 		courseID := testhelpers.Course.ID.String()
 		courseName := testhelpers.Course.Title
 
+		// The store only asks for the emails if the course wasn't already completed
 		mockProgressRepo := &mocks.ProgressRepositoryMock{
-			HasCompletedCourseFunc: func(ctx context.Context, params domain.HasCompletedCourseParams) (bool, error) {
-				return true, nil
+			SetCourseCompletedFunc: func(ctx context.Context, params domain.SetCourseCompletedParams) error {
+				return nil
 			},
 		}
 		mockUserRepo := &mocks.UserRepositoryMock{}
+		mockCertificateRepo := &mocks.CertificateRepositoryMock{}
 
 		h := &handlers.Handlers{
-			Progress: mockProgressRepo,
-			User:     mockUserRepo,
+			Progress:    mockProgressRepo,
+			User:        mockUserRepo,
+			Certificate: mockCertificateRepo,
 		}
 
 		req := &handlers.SetCourseCompletedParams{
@@ -412,21 +420,21 @@ func TestSetCourseCompleted_HappyPath(t *testing.T) {
 			t.Errorf("expected %d, got %d", http.StatusNoContent, rec.Code)
 		}
 
-		testhelpers.AssertRepoCalls(t, len(mockProgressRepo.HasCompletedCourseCalls()), 1, testhelpers.HasCompletedCourseHandlerName)
-		testhelpers.AssertRepoCalls(t, len(mockProgressRepo.SetCourseCompletedCalls()), 0, testhelpers.SetCourseCompletedHandlerName)
+		testhelpers.AssertRepoCalls(t, len(mockProgressRepo.SetCourseCompletedCalls()), 1, testhelpers.SetCourseCompletedHandlerName)
 		testhelpers.AssertRepoCalls(t, len(mockUserRepo.GetUserCalls()), 0, testhelpers.GetUserHandlerName)
+		testhelpers.AssertRepoCalls(t, len(mockCertificateRepo.AddCertificateCalls()), 0, testhelpers.SetCourseCompletedHandlerName)
 	})
 
 	t.Run("sets course completed - first time", func(t *testing.T) {
 		courseID := testhelpers.Course.ID.String()
 		courseName := testhelpers.Course.Title
 
+		var queued []*domain.OutboxEmail
 		mockProgressRepo := &mocks.ProgressRepositoryMock{
-			HasCompletedCourseFunc: func(ctx context.Context, params domain.HasCompletedCourseParams) (bool, error) {
-				return false, nil
-			},
 			SetCourseCompletedFunc: func(ctx context.Context, params domain.SetCourseCompletedParams) error {
-				return nil
+				var err error
+				queued, err = params.Emails(ctx)
+				return err
 			},
 		}
 		mockUserRepo := &mocks.UserRepositoryMock{
@@ -435,21 +443,34 @@ func TestSetCourseCompleted_HappyPath(t *testing.T) {
 			},
 		}
 		mockEmailRepo := &mocks.EmailServiceMock{
-			SendFunc: func(ctx context.Context, params email.EmailParams, templateName, emailName string) error {
-				return nil
-			},
 			GetTemplateNamesFunc: func() *email.TemplateNames {
-				return &email.TemplateNames{CourseCompletion: ""}
+				return &email.TemplateNames{CourseCompletion: "", CompletionConfirmation: ""}
 			},
 			GetEmailNamesFunc: func() *email.EmailNames {
-				return &email.EmailNames{CourseCompletion: ""}
+				return &email.EmailNames{CourseCompletion: "course-completion", CompletionConfirmation: "completion-confirmation"}
 			},
 		}
+		mockCertificateRepo := &mocks.CertificateRepositoryMock{
+			AddCertificateFunc: func(ctx context.Context, params domain.AddCertificateParams) (*domain.Certificate, error) {
+				return &domain.Certificate{
+					ID:             params.ID,
+					Number:         "SN-000001",
+					UserID:         params.UserID,
+					CourseID:       params.CourseID,
+					StorageKey:     params.StorageKey,
+					VerificationID: params.VerificationID,
+				}, nil
+			},
+		}
+		mockObjectStorage := newCertificateStorageMock()
 
 		h := &handlers.Handlers{
-			Progress:     mockProgressRepo,
-			User:         mockUserRepo,
-			EmailService: mockEmailRepo,
+			Progress:            mockProgressRepo,
+			User:                mockUserRepo,
+			EmailService:        mockEmailRepo,
+			Certificate:         mockCertificateRepo,
+			ObjectStorage:       mockObjectStorage,
+			CertificateRenderer: certificate.New("https://example.com/verify"),
 		}
 
 		req := &handlers.SetCourseCompletedParams{
@@ -468,9 +489,48 @@ func TestSetCourseCompleted_HappyPath(t *testing.T) {
 			t.Errorf("expected %d, got %d", http.StatusNoContent, rec.Code)
 		}
 
-		testhelpers.AssertRepoCalls(t, len(mockProgressRepo.HasCompletedCourseCalls()), 1, testhelpers.HasCompletedCourseHandlerName)
 		testhelpers.AssertRepoCalls(t, len(mockProgressRepo.SetCourseCompletedCalls()), 1, testhelpers.SetCourseCompletedHandlerName)
 		testhelpers.AssertRepoCalls(t, len(mockUserRepo.GetUserCalls()), 1, testhelpers.GetUserHandlerName)
+
+		if len(queued) != 2 || queued[0].EmailName != "course-completion" || queued[1].EmailName != "completion-confirmation" {
+			t.Fatalf("expected the completion emails to be queued with the completion, got %+v", queued)
+		}
+
+		var params email.CourseCompletionParams
+		if err := json.Unmarshal(queued[0].TemplateParams, &params); err != nil {
+			t.Fatalf("failed to unmarshal course completion params: %v", err)
+		}
+		var learnerParams email.CompletionConfirmationParams
+		if err := json.Unmarshal(queued[1].TemplateParams, &learnerParams); err != nil {
+			t.Fatalf("failed to unmarshal completion confirmation params: %v", err)
+		}
+
+		uploads := mockObjectStorage.UploadCalls()
+		testhelpers.AssertRepoCalls(t, len(uploads), 1, testhelpers.SetCourseCompletedHandlerName)
+
+		added := mockCertificateRepo.AddCertificateCalls()[0].Params
+		certificateKey := added.StorageKey
+		if uploads[0].Key != certificateKey || !bytes.HasPrefix(uploads[0].Body, []byte("%PDF-")) {
+			t.Errorf("expected the certificate PDF to be uploaded to %s, got %s", certificateKey, uploads[0].Key)
+		}
+
+		if added.VerificationID == "" || !bytes.Contains(uploads[0].Body, []byte("Verification ID "+added.VerificationID)) {
+			t.Errorf("expected the certificate to have a verification ID, got %q", added.VerificationID)
+		}
+		if !bytes.Contains(uploads[0].Body, []byte("Scan to verify")) {
+			t.Error("expected the certificate to have a verification QR code")
+		}
+
+		// The emails outlive signed download URLs, so they link to the verification page
+		verificationURL := "https://example.com/verify/" + added.VerificationID
+		if params.CertificateNumber != "SN-000001" || params.CertificateURL != verificationURL {
+			t.Errorf("expected the email to link to the certificate's verification page, got %+v", params)
+		}
+
+		if learnerParams.UserID != testhelpers.User.ID || learnerParams.UserEmail != testhelpers.User.Email ||
+			learnerParams.CertificateURL != params.CertificateURL {
+			t.Errorf("expected the learner to be sent their certificate, got %+v", learnerParams)
+		}
 	})
 }
 
@@ -515,7 +575,7 @@ func TestSetCourseCompleted_UnhappyPath(t *testing.T) {
 			},
 		},
 		{
-			name: "internal server error",
+			name: "completion not saved",
 			reqBody: &handlers.SetCourseCompletedParams{
 				CourseID:   courseID,
 				CourseName: courseName,
@@ -525,11 +585,27 @@ func TestSetCourseCompleted_UnhappyPath(t *testing.T) {
 			setup: func() *handlers.Handlers {
 				return &handlers.Handlers{
 					Progress: &mocks.ProgressRepositoryMock{
-						HasCompletedCourseFunc: func(ctx context.Context, params domain.HasCompletedCourseParams) (bool, error) {
-							return false, nil
+						SetCourseCompletedFunc: func(ctx context.Context, params domain.SetCourseCompletedParams) error {
+							return stdErrors.New("db error")
 						},
+					},
+				}
+			},
+		},
+		{
+			name: "internal server error",
+			reqBody: &handlers.SetCourseCompletedParams{
+				CourseID:   courseID,
+				CourseName: courseName,
+			},
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("user progress"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Progress: &mocks.ProgressRepositoryMock{
 						SetCourseCompletedFunc: func(ctx context.Context, params domain.SetCourseCompletedParams) error {
-							return nil
+							_, err := params.Emails(ctx)
+							return err
 						},
 					},
 					User: &mocks.UserRepositoryMock{
@@ -550,37 +626,170 @@ func TestSetCourseCompleted_UnhappyPath(t *testing.T) {
 			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
 		})
 	}
+
 }
 
 func TestResetProgress_HappyPath(t *testing.T) {
-	t.Run("resets progress successfully", func(t *testing.T) {
+	t.Run("resets progress of a list of users and notifies them", func(t *testing.T) {
+		reset := &domain.ProgressReset{
+			ID:          uuid.New(),
+			CourseID:    testhelpers.Course.ID,
+			CourseTitle: testhelpers.Course.Title,
+			Users: []domain.ResetUser{
+				{ID: "user-1", Name: "User A", Email: "usera@test.com"},
+				{ID: "user-2", Name: "User B", Email: "userb@test.com"},
+			},
+		}
+
+		var queued []*domain.OutboxEmail
 		mockRepo := &mocks.ProgressRepositoryMock{
-			ResetProgressFunc: func(ctx context.Context, params domain.ResetProgressParams) error {
-				return nil
+			ResetProgressFunc: func(ctx context.Context, params domain.ResetProgressParams) (*domain.ProgressReset, error) {
+				var err error
+				queued, err = params.Emails(reset)
+				return reset, err
 			},
 		}
 
-		h := &handlers.Handlers{Progress: mockRepo}
+		auditRepo := newAuditMock()
+		h := &handlers.Handlers{
+			Progress:     mockRepo,
+			Audit:        auditRepo,
+			EmailService: newProgressResetEmailMock(),
+		}
 
 		req := handlers.ResetProgressParams{
+			ResetTargetParams: handlers.ResetTargetParams{
+				UserIDs: []string{"user-1", "user-2"},
+				Reason:  "Course content changed",
+			},
 			CourseID: testhelpers.Course.ID.String(),
 		}
 
-		ctx, rec := testhelpers.SetupEchoContext(t, req, "reset-progress")
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "admin/reset-progress")
 
 		err := h.ResetProgress(ctx)
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
 
-		if rec.Code != http.StatusNoContent {
-			t.Errorf("expected %d, got %d", http.StatusNoContent, rec.Code)
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		calls := mockRepo.ResetProgressCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ResetProgressHandlerName)
+
+		expectedParams := domain.ResetProgressParams{
+			UserIDs:  []string{"user-1", "user-2"},
+			CourseID: testhelpers.Course.ID,
+			Reason:   "Course content changed",
+			ResetBy:  testhelpers.TestUserID,
+		}
+		if diff := cmp.Diff(expectedParams, calls[0].ResetProgressParams, ignoreResetEmails); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+
+		var actual domain.ProgressReset
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(*reset, actual); diff != "" {
+			t.Errorf("reset mismatch (-want +got):\n%s", diff)
+		}
+
+		// One entry records the reset of every learner
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.ResetProgressHandlerName)
+		entry := auditCalls[0].Params
+		if entry.TargetType != domain.AuditTargetCourse || entry.TargetID != testhelpers.Course.ID.String() {
+			t.Errorf("expected the reset to be recorded against course %s, got %+v", testhelpers.Course.ID, entry)
+		}
+		expectedAfter := `{"resetId":"` + reset.ID.String() + `","userIds":["user-1","user-2"],"reason":"Course content changed"}`
+		if string(entry.After) != expectedAfter {
+			t.Errorf("expected state after %s, got %s", expectedAfter, entry.After)
+		}
+
+		expectedEmails := utils.Map(reset.Users, func(user domain.ResetUser) *email.ProgressResetParams {
+			return &email.ProgressResetParams{
+				CourseName: testhelpers.Course.Title,
+				UserName:   user.Name,
+				UserEmail:  user.Email,
+				Reason:     "Course content changed",
+			}
+		})
+		if diff := cmp.Diff(expectedEmails, progressResetEmailParams(t, queued)); diff != "" {
+			t.Errorf("queued emails mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("resets progress of the course cohort", func(t *testing.T) {
+		mockRepo := &mocks.ProgressRepositoryMock{
+			ResetProgressFunc: func(ctx context.Context, params domain.ResetProgressParams) (*domain.ProgressReset, error) {
+				return &domain.ProgressReset{ID: uuid.New(), CourseID: params.CourseID}, nil
+			},
+		}
+
+		h := &handlers.Handlers{
+			Progress:     mockRepo,
+			Audit:        newAuditMock(),
+			EmailService: newProgressResetEmailMock(),
+		}
+
+		req := handlers.ResetProgressParams{
+			ResetTargetParams: handlers.ResetTargetParams{Cohort: true, Reason: "New compliance year"},
+			CourseID:          testhelpers.Course.ID.String(),
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "admin/reset-progress")
+
+		if err := h.ResetProgress(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
 		}
 
-		testhelpers.AssertRepoCalls(t, len(mockRepo.ResetProgressCalls()), 1, testhelpers.ResetProgressHandlerName)
+		calls := mockRepo.ResetProgressCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ResetProgressHandlerName)
+
+		if !calls[0].ResetProgressParams.Cohort || calls[0].ResetProgressParams.UserIDs != nil {
+			t.Errorf("expected a cohort reset, got %+v", calls[0].ResetProgressParams)
+		}
 	})
 }
 
+// ignoreResetEmails ignores the function reset params build their emails with, which can't be compared
+var ignoreResetEmails = cmpopts.IgnoreFields(domain.ResetProgressParams{}, "Emails")
+
+func newProgressResetEmailMock() *mocks.EmailServiceMock {
+	return &mocks.EmailServiceMock{
+		GetTemplateNamesFunc: func() *email.TemplateNames {
+			return &email.TemplateNames{ProgressReset: "progress-reset"}
+		},
+		GetEmailNamesFunc: func() *email.EmailNames {
+			return &email.EmailNames{ProgressReset: "progress-reset"}
+		},
+	}
+}
+
+// progressResetEmailParams decodes the params of the progress reset emails queued with a reset
+func progressResetEmailParams(t *testing.T, queued []*domain.OutboxEmail) []*email.ProgressResetParams {
+	t.Helper()
+
+	params := make([]*email.ProgressResetParams, 0, len(queued))
+	for _, outboxEmail := range queued {
+		if outboxEmail.EmailName != "progress-reset" {
+			t.Errorf("expected a progress reset email, got %s", outboxEmail.EmailName)
+		}
+
+		var emailParams email.ProgressResetParams
+		if err := json.Unmarshal(outboxEmail.TemplateParams, &emailParams); err != nil {
+			t.Fatalf("failed to unmarshal progress reset params: %v", err)
+		}
+		params = append(params, &emailParams)
+	}
+
+	return params
+}
+
 func TestResetProgress_UnhappyPath(t *testing.T) {
 	type testCase struct {
 		name           string
@@ -590,10 +799,52 @@ func TestResetProgress_UnhappyPath(t *testing.T) {
 		expectedErrMsg string
 	}
 
+	target := handlers.ResetTargetParams{UserID: "user-1", Reason: "Course content changed"}
+
 	tests := []testCase{
 		{
 			name:           "validation - missing courseId",
-			reqBody:        handlers.ResetProgressParams{},
+			reqBody:        handlers.ResetProgressParams{ResetTargetParams: target},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Progress: &mocks.ProgressRepositoryMock{}}
+			},
+		},
+		{
+			name: "validation - missing reason",
+			reqBody: handlers.ResetProgressParams{
+				ResetTargetParams: handlers.ResetTargetParams{UserID: "user-1"},
+				CourseID:          testhelpers.Course.ID.String(),
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Progress: &mocks.ProgressRepositoryMock{}}
+			},
+		},
+		{
+			name: "validation - no target",
+			reqBody: handlers.ResetProgressParams{
+				ResetTargetParams: handlers.ResetTargetParams{Reason: "Course content changed"},
+				CourseID:          testhelpers.Course.ID.String(),
+			},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{Progress: &mocks.ProgressRepositoryMock{}}
+			},
+		},
+		{
+			name: "validation - more than one target",
+			reqBody: handlers.ResetProgressParams{
+				ResetTargetParams: handlers.ResetTargetParams{
+					UserIDs: []string{"user-1"},
+					Cohort:  true,
+					Reason:  "Course content changed",
+				},
+				CourseID: testhelpers.Course.ID.String(),
+			},
 			wantStatus:     http.StatusBadRequest,
 			expectedErrMsg: errors.Validation,
 			setup: func() *handlers.Handlers {
@@ -603,7 +854,8 @@ func TestResetProgress_UnhappyPath(t *testing.T) {
 		{
 			name: "validation - invalid uuid",
 			reqBody: handlers.ResetProgressParams{
-				CourseID: "invalid-uuid",
+				ResetTargetParams: target,
+				CourseID:          "invalid-uuid",
 			},
 			wantStatus:     http.StatusBadRequest,
 			expectedErrMsg: errors.InvalidUUID,
@@ -611,20 +863,47 @@ func TestResetProgress_UnhappyPath(t *testing.T) {
 				return &handlers.Handlers{Progress: &mocks.ProgressRepositoryMock{}}
 			},
 		},
+		{
+			name: "course or user not found",
+			reqBody: handlers.ResetProgressParams{
+				ResetTargetParams: target,
+				CourseID:          testhelpers.Course.ID.String(),
+			},
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("course, quiz or user"),
+			setup: func() *handlers.Handlers {
+				return &handlers.Handlers{
+					Progress: &mocks.ProgressRepositoryMock{
+						ResetProgressFunc: func(
+							ctx context.Context,
+							params domain.ResetProgressParams,
+						) (*domain.ProgressReset, error) {
+							return nil, pgx.ErrNoRows
+						},
+					},
+					Audit: newAuditMock(),
+				}
+			},
+		},
 		{
 			name: "internal server error",
 			reqBody: handlers.ResetProgressParams{
-				CourseID: testhelpers.Course.ID.String(),
+				ResetTargetParams: target,
+				CourseID:          testhelpers.Course.ID.String(),
 			},
 			wantStatus:     http.StatusInternalServerError,
 			expectedErrMsg: errors.Updating("user progress"),
 			setup: func() *handlers.Handlers {
 				return &handlers.Handlers{
 					Progress: &mocks.ProgressRepositoryMock{
-						ResetProgressFunc: func(ctx context.Context, params domain.ResetProgressParams) error {
-							return stdErrors.New("db error")
+						ResetProgressFunc: func(
+							ctx context.Context,
+							params domain.ResetProgressParams,
+						) (*domain.ProgressReset, error) {
+							return nil, stdErrors.New("db error")
 						},
 					},
+					Audit: newAuditMock(),
 				}
 			},
 		},
@@ -747,3 +1026,162 @@ func TestGetAllProgress_UnhappyPath(t *testing.T) {
 		})
 	}
 }
+
+func TestListProgress_HappyPath(t *testing.T) {
+	t.Run("returns the first page of progress when no cursor is given", func(t *testing.T) {
+		expected := &domain.Page[*domain.FullProgress]{
+			Items: []*domain.FullProgress{
+				{
+					UserID:   "user-1",
+					UserName: "User A",
+					Email:    "usera@test.com",
+					Progress: []*domain.FullUserProgress{
+						{
+							CourseID:              testhelpers.Course.ID,
+							CourseName:            testhelpers.Course.Title,
+							CompletedIntro:        true,
+							CourseSectionProgress: []domain.CourseSectionProgress{},
+						},
+					},
+				},
+			},
+			TotalCount: 1,
+		}
+
+		mockRepo := &mocks.ProgressRepositoryMock{
+			ListProgressFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+				return expected, nil
+			},
+		}
+
+		h := &handlers.Handlers{Progress: mockRepo}
+
+		reqParams := handlers.ListProgressParams{CourseID: testhelpers.Course.ID.String()}
+		ctx, rec := testhelpers.SetupEchoContext(t, reqParams, "admin/progress/list")
+
+		err := h.ListProgress(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var actual *domain.Page[*domain.FullProgress]
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("progress mismatch (-want +got):\n%s", diff)
+		}
+
+		calls := mockRepo.ListProgressCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListProgressHandlerName)
+
+		if calls[0].Params.Cursor != nil {
+			t.Errorf("expected no cursor, got %v", calls[0].Params.Cursor)
+		}
+
+		if calls[0].Params.CourseID == nil || *calls[0].Params.CourseID != testhelpers.Course.ID {
+			t.Errorf("expected course ID filter %s, got %v", testhelpers.Course.ID, calls[0].Params.CourseID)
+		}
+	})
+}
+
+func TestListProgress_UnhappyPath(t *testing.T) {
+	t.Run("invalid sort direction", func(t *testing.T) {
+		mockRepo := &mocks.ProgressRepositoryMock{}
+		h := &handlers.Handlers{Progress: mockRepo}
+
+		reqParams := handlers.ListProgressParams{
+			PaginationParams: handlers.PaginationParams{SortDir: "sideways"},
+		}
+		ctx, _ := testhelpers.SetupEchoContext(t, reqParams, "admin/progress/list")
+
+		err := h.ListProgress(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.Validation)
+		testhelpers.AssertRepoCalls(t, len(mockRepo.ListProgressCalls()), 0, testhelpers.ListProgressHandlerName)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Progress: &mocks.ProgressRepositoryMock{
+				ListProgressFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "admin/progress/list")
+
+		err := h.ListProgress(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("user progress"))
+	})
+}
+
+func TestListProgress_Manager(t *testing.T) {
+	groupID := uuid.New()
+
+	tests := []struct {
+		name       string
+		reqParams  handlers.ListProgressParams
+		isManager  bool
+		wantStatus int
+	}{
+		{
+			name:      "manager of group",
+			reqParams: handlers.ListProgressParams{GroupID: groupID.String()},
+			isManager: true,
+		},
+		{
+			name:       "manager not of group",
+			reqParams:  handlers.ListProgressParams{GroupID: groupID.String()},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no group",
+			reqParams:  handlers.ListProgressParams{},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.ProgressRepositoryMock{
+				ListProgressFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+					return &domain.Page[*domain.FullProgress]{Items: []*domain.FullProgress{}}, nil
+				},
+			}
+			mockRoleRepo := &mocks.RoleRepositoryMock{
+				IsGroupManagerFunc: func(ctx context.Context, params domain.IsGroupManagerParams) (bool, error) {
+					return tt.isManager, nil
+				},
+			}
+
+			h := &handlers.Handlers{Progress: mockRepo, Role: mockRoleRepo}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqParams, "admin/progress/list", testhelpers.WithRole(config.ManagerRole))
+			err := h.ListProgress(ctx)
+
+			if tt.wantStatus != 0 {
+				testhelpers.AssertHTTPError(t, err, tt.wantStatus, errors.Forbidden("user progress"))
+				testhelpers.AssertRepoCalls(t, len(mockRepo.ListProgressCalls()), 0, testhelpers.ListProgressHandlerName)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			calls := mockRepo.ListProgressCalls()
+			testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListProgressHandlerName)
+			if calls[0].Params.GroupID == nil || *calls[0].Params.GroupID != groupID {
+				t.Errorf("expected progress for group %s, got %v", groupID, calls[0].Params.GroupID)
+			}
+		})
+	}
+}
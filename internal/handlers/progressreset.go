@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const progressResetTargetResource = "course, quiz or user"
+
+// ResetTargetParams choose whose progress is reset, exactly one of a user, a list of users or
+// everyone enrolled on the course
+type ResetTargetParams struct {
+	UserID  string   `json:"userId"`
+	UserIDs []string `json:"userIds" validate:"max=1000,unique,dive,required"`
+	Cohort  bool     `json:"cohort"`
+	Reason  string   `json:"reason" validate:"required,max=500"`
+}
+
+func (p *ResetTargetParams) userIDs() ([]string, error) {
+	targets := 0
+	for _, set := range []bool{p.UserID != "", len(p.UserIDs) > 0, p.Cohort} {
+		if set {
+			targets++
+		}
+	}
+
+	if targets != 1 {
+		return nil, httpError(
+			http.StatusBadRequest,
+			errors.Validation,
+			stdErrors.New("exactly one of userId, userIds or cohort is required"),
+		)
+	}
+
+	if p.UserID != "" {
+		return []string{p.UserID}, nil
+	}
+
+	return p.UserIDs, nil
+}
+
+// progressResetAuditState is what the audit log records for a reset, the learners' previous
+// progress is kept in the reset's archive
+type progressResetAuditState struct {
+	ResetID string   `json:"resetId"`
+	QuizID  string   `json:"quizId,omitempty"`
+	UserIDs []string `json:"userIds"`
+	Reason  string   `json:"reason"`
+}
+
+// resetProgressAudited makes the reset and records it against the course in one transaction. A
+// single entry lists the learners, so resetting a large cohort doesn't add an entry for each.
+func (h *Handlers) resetProgressAudited(
+	ctx context.Context,
+	action domain.AuditAction,
+	reason string,
+	resetProgress func(ctx context.Context) (*domain.ProgressReset, error),
+) (*domain.ProgressReset, error) {
+	var reset *domain.ProgressReset
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		reset, err = resetProgress(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		after := progressResetAuditStateFrom(reset, reason)
+		return auditEntry(ctx, action, domain.AuditTargetCourse, reset.CourseID.String(), nil, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reset, nil
+}
+
+func progressResetAuditStateFrom(reset *domain.ProgressReset, reason string) progressResetAuditState {
+	auditState := progressResetAuditState{
+		ResetID: reset.ID.String(),
+		UserIDs: utils.Map(reset.Users, func(user domain.ResetUser) string { return user.ID }),
+		Reason:  reason,
+	}
+	if reset.QuizID != nil {
+		auditState.QuizID = reset.QuizID.String()
+	}
+
+	return auditState
+}
+
+// progressResetEmails let each learner know why their progress was reset. The store queues them in
+// the reset's transaction.
+func (h *Handlers) progressResetEmails(reason string) func(reset *domain.ProgressReset) ([]*domain.OutboxEmail, error) {
+	return func(reset *domain.ProgressReset) ([]*domain.OutboxEmail, error) {
+		emailName := h.EmailService.GetEmailNames().ProgressReset
+		templateName := h.EmailService.GetTemplateNames().ProgressReset
+
+		emails := make([]*domain.OutboxEmail, 0, len(reset.Users))
+		for _, user := range reset.Users {
+			outboxEmail, err := email.NewOutboxEmail(&email.ProgressResetParams{
+				CourseName: reset.CourseTitle,
+				QuizReset:  reset.QuizID != nil,
+				UserName:   user.Name,
+				UserEmail:  user.Email,
+				Reason:     reason,
+			}, templateName, emailName)
+			if err != nil {
+				return nil, err
+			}
+
+			emails = append(emails, outboxEmail)
+		}
+
+		return emails, nil
+	}
+}
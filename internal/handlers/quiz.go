@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -166,14 +167,43 @@ func (h *Handlers) GetAllQuizSections(e echo.Context) error {
 	return e.JSON(http.StatusOK, sections)
 }
 
+type ListQuizSectionsParams struct {
+	PaginationParams
+	CourseID string `json:"courseId"`
+}
+
+func (h *Handlers) ListQuizSections(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListQuizSectionsParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	// Quiz sections are always sorted by course title then position, search matches the course title
+	pageParams, err := params.pageParams("", pageFilters{CourseID: params.CourseID})
+	if err != nil {
+		return err
+	}
+
+	sections, err := h.Quiz.ListQuizSections(ctx, pageParams)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting("quiz sections"), err)
+	}
+
+	return e.JSON(http.StatusOK, sections)
+}
+
 type ResetQuizProgressParams struct {
+	ResetTargetParams
 	QuizID string `json:"quizID" validate:"required"`
 }
 
+// ResetQuizProgress resets the answers and attempts of a quiz like ResetProgress
 func (h *Handlers) ResetQuizProgress(e echo.Context) error {
 	ctx := e.Request().Context()
 
-	userID, ok := getUserID(ctx)
+	adminID, ok := getUserID(ctx)
 	if !ok {
 		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
 	}
@@ -183,17 +213,42 @@ func (h *Handlers) ResetQuizProgress(e echo.Context) error {
 		return err
 	}
 
+	userIDs, err := params.userIDs()
+	if err != nil {
+		return err
+	}
+
 	quizID, err := uuid.Parse(params.QuizID)
 	if err != nil {
 		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
 	}
 
-	err = h.Quiz.ResetQuizProgress(ctx, userID, quizID)
+	reset, err := h.resetProgressAudited(ctx, domain.AuditActionResetQuizProgress, params.Reason,
+		func(ctx context.Context) (*domain.ProgressReset, error) {
+			reset, err := h.Quiz.ResetQuizProgress(ctx, domain.ResetQuizProgressParams{
+				UserIDs: userIDs,
+				Cohort:  params.Cohort,
+				QuizID:  quizID,
+				Reason:  params.Reason,
+				ResetBy: adminID,
+				Emails:  h.progressResetEmails(params.Reason),
+			})
+			if err != nil {
+				if errors.IsNotFoundErr(err) {
+					return nil, httpError(http.StatusNotFound, errors.NotFound(progressResetTargetResource), err)
+				}
+
+				return nil, httpError(http.StatusInternalServerError, errors.Deleting(quizStateResource), err)
+			}
+
+			return reset, nil
+		},
+	)
 	if err != nil {
-		return httpError(http.StatusInternalServerError, errors.Deleting(quizStateResource), err)
+		return err
 	}
 
-	return e.NoContent(http.StatusNoContent)
+	return e.JSON(http.StatusOK, reset)
 }
 
 type GetQuizStateParams struct {
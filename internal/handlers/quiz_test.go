@@ -5,16 +5,20 @@ import (
 	"encoding/json"
 	stdErrors "errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers"
 	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
 	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
 	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
 )
 
 func TestGetQuizQuestions_HappyPath(t *testing.T) {
@@ -169,3 +173,213 @@ func TestGetQuizQuestions_UnhappyPath(t *testing.T) {
 		})
 	}
 }
+
+func TestResetQuizProgress(t *testing.T) {
+	quizID := uuid.New()
+
+	t.Run("resets the quiz and notifies the learner", func(t *testing.T) {
+		reset := &domain.ProgressReset{
+			ID:          uuid.New(),
+			CourseID:    testhelpers.Course.ID,
+			CourseTitle: testhelpers.Course.Title,
+			QuizID:      &quizID,
+			Users:       []domain.ResetUser{{ID: "user-1", Name: "User A", Email: "usera@test.com"}},
+		}
+
+		var queued []*domain.OutboxEmail
+		mockRepo := &mocks.QuizRepositoryMock{
+			ResetQuizProgressFunc: func(
+				ctx context.Context,
+				params domain.ResetQuizProgressParams,
+			) (*domain.ProgressReset, error) {
+				var err error
+				queued, err = params.Emails(reset)
+				return reset, err
+			},
+		}
+
+		auditRepo := newAuditMock()
+		h := &handlers.Handlers{
+			Quiz:         mockRepo,
+			Audit:        auditRepo,
+			EmailService: newProgressResetEmailMock(),
+		}
+
+		req := handlers.ResetQuizProgressParams{
+			ResetTargetParams: handlers.ResetTargetParams{UserID: "user-1", Reason: "Answers were marked wrongly"},
+			QuizID:            quizID.String(),
+		}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "admin/reset-quiz-progress")
+
+		if err := h.ResetQuizProgress(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		calls := mockRepo.ResetQuizProgressCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ResetQuizProgressHandlerName)
+
+		expectedParams := domain.ResetQuizProgressParams{
+			UserIDs: []string{"user-1"},
+			QuizID:  quizID,
+			Reason:  "Answers were marked wrongly",
+			ResetBy: testhelpers.TestUserID,
+		}
+		ignoreEmails := cmpopts.IgnoreFields(domain.ResetQuizProgressParams{}, "Emails")
+		if diff := cmp.Diff(expectedParams, calls[0].Params, ignoreEmails); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.ResetQuizProgressHandlerName)
+		expectedAfter := `{"resetId":"` + reset.ID.String() + `","quizId":"` + quizID.String() +
+			`","userIds":["user-1"],"reason":"Answers were marked wrongly"}`
+		if string(auditCalls[0].Params.After) != expectedAfter {
+			t.Errorf("expected state after %s, got %s", expectedAfter, auditCalls[0].Params.After)
+		}
+
+		expectedEmails := []*email.ProgressResetParams{{
+			CourseName: testhelpers.Course.Title,
+			QuizReset:  true,
+			UserName:   "User A",
+			UserEmail:  "usera@test.com",
+			Reason:     "Answers were marked wrongly",
+		}}
+		if diff := cmp.Diff(expectedEmails, progressResetEmailParams(t, queued)); diff != "" {
+			t.Errorf("queued emails mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("quiz or user not found", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Quiz: &mocks.QuizRepositoryMock{
+				ResetQuizProgressFunc: func(
+					ctx context.Context,
+					params domain.ResetQuizProgressParams,
+				) (*domain.ProgressReset, error) {
+					return nil, pgx.ErrNoRows
+				},
+			},
+			Audit: newAuditMock(),
+		}
+
+		req := handlers.ResetQuizProgressParams{
+			ResetTargetParams: handlers.ResetTargetParams{Cohort: true, Reason: "Answers were marked wrongly"},
+			QuizID:            quizID.String(),
+		}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "admin/reset-quiz-progress")
+
+		err := h.ResetQuizProgress(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusNotFound, errors.NotFound("course, quiz or user"))
+	})
+}
+
+func TestListQuizSections_HappyPath(t *testing.T) {
+	t.Run("returns the page of quiz sections for the course", func(t *testing.T) {
+		courseID := uuid.New()
+		expected := &domain.Page[*domain.QuizSection]{
+			Items: []*domain.QuizSection{
+				{
+					ID:        uuid.New(),
+					Title:     "Quiz 1",
+					Position:  1,
+					Type:      domain.SectionTypeQuiz,
+					Questions: []domain.QuizQuestion{},
+				},
+			},
+			TotalCount: 1,
+		}
+
+		mockRepo := &mocks.QuizRepositoryMock{
+			ListQuizSectionsFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.QuizSection], error) {
+				return expected, nil
+			},
+		}
+
+		h := &handlers.Handlers{Quiz: mockRepo}
+
+		reqParams := handlers.ListQuizSectionsParams{
+			PaginationParams: handlers.PaginationParams{Search: "fire_safety"},
+			CourseID:         courseID.String(),
+		}
+		ctx, rec := testhelpers.SetupEchoContext(t, reqParams, "admin/quiz/sections/list")
+
+		err := h.ListQuizSections(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var actual *domain.Page[*domain.QuizSection]
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("quiz sections mismatch (-want +got):\n%s", diff)
+		}
+
+		calls := mockRepo.ListQuizSectionsCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListQuizSectionsHandlerName)
+
+		if calls[0].Params.CourseID == nil || *calls[0].Params.CourseID != courseID {
+			t.Errorf("expected course ID filter %s, got %v", courseID, calls[0].Params.CourseID)
+		}
+
+		if calls[0].Params.Search != "fire_safety" {
+			t.Errorf("expected search %q, got %q", "fire_safety", calls[0].Params.Search)
+		}
+	})
+}
+
+func TestListQuizSections_UnhappyPath(t *testing.T) {
+	t.Run("invalid course ID", func(t *testing.T) {
+		mockRepo := &mocks.QuizRepositoryMock{}
+		h := &handlers.Handlers{Quiz: mockRepo}
+
+		reqParams := handlers.ListQuizSectionsParams{CourseID: "not-a-uuid"}
+		ctx, _ := testhelpers.SetupEchoContext(t, reqParams, "admin/quiz/sections/list")
+
+		err := h.ListQuizSections(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.InvalidUUID)
+		testhelpers.AssertRepoCalls(t, len(mockRepo.ListQuizSectionsCalls()), 0, testhelpers.ListQuizSectionsHandlerName)
+	})
+
+	t.Run("search too long", func(t *testing.T) {
+		mockRepo := &mocks.QuizRepositoryMock{}
+		h := &handlers.Handlers{Quiz: mockRepo}
+
+		reqParams := handlers.ListQuizSectionsParams{
+			PaginationParams: handlers.PaginationParams{Search: strings.Repeat("a", 201)},
+		}
+		ctx, _ := testhelpers.SetupEchoContext(t, reqParams, "admin/quiz/sections/list")
+
+		err := h.ListQuizSections(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.Validation)
+		testhelpers.AssertRepoCalls(t, len(mockRepo.ListQuizSectionsCalls()), 0, testhelpers.ListQuizSectionsHandlerName)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Quiz: &mocks.QuizRepositoryMock{
+				ListQuizSectionsFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.QuizSection], error) {
+					return nil, stdErrors.New("db error")
+				},
+			},
+		}
+
+		ctx, _ := testhelpers.SetupEchoContext(t, nil, "admin/quiz/sections/list")
+
+		err := h.ListQuizSections(ctx)
+
+		testhelpers.AssertHTTPError(t, err, http.StatusInternalServerError, errors.Getting("quiz sections"))
+	})
+}
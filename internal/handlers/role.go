@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/middleware"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const (
+	roleResource              = "user role"
+	rolesResource             = "user roles"
+	groupManagersResource     = "group managers"
+	courseInstructorsResource = "course instructors"
+)
+
+// OwnRoleResponse tells the client what the current user can do, so it can show the right pages
+type OwnRoleResponse struct {
+	Role        config.Role             `json:"role"`
+	Permissions []middleware.Permission `json:"permissions"`
+	domain.RoleScope
+}
+
+func (h *Handlers) GetOwnRole(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	role, ok := getUserRole(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("role"), nil)
+	}
+
+	scope, err := h.Role.GetRoleScope(ctx, userID)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(roleResource), err)
+	}
+
+	return e.JSON(http.StatusOK, &OwnRoleResponse{
+		Role:        role,
+		Permissions: middleware.Permissions(role),
+		RoleScope:   *scope,
+	})
+}
+
+func (h *Handlers) GetUserRoles(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	roles, err := h.Role.GetUserRoles(ctx)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(rolesResource), err)
+	}
+
+	return e.JSON(http.StatusOK, roles)
+}
+
+type SetUserRoleParams struct {
+	UserID string `json:"userId" validate:"required"`
+	Role   string `json:"role" validate:"required,oneof=admin manager instructor auditor user"`
+}
+
+// SetUserRole grants a role to a user, replacing any role they had. The stored role overrides the
+// admin claim from the auth provider, so setting the user role demotes admins with the claim.
+func (h *Handlers) SetUserRole(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params SetUserRoleParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	// Stops admins from removing their own access to manage roles
+	if err := checkNotOwnAccount(e, params.UserID, "change the role of"); err != nil {
+		return err
+	}
+
+	adminID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		err := h.Role.SetUserRole(ctx, domain.SetUserRoleParams{
+			UserID:    params.UserID,
+			Role:      config.Role(params.Role),
+			GrantedBy: adminID,
+		})
+		if err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(userResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(roleResource), err)
+		}
+
+		after := roleAuditState{Role: params.Role}
+		return auditEntry(ctx, domain.AuditActionSetUserRole, domain.AuditTargetUser, params.UserID, nil, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// roleAuditState is the role granted to a user, as recorded in the audit log
+type roleAuditState struct {
+	Role string `json:"role"`
+}
+
+// AddGroupManagers lets managers view progress for the group. Unknown users are skipped.
+func (h *Handlers) AddGroupManagers(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	params, err := groupMembersParamsFrom(e)
+	if err != nil {
+		return err
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Role.AddGroupManagers(ctx, params); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(groupResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(groupManagersResource), err)
+		}
+
+		return groupMembersAuditEntry(ctx, domain.AuditActionAddGroupManagers, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (h *Handlers) RemoveGroupManagers(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	params, err := groupMembersParamsFrom(e)
+	if err != nil {
+		return err
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Role.RemoveGroupManagers(ctx, params); err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(groupManagersResource), err)
+		}
+
+		return groupMembersAuditEntry(ctx, domain.AuditActionRemoveGroupManagers, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+type CourseInstructorsParams struct {
+	CourseID string   `json:"courseId" validate:"required"`
+	UserIDs  []string `json:"userIds" validate:"required,min=1,max=1000,dive,required"`
+}
+
+// AssignCourseInstructors lets instructors edit the course. Unknown users are skipped.
+func (h *Handlers) AssignCourseInstructors(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	params, err := courseInstructorsParamsFrom(e)
+	if err != nil {
+		return err
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Role.AssignCourseInstructors(ctx, params); err != nil {
+			if errors.IsNotFoundErr(err) {
+				return nil, httpError(http.StatusNotFound, errors.NotFound(courseResource), err)
+			}
+
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(courseInstructorsResource), err)
+		}
+
+		return courseInstructorsAuditEntry(ctx, domain.AuditActionAssignInstructors, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+func (h *Handlers) UnassignCourseInstructors(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	params, err := courseInstructorsParamsFrom(e)
+	if err != nil {
+		return err
+	}
+
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.Role.UnassignCourseInstructors(ctx, params); err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(courseInstructorsResource), err)
+		}
+
+		return courseInstructorsAuditEntry(ctx, domain.AuditActionUnassignInstructors, params)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// courseInstructorsAuditState is the users assigned to or unassigned from a course, as recorded in
+// the audit log
+type courseInstructorsAuditState struct {
+	UserIDs []string `json:"userIds"`
+}
+
+// courseInstructorsAuditEntry records a change to a course's instructors against the course
+func courseInstructorsAuditEntry(
+	ctx context.Context,
+	action domain.AuditAction,
+	params domain.CourseInstructorsParams,
+) (*domain.AddAuditEntryParams, error) {
+	after := courseInstructorsAuditState{UserIDs: params.UserIDs}
+	return auditEntry(ctx, action, domain.AuditTargetCourse, params.CourseID.String(), nil, after)
+}
+
+func courseInstructorsParamsFrom(e echo.Context) (domain.CourseInstructorsParams, error) {
+	var params CourseInstructorsParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return domain.CourseInstructorsParams{}, err
+	}
+
+	courseID, err := uuid.Parse(params.CourseID)
+	if err != nil {
+		return domain.CourseInstructorsParams{}, httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	return domain.CourseInstructorsParams{
+		CourseID: courseID,
+		UserIDs:  utils.Unique(params.UserIDs),
+	}, nil
+}
+
+// checkCanEditCourse lets users who can edit every course through, instructors can only edit the
+// courses they are assigned to
+func (h *Handlers) checkCanEditCourse(ctx context.Context, courseID uuid.UUID) error {
+	role, ok := getUserRole(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("role"), nil)
+	}
+
+	if middleware.HasPermission(role, middleware.PermissionEditCourses) {
+		return nil
+	}
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	isInstructor, err := h.Role.IsCourseInstructor(ctx, domain.IsCourseInstructorParams{
+		UserID:   userID,
+		CourseID: courseID,
+	})
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(courseInstructorsResource), err)
+	}
+
+	if !isInstructor {
+		return httpError(http.StatusForbidden, errors.Forbidden(courseResource), nil)
+	}
+
+	return nil
+}
+
+// checkCanViewGroupProgress lets users who can view all progress through, managers can only view
+// progress for a group they manage
+func (h *Handlers) checkCanViewGroupProgress(ctx context.Context, groupID *uuid.UUID) error {
+	// API keys with the view progress scope can view every group's progress too
+	if middleware.ContextHasPermission(ctx, middleware.PermissionViewProgress) {
+		return nil
+	}
+
+	if groupID == nil {
+		return httpError(http.StatusForbidden, errors.Forbidden(progressResource), nil)
+	}
+
+	userID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	isManager, err := h.Role.IsGroupManager(ctx, domain.IsGroupManagerParams{
+		UserID:  userID,
+		GroupID: *groupID,
+	})
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(groupManagersResource), err)
+	}
+
+	if !isManager {
+		return httpError(http.StatusForbidden, errors.Forbidden(progressResource), nil)
+	}
+
+	return nil
+}
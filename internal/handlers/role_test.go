@@ -0,0 +1,311 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/middleware"
+)
+
+func TestGetOwnRole_HappyPath(t *testing.T) {
+	t.Run("returns role, permissions and scope", func(t *testing.T) {
+		scope := &domain.RoleScope{
+			ManagedGroupIDs:     []uuid.UUID{uuid.New()},
+			InstructedCourseIDs: []uuid.UUID{},
+		}
+
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			GetRoleScopeFunc: func(ctx context.Context, userID string) (*domain.RoleScope, error) {
+				return scope, nil
+			},
+		}
+
+		h := &handlers.Handlers{Role: mockRoleRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, nil, "roles/me", testhelpers.WithRole(config.ManagerRole))
+
+		err := h.GetOwnRole(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockRoleRepo.GetRoleScopeCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetOwnRoleHandlerName)
+		if calls[0].UserID != testhelpers.TestUserID {
+			t.Errorf("expected scope for %s, got %s", testhelpers.TestUserID, calls[0].UserID)
+		}
+
+		var actual handlers.OwnRoleResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		expected := handlers.OwnRoleResponse{
+			Role: config.ManagerRole,
+			Permissions: []middleware.Permission{
+				middleware.PermissionLearn,
+				middleware.PermissionViewTeam,
+				middleware.PermissionViewGroupProgress,
+			},
+			RoleScope: *scope,
+		}
+
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("role mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestSetUserRole_HappyPath(t *testing.T) {
+	t.Run("grants role to user", func(t *testing.T) {
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			SetUserRoleFunc: func(ctx context.Context, params domain.SetUserRoleParams) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{Role: mockRoleRepo, Audit: newAuditMock()}
+
+		req := handlers.SetUserRoleParams{UserID: testhelpers.User.ID, Role: string(config.InstructorRole)}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "roles/set")
+
+		err := h.SetUserRole(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRoleRepo.SetUserRoleCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetUserRoleHandlerName)
+
+		expected := domain.SetUserRoleParams{
+			UserID:    testhelpers.User.ID,
+			Role:      config.InstructorRole,
+			GrantedBy: testhelpers.TestUserID,
+		}
+
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("stores the user role so it overrides the admin claim", func(t *testing.T) {
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			SetUserRoleFunc: func(ctx context.Context, params domain.SetUserRoleParams) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{Role: mockRoleRepo, Audit: newAuditMock()}
+
+		req := handlers.SetUserRoleParams{UserID: testhelpers.User.ID, Role: string(config.UserRole)}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "roles/set")
+
+		err := h.SetUserRole(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRoleRepo.SetUserRoleCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.SetUserRoleHandlerName)
+
+		if calls[0].Params.Role != config.UserRole {
+			t.Errorf("expected %s role to be stored, got %s", config.UserRole, calls[0].Params.Role)
+		}
+	})
+}
+
+func TestSetUserRole_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.SetUserRoleParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - unknown role",
+			reqBody:        handlers.SetUserRoleParams{UserID: testhelpers.User.ID, Role: "owner"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "own account",
+			reqBody:        handlers.SetUserRoleParams{UserID: testhelpers.TestUserID, Role: string(config.UserRole)},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.OwnAccount("change the role of"),
+		},
+		{
+			name:           "user not found",
+			reqBody:        handlers.SetUserRoleParams{UserID: testhelpers.User.ID, Role: string(config.AuditorRole)},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.SetUserRoleParams{UserID: testhelpers.User.ID, Role: string(config.AuditorRole)},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("user role"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				Role: &mocks.RoleRepositoryMock{
+					SetUserRoleFunc: func(ctx context.Context, params domain.SetUserRoleParams) error {
+						return tt.repoErr
+					},
+				},
+				Audit: newAuditMock(),
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "roles/set")
+			err := h.SetUserRole(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestAddGroupManagers(t *testing.T) {
+	t.Run("adds managers to group", func(t *testing.T) {
+		groupID := uuid.New()
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			AddGroupManagersFunc: func(ctx context.Context, params domain.GroupMembersParams) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{Role: mockRoleRepo, Audit: newAuditMock()}
+
+		req := handlers.GroupMembersParams{GroupID: groupID.String(), UserIDs: []string{"user-1", "user-1"}}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "groups/managers/add")
+
+		err := h.AddGroupManagers(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRoleRepo.AddGroupManagersCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.AddGroupManagersHandlerName)
+
+		expected := domain.GroupMembersParams{GroupID: groupID, UserIDs: []string{"user-1"}}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("group not found", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Role: &mocks.RoleRepositoryMock{
+				AddGroupManagersFunc: func(ctx context.Context, params domain.GroupMembersParams) error {
+					return pgx.ErrNoRows
+				},
+			},
+			Audit: newAuditMock(),
+		}
+
+		req := handlers.GroupMembersParams{GroupID: uuid.New().String(), UserIDs: []string{"user-1"}}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "groups/managers/add")
+
+		err := h.AddGroupManagers(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusNotFound, errors.NotFound("group"))
+	})
+}
+
+func TestAssignCourseInstructors(t *testing.T) {
+	t.Run("assigns instructors to course", func(t *testing.T) {
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			AssignCourseInstructorsFunc: func(ctx context.Context, params domain.CourseInstructorsParams) error {
+				return nil
+			},
+		}
+
+		auditRepo := newAuditMock()
+
+		h := &handlers.Handlers{Role: mockRoleRepo, Audit: auditRepo}
+
+		req := handlers.CourseInstructorsParams{CourseID: testhelpers.Course.ID.String(), UserIDs: []string{"user-1"}}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "courses/instructors/assign")
+
+		err := h.AssignCourseInstructors(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockRoleRepo.AssignCourseInstructorsCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.AssignCourseInstructorsHandlerName)
+		if calls[0].Params.CourseID != testhelpers.Course.ID {
+			t.Errorf("expected course %s, got %s", testhelpers.Course.ID, calls[0].Params.CourseID)
+		}
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.AssignCourseInstructorsHandlerName)
+		entry := auditCalls[0].Params
+		if entry.Action != domain.AuditActionAssignInstructors || entry.TargetID != testhelpers.Course.ID.String() {
+			t.Errorf("expected %s audit entry for course %s, got %+v", domain.AuditActionAssignInstructors, testhelpers.Course.ID, entry)
+		}
+		if string(entry.After) != `{"userIds":["user-1"]}` {
+			t.Errorf("expected instructors user-1 to be assigned, got %s", entry.After)
+		}
+	})
+
+	t.Run("invalid course id", func(t *testing.T) {
+		mockRoleRepo := &mocks.RoleRepositoryMock{}
+		h := &handlers.Handlers{Role: mockRoleRepo}
+
+		req := handlers.CourseInstructorsParams{CourseID: "not-a-uuid", UserIDs: []string{"user-1"}}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "courses/instructors/assign")
+
+		err := h.AssignCourseInstructors(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusBadRequest, errors.InvalidUUID)
+		testhelpers.AssertRepoCalls(t, len(mockRoleRepo.AssignCourseInstructorsCalls()), 0, testhelpers.AssignCourseInstructorsHandlerName)
+	})
+
+	t.Run("course not found", func(t *testing.T) {
+		h := &handlers.Handlers{
+			Role: &mocks.RoleRepositoryMock{
+				AssignCourseInstructorsFunc: func(ctx context.Context, params domain.CourseInstructorsParams) error {
+					return pgx.ErrNoRows
+				},
+			},
+			Audit: newAuditMock(),
+		}
+
+		req := handlers.CourseInstructorsParams{CourseID: uuid.New().String(), UserIDs: []string{"user-1"}}
+		ctx, _ := testhelpers.SetupEchoContext(t, req, "courses/instructors/assign")
+
+		err := h.AssignCourseInstructors(ctx)
+		testhelpers.AssertHTTPError(t, err, http.StatusNotFound, errors.NotFound("course"))
+	})
+}
@@ -20,33 +20,76 @@ import (
 )
 
 const (
-	AddCourseHandlerName                  = "AddCourse"
-	EditCourseHandlerName                 = "EditCourse"
-	DeleteCourseHandlerName               = "DeleteCourse"
-	GetCourseHandlerName                  = "GetCourse"
-	GetCourseMaterialsHandlerName         = "GetCourseMaterials"
-	GetCoursesOverviewHandlerName         = "GetCoursesOverview"
-	GetAssignedCourseTitlesHandlerName    = "GetAssignedCourseTitles"
-	IsEnrolledHandlerName                 = "IsEnrolled"
-	GetVideoURLHandlerName                = "GetVideoURL"
-	GetVideoUploadURLHandlerName          = "GetVideoUploadURL"
-	GetMaterialUploadURLHandlerName       = "GetMaterialUploadURL"
-	UpdateCourseEnrolmentHandler          = "UpdateCourseEnrolment"
-	EnrolUserInCourseHandlerName          = "EnrolInCourse"
-	DisenrolUserInCourseHandlerName       = "DisenrolInCourse"
-	ResetProgressHandlerName              = "ResetProgress"
-	GetAllProgressHandlerName             = "GetAllProgress"
-	GetProgressHandlerName                = "GetProgress"
-	UpdateProgressHandlerName             = "UpdateProgress"
-	SetCourseCompletedHandlerName         = "SetCourseCompleted"
-	SetIntroCompletedHandlerName          = "SetIntroCompleted"
-	HasCompletedCourseHandlerName         = "HasCompletedCourse"
-	GetUserHandlerName                    = "GetUser"
-	SendEmailHandlerName                  = "SendEmail"
-	GetTemplateNamesHandlerName           = "GetEmailTemplateNames"
-	GetQuizQuestionsHandlerName           = "GetQuizQuestions"
-	GetCoursesHandlerName                 = "GetCourses"
-	GetUsersAndAssignedCoursesHandlerName = "GetUsersAndAssignedCourses"
+	AddCourseHandlerName                   = "AddCourse"
+	EditCourseHandlerName                  = "EditCourse"
+	DeleteCourseHandlerName                = "DeleteCourse"
+	GetCourseHandlerName                   = "GetCourse"
+	GetCourseMaterialsHandlerName          = "GetCourseMaterials"
+	GetCoursesOverviewHandlerName          = "GetCoursesOverview"
+	GetAssignedCourseTitlesHandlerName     = "GetAssignedCourseTitles"
+	IsEnrolledHandlerName                  = "IsEnrolled"
+	GetVideoURLHandlerName                 = "GetVideoURL"
+	GetVideoUploadURLHandlerName           = "GetVideoUploadURL"
+	GetMaterialUploadURLHandlerName        = "GetMaterialUploadURL"
+	UpdateCourseEnrolmentHandler           = "UpdateCourseEnrolment"
+	EnrolUserInCourseHandlerName           = "EnrolInCourse"
+	DisenrolUserInCourseHandlerName        = "DisenrolInCourse"
+	ResetProgressHandlerName               = "ResetProgress"
+	ResetQuizProgressHandlerName           = "ResetQuizProgress"
+	GetCertificateHandlerName              = "GetCertificate"
+	VerifyCertificateHandlerName           = "VerifyCertificate"
+	RevokeCertificateHandlerName           = "RevokeCertificate"
+	SetCertificateValidityHandlerName      = "SetCertificateValidity"
+	GetAllProgressHandlerName              = "GetAllProgress"
+	GetProgressHandlerName                 = "GetProgress"
+	UpdateProgressHandlerName              = "UpdateProgress"
+	SetCourseCompletedHandlerName          = "SetCourseCompleted"
+	SetIntroCompletedHandlerName           = "SetIntroCompleted"
+	GetUserHandlerName                     = "GetUser"
+	SendEmailHandlerName                   = "SendEmail"
+	GetTemplateNamesHandlerName            = "GetEmailTemplateNames"
+	GetQuizQuestionsHandlerName            = "GetQuizQuestions"
+	GetCoursesHandlerName                  = "GetCourses"
+	GetUsersAndAssignedCoursesHandlerName  = "GetUsersAndAssignedCourses"
+	ListUsersAndAssignedCoursesHandlerName = "ListUsersAndAssignedCourses"
+	ListProgressHandlerName                = "ListProgress"
+	ListCoursesHandlerName                 = "ListCourses"
+	ListQuizSectionsHandlerName            = "ListQuizSections"
+	SetEnrolmentDueDateHandlerName         = "SetEnrolmentDueDate"
+	BulkUpdateEnrolmentsHandlerName        = "BulkUpdateEnrolments"
+	AddGroupHandlerName                    = "AddGroup"
+	AddGroupMembersHandlerName             = "AddGroupMembers"
+	AssignCourseToGroupHandlerName         = "AssignCourseToGroup"
+	AddAccessCodeHandlerName               = "AddAccessCode"
+	RedeemAccessCodeHandlerName            = "RedeemAccessCode"
+	ImportUsersHandlerName                 = "ImportUsers"
+	GetUserImportHandlerName               = "GetUserImport"
+	ListUsersHandlerName                   = "ListUsers"
+	UpdateUserHandlerName                  = "UpdateUser"
+	DeactivateUserHandlerName              = "DeactivateUser"
+	DeleteUserHandlerName                  = "DeleteUser"
+	ExportUserDataHandlerName              = "ExportUserData"
+	EraseUserHandlerName                   = "EraseUser"
+	GetOwnRoleHandlerName                  = "GetOwnRole"
+	SetUserRoleHandlerName                 = "SetUserRole"
+	AddGroupManagersHandlerName            = "AddGroupManagers"
+	AssignCourseInstructorsHandlerName     = "AssignCourseInstructors"
+	SetLineManagerHandlerName              = "SetLineManager"
+	ListTeamProgressHandlerName            = "ListTeamProgress"
+	GetTeamComplianceHandlerName           = "GetTeamCompliance"
+	SignInHandlerName                      = "SignIn"
+	SetPasswordHandlerName                 = "SetPassword"
+	AddAPIKeyHandlerName                   = "AddAPIKey"
+	RevokeAPIKeyHandlerName                = "RevokeAPIKey"
+	ListAuditLogHandlerName                = "ListAuditLog"
+	PreviewEmailHandlerName                = "PreviewEmail"
+	GetEmailPreferencesHandlerName         = "GetEmailPreferences"
+	SetEmailPreferenceHandlerName          = "SetEmailPreference"
+	ListFailedEmailsHandlerName            = "ListFailedEmails"
+	RetryFailedEmailHandlerName            = "RetryFailedEmail"
+	DiscardFailedEmailHandlerName          = "DiscardFailedEmail"
+	GetEmailAttemptsHandlerName            = "GetEmailAttempts"
+	EmailWebhookHandlerName                = "EmailWebhook"
 
 	TestUserID = "test-user-id"
 )
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/auth"
+)
+
+const usersResource = "users"
+
+type ListUsersParams struct {
+	PaginationParams
+	SortBy string `json:"sortBy" validate:"omitempty,oneof=name email"`
+	Status string `json:"status" validate:"omitempty,oneof=active deactivated"`
+}
+
+func (h *Handlers) ListUsers(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ListUsersParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	pageParams, err := params.pageParams(params.SortBy, pageFilters{Status: params.Status})
+	if err != nil {
+		return err
+	}
+
+	users, err := h.User.ListUsers(ctx, pageParams)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(usersResource), err)
+	}
+
+	return e.JSON(http.StatusOK, users)
+}
+
+type UpdateUserParams struct {
+	UserID string `json:"userId" validate:"required"`
+	Name   string `json:"name" validate:"required,max=200"`
+	Email  string `json:"email" validate:"required,email"`
+}
+
+// UpdateUser changes the user's profile in the auth provider first, so a change it rejects,
+// such as an email used by another account, leaves the user unchanged
+func (h *Handlers) UpdateUser(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params UpdateUserParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	before, err := h.User.GetUser(ctx, params.UserID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(userResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Updating(userResource), err)
+	}
+
+	err = h.AuthProvider.UpdateUser(ctx, params.UserID, params.Email, params.Name)
+	if err != nil {
+		if stdErrors.Is(err, auth.ErrEmailAlreadyExists) {
+			return httpError(http.StatusConflict, errors.AlreadyExists("email"), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Updating(userResource), err)
+	}
+
+	var user *domain.User
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		user, err = h.User.UpdateUser(ctx, domain.UpdateUserParams{
+			ID:    params.UserID,
+			Name:  params.Name,
+			Email: params.Email,
+		})
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(userResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionUpdateUser, domain.AuditTargetUser, params.UserID, before, user)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, user)
+}
+
+type UserIDParams struct {
+	UserID string `json:"userId" validate:"required"`
+}
+
+// DeactivateUser stops the user logging in while keeping their enrolments and progress
+func (h *Handlers) DeactivateUser(e echo.Context) error {
+	return h.setUserDeactivated(e, true)
+}
+
+func (h *Handlers) ReactivateUser(e echo.Context) error {
+	return h.setUserDeactivated(e, false)
+}
+
+func (h *Handlers) setUserDeactivated(e echo.Context, deactivated bool) error {
+	ctx := e.Request().Context()
+
+	var params UserIDParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	if deactivated {
+		if err := checkNotOwnAccount(e, params.UserID, "deactivate"); err != nil {
+			return err
+		}
+	}
+
+	if _, err := h.User.GetUser(ctx, params.UserID); err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(userResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Updating(userResource), err)
+	}
+
+	// Disabled in the auth provider first so a deactivated user can't log in if updating their
+	// record fails
+	if err := h.AuthProvider.SetUserDisabled(ctx, params.UserID, deactivated); err != nil {
+		return httpError(http.StatusInternalServerError, errors.Updating(userResource), err)
+	}
+
+	action := domain.AuditActionReactivateUser
+	if deactivated {
+		action = domain.AuditActionDeactivateUser
+	}
+
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		err := h.User.SetUserDeactivated(ctx, domain.SetUserDeactivatedParams{
+			ID:          params.UserID,
+			Deactivated: deactivated,
+		})
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Updating(userResource), err)
+		}
+
+		before := userStatusAuditState{Deactivated: !deactivated}
+		after := userStatusAuditState{Deactivated: deactivated}
+		return auditEntry(ctx, action, domain.AuditTargetUser, params.UserID, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// userStatusAuditState is whether a user is deactivated, as recorded in the audit log
+type userStatusAuditState struct {
+	Deactivated bool `json:"deactivated"`
+}
+
+// DeleteUser permanently removes the user from the auth provider and the database,
+// along with their enrolments and progress
+func (h *Handlers) DeleteUser(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params UserIDParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	if err := checkNotOwnAccount(e, params.UserID, "delete"); err != nil {
+		return err
+	}
+
+	if _, err := h.User.GetUser(ctx, params.UserID); err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(userResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Deleting(userResource), err)
+	}
+
+	// Deleted from the auth provider first so the user can't log in if deleting their records fails
+	if err := h.AuthProvider.DeleteUser(ctx, params.UserID); err != nil {
+		return httpError(http.StatusInternalServerError, errors.Deleting(userResource), err)
+	}
+
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		if err := h.User.DeleteUser(ctx, params.UserID); err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(userResource), err)
+		}
+
+		// The user's details aren't recorded, so they're removed along with the user
+		return auditEntry(ctx, domain.AuditActionDeleteUser, domain.AuditTargetUser, params.UserID, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.NoContent(http.StatusNoContent)
+}
+
+// checkNotOwnAccount stops admins locking themselves out
+func checkNotOwnAccount(e echo.Context, userID, action string) error {
+	currentUserID, ok := getUserID(e.Request().Context())
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	if userID == currentUserID {
+		return httpError(http.StatusBadRequest, errors.OwnAccount(action), nil)
+	}
+
+	return nil
+}
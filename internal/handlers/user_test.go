@@ -0,0 +1,422 @@
+package handlers_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/services/auth"
+)
+
+func TestListUsers_HappyPath(t *testing.T) {
+	t.Run("lists deactivated users matching search", func(t *testing.T) {
+		mockUserRepo := &mocks.UserRepositoryMock{
+			ListUsersFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[domain.User], error) {
+				return &domain.Page[domain.User]{Items: []domain.User{*testhelpers.User}, TotalCount: 1}, nil
+			},
+		}
+
+		h := &handlers.Handlers{User: mockUserRepo}
+
+		req := handlers.ListUsersParams{
+			PaginationParams: handlers.PaginationParams{Search: "user"},
+			SortBy:           "email",
+			Status:           string(domain.UserStatusDeactivated),
+		}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/list")
+
+		err := h.ListUsers(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		calls := mockUserRepo.ListUsersCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ListUsersHandlerName)
+
+		deactivated := true
+		expected := domain.PageParams{SortBy: "email", Search: "user", Deactivated: &deactivated}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("page params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestListUsers_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.ListUsersParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - invalid status",
+			reqBody:        handlers.ListUsersParams{Status: "removed"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.ListUsersParams{},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Getting("users"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				User: &mocks.UserRepositoryMock{
+					ListUsersFunc: func(ctx context.Context, params domain.PageParams) (*domain.Page[domain.User], error) {
+						return nil, tt.repoErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/list")
+			err := h.ListUsers(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestUpdateUser_HappyPath(t *testing.T) {
+	t.Run("updates user in auth provider and database", func(t *testing.T) {
+		mockUserRepo := &mocks.UserRepositoryMock{
+			GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return testhelpers.User, nil
+			},
+			UpdateUserFunc: func(ctx context.Context, params domain.UpdateUserParams) (*domain.User, error) {
+				return &domain.User{ID: params.ID, Name: params.Name, Email: params.Email}, nil
+			},
+		}
+		mockAuthProvider := &mocks.AuthProviderMock{
+			UpdateUserFunc: func(ctx context.Context, id, email, name string) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{User: mockUserRepo, AuthProvider: mockAuthProvider, Audit: newAuditMock()}
+
+		req := handlers.UpdateUserParams{UserID: testhelpers.User.ID, Name: "Renamed", Email: "renamed@example.com"}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/update")
+
+		err := h.UpdateUser(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		providerCalls := mockAuthProvider.UpdateUserCalls()
+		testhelpers.AssertRepoCalls(t, len(providerCalls), 1, testhelpers.UpdateUserHandlerName)
+		if providerCalls[0].Email != req.Email || providerCalls[0].Name != req.Name {
+			t.Errorf("expected auth provider update with %q and %q, got %+v", req.Email, req.Name, providerCalls[0])
+		}
+
+		calls := mockUserRepo.UpdateUserCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.UpdateUserHandlerName)
+
+		expected := domain.UpdateUserParams{ID: req.UserID, Name: req.Name, Email: req.Email}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("update params mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestUpdateUser_UnhappyPath(t *testing.T) {
+	validReq := handlers.UpdateUserParams{UserID: testhelpers.User.ID, Name: "Renamed", Email: "renamed@example.com"}
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.UpdateUserParams
+		getErr         error
+		providerErr    error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - invalid email",
+			reqBody:        handlers.UpdateUserParams{UserID: testhelpers.User.ID, Name: "Renamed", Email: "not-an-email"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "user not found",
+			reqBody:        validReq,
+			getErr:         pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user"),
+		},
+		{
+			name:           "email used by another account",
+			reqBody:        validReq,
+			providerErr:    auth.ErrEmailAlreadyExists,
+			wantStatus:     http.StatusConflict,
+			expectedErrMsg: errors.AlreadyExists("email"),
+		},
+		{
+			name:           "auth provider error",
+			reqBody:        validReq,
+			providerErr:    stdErrors.New("firebase error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("user"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.UserRepositoryMock{
+				GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+					return testhelpers.User, tt.getErr
+				},
+			}
+			h := &handlers.Handlers{
+				User: mockUserRepo,
+				AuthProvider: &mocks.AuthProviderMock{
+					UpdateUserFunc: func(ctx context.Context, id, email, name string) error {
+						return tt.providerErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/update")
+			err := h.UpdateUser(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+			testhelpers.AssertRepoCalls(t, len(mockUserRepo.UpdateUserCalls()), 0, testhelpers.UpdateUserHandlerName)
+		})
+	}
+}
+
+func TestDeactivateUser_HappyPath(t *testing.T) {
+	t.Run("deactivates user and disables them in auth provider", func(t *testing.T) {
+		mockUserRepo := &mocks.UserRepositoryMock{
+			GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return testhelpers.User, nil
+			},
+			SetUserDeactivatedFunc: func(ctx context.Context, params domain.SetUserDeactivatedParams) error {
+				return nil
+			},
+		}
+		mockAuthProvider := &mocks.AuthProviderMock{
+			SetUserDisabledFunc: func(ctx context.Context, id string, disabled bool) error {
+				return nil
+			},
+		}
+
+		auditRepo := newAuditMock()
+		h := &handlers.Handlers{User: mockUserRepo, AuthProvider: mockAuthProvider, Audit: auditRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.UserIDParams{UserID: testhelpers.User.ID}, "users/deactivate")
+
+		err := h.DeactivateUser(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		calls := mockUserRepo.SetUserDeactivatedCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.DeactivateUserHandlerName)
+
+		expected := domain.SetUserDeactivatedParams{ID: testhelpers.User.ID, Deactivated: true}
+		if diff := cmp.Diff(expected, calls[0].Params); diff != "" {
+			t.Errorf("deactivate params mismatch (-want +got):\n%s", diff)
+		}
+
+		providerCalls := mockAuthProvider.SetUserDisabledCalls()
+		testhelpers.AssertRepoCalls(t, len(providerCalls), 1, testhelpers.DeactivateUserHandlerName)
+		if !providerCalls[0].Disabled {
+			t.Error("expected user to be disabled in auth provider")
+		}
+
+		auditCalls := auditRepo.AddAuditEntryCalls()
+		testhelpers.AssertRepoCalls(t, len(auditCalls), 1, testhelpers.DeactivateUserHandlerName)
+		if auditCalls[0].Params.Action != domain.AuditActionDeactivateUser {
+			t.Errorf("expected audit action %s, got %s", domain.AuditActionDeactivateUser, auditCalls[0].Params.Action)
+		}
+	})
+}
+
+func TestDeactivateUser_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name            string
+		reqBody         handlers.UserIDParams
+		getErr          error
+		providerErr     error
+		repoErr         error
+		wantStatus      int
+		expectedErrMsg  string
+		wantDisabled    int
+		wantDeactivated int
+	}{
+		{
+			name:           "validation error - missing user id",
+			reqBody:        handlers.UserIDParams{},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "own account",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.TestUserID},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.OwnAccount("deactivate"),
+		},
+		{
+			name:           "user not found",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.User.ID},
+			getErr:         pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user"),
+		},
+		{
+			name:           "auth provider error leaves the user active",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.User.ID},
+			providerErr:    stdErrors.New("firebase error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Updating("user"),
+			wantDisabled:   1,
+		},
+		{
+			name:            "internal server error",
+			reqBody:         handlers.UserIDParams{UserID: testhelpers.User.ID},
+			repoErr:         stdErrors.New("db error"),
+			wantStatus:      http.StatusInternalServerError,
+			expectedErrMsg:  errors.Updating("user"),
+			wantDisabled:    1,
+			wantDeactivated: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.UserRepositoryMock{
+				GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+					return testhelpers.User, tt.getErr
+				},
+				SetUserDeactivatedFunc: func(ctx context.Context, params domain.SetUserDeactivatedParams) error {
+					return tt.repoErr
+				},
+			}
+			mockAuthProvider := &mocks.AuthProviderMock{
+				SetUserDisabledFunc: func(ctx context.Context, id string, disabled bool) error {
+					return tt.providerErr
+				},
+			}
+			h := &handlers.Handlers{User: mockUserRepo, AuthProvider: mockAuthProvider, Audit: newAuditMock()}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/deactivate")
+			err := h.DeactivateUser(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+			testhelpers.AssertRepoCalls(t, len(mockAuthProvider.SetUserDisabledCalls()), tt.wantDisabled, testhelpers.DeactivateUserHandlerName)
+			testhelpers.AssertRepoCalls(t, len(mockUserRepo.SetUserDeactivatedCalls()), tt.wantDeactivated, testhelpers.DeactivateUserHandlerName)
+		})
+	}
+}
+
+func TestDeleteUser_HappyPath(t *testing.T) {
+	t.Run("deletes user from auth provider and database", func(t *testing.T) {
+		mockUserRepo := &mocks.UserRepositoryMock{
+			GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return testhelpers.User, nil
+			},
+			DeleteUserFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		}
+		mockAuthProvider := &mocks.AuthProviderMock{
+			DeleteUserFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{User: mockUserRepo, AuthProvider: mockAuthProvider, Audit: newAuditMock()}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.UserIDParams{UserID: testhelpers.User.ID}, "users/delete")
+
+		err := h.DeleteUser(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, rec.Code)
+		}
+
+		testhelpers.AssertRepoCalls(t, len(mockAuthProvider.DeleteUserCalls()), 1, testhelpers.DeleteUserHandlerName)
+		testhelpers.AssertRepoCalls(t, len(mockUserRepo.DeleteUserCalls()), 1, testhelpers.DeleteUserHandlerName)
+	})
+}
+
+func TestDeleteUser_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.UserIDParams
+		getErr         error
+		providerErr    error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "own account",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.TestUserID},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.OwnAccount("delete"),
+		},
+		{
+			name:           "user not found",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.User.ID},
+			getErr:         pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user"),
+		},
+		{
+			name:           "auth provider error",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.User.ID},
+			providerErr:    stdErrors.New("firebase error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Deleting("user"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.UserRepositoryMock{
+				GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+					return testhelpers.User, tt.getErr
+				},
+			}
+			h := &handlers.Handlers{
+				User: mockUserRepo,
+				AuthProvider: &mocks.AuthProviderMock{
+					DeleteUserFunc: func(ctx context.Context, id string) error {
+						return tt.providerErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/delete")
+			err := h.DeleteUser(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+			testhelpers.AssertRepoCalls(t, len(mockUserRepo.DeleteUserCalls()), 0, testhelpers.DeleteUserHandlerName)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+)
+
+const (
+	userDataResource = "user data"
+
+	userDataFormatJSON = "json"
+	userDataFormatZip  = "zip"
+)
+
+type ExportUserDataParams struct {
+	UserID string `json:"userId" validate:"required"`
+	// Format defaults to json, zip returns a bundle with a JSON file per type of record
+	Format string `json:"format" validate:"omitempty,oneof=json zip"`
+}
+
+// ExportUserData returns everything held about a user, for subject access requests
+func (h *Handlers) ExportUserData(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params ExportUserDataParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	export, err := h.User.ExportUserData(ctx, params.UserID)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(userResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Getting(userDataResource), err)
+	}
+
+	if params.Format != userDataFormatZip {
+		e.Response().Header().Set(echo.HeaderContentDisposition, userDataFilename(params.UserID, userDataFormatJSON))
+		return e.JSON(http.StatusOK, export)
+	}
+
+	bundle, err := userDataZip(export)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(userDataResource), err)
+	}
+
+	e.Response().Header().Set(echo.HeaderContentDisposition, userDataFilename(params.UserID, userDataFormatZip))
+	return e.Blob(http.StatusOK, "application/zip", bundle)
+}
+
+func userDataFilename(userID, format string) string {
+	return fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("user-data-%s.%s", userID, format))
+}
+
+func userDataZip(export *domain.UserDataExport) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"user.json", export.User},
+		{"enrolments.json", export.Enrolments},
+		{"progress.json", export.Progress},
+		{"quiz-states.json", export.QuizStates},
+		{"quiz-attempts.json", export.QuizAttempts},
+		{"groups.json", export.Groups},
+		{"access-code-redemptions.json", export.AccessCodeRedemptions},
+		{"emails.json", export.Emails},
+	}
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		fileWriter, err := writer.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(fileWriter)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// EraseUser removes the user from the auth provider and erases their personal data. Completed courses
+// are kept against an anonymised user so completion statistics are unaffected.
+func (h *Handlers) EraseUser(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params UserIDParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	if err := checkNotOwnAccount(e, params.UserID, "erase"); err != nil {
+		return err
+	}
+
+	if _, err := h.User.GetUser(ctx, params.UserID); err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(userResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Deleting(userDataResource), err)
+	}
+
+	if err := h.AuthProvider.DeleteUser(ctx, params.UserID); err != nil {
+		return httpError(http.StatusInternalServerError, errors.Deleting(userDataResource), err)
+	}
+
+	var erased *domain.ErasedUser
+	err := h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		erased, err = h.User.EraseUser(ctx, params.UserID)
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Deleting(userDataResource), err)
+		}
+
+		// Only what was kept is recorded, the erased personal data isn't
+		return auditEntry(ctx, domain.AuditActionEraseUser, domain.AuditTargetUser, params.UserID, nil, erased)
+	})
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, erased)
+}
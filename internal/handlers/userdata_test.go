@@ -0,0 +1,262 @@
+package handlers_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+)
+
+var userDataExport = &domain.UserDataExport{
+	ExportedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	User:       *testhelpers.User,
+	Enrolments: []domain.UserDataEnrolment{
+		{CourseID: testhelpers.Course.ID, CourseTitle: testhelpers.Course.Title, EnrolledAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	},
+	Progress:              []domain.UserDataProgress{},
+	QuizStates:            []domain.UserDataQuizState{},
+	QuizAttempts:          []domain.UserDataQuizAttempt{},
+	Groups:                []domain.UserDataGroup{},
+	AccessCodeRedemptions: []domain.UserDataAccessCodeRedemption{},
+	Emails:                []domain.UserDataEmail{},
+}
+
+func TestExportUserData_HappyPath(t *testing.T) {
+	t.Run("exports user data as json", func(t *testing.T) {
+		mockUserRepo := &mocks.UserRepositoryMock{
+			ExportUserDataFunc: func(ctx context.Context, id string) (*domain.UserDataExport, error) {
+				return userDataExport, nil
+			},
+		}
+
+		h := &handlers.Handlers{User: mockUserRepo}
+
+		req := handlers.ExportUserDataParams{UserID: testhelpers.User.ID}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/export")
+
+		err := h.ExportUserData(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		calls := mockUserRepo.ExportUserDataCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.ExportUserDataHandlerName)
+
+		var actual domain.UserDataExport
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(*userDataExport, actual); diff != "" {
+			t.Errorf("export mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("exports user data as zip bundle", func(t *testing.T) {
+		h := &handlers.Handlers{
+			User: &mocks.UserRepositoryMock{
+				ExportUserDataFunc: func(ctx context.Context, id string) (*domain.UserDataExport, error) {
+					return userDataExport, nil
+				},
+			},
+		}
+
+		req := handlers.ExportUserDataParams{UserID: testhelpers.User.ID, Format: "zip"}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/export")
+
+		err := h.ExportUserData(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if contentType := rec.Header().Get("Content-Type"); contentType != "application/zip" {
+			t.Errorf("expected zip content type, got %q", contentType)
+		}
+
+		reader, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+		if err != nil {
+			t.Fatalf("failed to read zip: %v", err)
+		}
+
+		names := make([]string, 0, len(reader.File))
+		for _, file := range reader.File {
+			names = append(names, file.Name)
+		}
+
+		for _, expected := range []string{"user.json", "enrolments.json", "progress.json", "emails.json"} {
+			if !slices.Contains(names, expected) {
+				t.Errorf("expected %s in bundle, got %v", expected, names)
+			}
+		}
+	})
+}
+
+func TestExportUserData_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.ExportUserDataParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - invalid format",
+			reqBody:        handlers.ExportUserDataParams{UserID: testhelpers.User.ID, Format: "csv"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "user not found",
+			reqBody:        handlers.ExportUserDataParams{UserID: testhelpers.User.ID},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.ExportUserDataParams{UserID: testhelpers.User.ID},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Getting("user data"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				User: &mocks.UserRepositoryMock{
+					ExportUserDataFunc: func(ctx context.Context, id string) (*domain.UserDataExport, error) {
+						return nil, tt.repoErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/export")
+			err := h.ExportUserData(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
+
+func TestEraseUser_HappyPath(t *testing.T) {
+	t.Run("erases user from auth provider and database", func(t *testing.T) {
+		erased := &domain.ErasedUser{ID: "erased-user", RetainedCompletions: 2}
+
+		mockUserRepo := &mocks.UserRepositoryMock{
+			GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+				return testhelpers.User, nil
+			},
+			EraseUserFunc: func(ctx context.Context, id string) (*domain.ErasedUser, error) {
+				return erased, nil
+			},
+		}
+		mockAuthProvider := &mocks.AuthProviderMock{
+			DeleteUserFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		}
+
+		h := &handlers.Handlers{User: mockUserRepo, AuthProvider: mockAuthProvider, Audit: newAuditMock()}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.UserIDParams{UserID: testhelpers.User.ID}, "users/erase")
+
+		err := h.EraseUser(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		testhelpers.AssertRepoCalls(t, len(mockAuthProvider.DeleteUserCalls()), 1, testhelpers.EraseUserHandlerName)
+
+		calls := mockUserRepo.EraseUserCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.EraseUserHandlerName)
+		if calls[0].Id != testhelpers.User.ID {
+			t.Errorf("expected user %s to be erased, got %s", testhelpers.User.ID, calls[0].Id)
+		}
+
+		var actual domain.ErasedUser
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if diff := cmp.Diff(*erased, actual); diff != "" {
+			t.Errorf("erased user mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestEraseUser_UnhappyPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		reqBody        handlers.UserIDParams
+		getErr         error
+		providerErr    error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "own account",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.TestUserID},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.OwnAccount("erase"),
+		},
+		{
+			name:           "user not found",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.User.ID},
+			getErr:         pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user"),
+		},
+		{
+			name:           "auth provider error",
+			reqBody:        handlers.UserIDParams{UserID: testhelpers.User.ID},
+			providerErr:    stdErrors.New("firebase error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Deleting("user data"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := &mocks.UserRepositoryMock{
+				GetUserFunc: func(ctx context.Context, id string) (*domain.User, error) {
+					return testhelpers.User, tt.getErr
+				},
+			}
+			h := &handlers.Handlers{
+				User: mockUserRepo,
+				AuthProvider: &mocks.AuthProviderMock{
+					DeleteUserFunc: func(ctx context.Context, id string) error {
+						return tt.providerErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/erase")
+			err := h.EraseUser(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+			testhelpers.AssertRepoCalls(t, len(mockUserRepo.EraseUserCalls()), 0, testhelpers.EraseUserHandlerName)
+		})
+	}
+}
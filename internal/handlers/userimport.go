@@ -0,0 +1,605 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const userImportResource = "user import"
+
+const (
+	// maxUserImportRows limits the number of users in a single CSV import. Rows are validated before
+	// the import starts, which checks each email isn't already used, so the limit keeps that within
+	// the server's write timeout.
+	maxUserImportRows = 1000
+	// importUserWorkers is how many users are imported at once. Most of a user's import is spent
+	// waiting on the auth provider, but each import also holds a database connection while the user
+	// is registered, so only a few run at once.
+	importUserWorkers = 4
+	// importedUserPasswordLength is the length of the random password imported users are created with,
+	// it is never shared, users choose their own password through the invitation link
+	importedUserPasswordLength = 32
+
+	importNameColumn    = "name"
+	importEmailColumn   = "email"
+	importGroupsColumn  = "groups"
+	importCoursesColumn = "courses"
+	importListSeparator = ";"
+	// importFirstRow is the CSV line number of the first user, after the header
+	importFirstRow = 2
+)
+
+type ImportUsersParams struct {
+	CSV    string `json:"csv" validate:"required"`
+	DryRun bool   `json:"dryRun"`
+}
+
+type UserImportParams struct {
+	ID string `json:"id" validate:"required"`
+}
+
+type ImportUserStatus string
+
+const (
+	// ImportUserStatusValid is only used in dry runs, for rows that would be imported
+	ImportUserStatusValid ImportUserStatus = "valid"
+	// ImportUserStatusPending is used for valid rows until the import has created their user
+	ImportUserStatusPending ImportUserStatus = "pending"
+	ImportUserStatusCreated ImportUserStatus = "created"
+	ImportUserStatusFailed  ImportUserStatus = "failed"
+)
+
+// ImportUserResult reports the outcome of a single CSV row. A created user can still have errors
+// when a later step, such as enrolment or sending the invitation, fails.
+type ImportUserResult struct {
+	Row            int              `json:"row"`
+	Email          string           `json:"email"`
+	UserID         string           `json:"userId,omitempty"`
+	Status         ImportUserStatus `json:"status"`
+	InvitationSent bool             `json:"invitationSent"`
+	Errors         []string         `json:"errors,omitempty"`
+}
+
+// ImportUsersResponse is the report of an import. Imports that aren't dry runs run in the
+// background, their report is fetched with the import's ID until its status is completed.
+type ImportUsersResponse struct {
+	ID      *uuid.UUID              `json:"id,omitempty"`
+	Status  domain.UserImportStatus `json:"status,omitempty"`
+	DryRun  bool                    `json:"dryRun"`
+	Valid   int                     `json:"valid"`
+	Pending int                     `json:"pending"`
+	Created int                     `json:"created"`
+	Failed  int                     `json:"failed"`
+	Results []ImportUserResult      `json:"results"`
+}
+
+type importRow struct {
+	row       int
+	name      string
+	email     string
+	groups    []string
+	courses   []string
+	groupIDs  []uuid.UUID
+	courseIDs []uuid.UUID
+}
+
+// importUserAuditState is what the audit log records for each imported user
+type importUserAuditState struct {
+	Name      string      `json:"name"`
+	Email     string      `json:"email"`
+	GroupIDs  []uuid.UUID `json:"groupIds,omitempty"`
+	CourseIDs []uuid.UUID `json:"courseIds,omitempty"`
+}
+
+// importLookup resolves the group names and course titles or IDs used in a CSV import
+type importLookup struct {
+	groupsByName   map[string]uuid.UUID
+	coursesByTitle map[string][]uuid.UUID
+	courseIDs      map[uuid.UUID]bool
+}
+
+// ImportUsers creates users from a CSV with the columns name, email, groups and courses. Groups and
+// courses are lists separated by semicolons, of group names and of course titles or IDs. Each user is
+// added to their groups, enrolled in their courses and sent an invitation to set their password.
+// Rows are handled independently so one invalid row doesn't stop the rest of the import.
+// Rows are validated straight away, then the valid rows are imported in the background and the
+// import's ID is returned to fetch its report with.
+func (h *Handlers) ImportUsers(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	adminID, ok := getUserID(ctx)
+	if !ok {
+		return httpError(http.StatusInternalServerError, errors.NotFoundInCtx("user"), nil)
+	}
+
+	var params ImportUsersParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	rows, err := parseImportCSV(params.CSV)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidFormat("CSV"), err)
+	}
+
+	if len(rows) > maxUserImportRows {
+		return httpError(http.StatusBadRequest, errors.TooMany("users", maxUserImportRows), nil)
+	}
+
+	lookup, err := h.newImportLookup(ctx)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting("groups and courses"), err)
+	}
+
+	response := &ImportUsersResponse{
+		DryRun:  params.DryRun,
+		Results: h.validateImportRows(ctx, rows, lookup, params.DryRun),
+	}
+	response.count()
+
+	if params.DryRun {
+		return e.JSON(http.StatusOK, response)
+	}
+
+	// The background import has its own copy of the report, so it can't change the response
+	report := *response
+	report.Results = slices.Clone(response.Results)
+
+	reportJSON, err := json.Marshal(&report)
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Creating(userImportResource), err)
+	}
+
+	id, err := h.UserImport.AddUserImport(ctx, domain.AddUserImportParams{Report: reportJSON, CreatedBy: adminID})
+	if err != nil {
+		return httpError(http.StatusInternalServerError, errors.Creating(userImportResource), err)
+	}
+
+	// The import outlives the request, but keeps its values for the audit log and logs
+	importCtx := context.WithoutCancel(ctx)
+	h.imports.Go(func() {
+		h.runImport(importCtx, id, rows, adminID, &report)
+	})
+
+	response.ID = &id
+	response.Status = domain.UserImportStatusRunning
+
+	return e.JSON(http.StatusAccepted, response)
+}
+
+// GetUserImport returns the report of an import. Rows of a running import stay pending until
+// the import completes.
+func (h *Handlers) GetUserImport(e echo.Context) error {
+	ctx := e.Request().Context()
+
+	var params UserImportParams
+	if err := bindAndValidate(e, &params); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(params.ID)
+	if err != nil {
+		return httpError(http.StatusBadRequest, errors.InvalidUUID, err)
+	}
+
+	userImport, err := h.UserImport.GetUserImport(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			return httpError(http.StatusNotFound, errors.NotFound(userImportResource), err)
+		}
+
+		return httpError(http.StatusInternalServerError, errors.Getting(userImportResource), err)
+	}
+
+	var response ImportUsersResponse
+	if err := json.Unmarshal(userImport.Report, &response); err != nil {
+		return httpError(http.StatusInternalServerError, errors.Getting(userImportResource), err)
+	}
+	response.ID = &userImport.ID
+	response.Status = userImport.Status
+
+	return e.JSON(http.StatusOK, response)
+}
+
+// WaitForImports waits for imports running in the background to finish, returning false if ctx is
+// done first
+func (h *Handlers) WaitForImports(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		h.imports.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// validateImportRows returns a result for each row, with the rows that can be imported valid in a
+// dry run and pending otherwise
+func (h *Handlers) validateImportRows(ctx context.Context, rows []importRow, lookup *importLookup, dryRun bool) []ImportUserResult {
+	results := make([]ImportUserResult, 0, len(rows))
+	seenEmails := map[string]int{}
+
+	for i := range rows {
+		row := &rows[i]
+		result := ImportUserResult{Row: row.row, Email: row.email}
+
+		result.Errors = h.validateImportRow(ctx, row, lookup, seenEmails)
+		switch {
+		case len(result.Errors) > 0:
+			result.Status = ImportUserStatusFailed
+		case dryRun:
+			result.Status = ImportUserStatusValid
+		default:
+			result.Status = ImportUserStatusPending
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// runImport imports the pending rows, importUserWorkers at a time, then saves the report. Results
+// are in the same order as rows, and each row's result is only written by the worker importing it.
+func (h *Handlers) runImport(ctx context.Context, id uuid.UUID, rows []importRow, adminID string, report *ImportUsersResponse) {
+	workers := make(chan struct{}, importUserWorkers)
+	var wg sync.WaitGroup
+
+	for i := range report.Results {
+		if report.Results[i].Status != ImportUserStatusPending {
+			continue
+		}
+
+		workers <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-workers }()
+			h.importUser(ctx, &rows[i], adminID, &report.Results[i])
+		})
+	}
+	wg.Wait()
+
+	report.count()
+	slog.InfoContext(
+		ctx,
+		"user import completed",
+		slog.String("import_id", id.String()),
+		slog.Int("created", report.Created),
+		slog.Int("failed", report.Failed),
+	)
+
+	reportJSON, err := json.Marshal(report)
+	if err == nil {
+		err = h.UserImport.CompleteUserImport(ctx, domain.CompleteUserImportParams{ID: id, Report: reportJSON})
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to save user import report", slog.Any("error", err), slog.String("import_id", id.String()))
+	}
+}
+
+// count totals the results by status
+func (r *ImportUsersResponse) count() {
+	r.Valid, r.Pending, r.Created, r.Failed = 0, 0, 0, 0
+
+	for _, result := range r.Results {
+		switch result.Status {
+		case ImportUserStatusValid:
+			r.Valid++
+		case ImportUserStatusPending:
+			r.Pending++
+		case ImportUserStatusCreated:
+			r.Created++
+		case ImportUserStatusFailed:
+			r.Failed++
+		}
+	}
+}
+
+func parseImportCSV(data string) ([]importRow, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.Wrap("CSV is empty")
+	}
+
+	columns := map[string]int{}
+	for i, header := range records[0] {
+		header = strings.TrimPrefix(header, "\ufeff") // byte order mark added by some spreadsheet exports
+		columns[strings.ToLower(strings.TrimSpace(header))] = i
+	}
+
+	for _, column := range []string{importNameColumn, importEmailColumn} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("missing %q column", column)
+		}
+	}
+
+	rows := make([]importRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		rows = append(rows, importRow{
+			row:     i + importFirstRow,
+			name:    csvCell(record, columns, importNameColumn),
+			email:   csvCell(record, columns, importEmailColumn),
+			groups:  splitImportList(csvCell(record, columns, importGroupsColumn)),
+			courses: splitImportList(csvCell(record, columns, importCoursesColumn)),
+		})
+	}
+
+	return rows, nil
+}
+
+func csvCell(record []string, columns map[string]int, column string) string {
+	i, ok := columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+func splitImportList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, importListSeparator) {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+
+	return items
+}
+
+func (h *Handlers) newImportLookup(ctx context.Context) (*importLookup, error) {
+	groups, err := h.Group.GetGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := h.Course.GetCoursesOverview(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := &importLookup{
+		groupsByName:   make(map[string]uuid.UUID, len(groups)),
+		coursesByTitle: make(map[string][]uuid.UUID, len(courses)),
+		courseIDs:      make(map[uuid.UUID]bool, len(courses)),
+	}
+
+	for _, group := range groups {
+		lookup.groupsByName[strings.ToLower(group.Name)] = group.ID
+	}
+
+	for _, course := range courses {
+		title := strings.ToLower(course.Title)
+		lookup.coursesByTitle[title] = append(lookup.coursesByTitle[title], course.ID)
+		lookup.courseIDs[course.ID] = true
+	}
+
+	return lookup, nil
+}
+
+// validateImportRow checks a row can be imported and resolves its group and course IDs,
+// returning every problem found with the row
+func (h *Handlers) validateImportRow(ctx context.Context, row *importRow, lookup *importLookup, seenEmails map[string]int) []string {
+	var rowErrs []string
+
+	if row.name == "" {
+		rowErrs = append(rowErrs, "name is required")
+	}
+
+	rowErrs = append(rowErrs, h.validateImportEmail(ctx, row, seenEmails)...)
+
+	for _, name := range row.groups {
+		groupID, ok := lookup.groupsByName[strings.ToLower(name)]
+		if !ok {
+			rowErrs = append(rowErrs, fmt.Sprintf("group %q not found", name))
+			continue
+		}
+		row.groupIDs = append(row.groupIDs, groupID)
+	}
+
+	for _, course := range row.courses {
+		courseID, err := lookup.resolveCourse(course)
+		if err != nil {
+			rowErrs = append(rowErrs, err.Error())
+			continue
+		}
+		row.courseIDs = append(row.courseIDs, courseID)
+	}
+
+	row.groupIDs = utils.Unique(row.groupIDs)
+	row.courseIDs = utils.Unique(row.courseIDs)
+
+	return rowErrs
+}
+
+func (h *Handlers) validateImportEmail(ctx context.Context, row *importRow, seenEmails map[string]int) []string {
+	if row.email == "" {
+		return []string{"email is required"}
+	}
+
+	address, err := mail.ParseAddress(row.email)
+	if err != nil || address.Address != row.email {
+		return []string{"invalid email"}
+	}
+
+	key := strings.ToLower(row.email)
+	if firstRow, ok := seenEmails[key]; ok {
+		return []string{fmt.Sprintf("duplicate email, first used on row %d", firstRow)}
+	}
+	seenEmails[key] = row.row
+
+	_, err = h.User.GetUserByEmail(ctx, row.email)
+	if err == nil {
+		return []string{errors.AlreadyExists(userResource)}
+	}
+	if !errors.IsNotFoundErr(err) {
+		slog.ErrorContext(ctx, "failed to check for existing user", slog.Any("error", err), slog.Int("row", row.row))
+		return []string{errors.Getting(userResource)}
+	}
+
+	return nil
+}
+
+// resolveCourse matches a course by ID, or by title when the title is unique
+func (l *importLookup) resolveCourse(course string) (uuid.UUID, error) {
+	if courseID, err := uuid.Parse(course); err == nil {
+		if !l.courseIDs[courseID] {
+			return uuid.Nil, fmt.Errorf("course %q not found", course)
+		}
+		return courseID, nil
+	}
+
+	courseIDs := l.coursesByTitle[strings.ToLower(course)]
+	switch len(courseIDs) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("course %q not found", course)
+	case 1:
+		return courseIDs[0], nil
+	default:
+		return uuid.Nil, fmt.Errorf("more than one course is titled %q, use the course ID instead", course)
+	}
+}
+
+// importUser creates the user from a validated row. The user is reported as created once they exist,
+// failures in the steps after that are added to the result errors.
+func (h *Handlers) importUser(ctx context.Context, row *importRow, adminID string, result *ImportUserResult) {
+	result.Status = ImportUserStatusFailed
+
+	password, err := utils.RandomCode(importedUserPasswordLength)
+	if err != nil {
+		h.addImportError(ctx, result, errors.Creating(userResource), err)
+		return
+	}
+
+	userID, err := h.AuthProvider.CreateUser(ctx, row.email, password, row.name)
+	if err != nil {
+		h.addImportError(ctx, result, errors.Creating(userResource), err)
+		return
+	}
+
+	// The import is recorded with the registration, the groups and courses are what the row asked for
+	after := importUserAuditState{Name: row.name, Email: row.email, GroupIDs: row.groupIDs, CourseIDs: row.courseIDs}
+	var user *domain.User
+	err = h.audited(ctx, func(ctx context.Context) (*domain.AddAuditEntryParams, error) {
+		var err error
+		user, err = h.Auth.RegisterUser(ctx, domain.RegisterParams{
+			ID:    userID,
+			Name:  row.name,
+			Email: row.email,
+		})
+		if err != nil {
+			return nil, httpError(http.StatusInternalServerError, errors.Creating(userResource), err)
+		}
+
+		return auditEntry(ctx, domain.AuditActionImportUser, domain.AuditTargetUser, user.ID, nil, after)
+	})
+	if err != nil {
+		h.addImportError(ctx, result, importErrorMessage(err), err)
+		return
+	}
+
+	result.UserID = user.ID
+	result.Status = ImportUserStatusCreated
+
+	for _, groupID := range row.groupIDs {
+		err := h.Group.AddGroupMembers(ctx, domain.GroupMembersParams{GroupID: groupID, UserIDs: []string{user.ID}})
+		if err != nil {
+			h.addImportError(ctx, result, errors.Updating(groupMembersResource), err)
+		}
+	}
+
+	if len(row.courseIDs) > 0 {
+		_, err := h.Enrolment.BulkUpdateEnrolments(ctx, domain.BulkUpdateEnrolmentsParams{
+			UserIDs:    []string{user.ID},
+			CourseIDs:  row.courseIDs,
+			Action:     domain.BulkEnrolmentActionEnrol,
+			EnrolledBy: adminID,
+		})
+		if err != nil {
+			h.addImportError(ctx, result, errors.Updating("enrolments"), err)
+		}
+	}
+
+	if err := h.sendInvitation(ctx, row.name, row.email); err != nil {
+		h.addImportError(ctx, result, "Error sending invitation email", err)
+		return
+	}
+	result.InvitationSent = true
+}
+
+func (h *Handlers) addImportError(ctx context.Context, result *ImportUserResult, message string, err error) {
+	slog.ErrorContext(
+		ctx,
+		"user import row failed",
+		slog.Any("error", err),
+		slog.String("message", message),
+		slog.Int("row", result.Row),
+	)
+	result.Errors = append(result.Errors, message)
+}
+
+// importErrorMessage is the message of an error from registering an imported user, which is
+// either failing to create the user or its audit entry
+func importErrorMessage(err error) string {
+	var httpErr *echo.HTTPError
+	if stdErrors.As(err, &httpErr) {
+		if message, ok := httpErr.Message.(string); ok {
+			return message
+		}
+	}
+
+	return errors.Creating(userResource)
+}
+
+// sendInvitation queues an email with a link for the user to set their password. Failed sends are
+// retried, but the admin recipient is not copied in as the link gives access to the account.
+func (h *Handlers) sendInvitation(ctx context.Context, name, userEmail string) error {
+	link, err := h.AuthProvider.GetPasswordSetupLink(ctx, userEmail)
+	if err != nil {
+		return err
+	}
+
+	return h.EmailService.Send(
+		ctx,
+		&email.InvitationParams{
+			UserName:        name,
+			UserEmail:       userEmail,
+			SetPasswordLink: link,
+		},
+		h.EmailService.GetTemplateNames().Invitation,
+		h.EmailService.GetEmailNames().Invitation,
+	)
+}
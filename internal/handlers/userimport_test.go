@@ -0,0 +1,517 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/handlers/testhelpers"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+)
+
+const importCSV = `name,email,groups,courses
+New Learner,new@example.com,Sales,Test Course
+,invalid-email,Unknown Group,
+Existing User,usera@gmail.com,,
+Second Learner,NEW@example.com,,
+`
+
+type importMocks struct {
+	imports    *mocks.UserImportRepositoryMock
+	auth       *mocks.AuthRepositoryMock
+	provider   *mocks.AuthProviderMock
+	group      *mocks.GroupRepositoryMock
+	enrolment  *mocks.EnrolmentRepositoryMock
+	emails     *mocks.EmailServiceMock
+	importID   uuid.UUID
+	newUserID  string
+	salesGroup uuid.UUID
+}
+
+func setupImportHandlers() (*handlers.Handlers, *importMocks) {
+	m := &importMocks{
+		importID:   uuid.New(),
+		newUserID:  uuid.New().String(),
+		salesGroup: uuid.New(),
+	}
+
+	m.imports = &mocks.UserImportRepositoryMock{
+		AddUserImportFunc: func(context.Context, domain.AddUserImportParams) (uuid.UUID, error) {
+			return m.importID, nil
+		},
+		CompleteUserImportFunc: func(context.Context, domain.CompleteUserImportParams) error {
+			return nil
+		},
+	}
+	m.auth = &mocks.AuthRepositoryMock{
+		RegisterUserFunc: func(ctx context.Context, params domain.RegisterParams) (*domain.User, error) {
+			return &domain.User{ID: params.ID, Name: params.Name, Email: params.Email}, nil
+		},
+	}
+	m.provider = &mocks.AuthProviderMock{
+		CreateUserFunc: func(_ context.Context, _, _, _ string) (string, error) {
+			return m.newUserID, nil
+		},
+		GetPasswordSetupLinkFunc: func(_ context.Context, email string) (string, error) {
+			return "https://example.com/set-password?email=" + email, nil
+		},
+	}
+	m.group = &mocks.GroupRepositoryMock{
+		GetGroupsFunc: func(context.Context) ([]domain.Group, error) {
+			return []domain.Group{{ID: m.salesGroup, Name: "Sales"}}, nil
+		},
+		AddGroupMembersFunc: func(context.Context, domain.GroupMembersParams) error {
+			return nil
+		},
+	}
+	m.enrolment = &mocks.EnrolmentRepositoryMock{
+		BulkUpdateEnrolmentsFunc: func(context.Context, domain.BulkUpdateEnrolmentsParams) ([]domain.BulkEnrolmentResult, error) {
+			return nil, nil
+		},
+	}
+	m.emails = &mocks.EmailServiceMock{
+		SendFunc: func(context.Context, email.EmailParams, string, string) error {
+			return nil
+		},
+		GetTemplateNamesFunc: func() *email.TemplateNames {
+			return &email.TemplateNames{Invitation: "invitation-template"}
+		},
+		GetEmailNamesFunc: func() *email.EmailNames {
+			return &email.EmailNames{Invitation: "invitation"}
+		},
+	}
+
+	h := &handlers.Handlers{
+		UserImport:   m.imports,
+		Audit:        newAuditMock(),
+		Auth:         m.auth,
+		AuthProvider: m.provider,
+		Group:        m.group,
+		Enrolment:    m.enrolment,
+		EmailService: m.emails,
+		Course: &mocks.CourseRepositoryMock{
+			GetCoursesOverviewFunc: func(context.Context) ([]domain.CourseOverview, error) {
+				return []domain.CourseOverview{{ID: testhelpers.Course.ID, Title: testhelpers.Course.Title}}, nil
+			},
+		},
+		User: &mocks.UserRepositoryMock{
+			GetUserByEmailFunc: func(_ context.Context, email string) (*domain.User, error) {
+				if email == testhelpers.User.Email {
+					return testhelpers.User, nil
+				}
+				return nil, pgx.ErrNoRows
+			},
+		},
+	}
+
+	return h, m
+}
+
+// runImport starts an import of csv and waits for it to complete, returning the response to
+// starting it and the report it completed with
+func runImport(t *testing.T, h *handlers.Handlers, m *importMocks, csv string) (started, completed handlers.ImportUsersResponse) {
+	t.Helper()
+
+	ctx, rec := testhelpers.SetupEchoContext(t, handlers.ImportUsersParams{CSV: csv}, "users/import")
+
+	err := h.ImportUsers(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected status %d, got %d", http.StatusAccepted, rec.Code)
+	}
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &started); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if !h.WaitForImports(context.Background()) {
+		t.Fatal("expected the import to complete")
+	}
+
+	completeCalls := m.imports.CompleteUserImportCalls()
+	testhelpers.AssertRepoCalls(t, len(completeCalls), 1, testhelpers.ImportUsersHandlerName)
+	if completeCalls[0].Params.ID != m.importID {
+		t.Errorf("expected import %s to be completed, got %s", m.importID, completeCalls[0].Params.ID)
+	}
+
+	if err := json.Unmarshal(completeCalls[0].Params.Report, &completed); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+
+	return started, completed
+}
+
+func TestImportUsers_HappyPath(t *testing.T) {
+	t.Run("creates valid rows in the background and reports errors for the rest", func(t *testing.T) {
+		h, m := setupImportHandlers()
+
+		started, completed := runImport(t, h, m, importCSV)
+
+		invalidRows := []handlers.ImportUserResult{
+			{
+				Row:    3,
+				Email:  "invalid-email",
+				Status: handlers.ImportUserStatusFailed,
+				Errors: []string{"name is required", "invalid email", `group "Unknown Group" not found`},
+			},
+			{
+				Row:    4,
+				Email:  testhelpers.User.Email,
+				Status: handlers.ImportUserStatusFailed,
+				Errors: []string{errors.AlreadyExists("user")},
+			},
+			{
+				Row:    5,
+				Email:  "NEW@example.com",
+				Status: handlers.ImportUserStatusFailed,
+				Errors: []string{"duplicate email, first used on row 2"},
+			},
+		}
+
+		expectedStarted := handlers.ImportUsersResponse{
+			ID:      &m.importID,
+			Status:  domain.UserImportStatusRunning,
+			Pending: 1,
+			Failed:  3,
+			Results: append([]handlers.ImportUserResult{
+				{Row: 2, Email: "new@example.com", Status: handlers.ImportUserStatusPending},
+			}, invalidRows...),
+		}
+		if diff := cmp.Diff(expectedStarted, started); diff != "" {
+			t.Errorf("response mismatch (-want +got):\n%s", diff)
+		}
+
+		// The report saved when the import starts is what the response reports, without the ID
+		addCalls := m.imports.AddUserImportCalls()
+		testhelpers.AssertRepoCalls(t, len(addCalls), 1, testhelpers.ImportUsersHandlerName)
+		var added handlers.ImportUsersResponse
+		if err := json.Unmarshal(addCalls[0].Params.Report, &added); err != nil {
+			t.Fatalf("failed to unmarshal added report: %v", err)
+		}
+		expectedStarted.ID, expectedStarted.Status = nil, ""
+		if diff := cmp.Diff(expectedStarted, added); diff != "" {
+			t.Errorf("added report mismatch (-want +got):\n%s", diff)
+		}
+		if addCalls[0].Params.CreatedBy != testhelpers.TestUserID {
+			t.Errorf("expected import created by %s, got %s", testhelpers.TestUserID, addCalls[0].Params.CreatedBy)
+		}
+
+		expectedCompleted := handlers.ImportUsersResponse{
+			Created: 1,
+			Failed:  3,
+			Results: append([]handlers.ImportUserResult{
+				{
+					Row:            2,
+					Email:          "new@example.com",
+					UserID:         m.newUserID,
+					Status:         handlers.ImportUserStatusCreated,
+					InvitationSent: true,
+				},
+			}, invalidRows...),
+		}
+		if diff := cmp.Diff(expectedCompleted, completed); diff != "" {
+			t.Errorf("report mismatch (-want +got):\n%s", diff)
+		}
+
+		createCalls := m.provider.CreateUserCalls()
+		testhelpers.AssertRepoCalls(t, len(createCalls), 1, testhelpers.ImportUsersHandlerName)
+		if len(createCalls[0].Password) != 32 {
+			t.Errorf("expected a 32 character generated password, got %d characters", len(createCalls[0].Password))
+		}
+
+		groupCalls := m.group.AddGroupMembersCalls()
+		testhelpers.AssertRepoCalls(t, len(groupCalls), 1, testhelpers.ImportUsersHandlerName)
+		expectedMembers := domain.GroupMembersParams{GroupID: m.salesGroup, UserIDs: []string{m.newUserID}}
+		if diff := cmp.Diff(expectedMembers, groupCalls[0].Params); diff != "" {
+			t.Errorf("group members params mismatch (-want +got):\n%s", diff)
+		}
+
+		enrolCalls := m.enrolment.BulkUpdateEnrolmentsCalls()
+		testhelpers.AssertRepoCalls(t, len(enrolCalls), 1, testhelpers.ImportUsersHandlerName)
+		expectedEnrolment := domain.BulkUpdateEnrolmentsParams{
+			UserIDs:    []string{m.newUserID},
+			CourseIDs:  []uuid.UUID{testhelpers.Course.ID},
+			Action:     domain.BulkEnrolmentActionEnrol,
+			EnrolledBy: testhelpers.TestUserID,
+		}
+		if diff := cmp.Diff(expectedEnrolment, enrolCalls[0].Params); diff != "" {
+			t.Errorf("enrolment params mismatch (-want +got):\n%s", diff)
+		}
+
+		sendCalls := m.emails.SendCalls()
+		testhelpers.AssertRepoCalls(t, len(sendCalls), 1, testhelpers.ImportUsersHandlerName)
+		expectedInvitation := &email.InvitationParams{
+			UserName:        "New Learner",
+			UserEmail:       "new@example.com",
+			SetPasswordLink: "https://example.com/set-password?email=new@example.com",
+		}
+		if diff := cmp.Diff(expectedInvitation, sendCalls[0].Params); diff != "" {
+			t.Errorf("invitation params mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("dry run validates rows without creating users", func(t *testing.T) {
+		h, m := setupImportHandlers()
+
+		req := handlers.ImportUsersParams{CSV: importCSV, DryRun: true}
+		ctx, rec := testhelpers.SetupEchoContext(t, req, "users/import")
+
+		err := h.ImportUsers(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var response handlers.ImportUsersResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		if !response.DryRun || response.Valid != 1 || response.Created != 0 || response.Failed != 3 {
+			t.Errorf("unexpected dry run totals: %+v", response)
+		}
+
+		if response.Results[0].Status != handlers.ImportUserStatusValid {
+			t.Errorf("expected first row to be valid, got %q", response.Results[0].Status)
+		}
+
+		testhelpers.AssertRepoCalls(t, len(m.provider.CreateUserCalls()), 0, testhelpers.ImportUsersHandlerName)
+		testhelpers.AssertRepoCalls(t, len(m.auth.RegisterUserCalls()), 0, testhelpers.ImportUsersHandlerName)
+		testhelpers.AssertRepoCalls(t, len(m.emails.SendCalls()), 0, testhelpers.ImportUsersHandlerName)
+		testhelpers.AssertRepoCalls(t, len(m.imports.AddUserImportCalls()), 0, testhelpers.ImportUsersHandlerName)
+	})
+
+	t.Run("imports every row of a large CSV", func(t *testing.T) {
+		h, m := setupImportHandlers()
+		m.provider.CreateUserFunc = func(context.Context, string, string, string) (string, error) {
+			return uuid.NewString(), nil
+		}
+
+		var csv strings.Builder
+		csv.WriteString("name,email\n")
+		for i := range 500 {
+			fmt.Fprintf(&csv, "Learner %d,learner%d@example.com\n", i, i)
+		}
+
+		started, completed := runImport(t, h, m, csv.String())
+
+		if started.Pending != 500 || started.Created != 0 {
+			t.Errorf("expected 500 pending rows when the import starts, got %+v", started)
+		}
+		if completed.Created != 500 || completed.Pending != 0 || completed.Failed != 0 {
+			t.Errorf("expected 500 created users, got %d created, %d pending and %d failed",
+				completed.Created, completed.Pending, completed.Failed)
+		}
+		for i, result := range completed.Results {
+			if result.Row != i+2 || result.Email != fmt.Sprintf("learner%d@example.com", i) {
+				t.Fatalf("expected result %d to be for row %d, got %+v", i, i+2, result)
+			}
+		}
+
+		testhelpers.AssertRepoCalls(t, len(m.auth.RegisterUserCalls()), 500, testhelpers.ImportUsersHandlerName)
+		testhelpers.AssertRepoCalls(t, len(m.emails.SendCalls()), 500, testhelpers.ImportUsersHandlerName)
+	})
+
+	t.Run("reports failed invitation for a created user", func(t *testing.T) {
+		h, m := setupImportHandlers()
+		m.emails.SendFunc = func(context.Context, email.EmailParams, string, string) error {
+			return stdErrors.New("mailgun error")
+		}
+
+		_, completed := runImport(t, h, m, "name,email\nNew Learner,new@example.com\n")
+
+		result := completed.Results[0]
+		if result.Status != handlers.ImportUserStatusCreated || result.InvitationSent || len(result.Errors) != 1 {
+			t.Errorf("expected created user with failed invitation, got %+v", result)
+		}
+	})
+}
+
+func TestImportUsers_UnhappyPath(t *testing.T) {
+	tooManyRows := "name,email\n" + strings.Repeat("Learner,learner@example.com\n", 1001)
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.ImportUsersParams
+		lookupErr      error
+		addImportErr   error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing csv",
+			reqBody:        handlers.ImportUsersParams{},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "missing email column",
+			reqBody:        handlers.ImportUsersParams{CSV: "name,groups\nLearner,Sales\n"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidFormat("CSV"),
+		},
+		{
+			name:           "malformed csv",
+			reqBody:        handlers.ImportUsersParams{CSV: "name,email\n\"Learner,learner@example.com\n"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidFormat("CSV"),
+		},
+		{
+			name:           "too many rows",
+			reqBody:        handlers.ImportUsersParams{CSV: tooManyRows},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.TooMany("users", 1000),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.ImportUsersParams{CSV: importCSV},
+			lookupErr:      stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Getting("groups and courses"),
+		},
+		{
+			name:           "error saving import",
+			reqBody:        handlers.ImportUsersParams{CSV: importCSV},
+			addImportErr:   stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Creating("user import"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, m := setupImportHandlers()
+			if tt.lookupErr != nil {
+				m.group.GetGroupsFunc = func(context.Context) ([]domain.Group, error) {
+					return nil, tt.lookupErr
+				}
+			}
+			m.imports.AddUserImportFunc = func(context.Context, domain.AddUserImportParams) (uuid.UUID, error) {
+				return m.importID, tt.addImportErr
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/import")
+			err := h.ImportUsers(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+			h.WaitForImports(context.Background())
+			testhelpers.AssertRepoCalls(t, len(m.provider.CreateUserCalls()), 0, testhelpers.ImportUsersHandlerName)
+		})
+	}
+}
+
+func TestGetUserImport(t *testing.T) {
+	t.Run("returns the report with the import's status", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo := &mocks.UserImportRepositoryMock{
+			GetUserImportFunc: func(context.Context, uuid.UUID) (*domain.UserImport, error) {
+				return &domain.UserImport{
+					ID:     id,
+					Status: domain.UserImportStatusCompleted,
+					Report: json.RawMessage(`{"dryRun":false,"created":1,"results":[{"row":2,"email":"new@example.com",` +
+						`"status":"created","invitationSent":true}]}`),
+				}, nil
+			},
+		}
+
+		h := &handlers.Handlers{UserImport: mockRepo}
+
+		ctx, rec := testhelpers.SetupEchoContext(t, handlers.UserImportParams{ID: id.String()}, "users/import/report")
+
+		err := h.GetUserImport(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+
+		var actual handlers.ImportUsersResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &actual); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+
+		expected := handlers.ImportUsersResponse{
+			ID:      &id,
+			Status:  domain.UserImportStatusCompleted,
+			Created: 1,
+			Results: []handlers.ImportUserResult{
+				{Row: 2, Email: "new@example.com", Status: handlers.ImportUserStatusCreated, InvitationSent: true},
+			},
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("response mismatch (-want +got):\n%s", diff)
+		}
+
+		calls := mockRepo.GetUserImportCalls()
+		testhelpers.AssertRepoCalls(t, len(calls), 1, testhelpers.GetUserImportHandlerName)
+		if calls[0].Id != id {
+			t.Errorf("expected import %s, got %s", id, calls[0].Id)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		reqBody        handlers.UserImportParams
+		repoErr        error
+		wantStatus     int
+		expectedErrMsg string
+	}{
+		{
+			name:           "validation error - missing id",
+			reqBody:        handlers.UserImportParams{},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.Validation,
+		},
+		{
+			name:           "invalid uuid format",
+			reqBody:        handlers.UserImportParams{ID: "invalid-uuid"},
+			wantStatus:     http.StatusBadRequest,
+			expectedErrMsg: errors.InvalidUUID,
+		},
+		{
+			name:           "import not found",
+			reqBody:        handlers.UserImportParams{ID: uuid.NewString()},
+			repoErr:        pgx.ErrNoRows,
+			wantStatus:     http.StatusNotFound,
+			expectedErrMsg: errors.NotFound("user import"),
+		},
+		{
+			name:           "internal server error",
+			reqBody:        handlers.UserImportParams{ID: uuid.NewString()},
+			repoErr:        stdErrors.New("db error"),
+			wantStatus:     http.StatusInternalServerError,
+			expectedErrMsg: errors.Getting("user import"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &handlers.Handlers{
+				UserImport: &mocks.UserImportRepositoryMock{
+					GetUserImportFunc: func(context.Context, uuid.UUID) (*domain.UserImport, error) {
+						return nil, tt.repoErr
+					},
+				},
+			}
+
+			ctx, _ := testhelpers.SetupEchoContext(t, tt.reqBody, "users/import/report")
+			err := h.GetUserImport(ctx)
+			testhelpers.AssertHTTPError(t, err, tt.wantStatus, tt.expectedErrMsg)
+		})
+	}
+}
@@ -4,15 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
-	"slices"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
 	"github.com/supanova-rp/supanova-server/internal/services/auth"
 )
@@ -22,47 +23,40 @@ type ContextKey string
 const (
 	UserIDContextKey ContextKey = "userID"
 	RoleContextKey   ContextKey = "role"
+	// Requests made with an API key have the key's ID and scopes in the context instead of a user
+	APIKeyIDContextKey ContextKey = "apiKeyID"
+	ScopesContextKey   ContextKey = "scopes"
 )
 
+const bearerScheme = "Bearer"
+
 type AuthParams struct {
 	AccessToken string `json:"access_token" validate:"required"`
 }
 
-var nonAdminPaths = []string{
-	fmt.Sprintf("/%s/course", config.APIVersion),
-	fmt.Sprintf("/%s/assigned-course-titles", config.APIVersion),
-	fmt.Sprintf("/%s/get-progress", config.APIVersion),
-	fmt.Sprintf("/%s/update-progress", config.APIVersion),
-	fmt.Sprintf("/%s/set-intro-completed", config.APIVersion),
-	fmt.Sprintf("/%s/set-course-completed", config.APIVersion),
-	fmt.Sprintf("/%s/video-url", config.APIVersion),
-	fmt.Sprintf("/%s/materials", config.APIVersion),
-	fmt.Sprintf("/%s/get-quiz-state", config.APIVersion),
-	fmt.Sprintf("/%s/set-quiz-state", config.APIVersion),
-	fmt.Sprintf("/%s/quiz/save-state", config.APIVersion),
-	fmt.Sprintf("/%s/quiz/save-attempt", config.APIVersion),
-	fmt.Sprintf("/%s/quiz/get-all-sections", config.APIVersion),
-}
-
-func AuthMiddleware(next echo.HandlerFunc, authProvider auth.AuthProvider) echo.HandlerFunc {
+// AuthMiddleware verifies the access token, sent as a bearer token or in the JSON body, and sets the
+// user's ID and role in the request context. API keys are accepted instead of an access token, their
+// scopes are set in the context instead. Routes check the role or scopes have the permission they
+// need with RequirePermission.
+func AuthMiddleware(
+	next echo.HandlerFunc,
+	authProvider auth.AuthProvider,
+	roles domain.RoleRepository,
+	apiKeys domain.APIKeyRepository,
+) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		bodyBytes, err := io.ReadAll(c.Request().Body)
+
+		accessToken, err := getAccessToken(c)
 		if err != nil {
-			slog.ErrorContext(ctx, "failed to read request body", slog.Any("error", err))
-			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			return err
 		}
 
-		// Restore body so handler can use c.Bind()
-		c.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-		var params AuthParams
-		if err := json.Unmarshal(bodyBytes, &params); err != nil {
-			slog.ErrorContext(ctx, "failed to unmarshal auth middleware request body", slog.Any("error", err))
-			return echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
+		if domain.IsAPIKey(accessToken) {
+			return authenticateAPIKey(c, next, accessToken, apiKeys)
 		}
 
-		user, err := authProvider.GetUserFromIDToken(ctx, params.AccessToken)
+		user, err := authProvider.GetUserFromIDToken(ctx, accessToken)
 		if err != nil {
 			slog.ErrorContext(ctx, "failed to get user from ID token", slog.Any("error", err))
 			return echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
@@ -73,13 +67,17 @@ func AuthMiddleware(next echo.HandlerFunc, authProvider auth.AuthProvider) echo.
 			return echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
 		}
 
-		isAdminPath := !slices.Contains(nonAdminPaths, c.Request().URL.Path)
-		if isAdminPath && !user.IsAdmin {
-			slog.WarnContext(ctx, "unauthorised request", slog.String("user_id", user.ID))
+		if user.Disabled {
+			slog.WarnContext(ctx, "request from deactivated user", slog.String("user_id", user.ID))
 			return echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
 		}
 
-		role := getUserRole(user.IsAdmin)
+		role, err := getUserRole(ctx, user, roles)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to get user role", slog.Any("error", err))
+			return echo.NewHTTPError(http.StatusInternalServerError, errors.Getting("user role"))
+		}
+
 		ctx = context.WithValue(ctx, RoleContextKey, role)
 		ctx = context.WithValue(ctx, UserIDContextKey, user.ID)
 		c.SetRequest(c.Request().WithContext(ctx))
@@ -88,24 +86,101 @@ func AuthMiddleware(next echo.HandlerFunc, authProvider auth.AuthProvider) echo.
 	}
 }
 
-func TestAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		userID := c.Request().Header.Get("X-Test-User-ID")
-		role := c.Request().Header.Get("X-Test-User-Role")
+func authenticateAPIKey(c echo.Context, next echo.HandlerFunc, key string, apiKeys domain.APIKeyRepository) error {
+	ctx := c.Request().Context()
 
-		ctx := c.Request().Context()
-		ctx = context.WithValue(ctx, UserIDContextKey, userID)
-		ctx = context.WithValue(ctx, RoleContextKey, config.Role(role))
-		c.SetRequest(c.Request().WithContext(ctx))
+	apiKey, err := apiKeys.GetAPIKeyByHash(ctx, domain.HashAPIKey(key))
+	if err != nil {
+		if errors.IsNotFoundErr(err) {
+			slog.WarnContext(ctx, "unknown API key")
+			return echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
+		}
 
-		return next(c)
+		slog.ErrorContext(ctx, "failed to get API key", slog.Any("error", err))
+		return echo.NewHTTPError(http.StatusInternalServerError, errors.Getting("API key"))
+	}
+
+	if err := apiKey.Validate(time.Now()); err != nil {
+		slog.WarnContext(ctx, "request with unusable API key", slog.String("api_key_id", apiKey.ID.String()), slog.Any("error", err))
+		return echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
+	}
+
+	// A request shouldn't be failed because its last use couldn't be recorded
+	if err := apiKeys.SetAPIKeyLastUsed(ctx, apiKey.ID); err != nil {
+		slog.ErrorContext(ctx, "failed to set API key last used", slog.Any("error", err))
+	}
+
+	scopes := make([]Permission, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, Permission(scope))
 	}
+
+	ctx = context.WithValue(ctx, ScopesContextKey, scopes)
+	ctx = context.WithValue(ctx, APIKeyIDContextKey, apiKey.ID.String())
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	return next(c)
 }
 
-func getUserRole(isAdmin bool) config.Role {
-	if isAdmin {
-		return config.AdminRole
+// getAccessToken reads the access token from the Authorization header, falling back to the
+// access_token field of the JSON body for clients that haven't moved to the header yet
+func getAccessToken(c echo.Context) (string, error) {
+	ctx := c.Request().Context()
+
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	if header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, bearerScheme) || token == "" {
+			slog.WarnContext(ctx, "malformed authorization header")
+			return "", echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
+		}
+
+		return token, nil
+	}
+
+	if c.Request().Body == nil {
+		slog.WarnContext(ctx, "request has no access token")
+		return "", echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
+	}
+
+	bodyBytes, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read request body", slog.Any("error", err))
+		return "", echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Restore body so handler can use c.Bind()
+	c.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+	var params AuthParams
+	if err := json.Unmarshal(bodyBytes, &params); err != nil {
+		slog.ErrorContext(ctx, "failed to unmarshal auth middleware request body", slog.Any("error", err))
+		return "", echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
+	}
+
+	if params.AccessToken == "" {
+		slog.WarnContext(ctx, "request has no access token")
+		return "", echo.NewHTTPError(http.StatusUnauthorized, errors.Unauthorised)
+	}
+
+	return params.AccessToken, nil
+}
+
+// getUserRole returns the role stored for the user, which overrides the admin claim from the auth
+// provider. Users without a stored role fall back to the claim, so admins don't need a stored role.
+func getUserRole(ctx context.Context, user *auth.User, roles domain.RoleRepository) (config.Role, error) {
+	role, err := roles.GetUserRole(ctx, user.ID)
+	if err == nil {
+		return role, nil
+	}
+
+	if !errors.IsNotFoundErr(err) {
+		return "", err
+	}
+
+	if user.IsAdmin {
+		return config.AdminRole, nil
 	}
 
-	return config.UserRole
+	return config.UserRole, nil
 }
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 
 	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
 	"github.com/supanova-rp/supanova-server/internal/middleware"
 	"github.com/supanova-rp/supanova-server/internal/middleware/testhelpers"
@@ -39,7 +42,7 @@ func TestMiddleware(t *testing.T) {
 
 		c := testhelpers.SetupEchoContext(t, reqBody, "course")
 
-		err := middleware.AuthMiddleware(nextMock, mockAuthProvider)(c)
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, noAPIKeyRepo)(c)
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
@@ -72,7 +75,7 @@ func TestMiddleware(t *testing.T) {
 
 		c := testhelpers.SetupEchoContext(t, reqBody, "course")
 
-		err := middleware.AuthMiddleware(nextMock, mockAuthProvider)(c)
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, noAPIKeyRepo)(c)
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
@@ -88,24 +91,128 @@ func TestMiddleware(t *testing.T) {
 		}
 	})
 
-	t.Run("unauthorised: user is non admin on admin route", func(t *testing.T) {
+	t.Run("authorised: stored role takes precedence over admin claim", func(t *testing.T) {
 		mockAuthProvider := &mocks.AuthProviderMock{
 			GetUserFromIDTokenFunc: func(ctx context.Context, token string) (*auth.User, error) {
 				return &auth.User{
 					ID:      testUserID,
-					IsAdmin: false,
+					IsAdmin: true,
 				}, nil
 			},
 		}
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			GetUserRoleFunc: func(ctx context.Context, userID string) (config.Role, error) {
+				return config.AuditorRole, nil
+			},
+		}
 
 		reqBody := map[string]interface{}{
 			"id":           uuid.New().String(),
 			"access_token": accessToken,
 		}
 
-		c := testhelpers.SetupEchoContext(t, reqBody, "add-course")
+		c := testhelpers.SetupEchoContext(t, reqBody, "course")
+
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, mockRoleRepo, noAPIKeyRepo)(c)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		role, ok := c.Request().Context().Value(middleware.RoleContextKey).(config.Role)
+		if !ok || role != config.AuditorRole {
+			t.Fatalf("expected auditor role in context, got %s", role)
+		}
+
+		calls := mockRoleRepo.GetUserRoleCalls()
+		if len(calls) != 1 || calls[0].UserID != testUserID {
+			t.Errorf("expected role to be looked up for %s, got %v", testUserID, calls)
+		}
+	})
+
+	t.Run("authorised: admin claim demoted by stored user role", func(t *testing.T) {
+		mockAuthProvider := &mocks.AuthProviderMock{
+			GetUserFromIDTokenFunc: func(ctx context.Context, token string) (*auth.User, error) {
+				return &auth.User{
+					ID:      testUserID,
+					IsAdmin: true,
+				}, nil
+			},
+		}
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			GetUserRoleFunc: func(ctx context.Context, userID string) (config.Role, error) {
+				return config.UserRole, nil
+			},
+		}
+
+		reqBody := map[string]interface{}{
+			"id":           uuid.New().String(),
+			"access_token": accessToken,
+		}
+
+		c := testhelpers.SetupEchoContext(t, reqBody, "course")
+
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, mockRoleRepo, noAPIKeyRepo)(c)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		role, ok := c.Request().Context().Value(middleware.RoleContextKey).(config.Role)
+		if !ok || role != config.UserRole {
+			t.Fatalf("expected user role in context, got %s", role)
+		}
+	})
+
+	t.Run("error: getting stored role fails", func(t *testing.T) {
+		mockAuthProvider := &mocks.AuthProviderMock{
+			GetUserFromIDTokenFunc: func(ctx context.Context, token string) (*auth.User, error) {
+				return &auth.User{
+					ID: testUserID,
+				}, nil
+			},
+		}
+		mockRoleRepo := &mocks.RoleRepositoryMock{
+			GetUserRoleFunc: func(ctx context.Context, userID string) (config.Role, error) {
+				return "", errors.New("db error")
+			},
+		}
+
+		reqBody := map[string]interface{}{
+			"id":           uuid.New().String(),
+			"access_token": accessToken,
+		}
+
+		c := testhelpers.SetupEchoContext(t, reqBody, "course")
+
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, mockRoleRepo, noAPIKeyRepo)(c)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		httpErr, ok := err.(*echo.HTTPError)
+		if !ok || httpErr.Code != http.StatusInternalServerError {
+			t.Errorf("expected status %d, got %v", http.StatusInternalServerError, err)
+		}
+	})
+
+	t.Run("unauthorised: user is deactivated", func(t *testing.T) {
+		mockAuthProvider := &mocks.AuthProviderMock{
+			GetUserFromIDTokenFunc: func(ctx context.Context, token string) (*auth.User, error) {
+				return &auth.User{
+					ID:       testUserID,
+					IsAdmin:  true,
+					Disabled: true,
+				}, nil
+			},
+		}
+
+		reqBody := map[string]interface{}{
+			"id":           uuid.New().String(),
+			"access_token": accessToken,
+		}
+
+		c := testhelpers.SetupEchoContext(t, reqBody, "course")
 
-		err := middleware.AuthMiddleware(nextMock, mockAuthProvider)(c)
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, noAPIKeyRepo)(c)
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -130,7 +237,7 @@ func TestMiddleware(t *testing.T) {
 
 		c := testhelpers.SetupEchoContext(t, reqBody, "add-course")
 
-		err := middleware.AuthMiddleware(nextMock, mockAuthProvider)(c)
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, noAPIKeyRepo)(c)
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -142,6 +249,207 @@ func TestMiddleware(t *testing.T) {
 	})
 }
 
+func TestMiddleware_AuthorizationHeader(t *testing.T) {
+	t.Run("authorised: bearer token in header", func(t *testing.T) {
+		mockAuthProvider := &mocks.AuthProviderMock{
+			GetUserFromIDTokenFunc: func(ctx context.Context, token string) (*auth.User, error) {
+				return &auth.User{ID: testUserID}, nil
+			},
+		}
+
+		c := testhelpers.SetupEchoContext(t, nil, "roles/me")
+		c.Request().Header.Set(echo.HeaderAuthorization, "Bearer "+accessToken)
+
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, noAPIKeyRepo)(c)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockAuthProvider.GetUserFromIDTokenCalls()
+		if len(calls) != 1 || calls[0].Token != accessToken {
+			t.Errorf("expected token %q to be verified, got %v", accessToken, calls)
+		}
+
+		userID, ok := c.Request().Context().Value(middleware.UserIDContextKey).(string)
+		if !ok || userID != testUserID {
+			t.Fatalf("expected userID in context, got %s", userID)
+		}
+	})
+
+	t.Run("authorised: header takes precedence over body token", func(t *testing.T) {
+		mockAuthProvider := &mocks.AuthProviderMock{
+			GetUserFromIDTokenFunc: func(ctx context.Context, token string) (*auth.User, error) {
+				return &auth.User{ID: testUserID}, nil
+			},
+		}
+
+		reqBody := map[string]interface{}{
+			"id":           uuid.New().String(),
+			"access_token": "body-access-token",
+		}
+
+		c := testhelpers.SetupEchoContext(t, reqBody, "course")
+		c.Request().Header.Set(echo.HeaderAuthorization, "bearer "+accessToken)
+
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, noAPIKeyRepo)(c)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		calls := mockAuthProvider.GetUserFromIDTokenCalls()
+		if len(calls) != 1 || calls[0].Token != accessToken {
+			t.Errorf("expected token %q to be verified, got %v", accessToken, calls)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "unauthorised: not a bearer token", header: "Basic " + accessToken},
+		{name: "unauthorised: empty bearer token", header: "Bearer "},
+		{name: "unauthorised: no token in header or body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthProvider := &mocks.AuthProviderMock{}
+
+			c := testhelpers.SetupEchoContext(t, nil, "roles/me")
+			if tt.header != "" {
+				c.Request().Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+
+			err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, noAPIKeyRepo)(c)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			httpErr, ok := err.(*echo.HTTPError)
+			if !ok || httpErr.Code != http.StatusUnauthorized {
+				t.Errorf("expected status %d, got %v", http.StatusUnauthorized, err)
+			}
+
+			if len(mockAuthProvider.GetUserFromIDTokenCalls()) != 0 {
+				t.Error("expected token not to be verified")
+			}
+		})
+	}
+}
+
+func TestMiddleware_APIKey(t *testing.T) {
+	apiKey := domain.APIKeyPrefix + "test-key"
+	keyID := uuid.New()
+
+	t.Run("authorised: sets key scopes in context", func(t *testing.T) {
+		mockAuthProvider := &mocks.AuthProviderMock{}
+		mockAPIKeyRepo := &mocks.APIKeyRepositoryMock{
+			GetAPIKeyByHashFunc: func(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+				return &domain.APIKey{ID: keyID, Scopes: []string{string(middleware.PermissionViewReports)}}, nil
+			},
+			SetAPIKeyLastUsedFunc: func(ctx context.Context, id uuid.UUID) error {
+				return nil
+			},
+		}
+
+		c := testhelpers.SetupEchoContext(t, nil, "users/list")
+		c.Request().Header.Set(echo.HeaderAuthorization, "Bearer "+apiKey)
+
+		err := middleware.AuthMiddleware(nextMock, mockAuthProvider, noStoredRoleRepo, mockAPIKeyRepo)(c)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		hashCalls := mockAPIKeyRepo.GetAPIKeyByHashCalls()
+		if len(hashCalls) != 1 || hashCalls[0].KeyHash != domain.HashAPIKey(apiKey) {
+			t.Errorf("expected key to be looked up by its hash, got %v", hashCalls)
+		}
+
+		usedCalls := mockAPIKeyRepo.SetAPIKeyLastUsedCalls()
+		if len(usedCalls) != 1 || usedCalls[0].Id != keyID {
+			t.Errorf("expected last used to be set for key %s, got %v", keyID, usedCalls)
+		}
+
+		ctx := c.Request().Context()
+		if !middleware.ContextHasPermission(ctx, middleware.PermissionViewReports) {
+			t.Error("expected key scope to be granted")
+		}
+		if middleware.ContextHasPermission(ctx, middleware.PermissionManageLearners) {
+			t.Error("expected permissions outside the key's scopes not to be granted")
+		}
+
+		if _, ok := ctx.Value(middleware.UserIDContextKey).(string); ok {
+			t.Error("expected no user ID in context for API key requests")
+		}
+
+		if len(mockAuthProvider.GetUserFromIDTokenCalls()) != 0 {
+			t.Error("expected API key not to be verified by the auth provider")
+		}
+	})
+
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		apiKey     *domain.APIKey
+		repoErr    error
+		wantStatus int
+	}{
+		{
+			name:       "unauthorised: unknown key",
+			repoErr:    pgx.ErrNoRows,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unauthorised: revoked key",
+			apiKey:     &domain.APIKey{ID: keyID, RevokedAt: &now},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unauthorised: expired key",
+			apiKey:     &domain.APIKey{ID: keyID, ExpiresAt: &now},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "internal server error",
+			repoErr:    errors.New("db error"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyRepo := &mocks.APIKeyRepositoryMock{
+				GetAPIKeyByHashFunc: func(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+					return tt.apiKey, tt.repoErr
+				},
+			}
+
+			c := testhelpers.SetupEchoContext(t, nil, "users/list")
+			c.Request().Header.Set(echo.HeaderAuthorization, "Bearer "+apiKey)
+
+			err := middleware.AuthMiddleware(nextMock, &mocks.AuthProviderMock{}, noStoredRoleRepo, mockAPIKeyRepo)(c)
+
+			httpErr, ok := err.(*echo.HTTPError)
+			if !ok || httpErr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %v", tt.wantStatus, err)
+			}
+
+			if len(mockAPIKeyRepo.SetAPIKeyLastUsedCalls()) != 0 {
+				t.Error("expected last used not to be set")
+			}
+		})
+	}
+}
+
+var noStoredRoleRepo = &mocks.RoleRepositoryMock{
+	GetUserRoleFunc: func(ctx context.Context, userID string) (config.Role, error) {
+		return "", pgx.ErrNoRows
+	},
+}
+
+var noAPIKeyRepo = &mocks.APIKeyRepositoryMock{}
+
 func nextMock(c echo.Context) error {
 	return nil
 }
@@ -15,6 +15,10 @@ import (
 	"github.com/supanova-rp/supanova-server/internal/config"
 )
 
+// sensitiveBodyKeys are removed from logged request bodies: access tokens, passwords, and the
+// one-time tokens for setting a password
+var sensitiveBodyKeys = []string{"access_token", "accessToken", "password", "token"}
+
 func Logging(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		start := time.Now()
@@ -60,12 +64,18 @@ func Logging(next echo.HandlerFunc) echo.HandlerFunc {
 			}
 		}
 
+		if apiKeyID, ok := c.Request().Context().Value(APIKeyIDContextKey).(string); ok {
+			attrs = append(attrs, slog.String("api_key_id", apiKeyID))
+		}
+
 		if len(bodyBytes) > 0 {
 			var bodyMap map[string]interface{}
 
 			err := json.Unmarshal(bodyBytes, &bodyMap)
 			if err == nil {
-				delete(bodyMap, "access_token") // Redact the access_token from logs
+				for _, key := range sensitiveBodyKeys {
+					delete(bodyMap, key)
+				}
 
 				sanitised, err := json.Marshal(bodyMap)
 				if err == nil {
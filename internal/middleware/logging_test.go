@@ -0,0 +1,54 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/middleware"
+	"github.com/supanova-rp/supanova-server/internal/middleware/testhelpers"
+)
+
+func TestLogging_RedactsSensitiveBody(t *testing.T) {
+	var logs bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	reqBody := map[string]any{
+		"email":        "usera@test.com",
+		"password":     "plaintext-password",
+		"token":        "set-password-token",
+		"access_token": accessToken,
+		"accessToken":  accessToken,
+	}
+	c := testhelpers.SetupEchoContext(t, reqBody, "auth/sign-in")
+
+	// Failed requests are logged at error, the default level
+	failing := func(echo.Context) error { return errors.New("invalid credentials") }
+	if err := middleware.Logging(failing)(c); err == nil {
+		t.Fatal("expected the handler's error")
+	}
+
+	var entry struct {
+		Body string `json:"body"`
+	}
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to unmarshal log entry %q: %v", logs.String(), err)
+	}
+
+	var actual map[string]any
+	if err := json.Unmarshal([]byte(entry.Body), &actual); err != nil {
+		t.Fatalf("failed to unmarshal logged body %q: %v", entry.Body, err)
+	}
+
+	expected := map[string]any{"email": "usera@test.com"}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("logged body mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+)
+
+type Permission string
+
+const (
+	// PermissionLearn allows taking assigned courses, every role has it
+	PermissionLearn Permission = "learn"
+	// PermissionViewTeam allows viewing progress for the user's direct reports, every role has it
+	PermissionViewTeam Permission = "team:view"
+	// PermissionViewCourses allows viewing every course, including quiz answers
+	PermissionViewCourses Permission = "courses:view"
+	// PermissionEditAssignedCourses allows editing the courses the user is an instructor on
+	PermissionEditAssignedCourses Permission = "courses:edit-assigned"
+	// PermissionEditCourses allows editing every course
+	PermissionEditCourses Permission = "courses:edit"
+	// PermissionManageCourses allows adding and deleting courses and assigning their instructors
+	PermissionManageCourses Permission = "courses:manage"
+	// PermissionViewGroupProgress allows viewing progress for the groups the user manages
+	PermissionViewGroupProgress Permission = "progress:view-group"
+	// PermissionViewProgress allows viewing progress for every user
+	PermissionViewProgress Permission = "progress:view"
+	// PermissionViewReports allows viewing users, enrolments, groups, access code redemptions and quiz
+	// attempts
+	PermissionViewReports Permission = "reports:view"
+	// PermissionManageLearners allows managing users, enrolments, groups, access codes and progress
+	PermissionManageLearners Permission = "learners:manage"
+	// PermissionManageRoles allows granting roles to users
+	PermissionManageRoles Permission = "roles:manage"
+)
+
+var rolePermissions = map[config.Role][]Permission{
+	config.AdminRole: {
+		PermissionLearn,
+		PermissionViewTeam,
+		PermissionViewCourses,
+		PermissionEditAssignedCourses,
+		PermissionEditCourses,
+		PermissionManageCourses,
+		PermissionViewGroupProgress,
+		PermissionViewProgress,
+		PermissionViewReports,
+		PermissionManageLearners,
+		PermissionManageRoles,
+	},
+	config.ManagerRole: {
+		PermissionLearn,
+		PermissionViewTeam,
+		PermissionViewGroupProgress,
+	},
+	config.InstructorRole: {
+		PermissionLearn,
+		PermissionViewTeam,
+		PermissionViewCourses,
+		PermissionEditAssignedCourses,
+	},
+	config.AuditorRole: {
+		PermissionLearn,
+		PermissionViewTeam,
+		PermissionViewCourses,
+		PermissionViewGroupProgress,
+		PermissionViewProgress,
+		PermissionViewReports,
+	},
+	config.UserRole: {
+		PermissionLearn,
+		PermissionViewTeam,
+	},
+}
+
+// APIKeyScopes are the permissions API keys can be given. Keys are for integrations that pull
+// reports, so they can only read. Keys created without scopes get DefaultAPIKeyScopes.
+var (
+	APIKeyScopes        = []Permission{PermissionViewCourses, PermissionViewProgress, PermissionViewReports}
+	DefaultAPIKeyScopes = []Permission{PermissionViewProgress, PermissionViewReports}
+)
+
+func HasPermission(role config.Role, permission Permission) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+func Permissions(role config.Role) []Permission {
+	return slices.Clone(rolePermissions[role])
+}
+
+// ContextHasPermission checks the scopes of the request's API key, or the user's role for requests
+// made by a user
+func ContextHasPermission(ctx context.Context, permission Permission) bool {
+	if scopes, ok := ctx.Value(ScopesContextKey).([]Permission); ok {
+		return slices.Contains(scopes, permission)
+	}
+
+	role, _ := ctx.Value(RoleContextKey).(config.Role)
+	return HasPermission(role, permission)
+}
+
+// RequirePermission rejects requests from users whose role, or API keys whose scopes, have neither the
+// permission nor any of the alternatives. It runs after the auth middleware, which sets the role or
+// scopes in the request context.
+func RequirePermission(permission Permission, alternatives ...Permission) echo.MiddlewareFunc {
+	permissions := append([]Permission{permission}, alternatives...)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			allowed := slices.ContainsFunc(permissions, func(p Permission) bool {
+				return ContextHasPermission(ctx, p)
+			})
+			if !allowed {
+				role, _ := ctx.Value(RoleContextKey).(config.Role)
+				slog.WarnContext(ctx, "forbidden request",
+					slog.String("role", string(role)),
+					slog.Any("permissions", permissions),
+				)
+				return echo.NewHTTPError(http.StatusForbidden, errors.Forbidden(c.Request().URL.Path))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/middleware"
+	"github.com/supanova-rp/supanova-server/internal/middleware/testhelpers"
+)
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       config.Role
+		scopes     []middleware.Permission
+		permission middleware.Permission
+		// alternatives are permissions that also allow the request
+		alternatives []middleware.Permission
+		wantStatus   int
+	}{
+		{
+			name:       "admin can manage courses",
+			role:       config.AdminRole,
+			permission: middleware.PermissionManageCourses,
+		},
+		{
+			name:       "user can learn",
+			role:       config.UserRole,
+			permission: middleware.PermissionLearn,
+		},
+		{
+			name:       "user can't view reports",
+			role:       config.UserRole,
+			permission: middleware.PermissionViewReports,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "manager can view progress for their groups",
+			role:       config.ManagerRole,
+			permission: middleware.PermissionViewGroupProgress,
+		},
+		{
+			name:       "manager can't view all progress",
+			role:       config.ManagerRole,
+			permission: middleware.PermissionViewProgress,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "instructor can edit assigned courses",
+			role:       config.InstructorRole,
+			permission: middleware.PermissionEditAssignedCourses,
+		},
+		{
+			name:       "instructor can't add courses",
+			role:       config.InstructorRole,
+			permission: middleware.PermissionManageCourses,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "auditor can view reports",
+			role:       config.AuditorRole,
+			permission: middleware.PermissionViewReports,
+		},
+		{
+			name:       "auditor can't manage learners",
+			role:       config.AuditorRole,
+			permission: middleware.PermissionManageLearners,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "API key can use its scopes",
+			scopes:     middleware.DefaultAPIKeyScopes,
+			permission: middleware.PermissionViewReports,
+		},
+		{
+			name:       "API key can't use permissions outside its scopes",
+			scopes:     middleware.DefaultAPIKeyScopes,
+			permission: middleware.PermissionManageLearners,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:         "API key can use a scope allowed as an alternative",
+			scopes:       middleware.DefaultAPIKeyScopes,
+			permission:   middleware.PermissionViewGroupProgress,
+			alternatives: []middleware.Permission{middleware.PermissionViewProgress},
+		},
+		{
+			name:         "manager can use the permission when alternatives are given",
+			role:         config.ManagerRole,
+			permission:   middleware.PermissionViewGroupProgress,
+			alternatives: []middleware.Permission{middleware.PermissionViewProgress},
+		},
+		{
+			name:         "user has neither the permission nor the alternatives",
+			role:         config.UserRole,
+			permission:   middleware.PermissionViewGroupProgress,
+			alternatives: []middleware.Permission{middleware.PermissionViewProgress},
+			wantStatus:   http.StatusForbidden,
+		},
+		{
+			name:       "missing role",
+			permission: middleware.PermissionLearn,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testhelpers.SetupEchoContext(t, map[string]any{}, "course")
+			if tt.role != "" {
+				ctx := context.WithValue(c.Request().Context(), middleware.RoleContextKey, tt.role)
+				c.SetRequest(c.Request().WithContext(ctx))
+			}
+			if tt.scopes != nil {
+				ctx := context.WithValue(c.Request().Context(), middleware.ScopesContextKey, tt.scopes)
+				c.SetRequest(c.Request().WithContext(ctx))
+			}
+
+			called := false
+			next := func(c echo.Context) error {
+				called = true
+				return nil
+			}
+
+			err := middleware.RequirePermission(tt.permission, tt.alternatives...)(next)(c)
+
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if !called {
+					t.Error("expected next handler to be called")
+				}
+
+				return
+			}
+
+			httpErr, ok := err.(*echo.HTTPError)
+			if !ok || httpErr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %v", tt.wantStatus, err)
+			}
+
+			if called {
+				t.Error("expected next handler not to be called")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/supanova-rp/supanova-server/internal/middleware"
+)
+
+// Router registers private routes. Every route declares the permission needed to call it, so a new
+// route can't be left open to every user by accident.
+type Router struct {
+	group *echo.Group
+}
+
+func NewRouter(group *echo.Group) *Router {
+	return &Router{group: group}
+}
+
+// POST registers a route callable by those with the permission or, if given, any of the alternatives
+func (r *Router) POST(
+	path string, handler echo.HandlerFunc, permission middleware.Permission, alternatives ...middleware.Permission,
+) *echo.Route {
+	return r.group.POST(path, handler, middleware.RequirePermission(permission, alternatives...))
+}
+
+// GET registers a route for reads that don't need a request body, the access token is sent in the
+// Authorization header
+func (r *Router) GET(
+	path string, handler echo.HandlerFunc, permission middleware.Permission, alternatives ...middleware.Permission,
+) *echo.Route {
+	return r.group.GET(path, handler, middleware.RequirePermission(permission, alternatives...))
+}
@@ -4,71 +4,170 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/supanova-rp/supanova-server/internal/handlers"
+	"github.com/supanova-rp/supanova-server/internal/middleware"
 )
 
-func RegisterCourseRoutes(private *echo.Group, h *handlers.Handlers) {
-	private.POST("/course", h.GetCourse)
-	private.POST("/materials", h.GetCourseMaterials)
+func RegisterCourseRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/course", h.GetCourse, middleware.PermissionLearn)
+	private.POST("/materials", h.GetCourseMaterials, middleware.PermissionLearn)
 	// TODO: To be deprecated and replaced with single /course-titles or /courses/overview endpoint
 	// that handles getting either assigned course details or all (depending on if user is admin or not)
-	private.POST("/assigned-course-titles", h.GetAssignedCourseTitles)
+	private.POST("/assigned-course-titles", h.GetAssignedCourseTitles, middleware.PermissionLearn)
 
-	// admin routes
-	private.POST("/course-titles", h.GetCoursesOverview)
+	private.POST("/course-titles", h.GetCoursesOverview, middleware.PermissionViewCourses)
 	// TODO: To be deprecated and replaced with /courses/overview endpoint on admin edit course dashboard and single
 	// /course endpoint when editing course
-	private.POST("/courses", h.GetCourses)
-	private.POST("/add-course", h.AddCourse)
-	private.POST("/edit-course", h.EditCourse)
-	private.POST("/delete-course", h.DeleteCourse)
+	private.POST("/courses", h.GetCourses, middleware.PermissionViewCourses)
+	private.POST("/courses/list", h.ListCourses, middleware.PermissionViewCourses)
+	private.POST("/edit-course", h.EditCourse, middleware.PermissionEditAssignedCourses)
+	private.POST("/add-course", h.AddCourse, middleware.PermissionManageCourses)
+	private.POST("/delete-course", h.DeleteCourse, middleware.PermissionManageCourses)
+	private.POST("/courses/instructors/assign", h.AssignCourseInstructors, middleware.PermissionManageCourses)
+	private.POST("/courses/instructors/unassign", h.UnassignCourseInstructors, middleware.PermissionManageCourses)
 }
 
-func RegisterProgressRoutes(private *echo.Group, h *handlers.Handlers) {
-	private.POST("/get-progress", h.GetProgress)
-	private.POST("/update-progress", h.UpdateProgress)
-	private.POST("/set-intro-completed", h.SetIntroCompleted)
-	private.POST("/set-course-completed", h.SetCourseCompleted)
+func RegisterProgressRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/get-progress", h.GetProgress, middleware.PermissionLearn)
+	private.POST("/update-progress", h.UpdateProgress, middleware.PermissionLearn)
+	private.POST("/set-intro-completed", h.SetIntroCompleted, middleware.PermissionLearn)
+	private.POST("/set-course-completed", h.SetCourseCompleted, middleware.PermissionLearn)
+	// Learners get their own certificate, admins can also get any learner's
+	private.POST("/certificate", h.GetCertificate, middleware.PermissionLearn)
 
-	// admin routes
-	private.POST("/admin/get-all-progress", h.GetAllProgress)
-	private.POST("/reset-progress", h.ResetProgress)
+	// TODO: To be deprecated and replaced with paginated /admin/progress/list endpoint once FE uses it
+	private.POST("/admin/get-all-progress", h.GetAllProgress, middleware.PermissionViewProgress)
+	// Managers can only list progress for the groups they manage, those who can view all progress,
+	// including API keys, can list any group's
+	private.POST("/admin/progress/list", h.ListProgress,
+		middleware.PermissionViewGroupProgress, middleware.PermissionViewProgress)
+	private.POST("/reset-progress", h.ResetProgress, middleware.PermissionManageLearners)
 }
 
-func RegisterQuizRoutes(private *echo.Group, h *handlers.Handlers) {
-	private.POST("/quiz/save-attempt", h.SaveQuizAttempt)
-	private.POST("/quiz/save-state", h.SaveQuizState)
-	private.POST("/quiz/get-all-sections", h.GetAllQuizSections)
+func RegisterQuizRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/quiz/save-attempt", h.SaveQuizAttempt, middleware.PermissionLearn)
+	private.POST("/quiz/save-state", h.SaveQuizState, middleware.PermissionLearn)
+	private.POST("/quiz/get-all-sections", h.GetAllQuizSections, middleware.PermissionLearn)
 
 	// -----------------------------------------------------
 	// to be deprecated and replaced with /quiz/get-attempt and /quiz/save-attempt
 	// once FE is no longer using them
-	private.POST("/get-quiz-state", h.GetQuizState)
-	private.POST("/set-quiz-state", h.SetQuizState)
+	private.POST("/get-quiz-state", h.GetQuizState, middleware.PermissionLearn)
+	private.POST("/set-quiz-state", h.SetQuizState, middleware.PermissionLearn)
 	// -----------------------------------------------------
 
-	// admin routes
-	private.POST("/admin/quiz/get-attempts", h.GetQuizAttemptsByUserID)
-	private.POST("/admin/quiz/reset-progress", h.ResetQuizProgress)
+	private.POST("/admin/quiz/get-attempts", h.GetQuizAttemptsByUserID, middleware.PermissionViewReports)
+	private.POST("/admin/quiz/sections/list", h.ListQuizSections, middleware.PermissionViewReports)
+	private.POST("/admin/quiz/reset-progress", h.ResetQuizProgress, middleware.PermissionManageLearners)
 	// TODO: To be deprecated and replaced with combination of /course and /courses/overview endpoint
 	// (for edit courses admin panel)
-	private.POST("/quiz-questions", h.GetQuizQuestions)
+	private.POST("/quiz-questions", h.GetQuizQuestions, middleware.PermissionViewCourses)
 }
 
-func RegisterMediaRoutes(private *echo.Group, h *handlers.Handlers) {
-	private.POST("/video-url", h.GetVideoURL)
+func RegisterMediaRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/video-url", h.GetVideoURL, middleware.PermissionLearn)
 
-	// admin routes
-	private.POST("/get-video-upload-url", h.GetVideoUploadURL)
-	private.POST("/get-material-upload-url", h.GetMaterialUploadURL)
+	private.POST("/get-video-upload-url", h.GetVideoUploadURL, middleware.PermissionEditAssignedCourses)
+	private.POST("/get-material-upload-url", h.GetMaterialUploadURL, middleware.PermissionEditAssignedCourses)
 }
 
-func RegisterEnrolmentRoutes(private *echo.Group, h *handlers.Handlers) {
-	// admin routes
-	private.POST("/users-to-courses", h.GetUsersAndAssignedCourses)
-	private.POST("/update-users-to-courses", h.UpdateCourseEnrolment)
+func RegisterEnrolmentRoutes(private *Router, h *handlers.Handlers) {
+	// TODO: To be deprecated and replaced with paginated /users-to-courses/list endpoint once FE uses it
+	private.POST("/users-to-courses", h.GetUsersAndAssignedCourses, middleware.PermissionViewReports)
+	private.POST("/users-to-courses/list", h.ListUsersAndAssignedCourses, middleware.PermissionViewReports)
+	// TODO: To be deprecated and replaced with /enrolments/bulk endpoint once FE uses it
+	private.POST("/update-users-to-courses", h.UpdateCourseEnrolment, middleware.PermissionManageLearners)
+	private.POST("/enrolments/bulk", h.BulkUpdateEnrolments, middleware.PermissionManageLearners)
+	private.POST("/enrolment/due-date", h.SetEnrolmentDueDate, middleware.PermissionManageLearners)
 }
 
-func RegisterAuthRoutes(private *echo.Group, h *handlers.Handlers) {
-	// admin routes
-	private.POST("/register", h.Register)
+func RegisterGroupRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/groups", h.GetGroups, middleware.PermissionViewReports)
+	private.POST("/groups/add", h.AddGroup, middleware.PermissionManageLearners)
+	private.POST("/groups/update", h.UpdateGroup, middleware.PermissionManageLearners)
+	private.POST("/groups/delete", h.DeleteGroup, middleware.PermissionManageLearners)
+	private.POST("/groups/members/add", h.AddGroupMembers, middleware.PermissionManageLearners)
+	private.POST("/groups/members/remove", h.RemoveGroupMembers, middleware.PermissionManageLearners)
+	private.POST("/groups/courses/assign", h.AssignCourseToGroup, middleware.PermissionManageLearners)
+	private.POST("/groups/courses/unassign", h.UnassignCourseFromGroup, middleware.PermissionManageLearners)
+	private.POST("/groups/managers/add", h.AddGroupManagers, middleware.PermissionManageLearners)
+	private.POST("/groups/managers/remove", h.RemoveGroupManagers, middleware.PermissionManageLearners)
+}
+
+func RegisterAccessCodeRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/access-codes/redeem", h.RedeemAccessCode, middleware.PermissionLearn)
+
+	// Listed codes can be redeemed, so only those who can create codes can see them
+	private.POST("/access-codes", h.GetAccessCodes, middleware.PermissionManageLearners)
+	private.POST("/access-codes/redemptions", h.GetAccessCodeRedemptions, middleware.PermissionViewReports)
+	private.POST("/access-codes/add", h.AddAccessCode, middleware.PermissionManageLearners)
+	private.POST("/access-codes/revoke", h.RevokeAccessCode, middleware.PermissionManageLearners)
+}
+
+func RegisterAuthRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/register", h.Register, middleware.PermissionManageLearners)
+}
+
+func RegisterLocalAuthRoutes(public *echo.Group, h *handlers.Handlers) {
+	public.POST("/auth/sign-in", h.SignIn)
+	public.POST("/auth/set-password", h.SetPassword)
+}
+
+func RegisterUserRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/users/list", h.ListUsers, middleware.PermissionViewReports)
+	private.POST("/users/import", h.ImportUsers, middleware.PermissionManageLearners)
+	private.POST("/users/import/report", h.GetUserImport, middleware.PermissionManageLearners)
+	private.POST("/users/update", h.UpdateUser, middleware.PermissionManageLearners)
+	private.POST("/users/deactivate", h.DeactivateUser, middleware.PermissionManageLearners)
+	private.POST("/users/reactivate", h.ReactivateUser, middleware.PermissionManageLearners)
+	private.POST("/users/delete", h.DeleteUser, middleware.PermissionManageLearners)
+	private.POST("/users/export", h.ExportUserData, middleware.PermissionManageLearners)
+	private.POST("/users/erase", h.EraseUser, middleware.PermissionManageLearners)
+}
+
+func RegisterRoleRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/roles/me", h.GetOwnRole, middleware.PermissionLearn)
+	private.GET("/roles/me", h.GetOwnRole, middleware.PermissionLearn)
+
+	private.POST("/roles", h.GetUserRoles, middleware.PermissionManageRoles)
+	private.POST("/roles/set", h.SetUserRole, middleware.PermissionManageRoles)
+}
+
+func RegisterTeamRoutes(private *Router, h *handlers.Handlers) {
+	// Users only see the direct reports they are the line manager of
+	private.POST("/team/progress", h.ListTeamProgress, middleware.PermissionViewTeam)
+	private.POST("/team/compliance", h.GetTeamCompliance, middleware.PermissionViewTeam)
+	private.GET("/team/compliance", h.GetTeamCompliance, middleware.PermissionViewTeam)
+
+	private.POST("/users/manager/set", h.SetLineManager, middleware.PermissionManageLearners)
+}
+
+func RegisterAPIKeyRoutes(private *Router, h *handlers.Handlers) {
+	// Keys can read every user's data, so only admins can manage them
+	private.POST("/api-keys", h.GetAPIKeys, middleware.PermissionManageRoles)
+	private.POST("/api-keys/add", h.AddAPIKey, middleware.PermissionManageRoles)
+	private.POST("/api-keys/revoke", h.RevokeAPIKey, middleware.PermissionManageRoles)
+}
+
+func RegisterAuditRoutes(private *Router, h *handlers.Handlers) {
+	private.POST("/audit-log", h.ListAuditLog, middleware.PermissionManageRoles)
+	private.POST("/audit-log/verify", h.VerifyAuditLog, middleware.PermissionManageRoles)
+}
+
+func RegisterCertificateRoutes(private *Router, public *echo.Group, h *handlers.Handlers) {
+	// Anyone can check a certificate is genuine, e.g. by scanning the QR code printed on it
+	public.GET("/certificates/verify/:verificationId", h.VerifyCertificate)
+	private.POST("/certificates/revoke", h.RevokeCertificate, middleware.PermissionManageLearners)
+	private.POST("/courses/certificate-validity", h.SetCertificateValidity, middleware.PermissionManageCourses)
+}
+
+func RegisterEmailRoutes(private *Router, public *echo.Group, h *handlers.Handlers) {
+	// The email provider reports deliveries and bounces here, webhooks are verified by their signature
+	public.POST("/webhooks/email", h.EmailWebhook)
+	private.POST("/admin/emails/preview", h.PreviewEmail, middleware.PermissionManageLearners)
+	private.POST("/admin/emails/failed", h.ListFailedEmails, middleware.PermissionManageLearners)
+	private.POST("/admin/emails/failed/attempts", h.GetEmailAttempts, middleware.PermissionManageLearners)
+	private.POST("/admin/emails/failed/retry", h.RetryFailedEmail, middleware.PermissionManageLearners)
+	private.POST("/admin/emails/failed/discard", h.DiscardFailedEmail, middleware.PermissionManageLearners)
+	private.GET("/email-preferences", h.GetEmailPreferences, middleware.PermissionLearn)
+	private.POST("/email-preferences", h.SetEmailPreference, middleware.PermissionLearn)
 }
@@ -64,15 +64,16 @@ func New(h *handlers.Handlers, authProvider auth.AuthProvider, cfg *config.App)
 	// Private group with auth middleware
 	private := e.Group("/" + config.APIVersion)
 
-	if cfg.Environment == config.EnvironmentTest {
-		private.Use(middleware.TestAuthMiddleware)
-	} else {
-		private.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
-			return middleware.AuthMiddleware(next, authProvider)
-		})
-	}
+	private.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return middleware.AuthMiddleware(next, authProvider, h.Role, h.APIKey)
+	})
+
+	registerRoutes(NewRouter(private), public, h)
 
-	registerRoutes(private, public, h)
+	// Users of the local auth provider sign in with the server, Firebase users sign in with Firebase
+	if _, ok := authProvider.(auth.PasswordAuthProvider); ok {
+		RegisterLocalAuthRoutes(public, h)
+	}
 
 	e.Server.ReadTimeout = readTimeout
 	e.Server.WriteTimeout = writeTimeout
@@ -101,15 +102,24 @@ func (s *Server) Stop() error {
 	return s.echo.Shutdown(shutdownCtx)
 }
 
-func registerRoutes(private, public *echo.Group, h *handlers.Handlers) {
+func registerRoutes(private *Router, public *echo.Group, h *handlers.Handlers) {
 	public.GET("/health", h.HealthCheck)
 
 	RegisterAuthRoutes(private, h)
+	RegisterUserRoutes(private, h)
 	RegisterCourseRoutes(private, h)
 	RegisterProgressRoutes(private, h)
 	RegisterQuizRoutes(private, h)
 	RegisterMediaRoutes(private, h)
 	RegisterEnrolmentRoutes(private, h)
+	RegisterGroupRoutes(private, h)
+	RegisterAccessCodeRoutes(private, h)
+	RegisterRoleRoutes(private, h)
+	RegisterTeamRoutes(private, h)
+	RegisterAPIKeyRoutes(private, h)
+	RegisterAuditRoutes(private, h)
+	RegisterCertificateRoutes(private, public, h)
+	RegisterEmailRoutes(private, public, h)
 }
 
 type customValidator struct {
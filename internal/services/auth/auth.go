@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
@@ -12,6 +14,11 @@ import (
 
 type Token string
 
+var (
+	ErrEmailAlreadyExists = errors.New("email already exists")
+	ErrTokenRevoked       = errors.New("token has been revoked")
+)
+
 type Auth struct {
 	client *auth.Client
 }
@@ -21,11 +28,18 @@ type Auth struct {
 type AuthProvider interface {
 	CreateUser(ctx context.Context, email, password, name string) (string, error)
 	GetUserFromIDToken(ctx context.Context, token string) (*User, error)
+	GetPasswordSetupLink(ctx context.Context, email string) (string, error)
+	UpdateUser(ctx context.Context, id, email, name string) error
+	SetUserDisabled(ctx context.Context, id string, disabled bool) error
+	DeleteUser(ctx context.Context, id string) error
 }
 
 type User struct {
-	ID      string
-	IsAdmin bool
+	ID       string
+	IsAdmin  bool
+	Disabled bool
+	// TokenExpiresAt is when the token the user was verified from expires
+	TokenExpiresAt time.Time
 }
 
 func New(ctx context.Context, credentials string) (*Auth, error) {
@@ -55,9 +69,17 @@ func (a *Auth) GetUserFromIDToken(ctx context.Context, accessToken string) (*Use
 		return nil, err
 	}
 
+	// Revoking a user's tokens moves TokensValidAfterMillis forward, this is the same check as
+	// VerifyIDTokenAndCheckRevoked without fetching the user twice
+	if token.IssuedAt*1000 < userRecord.TokensValidAfterMillis {
+		return nil, ErrTokenRevoked
+	}
+
 	return &User{
-		ID:      userRecord.UID,
-		IsAdmin: isAdmin(token),
+		ID:             userRecord.UID,
+		IsAdmin:        isAdmin(token),
+		Disabled:       userRecord.Disabled,
+		TokenExpiresAt: time.Unix(token.Expires, 0),
 	}, nil
 }
 
@@ -75,6 +97,42 @@ func (a *Auth) CreateUser(ctx context.Context, email, password, name string) (st
 	return userRecord.UID, nil
 }
 
+func (a *Auth) UpdateUser(ctx context.Context, id, email, name string) error {
+	params := (&auth.UserToUpdate{}).
+		Email(email).
+		DisplayName(name)
+
+	_, err := a.client.UpdateUser(ctx, id, params)
+	if auth.IsEmailAlreadyExists(err) {
+		return ErrEmailAlreadyExists
+	}
+
+	return err
+}
+
+// SetUserDisabled stops a disabled user from signing in or refreshing their ID token,
+// tokens issued before they were disabled are rejected by checking User.Disabled
+func (a *Auth) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	_, err := a.client.UpdateUser(ctx, id, (&auth.UserToUpdate{}).Disabled(disabled))
+	return err
+}
+
+// DeleteUser treats a user that doesn't exist as already deleted, so a failed delete can be retried
+func (a *Auth) DeleteUser(ctx context.Context, id string) error {
+	err := a.client.DeleteUser(ctx, id)
+	if auth.IsUserNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// GetPasswordSetupLink returns a one-time link that lets a user choose their own password,
+// used for users created by an admin who are not told a password
+func (a *Auth) GetPasswordSetupLink(ctx context.Context, email string) (string, error) {
+	return a.client.PasswordResetLink(ctx, email)
+}
+
 func isAdmin(token *auth.Token) bool {
 	adminValue, ok := token.Claims[string(config.AdminRole)]
 	if !ok {
@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	stdErrors "errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+)
+
+const (
+	accessTokenTTL   = time.Hour
+	passwordSetupTTL = 72 * time.Hour
+	localIssuer      = "supanova-local"
+
+	accessPurpose        = "access"
+	passwordSetupPurpose = "password-setup"
+)
+
+var (
+	ErrInvalidCredentials = stdErrors.New("invalid email or password")
+	ErrUserDisabled       = stdErrors.New("user is disabled")
+	ErrInvalidToken       = stdErrors.New("invalid token")
+)
+
+//go:generate moq -out ../../handlers/mocks/passwordauthprovider_mock.go -pkg mocks . PasswordAuthProvider
+
+// PasswordAuthProvider is implemented by auth providers that sign users in themselves, rather than
+// users signing in with the provider directly from the client
+type PasswordAuthProvider interface {
+	AuthProvider
+	SignIn(ctx context.Context, email, password string) (string, error)
+	SetPassword(ctx context.Context, token, password string) error
+}
+
+// Local is an auth provider that stores users in Postgres and signs its own tokens, so the app can
+// run without Firebase in development and testing
+type Local struct {
+	store            LocalUserRepository
+	signingKey       []byte
+	passwordSetupURL string
+}
+
+type LocalUserRepository interface {
+	AddLocalAuthUser(ctx context.Context, user *LocalUser) error
+	GetLocalAuthUser(ctx context.Context, id string) (*LocalUser, error)
+	GetLocalAuthUserByEmail(ctx context.Context, email string) (*LocalUser, error)
+	UpdateLocalAuthUser(ctx context.Context, id, email, name string) error
+	SetLocalAuthUserDisabled(ctx context.Context, id string, disabled bool) error
+	SetLocalAuthUserPassword(ctx context.Context, id, passwordHash string) error
+	DeleteLocalAuthUser(ctx context.Context, id string) error
+}
+
+type LocalUser struct {
+	ID           string
+	Email        string
+	Name         string
+	PasswordHash string
+	Disabled     bool
+}
+
+type localClaims struct {
+	Purpose string `json:"purpose"`
+	// PasswordFingerprint ties a password setup token to the password it replaces, so the token
+	// can only be used once
+	PasswordFingerprint string `json:"pwd,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// NewLocal creates the local auth provider. Password setup links point at the client's
+// set-password page.
+func NewLocal(cfg *config.Auth, clientURL string, store LocalUserRepository) *Local {
+	return &Local{
+		store:            store,
+		signingKey:       []byte(cfg.LocalSigningKey),
+		passwordSetupURL: clientURL + "/set-password",
+	}
+}
+
+func (l *Local) CreateUser(ctx context.Context, email, password, name string) (string, error) {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return "", err
+	}
+
+	user := &LocalUser{
+		ID:           uuid.NewString(),
+		Email:        email,
+		Name:         name,
+		PasswordHash: hash,
+	}
+
+	err = l.store.AddLocalAuthUser(ctx, user)
+	if errors.IsUniqueViolationErr(err) {
+		return "", ErrEmailAlreadyExists
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return user.ID, nil
+}
+
+func (l *Local) GetUserFromIDToken(ctx context.Context, token string) (*User, error) {
+	claims, err := l.parseToken(token, accessPurpose)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := l.store.GetLocalAuthUser(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Local users have no admin claim, admins are granted a role with the user_roles table
+	return &User{
+		ID:             user.ID,
+		Disabled:       user.Disabled,
+		TokenExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// SignIn checks the user's password and returns an access token for them
+func (l *Local) SignIn(ctx context.Context, email, password string) (string, error) {
+	user, err := l.store.GetLocalAuthUserByEmail(ctx, email)
+	if errors.IsNotFoundErr(err) {
+		return "", ErrInvalidCredentials
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	if user.Disabled {
+		return "", ErrUserDisabled
+	}
+
+	return l.IssueToken(user.ID)
+}
+
+// IssueToken returns an access token for the user without checking their password
+func (l *Local) IssueToken(userID string) (string, error) {
+	return l.signToken(localClaims{
+		Purpose:          accessPurpose,
+		RegisteredClaims: registeredClaims(userID, accessTokenTTL),
+	})
+}
+
+// GetPasswordSetupLink returns a link to the client's set-password page, with a token that can be
+// used once to replace the user's password
+func (l *Local) GetPasswordSetupLink(ctx context.Context, email string) (string, error) {
+	user, err := l.store.GetLocalAuthUserByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := l.signToken(localClaims{
+		Purpose:             passwordSetupPurpose,
+		PasswordFingerprint: passwordFingerprint(user.PasswordHash),
+		RegisteredClaims:    registeredClaims(user.ID, passwordSetupTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return l.passwordSetupURL + "?token=" + url.QueryEscape(token), nil
+}
+
+// SetPassword replaces the user's password using the token from a password setup link
+func (l *Local) SetPassword(ctx context.Context, token, password string) error {
+	claims, err := l.parseToken(token, passwordSetupPurpose)
+	if err != nil {
+		return err
+	}
+
+	user, err := l.store.GetLocalAuthUser(ctx, claims.Subject)
+	if err != nil {
+		return err
+	}
+
+	if claims.PasswordFingerprint != passwordFingerprint(user.PasswordHash) {
+		return ErrInvalidToken
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return l.store.SetLocalAuthUserPassword(ctx, user.ID, hash)
+}
+
+func (l *Local) UpdateUser(ctx context.Context, id, email, name string) error {
+	err := l.store.UpdateLocalAuthUser(ctx, id, email, name)
+	if errors.IsUniqueViolationErr(err) {
+		return ErrEmailAlreadyExists
+	}
+
+	return err
+}
+
+func (l *Local) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	return l.store.SetLocalAuthUserDisabled(ctx, id, disabled)
+}
+
+func (l *Local) DeleteUser(ctx context.Context, id string) error {
+	return l.store.DeleteLocalAuthUser(ctx, id)
+}
+
+func (l *Local) signToken(claims localClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(l.signingKey)
+}
+
+func (l *Local) parseToken(token, purpose string) (*localClaims, error) {
+	var claims localClaims
+	_, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		func(*jwt.Token) (any, error) { return l.signingKey, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(localIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if claims.Purpose != purpose || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+func registeredClaims(userID string, ttl time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+
+	return jwt.RegisteredClaims{
+		Issuer:    localIssuer,
+		Subject:   userID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// passwordFingerprint changes whenever the password does, as every bcrypt hash has its own salt
+func passwordFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/services/metrics"
+)
+
+const (
+	cacheHit         = "hit"
+	cacheMiss        = "miss"
+	cacheRevalidated = "revalidated"
+)
+
+// TokenCache wraps an auth provider and keeps the users verified from access tokens until the
+// tokens expire, so most requests don't wait on the provider. Cached tokens are verified with the
+// provider again every revocation check interval, so revoked tokens and disabled users are rejected
+// without waiting for the token to expire.
+type TokenCache struct {
+	AuthProvider
+
+	mu            sync.Mutex
+	entries       map[[sha256.Size]byte]*list.Element
+	recent        *list.List // most recently used first
+	size          int
+	checkInterval time.Duration
+	now           func() time.Time
+}
+
+type tokenCacheEntry struct {
+	key       [sha256.Size]byte
+	user      User
+	checkedAt time.Time
+}
+
+func NewTokenCache(provider AuthProvider, cfg *config.Auth) *TokenCache {
+	return &TokenCache{
+		AuthProvider:  provider,
+		entries:       make(map[[sha256.Size]byte]*list.Element, cfg.TokenCacheSize),
+		recent:        list.New(),
+		size:          cfg.TokenCacheSize,
+		checkInterval: cfg.RevocationCheckInterval,
+		now:           time.Now,
+	}
+}
+
+// GetUserFromIDToken returns the cached user for the token, verifying it with the provider if it
+// isn't cached or its revocation check is due
+func (c *TokenCache) GetUserFromIDToken(ctx context.Context, token string) (*User, error) {
+	// Tokens are keyed by their hash so the cache doesn't hold usable tokens
+	key := sha256.Sum256([]byte(token))
+	now := c.now()
+
+	result := cacheMiss
+	if user, found, checkDue := c.get(key, now); found {
+		if !checkDue {
+			metrics.TokenCacheLookupsTotal.WithLabelValues(cacheHit).Inc()
+			return user, nil
+		}
+
+		result = cacheRevalidated
+	}
+	metrics.TokenCacheLookupsTotal.WithLabelValues(result).Inc()
+
+	user, err := c.AuthProvider.GetUserFromIDToken(ctx, token)
+	if err != nil || user == nil || user.Disabled || !user.TokenExpiresAt.After(now) {
+		c.remove(key)
+		return user, err
+	}
+
+	c.add(key, user, now)
+
+	return user, nil
+}
+
+// SetUserDisabled removes the user's cached tokens once they're disabled, rather than waiting for
+// the next revocation check
+func (c *TokenCache) SetUserDisabled(ctx context.Context, id string, disabled bool) error {
+	if err := c.AuthProvider.SetUserDisabled(ctx, id, disabled); err != nil {
+		return err
+	}
+
+	c.removeUser(id)
+
+	return nil
+}
+
+func (c *TokenCache) DeleteUser(ctx context.Context, id string) error {
+	if err := c.AuthProvider.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+
+	c.removeUser(id)
+
+	return nil
+}
+
+// get returns a copy of the cached user, so callers can't change the cached one. Expired tokens
+// are removed and reported as not found.
+func (c *TokenCache) get(key [sha256.Size]byte, now time.Time) (user *User, found, checkDue bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false, false
+	}
+
+	entry := elem.Value.(*tokenCacheEntry)
+	if !entry.user.TokenExpiresAt.After(now) {
+		c.removeElement(elem)
+		return nil, false, false
+	}
+
+	c.recent.MoveToFront(elem)
+	cached := entry.user
+
+	return &cached, true, now.Sub(entry.checkedAt) >= c.checkInterval
+}
+
+func (c *TokenCache) add(key [sha256.Size]byte, user *User, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*tokenCacheEntry)
+		entry.user = *user
+		entry.checkedAt = now
+		c.recent.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.recent.PushFront(&tokenCacheEntry{key: key, user: *user, checkedAt: now})
+
+	for c.recent.Len() > c.size {
+		c.removeElement(c.recent.Back())
+	}
+
+	metrics.TokenCacheEntries.Set(float64(c.recent.Len()))
+}
+
+func (c *TokenCache) remove(key [sha256.Size]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *TokenCache) removeUser(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.recent.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*tokenCacheEntry).user.ID == id {
+			c.removeElement(elem)
+		}
+		elem = next
+	}
+}
+
+// removeElement must be called with the lock held
+func (c *TokenCache) removeElement(elem *list.Element) {
+	entry := c.recent.Remove(elem).(*tokenCacheEntry)
+	delete(c.entries, entry.key)
+
+	metrics.TokenCacheEntries.Set(float64(c.recent.Len()))
+}
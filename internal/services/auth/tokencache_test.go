@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+)
+
+var (
+	errTokenRevoked = errors.New("token revoked")
+	errTokenExpired = errors.New("token expired")
+	errUserNotFound = errors.New("user not found")
+)
+
+var cacheStart = time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+type fakeToken struct {
+	userID    string
+	expiresAt time.Time
+}
+
+// fakeProvider verifies tokens like Firebase, rejecting revoked and expired tokens and deleted users
+type fakeProvider struct {
+	AuthProvider
+
+	clock    *fakeClock
+	tokens   map[string]fakeToken
+	revoked  map[string]bool
+	disabled map[string]bool
+	deleted  map[string]bool
+	calls    int
+}
+
+func newFakeProvider(clock *fakeClock) *fakeProvider {
+	return &fakeProvider{
+		clock: clock,
+		tokens: map[string]fakeToken{
+			"token-a":  {userID: "user-1", expiresAt: cacheStart.Add(time.Hour)},
+			"token-a2": {userID: "user-1", expiresAt: cacheStart.Add(time.Hour)},
+			"token-b":  {userID: "user-2", expiresAt: cacheStart.Add(3 * time.Minute)},
+			"token-c":  {userID: "user-3", expiresAt: cacheStart.Add(time.Hour)},
+		},
+		revoked:  map[string]bool{},
+		disabled: map[string]bool{},
+		deleted:  map[string]bool{},
+	}
+}
+
+func (p *fakeProvider) GetUserFromIDToken(_ context.Context, token string) (*User, error) {
+	p.calls++
+
+	t := p.tokens[token]
+	switch {
+	case p.revoked[token]:
+		return nil, errTokenRevoked
+	case p.deleted[t.userID]:
+		return nil, errUserNotFound
+	case !t.expiresAt.After(p.clock.now):
+		return nil, errTokenExpired
+	}
+
+	return &User{ID: t.userID, Disabled: p.disabled[t.userID], TokenExpiresAt: t.expiresAt}, nil
+}
+
+func (p *fakeProvider) SetUserDisabled(_ context.Context, id string, disabled bool) error {
+	p.disabled[id] = disabled
+	return nil
+}
+
+func (p *fakeProvider) DeleteUser(_ context.Context, id string) error {
+	p.deleted[id] = true
+	return nil
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// cacheStep advances the clock then either looks up a token, or revokes a token, disables or
+// deletes a user
+type cacheStep struct {
+	advance time.Duration
+	token   string
+	revoke  string
+	disable string
+	delete  string
+	// wantCalls is the total number of times the provider has verified a token after the step
+	wantCalls    int
+	wantErr      error
+	wantDisabled bool
+}
+
+func TestTokenCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       int
+		steps      []cacheStep
+		wantCached int
+	}{
+		{
+			name: "cached tokens are verified again once the revocation check is due",
+			size: 10,
+			steps: []cacheStep{
+				{token: "token-a", wantCalls: 1},
+				{advance: time.Minute, token: "token-a", wantCalls: 1},
+				{advance: 4 * time.Minute, token: "token-a", wantCalls: 2},
+				{advance: time.Minute, token: "token-a", wantCalls: 2},
+			},
+			wantCached: 1,
+		},
+		{
+			name: "revoked tokens are rejected at the next revocation check",
+			size: 10,
+			steps: []cacheStep{
+				{token: "token-a", wantCalls: 1},
+				{revoke: "token-a", wantCalls: 1},
+				{advance: time.Minute, token: "token-a", wantCalls: 1},
+				{advance: 4 * time.Minute, token: "token-a", wantCalls: 2, wantErr: errTokenRevoked},
+				{token: "token-a", wantCalls: 3, wantErr: errTokenRevoked},
+			},
+			wantCached: 0,
+		},
+		{
+			name: "tokens expire at TokenExpiresAt before the revocation check is due",
+			size: 10,
+			steps: []cacheStep{
+				{token: "token-b", wantCalls: 1},
+				{advance: 2*time.Minute + 59*time.Second, token: "token-b", wantCalls: 1},
+				{advance: time.Second, token: "token-b", wantCalls: 2, wantErr: errTokenExpired},
+			},
+			wantCached: 0,
+		},
+		{
+			name: "the least recently used token is evicted at capacity",
+			size: 2,
+			steps: []cacheStep{
+				{token: "token-a", wantCalls: 1},
+				{token: "token-b", wantCalls: 2},
+				{token: "token-a", wantCalls: 2},
+				{token: "token-c", wantCalls: 3},
+				{token: "token-a", wantCalls: 3},
+				{token: "token-c", wantCalls: 3},
+				{token: "token-b", wantCalls: 4},
+			},
+			wantCached: 2,
+		},
+		{
+			name: "disabling a user removes all their cached tokens",
+			size: 10,
+			steps: []cacheStep{
+				{token: "token-a", wantCalls: 1},
+				{token: "token-a2", wantCalls: 2},
+				{token: "token-c", wantCalls: 3},
+				{disable: "user-1", wantCalls: 3},
+				{token: "token-a", wantCalls: 4, wantDisabled: true},
+				{token: "token-a2", wantCalls: 5, wantDisabled: true},
+				{token: "token-c", wantCalls: 5},
+				{token: "token-a", wantCalls: 6, wantDisabled: true},
+			},
+			wantCached: 1,
+		},
+		{
+			name: "deleting a user removes all their cached tokens",
+			size: 10,
+			steps: []cacheStep{
+				{token: "token-a", wantCalls: 1},
+				{token: "token-a2", wantCalls: 2},
+				{token: "token-c", wantCalls: 3},
+				{delete: "user-1", wantCalls: 3},
+				{token: "token-a", wantCalls: 4, wantErr: errUserNotFound},
+				{token: "token-a2", wantCalls: 5, wantErr: errUserNotFound},
+				{token: "token-c", wantCalls: 5},
+			},
+			wantCached: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clock := &fakeClock{now: cacheStart}
+			provider := newFakeProvider(clock)
+
+			cache := NewTokenCache(provider, &config.Auth{
+				TokenCacheSize:          tt.size,
+				RevocationCheckInterval: 5 * time.Minute,
+			})
+			cache.now = clock.Now
+
+			for i, step := range tt.steps {
+				clock.now = clock.now.Add(step.advance)
+
+				switch {
+				case step.revoke != "":
+					provider.revoked[step.revoke] = true
+				case step.disable != "":
+					if err := cache.SetUserDisabled(ctx, step.disable, true); err != nil {
+						t.Fatalf("step %d: failed to disable user: %v", i, err)
+					}
+				case step.delete != "":
+					if err := cache.DeleteUser(ctx, step.delete); err != nil {
+						t.Fatalf("step %d: failed to delete user: %v", i, err)
+					}
+				default:
+					user, err := cache.GetUserFromIDToken(ctx, step.token)
+					if !errors.Is(err, step.wantErr) {
+						t.Fatalf("step %d: expected error %v, got %v", i, step.wantErr, err)
+					}
+
+					wantUserID := provider.tokens[step.token].userID
+					if err == nil && (user == nil || user.ID != wantUserID || user.Disabled != step.wantDisabled) {
+						t.Errorf("step %d: expected user %s (disabled %t), got %+v", i, wantUserID, step.wantDisabled, user)
+					}
+				}
+
+				if provider.calls != step.wantCalls {
+					t.Errorf("step %d: expected %d provider calls, got %d", i, step.wantCalls, provider.calls)
+				}
+			}
+
+			if cache.recent.Len() != tt.wantCached || len(cache.entries) != tt.wantCached {
+				t.Errorf("expected %d cached tokens, got %d (%d keys)", tt.wantCached, cache.recent.Len(), len(cache.entries))
+			}
+		})
+	}
+}
+
+func TestTokenCache_ReturnsCopy(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: cacheStart}
+	provider := newFakeProvider(clock)
+
+	cache := NewTokenCache(provider, &config.Auth{TokenCacheSize: 10, RevocationCheckInterval: 5 * time.Minute})
+	cache.now = clock.Now
+
+	user, err := cache.GetUserFromIDToken(ctx, "token-a")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	user.IsAdmin = true
+
+	cached, err := cache.GetUserFromIDToken(ctx, "token-a")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cached.IsAdmin {
+		t.Error("expected changes to a returned user not to change the cached user")
+	}
+}
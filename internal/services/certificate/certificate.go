@@ -0,0 +1,204 @@
+package certificate
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// ContentType is the content type certificates are stored with
+const ContentType = "application/pdf"
+
+// certificateTemplate is the PDF content stream of the certificate's page, the text is set in the
+// standard Helvetica fonts so nothing has to be embedded in the PDF
+//
+//go:embed certificate.tmpl
+var certificateTemplate string
+
+var pageTemplate = template.Must(template.New("certificate").Funcs(template.FuncMap{
+	"pdf":      pdfString,
+	"truncate": truncate,
+}).Parse(certificateTemplate))
+
+// Renderer renders certificates as PDFs. If it has a verification URL, certificates have a QR code
+// linking to their public verification page, which is the URL followed by the verification ID.
+type Renderer struct {
+	verifyURL string
+}
+
+func New(verifyURL string) *Renderer {
+	return &Renderer{verifyURL: strings.TrimSuffix(verifyURL, "/")}
+}
+
+type Data struct {
+	Number          string
+	VerificationID  string
+	LearnerName     string
+	CourseTitle     string
+	CompletionTitle string
+	CompletionDate  string
+}
+
+type pageData struct {
+	*Data
+	QRCode string
+}
+
+// VerificationURL returns the certificate's public verification page, or an empty string if there's
+// no verification URL
+func (r *Renderer) VerificationURL(verificationID string) string {
+	if r.verifyURL == "" || verificationID == "" {
+		return ""
+	}
+
+	return r.verifyURL + "/" + verificationID
+}
+
+// Render returns the certificate as a single page PDF
+func (r *Renderer) Render(data *Data) ([]byte, error) {
+	page := pageData{Data: data}
+	if url := r.VerificationURL(data.VerificationID); url != "" {
+		code, err := qrCode(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render certificate QR code: %w", err)
+		}
+		page.QRCode = code
+	}
+
+	var content bytes.Buffer
+	if err := pageTemplate.Execute(&content, page); err != nil {
+		return nil, fmt.Errorf("failed to render certificate: %w", err)
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 842 595] " +
+			"/Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		fmt.Sprintf("<< /Title (%s) /Producer (Supanova) >>", pdfString("Certificate "+data.Number)),
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(
+		&pdf,
+		"trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1,
+		len(objects),
+		xref,
+	)
+
+	return pdf.Bytes(), nil
+}
+
+// The QR code is drawn in the bottom right corner of the page, below the footer's line
+const (
+	qrCodeX    = 698.0
+	qrCodeY    = 42.0
+	qrCodeSize = 72.0
+)
+
+// qrCode returns the PDF drawing operators for a QR code of the content, each run of dark modules
+// in a row is filled as one rectangle
+func qrCode(content string) (string, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	code.DisableBorder = true
+
+	bitmap := code.Bitmap()
+	module := qrCodeSize / float64(len(bitmap))
+
+	var b strings.Builder
+	for row, modules := range bitmap {
+		// PDF coordinates start at the bottom of the page
+		y := qrCodeY + float64(len(bitmap)-row-1)*module
+		for col := 0; col < len(modules); col++ {
+			if !modules[col] {
+				continue
+			}
+
+			start := col
+			for col < len(modules) && modules[col] {
+				col++
+			}
+			fmt.Fprintf(&b, "%.2f %.2f %.2f %.2f re\n", qrCodeX+float64(start)*module, y, float64(col-start)*module, module)
+		}
+	}
+
+	return b.String(), nil
+}
+
+const (
+	asciiDelete  = 0x7f
+	latin1Start  = 0xa0
+	latin1Finish = 0xff
+)
+
+// winAnsi maps the characters outside Latin-1 that are common in names and titles to their
+// WinAnsiEncoding codes
+var winAnsi = map[rune]byte{
+	'‘': 0x91,
+	'’': 0x92,
+	'“': 0x93,
+	'”': 0x94,
+	'–': 0x96,
+	'—': 0x97,
+	'…': 0x85,
+	'€': 0x80,
+}
+
+// pdfString escapes text for a PDF string literal in WinAnsiEncoding, characters the standard fonts
+// can't show are replaced with a question mark
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ':
+			b.WriteByte(' ')
+		case r < asciiDelete:
+			b.WriteRune(r)
+		case r >= latin1Start && r <= latin1Finish:
+			b.WriteByte(byte(r))
+		case winAnsi[r] != 0:
+			b.WriteByte(winAnsi[r])
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+// truncate shortens text so it fits on the page, the standard fonts aren't measured
+func truncate(maxLength int, text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	return strings.TrimSpace(string(runes[:maxLength-1])) + "…"
+}
@@ -15,7 +15,8 @@ type Cron struct {
 
 func New(schedule, jobName string) *Cron {
 	return &Cron{
-		client:   cron.New(),
+		// A job that's still running when it's next due is skipped, so runs never overlap
+		client:   cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger))),
 		schedule: schedule,
 		jobName:  jobName,
 	}
@@ -0,0 +1,203 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/metrics"
+)
+
+const (
+	// dispatchBatchSize is the most emails sent each time the dispatcher runs, the rest wait for
+	// the next run
+	dispatchBatchSize = 50
+	// dispatchLease is how long emails claimed by the dispatcher are hidden from other servers, it
+	// must be longer than it takes to send a batch
+	dispatchLease = 10 * time.Minute
+	// maxSendAttempts is how many times an email is tried before it's dead-lettered
+	maxSendAttempts = 8
+	// Retries back off exponentially from retryBaseDelay, up to retryMaxDelay
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = 6 * time.Hour
+)
+
+// NewOutboxEmail prepares an email to be queued in the outbox, for the store to queue in the same
+// transaction as the change it's about
+func NewOutboxEmail(params EmailParams, templateName, emailName string) (*domain.OutboxEmail, error) {
+	paramBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template params: %w", err)
+	}
+
+	return &domain.OutboxEmail{
+		EmailName:      emailName,
+		TemplateName:   templateName,
+		TemplateParams: paramBytes,
+	}, nil
+}
+
+// DispatchJob sends the queued emails that are due. Failed sends are retried with exponential
+// backoff, and dead-lettered after maxSendAttempts. Emails that can never be sent, because their
+// email name or params aren't recognised, are dead-lettered straight away. Emails are claimed
+// before they're sent, so servers dispatching at the same time don't send the same email.
+func (e *EmailService) DispatchJob(ctx context.Context) {
+	defer e.recordFailureMetrics(ctx)
+
+	queued, err := e.store.ClaimDueEmails(ctx, dispatchBatchSize, dispatchLease)
+	if err != nil {
+		slog.Error(errors.Getting("queued emails"), slog.Any("error", err))
+		return
+	}
+
+	if len(queued) == 0 {
+		slog.Debug("no queued emails to send")
+		return
+	}
+
+	for i := range queued {
+		e.dispatch(ctx, &queued[i])
+	}
+}
+
+func (e *EmailService) dispatch(ctx context.Context, queued *QueuedEmail) {
+	params, err := decodeParams(queued.EmailName, queued.TemplateParams)
+	if err != nil {
+		slog.Error("dead-lettering email that can't be sent", slog.Any("error", err), slog.String("id", queued.ID.String()))
+		e.setEmailDead(ctx, queued, err)
+		return
+	}
+
+	optedOut, err := e.isOptedOut(ctx, params, queued.EmailName)
+	if err != nil {
+		e.handleSendFailure(ctx, queued, err)
+		return
+	}
+
+	if optedOut {
+		if err := e.store.SetEmailSkipped(ctx, queued.ID); err != nil {
+			slog.Error(errors.Updating("skipped email"), slog.Any("error", err), slog.String("id", queued.ID.String()))
+		}
+		return
+	}
+
+	messageID, err := e.deliver(ctx, params, queued.TemplateName, queued.EmailName)
+	if err != nil {
+		e.handleSendFailure(ctx, queued, err)
+		return
+	}
+
+	if err := e.store.SetEmailSent(ctx, queued.ID, messageID); err != nil {
+		slog.Error(errors.Updating("sent email"), slog.Any("error", err), slog.String("id", queued.ID.String()))
+		return
+	}
+
+	slog.Debug("email sent", slog.String("id", queued.ID.String()), slog.String("email_name", queued.EmailName))
+}
+
+// deliver sends the email with the provider, returning the ID the provider gave the message
+func (e *EmailService) deliver(ctx context.Context, params EmailParams, templateName, emailName string) (string, error) {
+	message, err := e.newMessage(params, templateName, emailName)
+	if err != nil {
+		return "", err
+	}
+
+	return e.provider.Send(ctx, message)
+}
+
+func (e *EmailService) handleSendFailure(ctx context.Context, queued *QueuedEmail, sendErr error) {
+	attempts := queued.Attempts + 1
+	if attempts >= maxSendAttempts {
+		slog.Error("email failed too many times, dead-lettering it", slog.Any("error", sendErr), slog.String("id", queued.ID.String()))
+		e.setEmailDead(ctx, queued, sendErr)
+		return
+	}
+
+	slog.Warn(
+		"email send failed, retrying later",
+		slog.Any("error", sendErr),
+		slog.String("id", queued.ID.String()),
+		slog.Int("attempts", attempts),
+	)
+
+	err := e.store.SetEmailRetry(ctx, queued.ID, sendErr.Error(), time.Now().Add(retryDelay(attempts)))
+	if err != nil {
+		slog.Error(errors.Updating("failed email"), slog.Any("error", err), slog.String("id", queued.ID.String()))
+	}
+}
+
+func (e *EmailService) setEmailDead(ctx context.Context, queued *QueuedEmail, sendErr error) {
+	if err := e.store.SetEmailDead(ctx, queued.ID, sendErr.Error()); err != nil {
+		slog.Error(errors.Updating("dead-lettered email"), slog.Any("error", err), slog.String("id", queued.ID.String()))
+	}
+}
+
+// retryDelay doubles retryBaseDelay for each failed attempt after the first, up to retryMaxDelay
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, retryMaxDelay)
+}
+
+// decodeParams unmarshals the template params of a queued email into the email's params type
+func decodeParams(emailName string, data []byte) (EmailParams, error) {
+	var params EmailParams
+
+	switch emailName {
+	case courseCompletionEmail:
+		params = &CourseCompletionParams{}
+	case overdueEnrolmentEmail:
+		params = &OverdueEnrolmentParams{}
+	case invitationEmail:
+		params = &InvitationParams{}
+	case managerDigestEmail:
+		params = &ManagerDigestParams{}
+	case progressResetEmail:
+		params = &ProgressResetParams{}
+	case enrolmentEmail:
+		params = &EnrolmentParams{}
+	case notStartedReminderEmail:
+		params = &NotStartedReminderParams{}
+	case completionConfirmationEmail:
+		params = &CompletionConfirmationParams{}
+	case certificateExpiryEmail:
+		params = &CertificateExpiryParams{}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEmail, emailName)
+	}
+
+	if err := json.Unmarshal(data, params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal template params: %w", err)
+	}
+
+	return params, nil
+}
+
+// recordFailureMetrics sets the email failures gauge to the number of failed emails waiting to be
+// retried and dead-lettered
+func (e *EmailService) recordFailureMetrics(ctx context.Context) {
+	counts, err := e.store.CountFailedEmails(ctx)
+	if err != nil {
+		slog.Error(errors.Getting("failed email counts"), slog.Any("error", err))
+		return
+	}
+
+	for _, status := range []domain.EmailStatus{domain.EmailStatusPending, domain.EmailStatusDead} {
+		metrics.EmailFailures.WithLabelValues(string(status)).Set(float64(counts[status]))
+	}
+}
+
+func (e *EmailService) StopDispatcher(ctx context.Context) {
+	e.stopDispatch() // cancel cron contexts to prevent new jobs from starting
+
+	stopDispatchCtx := e.dispatchCron.Stop() // returns a context that waits until existing cron jobs finish
+	<-stopDispatchCtx.Done()
+	slog.Info("email dispatch cron jobs completed")
+}
@@ -0,0 +1,289 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+)
+
+var errSendFailed = errors.New("provider unavailable")
+
+type retryCall struct {
+	id            uuid.UUID
+	sendErr       string
+	nextAttemptAt time.Time
+}
+
+type deadCall struct {
+	id      uuid.UUID
+	sendErr string
+}
+
+// fakeEmailStore hands out the queued emails and records what the dispatcher does with them
+type fakeEmailStore struct {
+	EmailRepository
+
+	queued  []QueuedEmail
+	sent    []uuid.UUID
+	retries []retryCall
+	dead    []deadCall
+}
+
+func (s *fakeEmailStore) ClaimDueEmails(_ context.Context, _ int, _ time.Duration) ([]QueuedEmail, error) {
+	return s.queued, nil
+}
+
+func (s *fakeEmailStore) SetEmailSent(_ context.Context, id uuid.UUID, _ string) error {
+	s.sent = append(s.sent, id)
+	return nil
+}
+
+func (s *fakeEmailStore) SetEmailRetry(_ context.Context, id uuid.UUID, sendErr string, nextAttemptAt time.Time) error {
+	s.retries = append(s.retries, retryCall{id: id, sendErr: sendErr, nextAttemptAt: nextAttemptAt})
+	return nil
+}
+
+func (s *fakeEmailStore) SetEmailDead(_ context.Context, id uuid.UUID, sendErr string) error {
+	s.dead = append(s.dead, deadCall{id: id, sendErr: sendErr})
+	return nil
+}
+
+func (s *fakeEmailStore) CountFailedEmails(_ context.Context) (map[domain.EmailStatus]int64, error) {
+	return map[domain.EmailStatus]int64{}, nil
+}
+
+// fakeSendProvider records the messages sent, or fails every send with err
+type fakeSendProvider struct {
+	err  error
+	sent []*Message
+}
+
+func (p *fakeSendProvider) Send(_ context.Context, message *Message) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+
+	p.sent = append(p.sent, message)
+	return uuid.NewString(), nil
+}
+
+func newTestEmailService(t *testing.T, store EmailRepository, provider Provider) *EmailService {
+	t.Helper()
+
+	templates, err := LoadTemplates("")
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+
+	return &EmailService{
+		provider:  provider,
+		templates: templates,
+		sender:    "noreply@supanova.test",
+		recipient: "admin@supanova.test",
+		store:     store,
+	}
+}
+
+func queuedOverdueEmail(t *testing.T, attempts int) QueuedEmail {
+	t.Helper()
+
+	params, err := json.Marshal(OverdueEnrolmentParams{
+		CourseName: "Fire Safety",
+		UserName:   "User A",
+		UserEmail:  "usera@test.com",
+		DueDate:    "01/07/2026",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal params: %v", err)
+	}
+
+	return QueuedEmail{
+		ID:             uuid.New(),
+		EmailName:      overdueEnrolmentEmail,
+		TemplateParams: params,
+		Attempts:       attempts,
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Minute},
+		{attempts: 2, expected: 2 * time.Minute},
+		{attempts: 3, expected: 4 * time.Minute},
+		{attempts: 4, expected: 8 * time.Minute},
+		{attempts: 5, expected: 16 * time.Minute},
+		{attempts: 6, expected: 32 * time.Minute},
+		{attempts: 7, expected: 64 * time.Minute},
+		{attempts: 8, expected: 128 * time.Minute},
+		{attempts: 9, expected: 256 * time.Minute},
+		{attempts: 10, expected: 6 * time.Hour},
+		{attempts: 50, expected: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if actual := retryDelay(tt.attempts); actual != tt.expected {
+			t.Errorf("retryDelay(%d): expected %s, got %s", tt.attempts, tt.expected, actual)
+		}
+	}
+}
+
+func TestHandleSendFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		// attempts is how many times the email had failed before this attempt
+		attempts  int
+		wantDelay time.Duration
+		wantDead  bool
+	}{
+		{
+			name:      "retries after a minute the first time it fails",
+			attempts:  0,
+			wantDelay: time.Minute,
+		},
+		{
+			name:      "doubles the delay for each failed attempt",
+			attempts:  3,
+			wantDelay: 8 * time.Minute,
+		},
+		{
+			name:      "retries the last attempt before dead-lettering",
+			attempts:  maxSendAttempts - 2,
+			wantDelay: 64 * time.Minute,
+		},
+		{
+			name:     "dead-letters the email once it has failed maxSendAttempts times",
+			attempts: maxSendAttempts - 1,
+			wantDead: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeEmailStore{}
+			service := newTestEmailService(t, store, &fakeSendProvider{})
+			queued := queuedOverdueEmail(t, tt.attempts)
+
+			start := time.Now()
+			service.handleSendFailure(context.Background(), &queued, errSendFailed)
+			end := time.Now()
+
+			if tt.wantDead {
+				expected := []deadCall{{id: queued.ID, sendErr: errSendFailed.Error()}}
+				if diff := cmp.Diff(expected, store.dead, cmp.AllowUnexported(deadCall{})); diff != "" {
+					t.Errorf("dead-lettered emails mismatch (-want +got):\n%s", diff)
+				}
+				if len(store.retries) != 0 {
+					t.Errorf("expected no retries, got %d", len(store.retries))
+				}
+				return
+			}
+
+			if len(store.dead) != 0 {
+				t.Errorf("expected no dead-lettered emails, got %d", len(store.dead))
+			}
+			if len(store.retries) != 1 {
+				t.Fatalf("expected 1 retry, got %d", len(store.retries))
+			}
+
+			retry := store.retries[0]
+			if retry.id != queued.ID || retry.sendErr != errSendFailed.Error() {
+				t.Errorf("expected retry of %s with %q, got %+v", queued.ID, errSendFailed, retry)
+			}
+			if retry.nextAttemptAt.Before(start.Add(tt.wantDelay)) || retry.nextAttemptAt.After(end.Add(tt.wantDelay)) {
+				t.Errorf("expected next attempt %s after the failure, got %s", tt.wantDelay, retry.nextAttemptAt.Sub(start))
+			}
+		})
+	}
+}
+
+func TestDecodeParams(t *testing.T) {
+	t.Run("decodes the params of a known email", func(t *testing.T) {
+		queued := queuedOverdueEmail(t, 0)
+
+		params, err := decodeParams(queued.EmailName, queued.TemplateParams)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected := &OverdueEnrolmentParams{
+			CourseName: "Fire Safety",
+			UserName:   "User A",
+			UserEmail:  "usera@test.com",
+			DueDate:    "01/07/2026",
+		}
+		if diff := cmp.Diff(EmailParams(expected), params); diff != "" {
+			t.Errorf("params mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("rejects an unknown email", func(t *testing.T) {
+		_, err := decodeParams("unknown-email", []byte(`{}`))
+		if !errors.Is(err, ErrUnknownEmail) {
+			t.Errorf("expected %v, got %v", ErrUnknownEmail, err)
+		}
+	})
+
+	t.Run("rejects params that aren't valid JSON", func(t *testing.T) {
+		_, err := decodeParams(overdueEnrolmentEmail, []byte(`{`))
+		if err == nil {
+			t.Error("expected an error, got nil")
+		}
+	})
+}
+
+func TestDispatchJob(t *testing.T) {
+	t.Run("sends due emails and marks them sent", func(t *testing.T) {
+		queued := queuedOverdueEmail(t, 0)
+		store := &fakeEmailStore{queued: []QueuedEmail{queued}}
+		provider := &fakeSendProvider{}
+
+		newTestEmailService(t, store, provider).DispatchJob(context.Background())
+
+		if len(provider.sent) != 1 || provider.sent[0].To != "usera@test.com" {
+			t.Fatalf("expected 1 email sent to usera@test.com, got %+v", provider.sent)
+		}
+		if diff := cmp.Diff([]uuid.UUID{queued.ID}, store.sent); diff != "" {
+			t.Errorf("sent emails mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("retries emails the provider fails to send", func(t *testing.T) {
+		queued := queuedOverdueEmail(t, 0)
+		store := &fakeEmailStore{queued: []QueuedEmail{queued}}
+
+		newTestEmailService(t, store, &fakeSendProvider{err: errSendFailed}).DispatchJob(context.Background())
+
+		if len(store.retries) != 1 || store.retries[0].id != queued.ID {
+			t.Errorf("expected a retry of %s, got %+v", queued.ID, store.retries)
+		}
+		if len(store.sent) != 0 || len(store.dead) != 0 {
+			t.Errorf("expected no sent or dead-lettered emails, got %d sent and %d dead", len(store.sent), len(store.dead))
+		}
+	})
+
+	t.Run("dead-letters emails that can never be sent straight away", func(t *testing.T) {
+		queued := queuedOverdueEmail(t, 0)
+		queued.EmailName = "unknown-email"
+		store := &fakeEmailStore{queued: []QueuedEmail{queued}}
+		provider := &fakeSendProvider{}
+
+		newTestEmailService(t, store, provider).DispatchJob(context.Background())
+
+		if len(store.dead) != 1 || store.dead[0].id != queued.ID {
+			t.Errorf("expected %s to be dead-lettered, got %+v", queued.ID, store.dead)
+		}
+		if len(store.retries) != 0 || len(provider.sent) != 0 {
+			t.Errorf("expected no retries or sends, got %d retries and %d sends", len(store.retries), len(provider.sent))
+		}
+	})
+}
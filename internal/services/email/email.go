@@ -2,64 +2,110 @@ package email
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"log/slog"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/mailgun/mailgun-go/v5"
 
 	"github.com/supanova-rp/supanova-server/internal/config"
-	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/services/cron"
-	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
-	"github.com/supanova-rp/supanova-server/internal/utils"
 )
 
 type EmailService struct {
-	client        *mailgun.Client
+	provider      Provider
+	templates     *Templates
 	sender        string
 	recipient     string
-	domain        string
 	templateNames *TemplateNames
 	emailNames    *EmailNames
 	store         EmailRepository
-	retryCron     *cron.Cron
-	stopRetry     context.CancelFunc
+	dispatchCron  *cron.Cron
+	stopDispatch  context.CancelFunc
 }
 
 type EmailRepository interface {
-	AddFailedEmail(context.Context, sqlc.AddFailedEmailParams) error
-	GetFailedEmails(context.Context) ([]FailedEmail, error)
-	UpdateFailedEmail(context.Context, sqlc.UpdateFailedEmailParams) error
-	DeleteFailedEmail(context.Context, pgtype.UUID) error
+	EnqueueEmail(ctx context.Context, outboxEmail *domain.OutboxEmail) error
+	ClaimDueEmails(ctx context.Context, limit int, lease time.Duration) ([]QueuedEmail, error)
+	SetEmailSent(ctx context.Context, id uuid.UUID, providerMessageID string) error
+	SetEmailSkipped(ctx context.Context, id uuid.UUID) error
+	SetEmailRetry(ctx context.Context, id uuid.UUID, sendErr string, nextAttemptAt time.Time) error
+	SetEmailDead(ctx context.Context, id uuid.UUID, sendErr string) error
+	CountFailedEmails(ctx context.Context) (map[domain.EmailStatus]int64, error)
+	IsEmailOptedOut(ctx context.Context, userID, emailName string) (bool, error)
 }
 
-type FailedEmail struct {
+// QueuedEmail is an email in the outbox that is due to be sent, claimed by this server
+type QueuedEmail struct {
 	ID             uuid.UUID
-	TemplateParams []byte
-	TemplateName   string
 	EmailName      string
-	Retries        int
+	TemplateName   string
+	TemplateParams []byte
+	Attempts       int
 }
 
 type EmailParams interface {
 	ToTemplateVariables() map[string]string
 }
 
+// LearnerEmailParams are implemented by emails that are sent to a learner,
+// the admin recipient is copied in on these
+type LearnerEmailParams interface {
+	EmailParams
+	LearnerEmail() string
+}
+
+// PrivateEmailParams are implemented by learner emails containing account credentials or
+// meant only for the recipient, the admin recipient is not copied in on these
+type PrivateEmailParams interface {
+	LearnerEmailParams
+	IsPrivate() bool
+}
+
+// OptionalEmailParams are implemented by learner emails the learner can opt out of. Emails needed
+// for compliance, like overdue training and certificate expiry, can't be opted out of.
+type OptionalEmailParams interface {
+	LearnerEmailParams
+	OptOutUserID() string
+}
+
 type TemplateNames struct {
-	CourseCompletion string
+	CourseCompletion       string
+	OverdueEnrolment       string
+	Invitation             string
+	ManagerDigest          string
+	ProgressReset          string
+	Enrolment              string
+	NotStartedReminder     string
+	CompletionConfirmation string
+	CertificateExpiry      string
 }
 
 type EmailNames struct {
-	CourseCompletion string
+	CourseCompletion       string
+	OverdueEnrolment       string
+	Invitation             string
+	ManagerDigest          string
+	ProgressReset          string
+	Enrolment              string
+	NotStartedReminder     string
+	CompletionConfirmation string
+	CertificateExpiry      string
 }
 
+// CourseCompletionParams link to the learner's completion certificate's verification page. The
+// certificate fields are empty if it couldn't be issued, and the URL is if there's no verification page.
 type CourseCompletionParams struct {
 	CourseName          string `json:"course_name"`
 	UserName            string `json:"user_name"`
 	UserEmail           string `json:"user_email"`
 	CompletionTimestamp string `json:"completion_timestamp"`
+	CertificateNumber   string `json:"certificate_number"`
+	CertificateURL      string `json:"certificate_url"`
 }
 
 func (p *CourseCompletionParams) ToTemplateVariables() map[string]string {
@@ -68,240 +114,473 @@ func (p *CourseCompletionParams) ToTemplateVariables() map[string]string {
 		"user_name":            p.UserName,
 		"user_email":           p.UserEmail,
 		"completion_timestamp": p.CompletionTimestamp,
+		"certificate_number":   p.CertificateNumber,
+		"certificate_url":      p.CertificateURL,
 	}
 }
 
-func New(cfg *config.EmailService, store EmailRepository) (*EmailService, error) {
-	mg := mailgun.NewMailgun(cfg.SendingKey)
-	err := mg.SetAPIBase(mailgun.APIBaseEU)
-	if err != nil {
-		return nil, err
+type OverdueEnrolmentParams struct {
+	CourseName string `json:"course_name"`
+	UserName   string `json:"user_name"`
+	UserEmail  string `json:"user_email"`
+	DueDate    string `json:"due_date"`
+}
+
+func (p *OverdueEnrolmentParams) ToTemplateVariables() map[string]string {
+	return map[string]string{
+		"course_name": p.CourseName,
+		"user_name":   p.UserName,
+		"user_email":  p.UserEmail,
+		"due_date":    p.DueDate,
 	}
+}
 
-	retryCron := cron.New(cfg.CronSchedule, "email-retry")
+func (p *OverdueEnrolmentParams) LearnerEmail() string {
+	return p.UserEmail
+}
 
-	service := &EmailService{
-		client:    mg,
-		domain:    cfg.Domain,
-		sender:    cfg.Sender,
-		recipient: cfg.Recipient,
-		templateNames: &TemplateNames{
-			CourseCompletion: cfg.CourseCompletionTemplateName,
-		},
-		emailNames: &EmailNames{
-			CourseCompletion: "course-completion",
-		},
-		store:     store,
-		retryCron: retryCron,
-	}
+type InvitationParams struct {
+	UserName        string `json:"user_name"`
+	UserEmail       string `json:"user_email"`
+	SetPasswordLink string `json:"set_password_link"`
+}
 
-	stopRetry, err := service.SetupRetry()
-	if err != nil {
-		return nil, err
+func (p *InvitationParams) ToTemplateVariables() map[string]string {
+	return map[string]string{
+		"user_name":         p.UserName,
+		"user_email":        p.UserEmail,
+		"set_password_link": p.SetPasswordLink,
 	}
-	service.stopRetry = stopRetry
+}
 
-	return service, nil
+func (p *InvitationParams) LearnerEmail() string {
+	return p.UserEmail
 }
 
-func (e *EmailService) GetTemplateNames() *TemplateNames {
-	return e.templateNames
+func (p *InvitationParams) IsPrivate() bool {
+	return true
 }
 
-func (e *EmailService) GetEmailNames() *EmailNames {
-	return e.emailNames
+// ManagerDigestParams list the overdue and soon to be due training of a line manager's
+// direct reports, one line per enrolment
+type ManagerDigestParams struct {
+	ManagerName     string   `json:"manager_name"`
+	ManagerEmail    string   `json:"manager_email"`
+	OverdueTraining []string `json:"overdue_training"`
+	DueSoonTraining []string `json:"due_soon_training"`
 }
 
-func (e *EmailService) SetupRetry() (context.CancelFunc, error) {
-	return e.retryCron.Setup(e.RetryJob())
+func (p *ManagerDigestParams) ToTemplateVariables() map[string]string {
+	return map[string]string{
+		"manager_name":      p.ManagerName,
+		"overdue_count":     strconv.Itoa(len(p.OverdueTraining)),
+		"overdue_training":  strings.Join(p.OverdueTraining, "\n"),
+		"due_soon_count":    strconv.Itoa(len(p.DueSoonTraining)),
+		"due_soon_training": strings.Join(p.DueSoonTraining, "\n"),
+	}
 }
 
-func (e *EmailService) AddFailedEmail(ctx context.Context, err error, templateParams EmailParams, templateName, emailName string) {
-	paramBytes, marshalErr := json.Marshal(templateParams)
-	if marshalErr != nil {
-		slog.Error("failed to marshal template params", slog.Any("error", marshalErr))
-		return
+func (p *ManagerDigestParams) LearnerEmail() string {
+	return p.ManagerEmail
+}
+
+func (p *ManagerDigestParams) IsPrivate() bool {
+	return true
+}
+
+// ProgressResetParams tell a learner that an admin has reset their progress in a course, or in
+// one of its quizzes, and why
+type ProgressResetParams struct {
+	CourseName string `json:"course_name"`
+	QuizReset  bool   `json:"quiz_reset"`
+	UserName   string `json:"user_name"`
+	UserEmail  string `json:"user_email"`
+	Reason     string `json:"reason"`
+}
+
+func (p *ProgressResetParams) ToTemplateVariables() map[string]string {
+	resetScope := "course"
+	if p.QuizReset {
+		resetScope = "quiz"
 	}
 
-	sqlcParams := sqlc.AddFailedEmailParams{
-		Error:          err.Error(),
-		TemplateName:   templateName,
-		TemplateParams: paramBytes,
-		EmailName:      emailName,
+	return map[string]string{
+		"course_name": p.CourseName,
+		"reset_scope": resetScope,
+		"user_name":   p.UserName,
+		"user_email":  p.UserEmail,
+		"reason":      p.Reason,
 	}
+}
 
-	err = e.store.AddFailedEmail(ctx, sqlcParams)
-	if err != nil {
-		slog.Error("failed to add email to DB", slog.Any("error", err))
-	} else {
-		slog.Debug("added email to DB")
+func (p *ProgressResetParams) LearnerEmail() string {
+	return p.UserEmail
+}
+
+// EnrolmentParams tell a learner they've been enrolled on a course, the due date is empty if the
+// enrolment doesn't have one
+type EnrolmentParams struct {
+	UserID     string `json:"user_id"`
+	UserName   string `json:"user_name"`
+	UserEmail  string `json:"user_email"`
+	CourseName string `json:"course_name"`
+	CourseLink string `json:"course_link"`
+	DueDate    string `json:"due_date"`
+}
+
+func (p *EnrolmentParams) ToTemplateVariables() map[string]string {
+	return map[string]string{
+		"user_name":   p.UserName,
+		"user_email":  p.UserEmail,
+		"course_name": p.CourseName,
+		"course_link": p.CourseLink,
+		"due_date":    p.DueDate,
 	}
 }
 
-func (e *EmailService) Send(ctx context.Context, params EmailParams, templateName, emailName string) (err error) {
-	message := mailgun.NewMessage(
-		e.domain,
-		e.sender,
-		"", // subject set by template
-		"", // text set by template,
-		e.recipient,
-	)
+func (p *EnrolmentParams) LearnerEmail() string {
+	return p.UserEmail
+}
 
-	message.SetTemplate(templateName)
+func (p *EnrolmentParams) IsPrivate() bool {
+	return true
+}
 
-	defer func() {
-		if err != nil {
-			e.AddFailedEmail(ctx, err, params, templateName, emailName)
-		}
-	}()
+func (p *EnrolmentParams) OptOutUserID() string {
+	return p.UserID
+}
 
-	for key, value := range params.ToTemplateVariables() {
-		if err := message.AddTemplateVariable(key, value); err != nil {
-			return err
-		}
-	}
+// NotStartedReminderParams remind a learner about a course they haven't started, the due date is
+// empty if the enrolment doesn't have one
+type NotStartedReminderParams struct {
+	UserID       string `json:"user_id"`
+	UserName     string `json:"user_name"`
+	UserEmail    string `json:"user_email"`
+	CourseName   string `json:"course_name"`
+	CourseLink   string `json:"course_link"`
+	EnrolledDate string `json:"enrolled_date"`
+	DueDate      string `json:"due_date"`
+}
 
-	_, err = e.client.Send(ctx, message)
-	return err
-}
-
-type RetryParams struct {
-	ID             pgtype.UUID
-	templateParams EmailParams
-	templateName   string
-	emailName      string
-	retries        int
-}
-
-func (e *EmailService) RetryJob() func(ctx context.Context) {
-	return func(ctx context.Context) {
-		failedEmails, err := e.store.GetFailedEmails(ctx)
-
-		if err != nil {
-			slog.Error(errors.Getting("failed emails"), slog.Any("error", err))
-			return
-		}
-
-		if len(failedEmails) == 0 {
-			slog.Debug("no failed emails to retry")
-			return
-		}
-
-		var sendParams []RetryParams
-
-		for _, fe := range failedEmails {
-			switch fe.EmailName {
-			case e.GetEmailNames().CourseCompletion:
-				sendParams = appendParams[*CourseCompletionParams](
-					&fe,
-					sendParams,
-				)
-			default:
-				slog.Error("email name not found", slog.String("email_name", fe.EmailName))
-			}
-		}
-
-		for _, sendP := range sendParams {
-			err := e.RetrySend(ctx, &sendP)
-			if err != nil {
-				slog.Error("email retry failed", slog.Any("error", err))
-				e.handleRetryFailure(ctx, &sendP, err)
-			} else {
-				slog.Debug("email retry success", slog.String("id", sendP.ID.String()))
-				e.deleteFailedEmail(ctx, sendP.ID)
-			}
-		}
+func (p *NotStartedReminderParams) ToTemplateVariables() map[string]string {
+	return map[string]string{
+		"user_name":     p.UserName,
+		"user_email":    p.UserEmail,
+		"course_name":   p.CourseName,
+		"course_link":   p.CourseLink,
+		"enrolled_date": p.EnrolledDate,
+		"due_date":      p.DueDate,
 	}
 }
 
-func appendParams[T EmailParams](params *FailedEmail, sendParams []RetryParams) []RetryParams {
-	var templateParams T
-	if err := json.Unmarshal(params.TemplateParams, &templateParams); err != nil {
-		slog.Error("failed to unmarshal template params", slog.Any("error", err))
-		return sendParams
+func (p *NotStartedReminderParams) LearnerEmail() string {
+	return p.UserEmail
+}
+
+func (p *NotStartedReminderParams) IsPrivate() bool {
+	return true
+}
+
+func (p *NotStartedReminderParams) OptOutUserID() string {
+	return p.UserID
+}
+
+// CompletionConfirmationParams confirm to a learner that they've completed a course, with their
+// certificate. The admin is sent CourseCompletionParams separately.
+type CompletionConfirmationParams struct {
+	UserID              string `json:"user_id"`
+	UserName            string `json:"user_name"`
+	UserEmail           string `json:"user_email"`
+	CourseName          string `json:"course_name"`
+	CompletionTimestamp string `json:"completion_timestamp"`
+	CertificateNumber   string `json:"certificate_number"`
+	CertificateURL      string `json:"certificate_url"`
+}
+
+func (p *CompletionConfirmationParams) ToTemplateVariables() map[string]string {
+	return map[string]string{
+		"user_name":            p.UserName,
+		"user_email":           p.UserEmail,
+		"course_name":          p.CourseName,
+		"completion_timestamp": p.CompletionTimestamp,
+		"certificate_number":   p.CertificateNumber,
+		"certificate_url":      p.CertificateURL,
 	}
+}
 
-	pgUUID, err := utils.PGUUIDFromString(params.ID.String())
-	if err != nil {
-		slog.Error("failed to parse failed email id", slog.Any("error", err), slog.String("id", params.ID.String()))
-		return sendParams
+func (p *CompletionConfirmationParams) LearnerEmail() string {
+	return p.UserEmail
+}
+
+func (p *CompletionConfirmationParams) IsPrivate() bool {
+	return true
+}
+
+func (p *CompletionConfirmationParams) OptOutUserID() string {
+	return p.UserID
+}
+
+// CertificateExpiryParams warn a learner that their certificate for a course is about to expire,
+// so they can retake it in time
+type CertificateExpiryParams struct {
+	UserID            string `json:"user_id"`
+	UserName          string `json:"user_name"`
+	UserEmail         string `json:"user_email"`
+	CourseName        string `json:"course_name"`
+	CourseLink        string `json:"course_link"`
+	CertificateNumber string `json:"certificate_number"`
+	ExpiryDate        string `json:"expiry_date"`
+}
+
+func (p *CertificateExpiryParams) ToTemplateVariables() map[string]string {
+	return map[string]string{
+		"user_name":          p.UserName,
+		"user_email":         p.UserEmail,
+		"course_name":        p.CourseName,
+		"course_link":        p.CourseLink,
+		"certificate_number": p.CertificateNumber,
+		"expiry_date":        p.ExpiryDate,
 	}
+}
 
-	sendParams = append(sendParams, RetryParams{
-		ID:             pgUUID,
-		templateName:   params.TemplateName,
-		templateParams: templateParams,
-		emailName:      params.EmailName,
-		retries:        params.Retries,
-	})
+func (p *CertificateExpiryParams) LearnerEmail() string {
+	return p.UserEmail
+}
+
+const (
+	courseCompletionEmail       = "course-completion"
+	overdueEnrolmentEmail       = "overdue-enrolment"
+	invitationEmail             = "invitation"
+	managerDigestEmail          = "manager-digest"
+	progressResetEmail          = "progress-reset"
+	enrolmentEmail              = "enrolment"
+	notStartedReminderEmail     = "not-started-reminder"
+	completionConfirmationEmail = "completion-confirmation"
+	certificateExpiryEmail      = "certificate-expiry"
+)
 
-	return sendParams
+var allEmailNames = []string{
+	courseCompletionEmail,
+	overdueEnrolmentEmail,
+	invitationEmail,
+	managerDigestEmail,
+	progressResetEmail,
+	enrolmentEmail,
+	notStartedReminderEmail,
+	completionConfirmationEmail,
+	certificateExpiryEmail,
 }
 
-func (e *EmailService) RetrySend(ctx context.Context, params *RetryParams) error {
-	message := mailgun.NewMessage(
-		e.domain,
-		e.sender,
-		"", // subject set by template
-		"", // text set by template,
-		e.recipient,
-	)
+// OptionalEmails are the emails learners can opt out of
+var OptionalEmails = []string{
+	enrolmentEmail,
+	notStartedReminderEmail,
+	completionConfirmationEmail,
+}
 
-	message.SetTemplate(params.templateName)
+// sampleParams fill in email previews
+var sampleParams = map[string]EmailParams{
+	courseCompletionEmail: &CourseCompletionParams{
+		CourseName:          "Radiation Safety Awareness",
+		UserName:            "Alex Smith",
+		UserEmail:           "alex.smith@example.com",
+		CompletionTimestamp: "1 March 2026 at 14:30",
+		CertificateNumber:   "SN-000123",
+		CertificateURL:      "https://example.com/certificates/verify/abc123",
+	},
+	overdueEnrolmentEmail: &OverdueEnrolmentParams{
+		CourseName: "Radiation Safety Awareness",
+		UserName:   "Alex Smith",
+		UserEmail:  "alex.smith@example.com",
+		DueDate:    "1 March 2026",
+	},
+	invitationEmail: &InvitationParams{
+		UserName:        "Alex Smith",
+		UserEmail:       "alex.smith@example.com",
+		SetPasswordLink: "https://example.com/set-password",
+	},
+	managerDigestEmail: &ManagerDigestParams{
+		ManagerName:     "Sam Jones",
+		ManagerEmail:    "sam.jones@example.com",
+		OverdueTraining: []string{"Alex Smith - Radiation Safety Awareness, due 1 March 2026"},
+		DueSoonTraining: []string{"Jo Brown - Contamination Control, due 20 March 2026"},
+	},
+	progressResetEmail: &ProgressResetParams{
+		CourseName: "Radiation Safety Awareness",
+		QuizReset:  true,
+		UserName:   "Alex Smith",
+		UserEmail:  "alex.smith@example.com",
+		Reason:     "The quiz questions have been updated",
+	},
+	enrolmentEmail: &EnrolmentParams{
+		UserName:   "Alex Smith",
+		UserEmail:  "alex.smith@example.com",
+		CourseName: "Radiation Safety Awareness",
+		CourseLink: "https://example.com/course/1",
+		DueDate:    "1 March 2026",
+	},
+	notStartedReminderEmail: &NotStartedReminderParams{
+		UserName:     "Alex Smith",
+		UserEmail:    "alex.smith@example.com",
+		CourseName:   "Radiation Safety Awareness",
+		CourseLink:   "https://example.com/course/1",
+		EnrolledDate: "1 February 2026",
+		DueDate:      "1 March 2026",
+	},
+	completionConfirmationEmail: &CompletionConfirmationParams{
+		UserName:            "Alex Smith",
+		UserEmail:           "alex.smith@example.com",
+		CourseName:          "Radiation Safety Awareness",
+		CompletionTimestamp: "1 March 2026 at 14:30",
+		CertificateNumber:   "SN-000123",
+		CertificateURL:      "https://example.com/certificates/verify/abc123",
+	},
+	certificateExpiryEmail: &CertificateExpiryParams{
+		UserName:          "Alex Smith",
+		UserEmail:         "alex.smith@example.com",
+		CourseName:        "Radiation Safety Awareness",
+		CourseLink:        "https://example.com/course/1",
+		CertificateNumber: "SN-000123",
+		ExpiryDate:        "1 March 2027",
+	},
+}
 
-	for key, value := range params.templateParams.ToTemplateVariables() {
-		if err := message.AddTemplateVariable(key, value); err != nil {
-			return err
-		}
+func New(cfg *config.EmailService, store EmailRepository) (*EmailService, error) {
+	provider, err := NewProvider(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := e.client.Send(ctx, message)
+	templates, err := LoadTemplates(cfg.TemplatesDir)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
-}
+	dispatchCron := cron.New(cfg.DispatchCronSchedule, "email-dispatch")
+
+	service := &EmailService{
+		provider:  provider,
+		templates: templates,
+		sender:    cfg.Sender,
+		recipient: cfg.Recipient,
+		templateNames: &TemplateNames{
+			CourseCompletion:       cfg.CourseCompletionTemplateName,
+			OverdueEnrolment:       cfg.OverdueEnrolmentTemplateName,
+			Invitation:             cfg.InvitationTemplateName,
+			ManagerDigest:          cfg.ManagerDigestTemplateName,
+			ProgressReset:          cfg.ProgressResetTemplateName,
+			Enrolment:              cfg.EnrolmentTemplateName,
+			NotStartedReminder:     cfg.NotStartedReminderTemplateName,
+			CompletionConfirmation: cfg.CompletionConfirmationTemplateName,
+			CertificateExpiry:      cfg.CertificateExpiryTemplateName,
+		},
+		emailNames: &EmailNames{
+			CourseCompletion:       courseCompletionEmail,
+			OverdueEnrolment:       overdueEnrolmentEmail,
+			Invitation:             invitationEmail,
+			ManagerDigest:          managerDigestEmail,
+			ProgressReset:          progressResetEmail,
+			Enrolment:              enrolmentEmail,
+			NotStartedReminder:     notStartedReminderEmail,
+			CompletionConfirmation: completionConfirmationEmail,
+			CertificateExpiry:      certificateExpiryEmail,
+		},
+		store:        store,
+		dispatchCron: dispatchCron,
+	}
 
-func (e *EmailService) deleteFailedEmail(ctx context.Context, id pgtype.UUID) {
-	err := e.store.DeleteFailedEmail(ctx, id)
+	stopDispatch, err := service.dispatchCron.Setup(service.DispatchJob)
 	if err != nil {
-		slog.Error("failed to delete email", slog.Any("error", err))
-		return
+		return nil, err
 	}
+	service.stopDispatch = stopDispatch
 
-	slog.Debug("delete email success", slog.String("id", id.String()))
+	return service, nil
 }
 
-func (e *EmailService) handleRetryFailure(ctx context.Context, retryParams *RetryParams, err error) {
-	if retryParams.retries > 0 {
-		e.updateFailedEmail(ctx, retryParams, err)
-		return
+func (e *EmailService) GetTemplateNames() *TemplateNames {
+	return e.templateNames
+}
+
+func (e *EmailService) GetEmailNames() *EmailNames {
+	return e.emailNames
+}
+
+// Send queues the email in the outbox, the dispatcher sends it shortly after. Emails about a change
+// to the database should be queued in the same transaction instead, with NewOutboxEmail.
+func (e *EmailService) Send(ctx context.Context, params EmailParams, templateName, emailName string) error {
+	outboxEmail, err := NewOutboxEmail(params, templateName, emailName)
+	if err != nil {
+		return err
 	}
 
-	slog.Debug("no retries left")
-	e.deleteFailedEmail(ctx, retryParams.ID)
+	return e.store.EnqueueEmail(ctx, outboxEmail)
 }
 
-func (e *EmailService) updateFailedEmail(ctx context.Context, retryParams *RetryParams, err error) {
-	sqlcParams := sqlc.UpdateFailedEmailParams{
-		Retries: int32(retryParams.retries) - 1, // #nosec G115 retry value is guaranteed to be small because 0 <= value >= 5
-		Error:   err.Error(),
+// isOptedOut checks whether the learner has opted out of an optional email, in which case it isn't
+// sent. Opting out after an email was queued stops it being sent.
+func (e *EmailService) isOptedOut(ctx context.Context, params EmailParams, emailName string) (bool, error) {
+	optionalParams, ok := params.(OptionalEmailParams)
+	if !ok || optionalParams.OptOutUserID() == "" {
+		return false, nil
 	}
 
-	err = e.store.UpdateFailedEmail(ctx, sqlcParams)
+	optedOut, err := e.store.IsEmailOptedOut(ctx, optionalParams.OptOutUserID(), emailName)
 	if err != nil {
-		slog.Error("failed to update email", slog.String("id", retryParams.ID.String()), slog.Any("error", err))
-		return
+		return false, err
 	}
 
-	slog.Debug("updated failed email")
+	if optedOut {
+		slog.Debug("learner opted out of email", slog.String("email_name", emailName), slog.String("user_id", optionalParams.OptOutUserID()))
+	}
+
+	return optedOut, nil
 }
 
-func (e *EmailService) StopRetry(ctx context.Context) {
-	e.stopRetry() // cancel cron contexts to prevent new jobs from starting
+// Preview renders an email with sample template variables, any variables given replace the
+// sample ones
+func (e *EmailService) Preview(emailName string, variables map[string]string) (*RenderedEmail, error) {
+	sample, ok := sampleParams[emailName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEmail, emailName)
+	}
+
+	previewVariables := sample.ToTemplateVariables()
+	maps.Copy(previewVariables, variables)
+
+	return e.templates.Render(emailName, previewVariables)
+}
+
+// newMessage builds a message rendered from the email's templates, sent to the learner for
+// learner emails and to the admin recipient otherwise. The admin is copied in on learner emails
+// unless they are private.
+func (e *EmailService) newMessage(params EmailParams, templateName, emailName string) (*Message, error) {
+	variables := params.ToTemplateVariables()
+
+	rendered, err := e.templates.Render(emailName, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Message{
+		From:         e.sender,
+		To:           e.recipient,
+		Subject:      rendered.Subject,
+		Text:         rendered.Text,
+		HTML:         rendered.HTML,
+		TemplateName: templateName,
+		Variables:    variables,
+	}
+
+	learnerParams, isLearnerEmail := params.(LearnerEmailParams)
+	if isLearnerEmail {
+		message.To = learnerParams.LearnerEmail()
+	}
+
+	privateParams, isPrivateEmail := params.(PrivateEmailParams)
+	if isLearnerEmail && !(isPrivateEmail && privateParams.IsPrivate()) {
+		message.CC = append(message.CC, e.recipient)
+	}
 
-	stopRetryCtx := e.retryCron.Stop() // returns a context that waits until existing cron jobs finish
-	<-stopRetryCtx.Done()
-	slog.Info("email retry cron jobs completed")
+	return message, nil
 }
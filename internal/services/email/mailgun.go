@@ -0,0 +1,149 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailgun/mailgun-go/v5"
+	"github.com/mailgun/mailgun-go/v5/events"
+	"github.com/mailgun/mailgun-go/v5/mtypes"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+)
+
+// webhookMaxAge is how long after Mailgun signs a webhook it's accepted, so a webhook that's been
+// intercepted can't be replayed later
+const webhookMaxAge = 15 * time.Minute
+
+// Mailgun sends emails with Mailgun's EU API. Messages with a template name use that template
+// hosted by Mailgun rather than the rendered content.
+type Mailgun struct {
+	client *mailgun.Client
+	domain string
+}
+
+func NewMailgun(cfg *config.Mailgun) (*Mailgun, error) {
+	mg := mailgun.NewMailgun(cfg.SendingKey)
+	err := mg.SetAPIBase(mailgun.APIBaseEU)
+	if err != nil {
+		return nil, err
+	}
+
+	mg.SetWebhookSigningKey(cfg.WebhookSigningKey)
+
+	return &Mailgun{client: mg, domain: cfg.Domain}, nil
+}
+
+func (m *Mailgun) Send(ctx context.Context, message *Message) (string, error) {
+	var mgMessage *mailgun.PlainMessage
+	if message.TemplateName != "" {
+		mgMessage = mailgun.NewMessage(
+			m.domain,
+			message.From,
+			"", // subject set by template
+			"", // text set by template,
+			message.To,
+		)
+
+		mgMessage.SetTemplate(message.TemplateName)
+
+		for key, value := range message.Variables {
+			if err := mgMessage.AddTemplateVariable(key, value); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		mgMessage = mailgun.NewMessage(m.domain, message.From, message.Subject, message.Text, message.To)
+		mgMessage.SetHTML(message.HTML)
+	}
+
+	for _, cc := range message.CC {
+		mgMessage.AddCC(cc)
+	}
+
+	resp, err := m.client.Send(ctx, mgMessage)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(resp.ID, "<>"), nil
+}
+
+// ParseWebhook returns the delivery event sent by a Mailgun webhook. Only delivered, failed and
+// complained events are delivery events, failures are bounces if they're permanent.
+func (m *Mailgun) ParseWebhook(body []byte, now time.Time) (*domain.EmailEvent, error) {
+	if m.client.WebhookSigningKey() == "" {
+		return nil, ErrWebhookNotConfigured
+	}
+
+	var payload mtypes.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebhook, err)
+	}
+
+	if err := verifySignature(m.client, payload.Signature, now); err != nil {
+		return nil, err
+	}
+
+	event, err := events.ParseEvent(payload.EventData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWebhook, err)
+	}
+
+	switch e := event.(type) {
+	case *events.Delivered:
+		return mailgunEvent(&e.Generic, &e.Message, e.Recipient, domain.EmailEventDelivered, ""), nil
+	case *events.Failed:
+		eventType := domain.EmailEventDeferred
+		if e.Severity == "permanent" {
+			eventType = domain.EmailEventBounced
+		}
+
+		reason := e.DeliveryStatus.Description
+		if reason == "" {
+			reason = e.DeliveryStatus.Message
+		}
+
+		return mailgunEvent(&e.Generic, &e.Message, e.Recipient, eventType, reason), nil
+	case *events.Complained:
+		return mailgunEvent(&e.Generic, &e.Message, e.Recipient, domain.EmailEventComplained, ""), nil
+	default:
+		return nil, nil
+	}
+}
+
+func verifySignature(client *mailgun.Client, signature mtypes.Signature, now time.Time) error {
+	verified, err := client.VerifyWebhookSignature(signature)
+	if err != nil || !verified {
+		return ErrInvalidWebhookSignature
+	}
+
+	timestamp, err := strconv.ParseInt(signature.TimeStamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(timestamp, 0)).Abs() > webhookMaxAge {
+		return ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+func mailgunEvent(
+	generic *events.Generic,
+	message *events.Message,
+	recipient string,
+	eventType domain.EmailEventType,
+	reason string,
+) *domain.EmailEvent {
+	return &domain.EmailEvent{
+		ProviderEventID: generic.ID,
+		MessageID:       strings.Trim(message.Headers.MessageID, "<>"),
+		Type:            eventType,
+		Recipient:       recipient,
+		Reason:          reason,
+		OccurredAt:      generic.GetTimestamp(),
+	}
+}
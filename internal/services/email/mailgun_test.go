@@ -0,0 +1,213 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+)
+
+const testWebhookSigningKey = "webhook-signing-key"
+
+var webhookNow = time.Date(2026, 7, 10, 9, 0, 0, 0, time.UTC)
+
+// webhookBody returns a webhook with the event, signed like Mailgun at signedAt with key
+func webhookBody(t *testing.T, key string, signedAt time.Time, event map[string]any) []byte {
+	t.Helper()
+
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	token := "webhook-token"
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + token))
+
+	body, err := json.Marshal(map[string]any{
+		"signature": map[string]string{
+			"timestamp": timestamp,
+			"token":     token,
+			"signature": hex.EncodeToString(mac.Sum(nil)),
+		},
+		"event-data": event,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal webhook: %v", err)
+	}
+
+	return body
+}
+
+func webhookEvent(name string, fields map[string]any) map[string]any {
+	event := map[string]any{
+		"event":     name,
+		"id":        "event-1",
+		"timestamp": float64(webhookNow.Add(-time.Minute).Unix()),
+		"recipient": "usera@test.com",
+		"message": map[string]any{
+			"headers": map[string]string{"message-id": "<message-1@mg.supanova.test>"},
+		},
+	}
+	for key, value := range fields {
+		event[key] = value
+	}
+
+	return event
+}
+
+func newTestMailgun(t *testing.T, signingKey string) *Mailgun {
+	t.Helper()
+
+	mg, err := NewMailgun(&config.Mailgun{
+		SendingKey:        "sending-key",
+		Domain:            "mg.supanova.test",
+		WebhookSigningKey: signingKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to create Mailgun provider: %v", err)
+	}
+
+	return mg
+}
+
+func TestMailgunParseWebhook(t *testing.T) {
+	deliveryEvent := func(eventType domain.EmailEventType, reason string) *domain.EmailEvent {
+		return &domain.EmailEvent{
+			ProviderEventID: "event-1",
+			MessageID:       "message-1@mg.supanova.test",
+			Type:            eventType,
+			Recipient:       "usera@test.com",
+			Reason:          reason,
+			OccurredAt:      webhookNow.Add(-time.Minute),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		body     []byte
+		expected *domain.EmailEvent
+	}{
+		{
+			name:     "delivered",
+			body:     webhookBody(t, testWebhookSigningKey, webhookNow, webhookEvent("delivered", nil)),
+			expected: deliveryEvent(domain.EmailEventDelivered, ""),
+		},
+		{
+			name: "permanent failures are bounces",
+			body: webhookBody(t, testWebhookSigningKey, webhookNow, webhookEvent("failed", map[string]any{
+				"severity":        "permanent",
+				"delivery-status": map[string]any{"description": "Mailbox does not exist", "message": "550 5.1.1"},
+			})),
+			expected: deliveryEvent(domain.EmailEventBounced, "Mailbox does not exist"),
+		},
+		{
+			name: "temporary failures are deferred, with the message if there's no description",
+			body: webhookBody(t, testWebhookSigningKey, webhookNow, webhookEvent("failed", map[string]any{
+				"severity":        "temporary",
+				"delivery-status": map[string]any{"message": "421 Try again later"},
+			})),
+			expected: deliveryEvent(domain.EmailEventDeferred, "421 Try again later"),
+		},
+		{
+			name:     "complained",
+			body:     webhookBody(t, testWebhookSigningKey, webhookNow, webhookEvent("complained", nil)),
+			expected: deliveryEvent(domain.EmailEventComplained, ""),
+		},
+		{
+			name: "events that aren't about delivery are ignored",
+			body: webhookBody(t, testWebhookSigningKey, webhookNow, webhookEvent("opened", nil)),
+		},
+		{
+			name:     "accepted within webhookMaxAge of being signed",
+			body:     webhookBody(t, testWebhookSigningKey, webhookNow.Add(-webhookMaxAge), webhookEvent("delivered", nil)),
+			expected: deliveryEvent(domain.EmailEventDelivered, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := newTestMailgun(t, testWebhookSigningKey).ParseWebhook(tt.body, webhookNow)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("event mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMailgunParseWebhook_Rejected(t *testing.T) {
+	delivered := webhookEvent("delivered", nil)
+
+	tests := []struct {
+		name       string
+		signingKey string
+		body       []byte
+		wantErr    error
+	}{
+		{
+			name:       "signed with a different key",
+			signingKey: testWebhookSigningKey,
+			body:       webhookBody(t, "another-key", webhookNow, delivered),
+			wantErr:    ErrInvalidWebhookSignature,
+		},
+		{
+			name:       "signature isn't hex",
+			signingKey: testWebhookSigningKey,
+			body: []byte(`{"signature":{"timestamp":"` + strconv.FormatInt(webhookNow.Unix(), 10) +
+				`","token":"webhook-token","signature":"not-hex"},"event-data":{"event":"delivered"}}`),
+			wantErr: ErrInvalidWebhookSignature,
+		},
+		{
+			name:       "signed longer than webhookMaxAge ago",
+			signingKey: testWebhookSigningKey,
+			body:       webhookBody(t, testWebhookSigningKey, webhookNow.Add(-webhookMaxAge-time.Second), delivered),
+			wantErr:    ErrInvalidWebhookSignature,
+		},
+		{
+			name:       "signed further than webhookMaxAge in the future",
+			signingKey: testWebhookSigningKey,
+			body:       webhookBody(t, testWebhookSigningKey, webhookNow.Add(webhookMaxAge+time.Second), delivered),
+			wantErr:    ErrInvalidWebhookSignature,
+		},
+		{
+			name:       "payload isn't JSON",
+			signingKey: testWebhookSigningKey,
+			body:       []byte(`{"signature":`),
+			wantErr:    ErrInvalidWebhook,
+		},
+		{
+			name:       "event data isn't an event",
+			signingKey: testWebhookSigningKey,
+			body:       webhookBody(t, testWebhookSigningKey, webhookNow, map[string]any{"event": "not-an-event"}),
+			wantErr:    ErrInvalidWebhook,
+		},
+		{
+			name:    "no signing key is configured",
+			body:    webhookBody(t, testWebhookSigningKey, webhookNow, delivered),
+			wantErr: ErrWebhookNotConfigured,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := newTestMailgun(t, tt.signingKey).ParseWebhook(tt.body, webhookNow)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if event != nil {
+				t.Errorf("expected no event, got %+v", event)
+			}
+		})
+	}
+}
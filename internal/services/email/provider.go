@@ -0,0 +1,69 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+)
+
+// Provider sends emails, rendering them from their template. Send returns the ID the provider gave
+// the message, without angle brackets, which delivery events refer to.
+type Provider interface {
+	Send(ctx context.Context, message *Message) (string, error)
+}
+
+// WebhookParser is a provider that reports delivery events with a webhook. ParseWebhook verifies
+// the webhook's signature and returns the event, or nil if it's not a delivery event.
+type WebhookParser interface {
+	ParseWebhook(body []byte, now time.Time) (*domain.EmailEvent, error)
+}
+
+var (
+	// ErrWebhookNotConfigured is returned for webhooks if the provider doesn't send them, or no
+	// signing key is configured
+	ErrWebhookNotConfigured = errors.New("email webhook not configured")
+	// ErrInvalidWebhookSignature is returned for webhooks that aren't signed by the provider, or
+	// were signed too long ago
+	ErrInvalidWebhookSignature = errors.New("invalid email webhook signature")
+	ErrInvalidWebhook          = errors.New("invalid email webhook")
+)
+
+// Message is an email rendered from the repository's templates. TemplateName is the name of a
+// template hosted by the provider, if one is configured, which is sent with Variables instead.
+type Message struct {
+	From         string
+	To           string
+	CC           []string
+	Subject      string
+	Text         string
+	HTML         string
+	TemplateName string
+	Variables    map[string]string
+}
+
+// ParseWebhook returns the delivery event sent by the provider's webhook, or nil if the webhook
+// isn't about a delivery
+func (e *EmailService) ParseWebhook(body []byte) (*domain.EmailEvent, error) {
+	parser, ok := e.provider.(WebhookParser)
+	if !ok {
+		return nil, ErrWebhookNotConfigured
+	}
+
+	return parser.ParseWebhook(body, time.Now())
+}
+
+// NewProvider returns the email provider selected in config
+func NewProvider(cfg *config.EmailService) (Provider, error) {
+	switch cfg.Provider {
+	case config.MailgunEmailProvider:
+		return NewMailgun(cfg.Mailgun)
+	case config.SMTPEmailProvider:
+		return NewSMTP(cfg.SMTP), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,182 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+)
+
+// SMTP sends emails through an SMTP server, such as a local SMTP catcher in development. Template
+// names are ignored, as templates hosted by Mailgun aren't available.
+type SMTP struct {
+	addr     string
+	host     string
+	username string
+	password string
+	dialer   *net.Dialer
+}
+
+const smtpDialTimeout = 10 * time.Second
+
+func NewSMTP(cfg *config.SMTP) *SMTP {
+	return &SMTP{
+		addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host:     cfg.Host,
+		username: cfg.Username,
+		password: cfg.Password,
+		dialer:   &net.Dialer{Timeout: smtpDialTimeout},
+	}
+}
+
+func (s *SMTP) Send(ctx context.Context, message *Message) (string, error) {
+	// The sender can include a display name, the envelope only has its address
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid sender %q: %w", message.From, err)
+	}
+
+	id := fmt.Sprintf("%s@%s", uuid.New(), senderDomain(from.Address))
+	if err := s.send(ctx, message, from.Address, id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *SMTP) send(ctx context.Context, message *Message, fromAddress, id string) error {
+	conn, err := s.dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close() //nolint:errcheck
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close() //nolint:errcheck
+		return err
+	}
+	defer client.Close() //nolint:errcheck
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	// PlainAuth refuses to send the password unencrypted, except to localhost
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(fromAddress); err != nil {
+		return err
+	}
+	for _, recipient := range append([]string{message.To}, message.CC...) {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	body, err := smtpMessage(message, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// smtpMessage returns the message with its headers, and its text and HTML as alternative
+// quoted-printable parts
+func smtpMessage(message *Message, id string) ([]byte, error) {
+	var b bytes.Buffer
+	parts := multipart.NewWriter(&b)
+
+	headers := [][2]string{
+		{"From", message.From},
+		{"To", message.To},
+	}
+	if len(message.CC) > 0 {
+		headers = append(headers, [2]string{"Cc", strings.Join(message.CC, ", ")})
+	}
+	headers = append(headers,
+		[2]string{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		[2]string{"Date", time.Now().Format(time.RFC1123Z)},
+		[2]string{"Message-ID", "<" + id + ">"},
+		[2]string{"MIME-Version", "1.0"},
+		[2]string{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	)
+
+	for _, header := range headers {
+		fmt.Fprintf(&b, "%s: %s\r\n", header[0], header[1])
+	}
+	b.WriteString("\r\n")
+
+	// Clients show the last part they can, so the HTML goes after the text
+	for _, part := range [][2]string{{"text/plain", message.Text}, {"text/html", message.HTML}} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part[0] + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part[1])); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func senderDomain(address string) string {
+	_, domain, found := strings.Cut(address, "@")
+	if !found {
+		return "localhost"
+	}
+
+	return domain
+}
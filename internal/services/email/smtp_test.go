@@ -0,0 +1,282 @@
+package email
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+)
+
+// smtpSession is what the fake SMTP server was sent
+type smtpSession struct {
+	auth       string
+	from       string
+	recipients []string
+	data       []byte
+}
+
+// fakeSMTPServer accepts a single connection and speaks enough SMTP for net/smtp to send a message.
+// Recipients in reject are refused, and PLAIN auth is only offered if auth is set.
+func fakeSMTPServer(t *testing.T, auth bool, reject map[string]bool) (*config.SMTP, <-chan smtpSession) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() }) //nolint:errcheck
+
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close() //nolint:errcheck
+		if err := conn.SetDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			return
+		}
+
+		sessions <- serveSMTP(textproto.NewConn(conn), auth, reject)
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to split address: %v", err)
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatalf("failed to parse port: %v", err)
+	}
+
+	return &config.SMTP{Host: host, Port: portNumber}, sessions
+}
+
+func serveSMTP(conn *textproto.Conn, auth bool, reject map[string]bool) smtpSession {
+	var session smtpSession
+
+	reply := func(format string, args ...any) bool {
+		return conn.PrintfLine(format, args...) == nil
+	}
+
+	if !reply("220 localhost ESMTP") {
+		return session
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return session
+		}
+
+		command, arg, _ := strings.Cut(line, " ")
+		ok := true
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			if auth {
+				ok = reply("250-localhost") && reply("250 AUTH PLAIN")
+			} else {
+				ok = reply("250 localhost")
+			}
+		case "AUTH":
+			session.auth = strings.TrimPrefix(arg, "PLAIN ")
+			ok = reply("235 Authenticated")
+		case "MAIL":
+			session.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			ok = reply("250 OK")
+		case "RCPT":
+			recipient := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if reject[recipient] {
+				ok = reply("550 No such user")
+				break
+			}
+			session.recipients = append(session.recipients, recipient)
+			ok = reply("250 OK")
+		case "DATA":
+			if !reply("354 Go ahead") {
+				return session
+			}
+			if session.data, err = conn.ReadDotBytes(); err != nil {
+				return session
+			}
+			ok = reply("250 Queued")
+		case "QUIT":
+			reply("221 Bye")
+			return session
+		default:
+			ok = reply("502 Command not implemented")
+		}
+
+		if !ok {
+			return session
+		}
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	message := &Message{
+		From:    "Supanova <noreply@supanova.test>",
+		To:      "learner@example.com",
+		CC:      []string{"manager@example.com"},
+		Subject: "Course overdue – Fire Safety",
+		Text:    "Your course is overdue.",
+		HTML:    "<p>Your course is overdue.</p>",
+	}
+
+	tests := []struct {
+		name           string
+		username       string
+		password       string
+		reject         map[string]bool
+		wantErr        bool
+		wantAuth       string
+		wantRecipients []string
+	}{
+		{
+			name:           "sends the message to the recipient and CCs",
+			wantRecipients: []string{"learner@example.com", "manager@example.com"},
+		},
+		{
+			name:           "authenticates when a username is configured",
+			username:       "user",
+			password:       "secret",
+			wantAuth:       base64.StdEncoding.EncodeToString([]byte("\x00user\x00secret")),
+			wantRecipients: []string{"learner@example.com", "manager@example.com"},
+		},
+		{
+			name:    "fails if a recipient is rejected",
+			reject:  map[string]bool{"manager@example.com": true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, sessions := fakeSMTPServer(t, tt.username != "", tt.reject)
+			cfg.Username = tt.username
+			cfg.Password = tt.password
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			id, err := NewSMTP(cfg).Send(ctx, message)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			session := <-sessions
+			if session.from != "noreply@supanova.test" {
+				t.Errorf("expected envelope sender noreply@supanova.test, got %q", session.from)
+			}
+			if session.auth != tt.wantAuth {
+				t.Errorf("expected auth %q, got %q", tt.wantAuth, session.auth)
+			}
+			if diff := cmp.Diff(tt.wantRecipients, session.recipients); diff != "" {
+				t.Errorf("recipients mismatch (-want +got):\n%s", diff)
+			}
+
+			if !strings.HasSuffix(id, "@supanova.test") {
+				t.Errorf("expected message ID at the sender's domain, got %q", id)
+			}
+			assertSMTPMessage(t, session.data, message, id)
+		})
+	}
+}
+
+func TestSMTPSend_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close() //nolint:errcheck
+
+	_, err = NewSMTP(&config.SMTP{Host: "127.0.0.1", Port: port}).Send(context.Background(), &Message{
+		From: "noreply@supanova.test",
+		To:   "learner@example.com",
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to connect to SMTP server") {
+		t.Errorf("expected a connection error, got %v", err)
+	}
+}
+
+// assertSMTPMessage checks the headers of the message the server received, and that its text and
+// HTML parts decode to what was sent
+func assertSMTPMessage(t *testing.T, data []byte, expected *Message, id string) {
+	t.Helper()
+
+	received, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(received.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+
+	headers := map[string]string{
+		"From":       received.Header.Get("From"),
+		"To":         received.Header.Get("To"),
+		"Cc":         received.Header.Get("Cc"),
+		"Subject":    subject,
+		"Message-ID": received.Header.Get("Message-ID"),
+	}
+	expectedHeaders := map[string]string{
+		"From":       expected.From,
+		"To":         expected.To,
+		"Cc":         strings.Join(expected.CC, ", "),
+		"Subject":    expected.Subject,
+		"Message-ID": "<" + id + ">",
+	}
+	if diff := cmp.Diff(expectedHeaders, headers); diff != "" {
+		t.Errorf("headers mismatch (-want +got):\n%s", diff)
+	}
+
+	_, boundary, found := strings.Cut(received.Header.Get("Content-Type"), "boundary=")
+	if !found {
+		t.Fatalf("expected a multipart message, got %q", received.Header.Get("Content-Type"))
+	}
+
+	// The multipart reader decodes quoted-printable parts
+	var parts []string
+	reader := multipart.NewReader(received.Body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read part: %v", err)
+		}
+
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("failed to read part body: %v", err)
+		}
+		parts = append(parts, string(body))
+	}
+
+	if diff := cmp.Diff([]string{expected.Text, expected.HTML}, parts); diff != "" {
+		t.Errorf("parts mismatch (-want +got):\n%s", diff)
+	}
+}
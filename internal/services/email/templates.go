@@ -0,0 +1,145 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"strings"
+	texttemplate "text/template"
+)
+
+// templateFiles has a subject, plain text and HTML template for each email, named after the email.
+// The HTML templates define the "content" of layout.html.
+//
+//go:embed templates
+var templateFiles embed.FS
+
+// ErrUnknownEmail is returned when rendering an email that has no templates
+var ErrUnknownEmail = errors.New("unknown email")
+
+var templateFuncs = map[string]any{
+	"lines": lines,
+}
+
+// RenderedEmail is an email rendered from its templates
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+}
+
+type emailTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// Templates render emails from the templates embedded in the binary
+type Templates struct {
+	emails map[string]*emailTemplate
+}
+
+// LoadTemplates parses the template of every email up front, so broken templates stop the server
+// starting. Templates in overrideDir replace the embedded template with the same file name, so a
+// deployment can change its emails without a new build.
+func LoadTemplates(overrideDir string) (*Templates, error) {
+	embedded, err := fs.Sub(templateFiles, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	files := embedded
+	if overrideDir != "" {
+		files = overlayFS{override: os.DirFS(overrideDir), base: embedded}
+	}
+
+	templates := &Templates{emails: make(map[string]*emailTemplate, len(allEmailNames))}
+	for _, name := range allEmailNames {
+		tmpl, err := parseEmailTemplate(files, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s email template: %w", name, err)
+		}
+		templates.emails[name] = tmpl
+	}
+
+	return templates, nil
+}
+
+func parseEmailTemplate(files fs.FS, name string) (*emailTemplate, error) {
+	subject, err := texttemplate.New(name+".subject.txt").Funcs(templateFuncs).ParseFS(files, name+".subject.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := texttemplate.New(name+".txt").Funcs(templateFuncs).ParseFS(files, name+".txt")
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := htmltemplate.New("layout").Funcs(templateFuncs).ParseFS(files, "layout.html", name+".html")
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailTemplate{subject: subject, text: text, html: html}, nil
+}
+
+// Render renders the email with its template variables
+func (t *Templates) Render(emailName string, variables map[string]string) (*RenderedEmail, error) {
+	tmpl, ok := t.emails[emailName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEmail, emailName)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, variables); err != nil {
+		return nil, err
+	}
+	if err := tmpl.text.Execute(&text, variables); err != nil {
+		return nil, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", variables); err != nil {
+		return nil, err
+	}
+
+	return &RenderedEmail{
+		// Subjects are a single line, the template's trailing newline would end the header
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+// lines splits a template variable holding a list, one item per line
+func lines(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, "\n") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// overlayFS opens files from override if they're there, and from base otherwise
+type overlayFS struct {
+	override fs.FS
+	base     fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	f, err := o.override.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return o.base.Open(name)
+}
@@ -27,6 +27,31 @@ var (
 		},
 		[]string{"method", "path", "status"},
 	)
+
+	TokenCacheLookupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "token_cache_lookups_total",
+			Help: "Total number of access token cache lookups by result (hit, miss or revalidated), " +
+				"the hit rate is hits over all lookups",
+		},
+		[]string{"result"},
+	)
+
+	TokenCacheEntries = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "token_cache_entries",
+			Help: "Number of verified access tokens in the token cache",
+		},
+	)
+
+	EmailFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "email_failures",
+			Help: "Number of failed emails by status, pending emails are waiting to be retried and dead emails " +
+				"need an admin to retry or discard them",
+		},
+		[]string{"status"},
+	)
 )
 
 func RegisterMetrics() {
@@ -34,5 +59,8 @@ func RegisterMetrics() {
 		HTTPRequestDuration,
 		HTTPRequestsTotal,
 		HTTPRequestsErrorsTotal,
+		TokenCacheLookupsTotal,
+		TokenCacheEntries,
+		EmailFailures,
 	)
 }
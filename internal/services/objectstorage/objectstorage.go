@@ -1,6 +1,7 @@
 package objectstorage
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
@@ -69,6 +70,21 @@ func (s *Store) GenerateUploadURL(ctx context.Context, key string, contentType *
 	return req.URL, nil
 }
 
+// Upload stores files the server creates itself, such as completion certificates
+func (s *Store) Upload(ctx context.Context, key string, body []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload to s3: %v", err)
+	}
+
+	return nil
+}
+
 func parseCDNKey(pemKey string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode([]byte(pemKey))
 	if block == nil {
@@ -0,0 +1,362 @@
+package reminders
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+	_ "time/tzdata" // embed timezone database in binary so time.LoadLocation works on ubuntu
+
+	"github.com/google/uuid"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/errors"
+	"github.com/supanova-rp/supanova-server/internal/services/cron"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+)
+
+var location, _ = time.LoadLocation("Europe/London")
+
+// digestDueWithinDays is how far ahead the manager digest looks for training that is due soon
+const digestDueWithinDays = 14
+
+// notStartedAfterDays is how long after enrolment learners are reminded about a course they
+// haven't started
+const notStartedAfterDays = 7
+
+// expiryNoticeWithinDays is how far ahead of a certificate expiring the learner is told about it
+const expiryNoticeWithinDays = 30
+
+const dateFormat = "02/01/2006"
+
+//go:generate moq -out ../../handlers/mocks/reminder_mock.go -pkg mocks . ReminderRepository
+
+type ReminderRepository interface {
+	GetOverdueEnrolments(context.Context) ([]domain.OverdueEnrolment, error)
+	SetEnrolmentOverdue(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error
+	GetManagerDigests(ctx context.Context, dueWithinDays int) ([]domain.ManagerDigest, error)
+	GetUnnotifiedEnrolments(context.Context) ([]domain.EnrolmentNotice, error)
+	SetEnrolmentNotified(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error
+	GetNotStartedEnrolments(ctx context.Context, enrolledDays int) ([]domain.EnrolmentNotice, error)
+	SetEnrolmentReminded(ctx context.Context, enrolmentID uuid.UUID, reminder *domain.OutboxEmail) error
+	GetExpiringCertificates(ctx context.Context, expiresWithinDays int) ([]domain.ExpiringCertificate, error)
+	SetCertificateExpiryNotified(ctx context.Context, id uuid.UUID, notification *domain.OutboxEmail) error
+}
+
+type EmailService interface {
+	Send(ctx context.Context, params email.EmailParams, templateName, emailName string) error
+	GetTemplateNames() *email.TemplateNames
+	GetEmailNames() *email.EmailNames
+}
+
+type ReminderService struct {
+	store         ReminderRepository
+	email         EmailService
+	clientURL     string
+	overdueCron   *cron.Cron
+	stopOverdue   context.CancelFunc
+	digestCron    *cron.Cron
+	stopDigest    context.CancelFunc
+	enrolmentCron *cron.Cron
+	stopEnrolment context.CancelFunc
+	learnerCron   *cron.Cron
+	stopLearner   context.CancelFunc
+}
+
+// New sets up the reminder cron jobs, clientURL is where the links to courses in learner emails go
+func New(cfg *config.Reminders, clientURL string, store ReminderRepository, emailService EmailService) (*ReminderService, error) {
+	service := &ReminderService{
+		store:         store,
+		email:         emailService,
+		clientURL:     strings.TrimSuffix(clientURL, "/"),
+		overdueCron:   cron.New(cfg.OverdueCronSchedule, "overdue-enrolments"),
+		digestCron:    cron.New(cfg.ManagerDigestCronSchedule, "manager-digest"),
+		enrolmentCron: cron.New(cfg.EnrolmentCronSchedule, "enrolment-notifications"),
+		learnerCron:   cron.New(cfg.LearnerReminderCronSchedule, "learner-reminders"),
+	}
+
+	stopOverdue, err := service.overdueCron.Setup(service.OverdueJob)
+	if err != nil {
+		return nil, err
+	}
+	service.stopOverdue = stopOverdue
+
+	stopDigest, err := service.digestCron.Setup(service.ManagerDigestJob)
+	if err != nil {
+		stopOverdue()
+		return nil, err
+	}
+	service.stopDigest = stopDigest
+
+	stopEnrolment, err := service.enrolmentCron.Setup(service.EnrolmentNotificationJob)
+	if err != nil {
+		stopOverdue()
+		stopDigest()
+		return nil, err
+	}
+	service.stopEnrolment = stopEnrolment
+
+	stopLearner, err := service.learnerCron.Setup(service.LearnerReminderJob)
+	if err != nil {
+		stopOverdue()
+		stopDigest()
+		stopEnrolment()
+		return nil, err
+	}
+	service.stopLearner = stopLearner
+
+	return service, nil
+}
+
+// OverdueJob flags enrolments that have passed their due date without being completed and
+// emails the learner (copying in the admin recipient). Each enrolment is only flagged once, the
+// email is queued in the same transaction as the flag.
+func (r *ReminderService) OverdueJob(ctx context.Context) {
+	enrolments, err := r.store.GetOverdueEnrolments(ctx)
+	if err != nil {
+		slog.Error(errors.Getting("overdue enrolments"), slog.Any("error", err))
+		return
+	}
+
+	if len(enrolments) == 0 {
+		slog.Debug("no overdue enrolments")
+		return
+	}
+
+	for _, enrolment := range enrolments {
+		notification, err := email.NewOutboxEmail(
+			&email.OverdueEnrolmentParams{
+				CourseName: enrolment.CourseTitle,
+				UserName:   enrolment.UserName,
+				UserEmail:  enrolment.UserEmail,
+				DueDate:    enrolment.DueAt.In(location).Format(dateFormat),
+			},
+			r.email.GetTemplateNames().OverdueEnrolment,
+			r.email.GetEmailNames().OverdueEnrolment,
+		)
+		if err != nil {
+			slog.Error("failed to queue overdue enrolment email", slog.Any("error", err), slog.String("id", enrolment.ID.String()))
+			continue
+		}
+
+		if err := r.store.SetEnrolmentOverdue(ctx, enrolment.ID, notification); err != nil {
+			slog.Error(errors.Updating("overdue enrolment"), slog.Any("error", err), slog.String("id", enrolment.ID.String()))
+		}
+	}
+
+	slog.Info("flagged overdue enrolments", slog.Int("count", len(enrolments)))
+}
+
+// ManagerDigestJob emails each line manager a list of their direct reports' training that is
+// overdue or due within the next two weeks. Managers with nothing outstanding aren't emailed.
+func (r *ReminderService) ManagerDigestJob(ctx context.Context) {
+	digests, err := r.store.GetManagerDigests(ctx, digestDueWithinDays)
+	if err != nil {
+		slog.Error(errors.Getting("manager digests"), slog.Any("error", err))
+		return
+	}
+
+	if len(digests) == 0 {
+		slog.Debug("no manager digests to send")
+		return
+	}
+
+	now := time.Now()
+	sent := 0
+
+	for _, digest := range digests {
+		if digest.ManagerEmail == "" {
+			slog.Warn("line manager has no email", slog.String("manager_id", digest.ManagerID))
+			continue
+		}
+
+		params := &email.ManagerDigestParams{
+			ManagerName:  digest.ManagerName,
+			ManagerEmail: digest.ManagerEmail,
+		}
+
+		for _, enrolment := range digest.Enrolments {
+			line := enrolment.UserName + " - " + enrolment.CourseTitle + " (due " + enrolment.DueAt.In(location).Format(dateFormat) + ")"
+			if enrolment.DueAt.Before(now) {
+				params.OverdueTraining = append(params.OverdueTraining, line)
+			} else {
+				params.DueSoonTraining = append(params.DueSoonTraining, line)
+			}
+		}
+
+		err := r.email.Send(
+			ctx,
+			params,
+			r.email.GetTemplateNames().ManagerDigest,
+			r.email.GetEmailNames().ManagerDigest,
+		)
+		if err != nil {
+			slog.Error("failed to send manager digest email", slog.Any("error", err), slog.String("manager_id", digest.ManagerID))
+			continue
+		}
+
+		sent++
+	}
+
+	slog.Info("sent manager digests", slog.Int("count", sent))
+}
+
+// EnrolmentNotificationJob emails learners about courses they've been enrolled on, with a link to
+// the course. Each enrolment is only notified once, even if the learner opted out of the email.
+func (r *ReminderService) EnrolmentNotificationJob(ctx context.Context) {
+	enrolments, err := r.store.GetUnnotifiedEnrolments(ctx)
+	if err != nil {
+		slog.Error(errors.Getting("unnotified enrolments"), slog.Any("error", err))
+		return
+	}
+
+	if len(enrolments) == 0 {
+		slog.Debug("no new enrolments to notify")
+		return
+	}
+
+	for _, enrolment := range enrolments {
+		notification, err := email.NewOutboxEmail(
+			&email.EnrolmentParams{
+				UserID:     enrolment.UserID,
+				UserName:   enrolment.UserName,
+				UserEmail:  enrolment.UserEmail,
+				CourseName: enrolment.CourseTitle,
+				CourseLink: r.courseLink(enrolment.CourseID),
+				DueDate:    formatDate(enrolment.DueAt),
+			},
+			r.email.GetTemplateNames().Enrolment,
+			r.email.GetEmailNames().Enrolment,
+		)
+		if err != nil {
+			slog.Error("failed to queue enrolment email", slog.Any("error", err), slog.String("id", enrolment.ID.String()))
+			continue
+		}
+
+		if err := r.store.SetEnrolmentNotified(ctx, enrolment.ID, notification); err != nil {
+			slog.Error(errors.Updating("enrolment notification"), slog.Any("error", err), slog.String("id", enrolment.ID.String()))
+		}
+	}
+
+	slog.Info("notified learners of enrolments", slog.Int("count", len(enrolments)))
+}
+
+// LearnerReminderJob reminds learners about courses they haven't started, and certificates that
+// are about to expire
+func (r *ReminderService) LearnerReminderJob(ctx context.Context) {
+	r.NotStartedJob(ctx)
+	r.CertificateExpiryJob(ctx)
+}
+
+// NotStartedJob reminds learners about courses they were enrolled on a week ago and haven't
+// started. Each enrolment is only reminded about once, overdue enrolments are left to OverdueJob.
+func (r *ReminderService) NotStartedJob(ctx context.Context) {
+	enrolments, err := r.store.GetNotStartedEnrolments(ctx, notStartedAfterDays)
+	if err != nil {
+		slog.Error(errors.Getting("not started enrolments"), slog.Any("error", err))
+		return
+	}
+
+	if len(enrolments) == 0 {
+		slog.Debug("no not started enrolments")
+		return
+	}
+
+	for _, enrolment := range enrolments {
+		reminder, err := email.NewOutboxEmail(
+			&email.NotStartedReminderParams{
+				UserID:       enrolment.UserID,
+				UserName:     enrolment.UserName,
+				UserEmail:    enrolment.UserEmail,
+				CourseName:   enrolment.CourseTitle,
+				CourseLink:   r.courseLink(enrolment.CourseID),
+				EnrolledDate: formatDate(&enrolment.EnrolledAt),
+				DueDate:      formatDate(enrolment.DueAt),
+			},
+			r.email.GetTemplateNames().NotStartedReminder,
+			r.email.GetEmailNames().NotStartedReminder,
+		)
+		if err != nil {
+			slog.Error("failed to queue not started reminder email", slog.Any("error", err), slog.String("id", enrolment.ID.String()))
+			continue
+		}
+
+		if err := r.store.SetEnrolmentReminded(ctx, enrolment.ID, reminder); err != nil {
+			slog.Error(errors.Updating("enrolment reminder"), slog.Any("error", err), slog.String("id", enrolment.ID.String()))
+		}
+	}
+
+	slog.Info("reminded learners of not started enrolments", slog.Int("count", len(enrolments)))
+}
+
+// CertificateExpiryJob tells learners their certificate for a course expires within the next 30
+// days, so they can retake it. Each certificate is only notified once.
+func (r *ReminderService) CertificateExpiryJob(ctx context.Context) {
+	certificates, err := r.store.GetExpiringCertificates(ctx, expiryNoticeWithinDays)
+	if err != nil {
+		slog.Error(errors.Getting("expiring certificates"), slog.Any("error", err))
+		return
+	}
+
+	if len(certificates) == 0 {
+		slog.Debug("no expiring certificates")
+		return
+	}
+
+	for _, certificate := range certificates {
+		notification, err := email.NewOutboxEmail(
+			&email.CertificateExpiryParams{
+				UserID:            certificate.UserID,
+				UserName:          certificate.UserName,
+				UserEmail:         certificate.UserEmail,
+				CourseName:        certificate.CourseTitle,
+				CourseLink:        r.courseLink(certificate.CourseID),
+				CertificateNumber: certificate.Number,
+				ExpiryDate:        formatDate(&certificate.ExpiresAt),
+			},
+			r.email.GetTemplateNames().CertificateExpiry,
+			r.email.GetEmailNames().CertificateExpiry,
+		)
+		if err != nil {
+			slog.Error("failed to queue certificate expiry email", slog.Any("error", err), slog.String("id", certificate.ID.String()))
+			continue
+		}
+
+		if err := r.store.SetCertificateExpiryNotified(ctx, certificate.ID, notification); err != nil {
+			slog.Error(errors.Updating("certificate expiry notification"), slog.Any("error", err), slog.String("id", certificate.ID.String()))
+		}
+	}
+
+	slog.Info("notified learners of expiring certificates", slog.Int("count", len(certificates)))
+}
+
+func (r *ReminderService) courseLink(courseID uuid.UUID) string {
+	return r.clientURL + "/course/" + courseID.String()
+}
+
+// formatDate formats an optional date for emails, it's empty if there isn't one
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.In(location).Format(dateFormat)
+}
+
+func (r *ReminderService) Stop() {
+	r.stopOverdue() // cancel cron contexts to prevent new jobs from starting
+	r.stopDigest()
+	r.stopEnrolment()
+	r.stopLearner()
+
+	stopOverdueCtx := r.overdueCron.Stop() // returns a context that waits until existing cron jobs finish
+	stopDigestCtx := r.digestCron.Stop()
+	stopEnrolmentCtx := r.enrolmentCron.Stop()
+	stopLearnerCtx := r.learnerCron.Stop()
+	<-stopOverdueCtx.Done()
+	<-stopDigestCtx.Done()
+	<-stopEnrolmentCtx.Done()
+	<-stopLearnerCtx.Done()
+	slog.Info("reminder cron jobs completed")
+}
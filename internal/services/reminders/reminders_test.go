@@ -0,0 +1,257 @@
+package reminders_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
+	"github.com/supanova-rp/supanova-server/internal/services/reminders"
+)
+
+const (
+	overdueTemplateName = "overdue-template"
+	overdueEmailName    = "overdue"
+)
+
+func newEmailMock() *mocks.EmailServiceMock {
+	return &mocks.EmailServiceMock{
+		GetTemplateNamesFunc: func() *email.TemplateNames {
+			return &email.TemplateNames{OverdueEnrolment: overdueTemplateName}
+		},
+		GetEmailNamesFunc: func() *email.EmailNames {
+			return &email.EmailNames{OverdueEnrolment: overdueEmailName}
+		},
+	}
+}
+
+func newReminderService(t *testing.T, store reminders.ReminderRepository, emailService reminders.EmailService) *reminders.ReminderService {
+	t.Helper()
+
+	// The jobs are run directly, so the crons are scheduled far enough ahead that they never fire
+	schedule := "0 0 1 1 *"
+	service, err := reminders.New(&config.Reminders{
+		OverdueCronSchedule:         schedule,
+		ManagerDigestCronSchedule:   schedule,
+		EnrolmentCronSchedule:       schedule,
+		LearnerReminderCronSchedule: schedule,
+	}, "https://example.com/", store, emailService)
+	if err != nil {
+		t.Fatalf("failed to create reminder service: %v", err)
+	}
+	t.Cleanup(service.Stop)
+
+	return service
+}
+
+// overdueStore mimics GetOverdueEnrolments: enrolments past their due date that aren't completed,
+// haven't been flagged as overdue yet, and belong to learners who can sign in
+type overdueStore struct {
+	enrolments []domain.OverdueEnrolment
+	completed  map[uuid.UUID]bool
+	leavers    map[string]bool
+	flagged    map[uuid.UUID]bool
+	failFlag   map[uuid.UUID]bool
+	now        time.Time
+}
+
+func (s *overdueStore) mock() *mocks.ReminderRepositoryMock {
+	return &mocks.ReminderRepositoryMock{
+		GetOverdueEnrolmentsFunc: func(ctx context.Context) ([]domain.OverdueEnrolment, error) {
+			var overdue []domain.OverdueEnrolment
+			for _, enrolment := range s.enrolments {
+				if enrolment.DueAt.Before(s.now) && !s.completed[enrolment.ID] && !s.flagged[enrolment.ID] &&
+					!s.leavers[enrolment.UserID] {
+					overdue = append(overdue, enrolment)
+				}
+			}
+			return overdue, nil
+		},
+		SetEnrolmentOverdueFunc: func(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+			if s.failFlag[enrolmentID] {
+				return stdErrors.New("db error")
+			}
+			s.flagged[enrolmentID] = true
+			return nil
+		},
+	}
+}
+
+func TestOverdueJob(t *testing.T) {
+	now := time.Date(2026, 7, 10, 9, 0, 0, 0, time.UTC)
+
+	overdue := domain.OverdueEnrolment{
+		ID:          uuid.New(),
+		UserID:      "user-1",
+		UserName:    "User A",
+		UserEmail:   "usera@test.com",
+		CourseID:    uuid.New(),
+		CourseTitle: "Fire Safety",
+		// Late evening UTC is the next day in London during BST
+		DueAt: time.Date(2026, 6, 30, 23, 30, 0, 0, time.UTC),
+	}
+	otherOverdue := domain.OverdueEnrolment{
+		ID:          uuid.New(),
+		UserID:      "user-2",
+		UserName:    "User B",
+		UserEmail:   "userb@test.com",
+		CourseID:    uuid.New(),
+		CourseTitle: "First Aid",
+		DueAt:       now.Add(-time.Hour),
+	}
+	completed := domain.OverdueEnrolment{ID: uuid.New(), CourseTitle: "Completed", DueAt: now.Add(-48 * time.Hour)}
+	notDue := domain.OverdueEnrolment{ID: uuid.New(), CourseTitle: "Not Due", DueAt: now.Add(48 * time.Hour)}
+	deactivated := domain.OverdueEnrolment{ID: uuid.New(), UserID: "deactivated-user", CourseTitle: "Left", DueAt: now.Add(-48 * time.Hour)}
+	erased := domain.OverdueEnrolment{ID: uuid.New(), UserID: "erased-user", CourseTitle: "Erased", DueAt: now.Add(-48 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		flagged  []uuid.UUID
+		failFlag []uuid.UUID
+		runs     int
+		// wantFlagged are the enrolments SetEnrolmentOverdue is called with, in order
+		wantFlagged []uuid.UUID
+	}{
+		{
+			name:        "flags overdue enrolments that aren't completed, except for deactivated and erased learners",
+			runs:        1,
+			wantFlagged: []uuid.UUID{overdue.ID, otherOverdue.ID},
+		},
+		{
+			name:        "doesn't email an enrolment again once it's flagged",
+			runs:        2,
+			wantFlagged: []uuid.UUID{overdue.ID, otherOverdue.ID},
+		},
+		{
+			name:        "skips enrolments flagged by an earlier run",
+			flagged:     []uuid.UUID{overdue.ID},
+			runs:        1,
+			wantFlagged: []uuid.UUID{otherOverdue.ID},
+		},
+		{
+			name:        "retries an enrolment whose flag failed without stopping the others",
+			failFlag:    []uuid.UUID{overdue.ID},
+			runs:        2,
+			wantFlagged: []uuid.UUID{overdue.ID, otherOverdue.ID, overdue.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &overdueStore{
+				enrolments: []domain.OverdueEnrolment{overdue, otherOverdue, completed, notDue, deactivated, erased},
+				completed:  map[uuid.UUID]bool{completed.ID: true},
+				leavers:    map[string]bool{deactivated.UserID: true, erased.UserID: true},
+				flagged:    map[uuid.UUID]bool{},
+				failFlag:   map[uuid.UUID]bool{},
+				now:        now,
+			}
+			for _, id := range tt.flagged {
+				store.flagged[id] = true
+			}
+			for _, id := range tt.failFlag {
+				store.failFlag[id] = true
+			}
+
+			mockRepo := store.mock()
+			mockEmail := newEmailMock()
+			service := newReminderService(t, mockRepo, mockEmail)
+
+			for range tt.runs {
+				service.OverdueJob(context.Background())
+			}
+
+			calls := mockRepo.SetEnrolmentOverdueCalls()
+			var actual []uuid.UUID
+			for _, call := range calls {
+				actual = append(actual, call.EnrolmentID)
+			}
+
+			if diff := cmp.Diff(tt.wantFlagged, actual); diff != "" {
+				t.Errorf("flagged enrolments mismatch (-want +got):\n%s", diff)
+			}
+
+			// The email is queued with the flag rather than sent straight away, so a failed flag
+			// can't leave an email sent for an enrolment that will be picked up again
+			if len(mockEmail.SendCalls()) != 0 {
+				t.Errorf("expected no emails sent directly, got %d", len(mockEmail.SendCalls()))
+			}
+		})
+	}
+}
+
+func TestOverdueJob_QueuesEmail(t *testing.T) {
+	enrolment := domain.OverdueEnrolment{
+		ID:          uuid.New(),
+		UserName:    "User A",
+		UserEmail:   "usera@test.com",
+		CourseTitle: "Fire Safety",
+		DueAt:       time.Date(2026, 6, 30, 23, 30, 0, 0, time.UTC),
+	}
+
+	mockRepo := &mocks.ReminderRepositoryMock{
+		GetOverdueEnrolmentsFunc: func(ctx context.Context) ([]domain.OverdueEnrolment, error) {
+			return []domain.OverdueEnrolment{enrolment}, nil
+		},
+		SetEnrolmentOverdueFunc: func(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+			return nil
+		},
+	}
+	service := newReminderService(t, mockRepo, newEmailMock())
+
+	service.OverdueJob(context.Background())
+
+	calls := mockRepo.SetEnrolmentOverdueCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 SetEnrolmentOverdue call, got %d", len(calls))
+	}
+
+	notification := calls[0].Notification
+	if notification.TemplateName != overdueTemplateName || notification.EmailName != overdueEmailName {
+		t.Errorf("expected %s email with template %s, got %s with %s",
+			overdueEmailName, overdueTemplateName, notification.EmailName, notification.TemplateName)
+	}
+
+	var actual email.OverdueEnrolmentParams
+	if err := json.Unmarshal(notification.TemplateParams, &actual); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	expected := email.OverdueEnrolmentParams{
+		CourseName: "Fire Safety",
+		UserName:   "User A",
+		UserEmail:  "usera@test.com",
+		DueDate:    "01/07/2026",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("email params mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOverdueJob_GetEnrolmentsFails(t *testing.T) {
+	mockRepo := &mocks.ReminderRepositoryMock{
+		GetOverdueEnrolmentsFunc: func(ctx context.Context) ([]domain.OverdueEnrolment, error) {
+			return nil, stdErrors.New("db error")
+		},
+	}
+	mockEmail := newEmailMock()
+	service := newReminderService(t, mockRepo, mockEmail)
+
+	service.OverdueJob(context.Background())
+
+	if len(mockRepo.SetEnrolmentOverdueCalls()) != 0 {
+		t.Errorf("expected no enrolments flagged, got %d", len(mockRepo.SetEnrolmentOverdueCalls()))
+	}
+
+	if len(mockEmail.SendCalls()) != 0 {
+		t.Errorf("expected no emails sent, got %d", len(mockEmail.SendCalls()))
+	}
+}
@@ -0,0 +1,167 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+func (s *Store) AddAccessCode(ctx context.Context, params domain.AddAccessCodeParams) (*domain.AccessCode, error) {
+	row, err := ExecQuery(ctx, func() (sqlc.CourseAccessCode, error) {
+		return s.queries(ctx).AddAccessCode(ctx, sqlc.AddAccessCodeParams{
+			Code:      params.Code,
+			CourseID:  utils.PGUUIDFromUUID(params.CourseID),
+			ExpiresAt: utils.PGTimestamptzFrom(params.ExpiresAt),
+			MaxUses:   utils.PGInt4From(params.MaxUses),
+			CreatedBy: utils.PGTextFrom(params.CreatedBy),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessCode := accessCodeFrom(row)
+	return &accessCode, nil
+}
+
+func (s *Store) GetAccessCodes(ctx context.Context, courseID *uuid.UUID) ([]domain.AccessCode, error) {
+	var pgCourseID pgtype.UUID
+	if courseID != nil {
+		pgCourseID = utils.PGUUIDFromUUID(*courseID)
+	}
+
+	rows, err := ExecQuery(ctx, func() ([]sqlc.CourseAccessCode, error) {
+		return s.queries(ctx).GetAccessCodes(ctx, pgCourseID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rows, accessCodeFrom), nil
+}
+
+func (s *Store) RevokeAccessCode(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).RevokeAccessCode(ctx, utils.PGUUIDFromUUID(id))
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) GetAccessCodeRedemptions(ctx context.Context, id uuid.UUID) ([]domain.AccessCodeRedemption, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetAccessCodeRedemptionsRow, error) {
+		return s.queries(ctx).GetAccessCodeRedemptions(ctx, utils.PGUUIDFromUUID(id))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rows, func(row sqlc.GetAccessCodeRedemptionsRow) domain.AccessCodeRedemption {
+		return domain.AccessCodeRedemption{
+			UserID:     row.UserID,
+			UserName:   row.UserName.String,
+			UserEmail:  row.UserEmail.String,
+			RedeemedAt: row.RedeemedAt.Time,
+		}
+	}), nil
+}
+
+// RedeemAccessCode enrols the user in the code's course and records the redemption. The code is
+// locked for the duration of the transaction so max uses can't be exceeded by concurrent requests.
+func (s *Store) RedeemAccessCode(
+	ctx context.Context,
+	params domain.RedeemAccessCodeParams,
+) (*domain.RedeemAccessCodeResult, error) {
+	var result *domain.RedeemAccessCodeResult
+
+	err := ExecCommand(ctx, func() error {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+
+		row, err := qtx.GetAccessCodeForUpdate(ctx, params.Code)
+		if err != nil {
+			return err
+		}
+		accessCode := accessCodeFrom(row)
+
+		redeemed, err := qtx.HasRedeemedAccessCode(ctx, sqlc.HasRedeemedAccessCodeParams{
+			AccessCodeID: row.ID,
+			UserID:       params.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check access code redemptions: %w", err)
+		}
+
+		if redeemed {
+			result = &domain.RedeemAccessCodeResult{CourseID: accessCode.CourseID, AlreadyRedeemed: true}
+			return nil
+		}
+
+		if err := accessCode.Validate(time.Now()); err != nil {
+			return err
+		}
+
+		_, err = qtx.EnrolInCourse(ctx, sqlc.EnrolInCourseParams{
+			UserID:     utils.PGTextFrom(params.UserID),
+			CourseID:   row.CourseID,
+			EnrolledBy: utils.PGTextFrom(params.UserID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enrol in course: %w", err)
+		}
+
+		err = qtx.AddAccessCodeRedemption(ctx, sqlc.AddAccessCodeRedemptionParams{
+			AccessCodeID: row.ID,
+			UserID:       params.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add access code redemption: %w", err)
+		}
+
+		if err := qtx.IncrementAccessCodeUses(ctx, row.ID); err != nil {
+			return fmt.Errorf("failed to increment access code uses: %w", err)
+		}
+
+		result = &domain.RedeemAccessCodeResult{CourseID: accessCode.CourseID}
+		return tx.Commit(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func accessCodeFrom(row sqlc.CourseAccessCode) domain.AccessCode {
+	return domain.AccessCode{
+		ID:        utils.UUIDFrom(row.ID),
+		Code:      row.Code,
+		CourseID:  utils.UUIDFrom(row.CourseID),
+		ExpiresAt: utils.TimeFrom(row.ExpiresAt),
+		MaxUses:   utils.IntFrom(row.MaxUses),
+		Uses:      int(row.Uses),
+		CreatedBy: row.CreatedBy.String,
+		CreatedAt: row.CreatedAt.Time,
+		RevokedAt: utils.TimeFrom(row.RevokedAt),
+	}
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+func (s *Store) AddAPIKey(ctx context.Context, params domain.AddAPIKeyParams) (*domain.APIKey, error) {
+	row, err := ExecQuery(ctx, func() (sqlc.ApiKey, error) {
+		return s.queries(ctx).AddAPIKey(ctx, sqlc.AddAPIKeyParams{
+			Name:      params.Name,
+			KeyPrefix: params.Prefix,
+			KeyHash:   params.KeyHash,
+			Scopes:    params.Scopes,
+			CreatedBy: utils.PGTextFrom(params.CreatedBy),
+			ExpiresAt: utils.PGTimestamptzFrom(params.ExpiresAt),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := apiKeyFrom(row)
+	return &apiKey, nil
+}
+
+func (s *Store) GetAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.ApiKey, error) {
+		return s.queries(ctx).GetAPIKeys(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rows, apiKeyFrom), nil
+}
+
+func (s *Store) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	row, err := ExecQuery(ctx, func() (sqlc.ApiKey, error) {
+		return s.queries(ctx).GetAPIKeyByHash(ctx, keyHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := apiKeyFrom(row)
+	return &apiKey, nil
+}
+
+func (s *Store) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).RevokeAPIKey(ctx, utils.PGUUIDFromUUID(id))
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) SetAPIKeyLastUsed(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).SetAPIKeyLastUsed(ctx, utils.PGUUIDFromUUID(id))
+	})
+}
+
+func apiKeyFrom(row sqlc.ApiKey) domain.APIKey {
+	return domain.APIKey{
+		ID:         utils.UUIDFrom(row.ID),
+		Name:       row.Name,
+		Prefix:     row.KeyPrefix,
+		Scopes:     row.Scopes,
+		CreatedBy:  row.CreatedBy.String,
+		CreatedAt:  row.CreatedAt.Time,
+		ExpiresAt:  utils.TimeFrom(row.ExpiresAt),
+		LastUsedAt: utils.TimeFrom(row.LastUsedAt),
+		RevokedAt:  utils.TimeFrom(row.RevokedAt),
+	}
+}
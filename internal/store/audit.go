@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+const auditChainBatchSize = 1000
+
+// AddAuditEntry chains the entry from the last one. Writers are serialised so two entries can't
+// chain from the same one.
+func (s *Store) AddAuditEntry(ctx context.Context, params domain.AddAuditEntryParams) error {
+	return ExecCommand(ctx, func() error {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+
+		if err := qtx.LockAuditLog(ctx); err != nil {
+			return err
+		}
+
+		prevHash, err := qtx.GetLastAuditHash(ctx)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		entry := &domain.AuditEntry{
+			ActorID:    params.ActorID,
+			Action:     params.Action,
+			TargetType: params.TargetType,
+			TargetID:   params.TargetID,
+			Before:     params.Before,
+			After:      params.After,
+			// Postgres stores microseconds, the hash must cover the time as it's read back
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+			PrevHash:  prevHash,
+		}
+
+		entry.Hash, err = domain.AuditHash(entry)
+		if err != nil {
+			return fmt.Errorf("failed to hash audit entry: %w", err)
+		}
+
+		_, err = qtx.AddAuditEntry(ctx, sqlc.AddAuditEntryParams{
+			ActorID:    optionalText(entry.ActorID),
+			Action:     string(entry.Action),
+			TargetType: entry.TargetType,
+			TargetID:   entry.TargetID,
+			Before:     entry.Before,
+			After:      entry.After,
+			CreatedAt:  utils.PGTimestamptzFrom(&entry.CreatedAt),
+			PrevHash:   entry.PrevHash,
+			Hash:       entry.Hash,
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+func (s *Store) WithAuditEntry(
+	ctx context.Context,
+	change func(ctx context.Context) (*domain.AddAuditEntryParams, error),
+) error {
+	return s.InTx(ctx, func(ctx context.Context) error {
+		entry, err := change(ctx)
+		if err != nil || entry == nil {
+			return err
+		}
+
+		return s.AddAuditEntry(ctx, *entry)
+	})
+}
+
+func (s *Store) ListAuditEntries(
+	ctx context.Context,
+	params domain.ListAuditEntriesParams,
+) (*domain.Page[domain.AuditEntry], error) {
+	args := pageArgsFrom(params.PageParams)
+	filters := sqlc.CountAuditEntriesParams{
+		ActorID:     optionalText(params.ActorID),
+		Action:      optionalText(params.Action),
+		TargetType:  optionalText(params.TargetType),
+		TargetID:    optionalText(params.TargetID),
+		CreatedFrom: utils.PGTimestamptzFrom(params.CreatedFrom),
+		CreatedTo:   utils.PGTimestamptzFrom(params.CreatedTo),
+		Search:      args.Search,
+	}
+
+	total, err := ExecQuery(ctx, func() (int64, error) {
+		return s.queries(ctx).CountAuditEntries(ctx, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ExecQuery(ctx, func() ([]sqlc.AuditLog, error) {
+		return s.queries(ctx).ListAuditEntries(ctx, sqlc.ListAuditEntriesParams{
+			ActorID:     filters.ActorID,
+			Action:      filters.Action,
+			TargetType:  filters.TargetType,
+			TargetID:    filters.TargetID,
+			CreatedFrom: filters.CreatedFrom,
+			CreatedTo:   filters.CreatedTo,
+			Search:      filters.Search,
+			CursorID:    args.CursorID,
+			SortDesc:    args.SortDesc,
+			PageLimit:   args.PageLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pageFrom(
+		rows,
+		params.PageParams,
+		total,
+		func(row sqlc.AuditLog) domain.Cursor {
+			id := fmt.Sprint(row.ID)
+			return domain.Cursor{Key: id, ID: id}
+		},
+		func(row sqlc.AuditLog) (domain.AuditEntry, error) {
+			return auditEntryFrom(row), nil
+		},
+	)
+}
+
+// VerifyAuditLog recomputes the hash chain from the first entry, stopping at the first entry that
+// doesn't match
+func (s *Store) VerifyAuditLog(ctx context.Context) (*domain.AuditVerification, error) {
+	verification := &domain.AuditVerification{Valid: true}
+	var lastID int64
+	prevHash := ""
+
+	for {
+		rows, err := ExecQuery(ctx, func() ([]sqlc.AuditLog, error) {
+			return s.queries(ctx).GetAuditChain(ctx, sqlc.GetAuditChainParams{
+				AfterID:   lastID,
+				BatchSize: auditChainBatchSize,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			entry := auditEntryFrom(row)
+			hash, err := domain.AuditHash(&entry)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash audit entry: %w", err)
+			}
+
+			if entry.PrevHash != prevHash || entry.Hash != hash {
+				verification.Valid = false
+				verification.FirstInvalidID = &entry.ID
+				return verification, nil
+			}
+
+			verification.EntriesChecked++
+			prevHash = entry.Hash
+			lastID = entry.ID
+		}
+
+		if len(rows) < auditChainBatchSize {
+			return verification, nil
+		}
+	}
+}
+
+func optionalText(text string) pgtype.Text {
+	return pgtype.Text{String: text, Valid: text != ""}
+}
+
+func auditEntryFrom(row sqlc.AuditLog) domain.AuditEntry {
+	return domain.AuditEntry{
+		ID:         row.ID,
+		ActorID:    row.ActorID.String,
+		Action:     domain.AuditAction(row.Action),
+		TargetType: row.TargetType,
+		TargetID:   row.TargetID,
+		Before:     row.Before,
+		After:      row.After,
+		CreatedAt:  row.CreatedAt.Time.UTC(),
+		PrevHash:   row.PrevHash,
+		Hash:       row.Hash,
+	}
+}
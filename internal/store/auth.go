@@ -10,7 +10,7 @@ import (
 
 func (s *Store) RegisterUser(ctx context.Context, params domain.RegisterParams) (*domain.User, error) {
 	id, err := ExecQuery(ctx, func() (string, error) {
-		return s.Queries.InsertUser(ctx, sqlc.InsertUserParams{
+		return s.queries(ctx).InsertUser(ctx, sqlc.InsertUserParams{
 			ID:    params.ID,
 			Name:  utils.PGTextFrom(params.Name),
 			Email: utils.PGTextFrom(params.Email),
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+// AddCertificate returns the certificate with its number, which is only known once it's added.
+// Certificates issued in a larger transaction are added in a savepoint, so the transaction can
+// carry on without the certificate if it can't be added.
+func (s *Store) AddCertificate(ctx context.Context, params domain.AddCertificateParams) (*domain.Certificate, error) {
+	id := utils.PGUUIDFromUUID(params.ID)
+
+	var row sqlc.GetCertificateRow
+	err := s.InTx(ctx, func(ctx context.Context) error {
+		err := s.queries(ctx).AddCertificate(ctx, sqlc.AddCertificateParams{
+			ID:             id,
+			UserID:         params.UserID,
+			CourseID:       utils.PGUUIDFromUUID(params.CourseID),
+			StorageKey:     params.StorageKey,
+			VerificationID: params.VerificationID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add certificate: %w", err)
+		}
+
+		row, err = s.queries(ctx).GetCertificate(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return certificateFrom(sqlc.GetLatestCertificateRow(row)), nil
+}
+
+func (s *Store) GetLatestCertificate(
+	ctx context.Context,
+	params domain.GetLatestCertificateParams,
+) (*domain.Certificate, error) {
+	row, err := ExecQuery(ctx, func() (sqlc.GetLatestCertificateRow, error) {
+		return s.queries(ctx).GetLatestCertificate(ctx, sqlc.GetLatestCertificateParams{
+			UserID:   params.UserID,
+			CourseID: utils.PGUUIDFromUUID(params.CourseID),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return certificateFrom(row), nil
+}
+
+func (s *Store) GetCertificateByVerificationID(ctx context.Context, verificationID string) (*domain.Certificate, error) {
+	row, err := ExecQuery(ctx, func() (sqlc.GetCertificateByVerificationIDRow, error) {
+		return s.queries(ctx).GetCertificateByVerificationID(ctx, verificationID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return certificateFrom(sqlc.GetLatestCertificateRow(row)), nil
+}
+
+func (s *Store) DeleteCertificate(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).DeleteCertificate(ctx, utils.PGUUIDFromUUID(id))
+	})
+}
+
+func (s *Store) RevokeCertificate(ctx context.Context, params domain.RevokeCertificateParams) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).RevokeCertificate(ctx, sqlc.RevokeCertificateParams{
+			RevokedBy: optionalText(params.RevokedBy),
+			Reason:    utils.PGTextFrom(params.Reason),
+			ID:        utils.PGUUIDFromUUID(params.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) SetCertificateValidity(ctx context.Context, params domain.SetCertificateValidityParams) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).SetCertificateValidity(ctx, sqlc.SetCertificateValidityParams{
+			ValidityMonths: utils.PGInt4From(params.ValidityMonths),
+			CourseID:       utils.PGUUIDFromUUID(params.CourseID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) GetExpiringCertificates(ctx context.Context, expiresWithinDays int) ([]domain.ExpiringCertificate, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetExpiringCertificatesRow, error) {
+		return s.queries(ctx).GetExpiringCertificates(ctx, int32(expiresWithinDays)) //nolint:gosec
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rows, func(row sqlc.GetExpiringCertificatesRow) domain.ExpiringCertificate {
+		return domain.ExpiringCertificate{
+			ID:          utils.UUIDFrom(row.ID),
+			Number:      domain.CertificateNumber(row.Number),
+			UserID:      row.UserID,
+			UserName:    row.UserName.String,
+			UserEmail:   row.UserEmail.String,
+			CourseID:    utils.UUIDFrom(row.CourseID),
+			CourseTitle: row.CourseTitle.String,
+			ExpiresAt:   row.ExpiresAt.Time,
+		}
+	}), nil
+}
+
+func (s *Store) SetCertificateExpiryNotified(ctx context.Context, id uuid.UUID, notification *domain.OutboxEmail) error {
+	return s.execWithEmails(ctx, func(qtx *sqlc.Queries) error {
+		return qtx.SetCertificateExpiryNotified(ctx, utils.PGUUIDFromUUID(id))
+	}, notification)
+}
+
+func certificateFrom(row sqlc.GetLatestCertificateRow) *domain.Certificate {
+	return &domain.Certificate{
+		ID:              utils.UUIDFrom(row.ID),
+		Number:          domain.CertificateNumber(row.Number),
+		UserID:          row.UserID,
+		UserName:        row.UserName.String,
+		CourseID:        utils.UUIDFrom(row.CourseID),
+		CourseTitle:     row.CourseTitle.String,
+		CompletionTitle: row.CompletionTitle.String,
+		StorageKey:      row.StorageKey,
+		VerificationID:  row.VerificationID,
+		IssuedAt:        row.IssuedAt.Time.UTC(),
+		ExpiresAt:       utils.TimeFrom(row.ExpiresAt),
+		RevokedAt:       utils.TimeFrom(row.RevokedAt),
+		RevokeReason:    row.RevokeReason.String,
+	}
+}
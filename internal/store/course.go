@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -41,7 +42,7 @@ type sqlcCourseMaterial struct {
 
 func (s *Store) GetCourse(ctx context.Context, id pgtype.UUID) (*domain.Course, error) {
 	course, err := ExecQuery(ctx, func() (sqlc.GetCourseRow, error) {
-		return s.Queries.GetCourse(ctx, id)
+		return s.queries(ctx).GetCourse(ctx, id)
 	})
 	if err != nil {
 		return nil, err
@@ -144,7 +145,7 @@ func courseFrom(row *sqlc.GetCourseRow) (*domain.Course, error) {
 
 func (s *Store) GetCourseSections(ctx context.Context, courseID pgtype.UUID) ([]domain.CourseSection, error) {
 	videos, err := ExecQuery(ctx, func() ([]sqlc.GetCourseVideoSectionsRow, error) {
-		return s.Queries.GetCourseVideoSections(ctx, courseID)
+		return s.queries(ctx).GetCourseVideoSections(ctx, courseID)
 	})
 	if err != nil {
 		return nil, err
@@ -174,7 +175,7 @@ func (s *Store) AddCourse(ctx context.Context, params *domain.AddCourseParams) (
 	var courseID pgtype.UUID
 
 	err := ExecCommand(ctx, func() error {
-		tx, err := s.pool.Begin(ctx)
+		tx, err := s.begin(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
@@ -236,7 +237,7 @@ func (s *Store) AddCourse(ctx context.Context, params *domain.AddCourseParams) (
 // TODO: Remove once edit course dashboard reuses /courses/overview endpoint
 func (s *Store) GetAllCourses(ctx context.Context) ([]*domain.AllCourseLegacy, error) {
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetAllCoursesRow, error) {
-		return s.Queries.GetAllCourses(ctx)
+		return s.queries(ctx).GetAllCourses(ctx)
 	})
 	if err != nil {
 		return nil, err
@@ -247,6 +248,51 @@ func (s *Store) GetAllCourses(ctx context.Context) ([]*domain.AllCourseLegacy, e
 	})
 }
 
+func (s *Store) ListCourses(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.AllCourseLegacy], error) {
+	args := pageArgsFrom(params)
+
+	total, err := ExecQuery(ctx, func() (int64, error) {
+		return s.queries(ctx).CountCourses(ctx, args.Search)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ExecQuery(ctx, func() ([]sqlc.ListCoursesRow, error) {
+		return s.queries(ctx).ListCourses(ctx, sqlc.ListCoursesParams{
+			Search:    args.Search,
+			CursorID:  args.CursorID,
+			SortDesc:  args.SortDesc,
+			CursorKey: args.CursorKey,
+			PageLimit: args.PageLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pageFrom(
+		rows,
+		params,
+		total,
+		func(row sqlc.ListCoursesRow) domain.Cursor {
+			return domain.Cursor{Key: row.SortKey, ID: utils.UUIDFrom(row.ID).String()}
+		},
+		func(row sqlc.ListCoursesRow) (*domain.AllCourseLegacy, error) {
+			return allCourseFrom(&sqlc.GetAllCoursesRow{
+				ID:                row.ID,
+				Title:             row.Title,
+				Description:       row.Description,
+				CompletionTitle:   row.CompletionTitle,
+				CompletionMessage: row.CompletionMessage,
+				VideoSections:     row.VideoSections,
+				QuizSections:      row.QuizSections,
+				Materials:         row.Materials,
+			})
+		},
+	)
+}
+
 // TODO: Remove once edit course dashboard reuses /courses/overview endpoint
 func allCourseFrom(row *sqlc.GetAllCoursesRow) (*domain.AllCourseLegacy, error) {
 	var sqlcVideos []sqlcVideoSection
@@ -336,7 +382,7 @@ func allCourseFrom(row *sqlc.GetAllCoursesRow) (*domain.AllCourseLegacy, error)
 
 func (s *Store) GetCoursesOverview(ctx context.Context) ([]domain.CourseOverview, error) {
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetCoursesOverviewRow, error) {
-		return s.Queries.GetCoursesOverview(ctx)
+		return s.queries(ctx).GetCoursesOverview(ctx)
 	})
 	if err != nil {
 		return nil, err
@@ -345,26 +391,34 @@ func (s *Store) GetCoursesOverview(ctx context.Context) ([]domain.CourseOverview
 	return utils.Map(rows, courseOverviewFrom), nil
 }
 
-func (s *Store) GetAssignedCourseTitles(ctx context.Context, userID string) ([]domain.CourseOverview, error) {
+func (s *Store) GetAssignedCourseTitles(ctx context.Context, userID string) ([]domain.AssignedCourseOverview, error) {
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetAssignedCourseTitlesRow, error) {
-		return s.Queries.GetAssignedCourseTitles(ctx, utils.PGTextFrom(userID))
+		return s.queries(ctx).GetAssignedCourseTitles(ctx, utils.PGTextFrom(userID))
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return utils.Map(rows, func(row sqlc.GetAssignedCourseTitlesRow) domain.CourseOverview {
-		return domain.CourseOverview{
-			ID:          utils.UUIDFrom(row.ID),
-			Title:       row.Title.String,
-			Description: row.Description.String,
+	now := time.Now()
+
+	return utils.Map(rows, func(row sqlc.GetAssignedCourseTitlesRow) domain.AssignedCourseOverview {
+		dueDate := utils.TimeFrom(row.DueAt)
+
+		return domain.AssignedCourseOverview{
+			CourseOverview: domain.CourseOverview{
+				ID:          utils.UUIDFrom(row.ID),
+				Title:       row.Title.String,
+				Description: row.Description.String,
+			},
+			DueDate: dueDate,
+			Status:  domain.EnrolmentStatusFrom(row.Started, row.CompletedCourse, dueDate, now),
 		}
 	}), nil
 }
 
 func (s *Store) GetCourseMaterials(ctx context.Context, courseID uuid.UUID) ([]domain.CourseMaterial, error) {
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetCourseMaterialsRow, error) {
-		return s.Queries.GetCourseMaterials(ctx, utils.PGUUIDFromUUID(courseID))
+		return s.queries(ctx).GetCourseMaterials(ctx, utils.PGUUIDFromUUID(courseID))
 	})
 	if err != nil {
 		return nil, err
@@ -377,7 +431,7 @@ func (s *Store) EditCourse(ctx context.Context, params *domain.EditCourseParams)
 	courseID := utils.PGUUIDFromUUID(params.CourseID)
 
 	err := ExecCommand(ctx, func() error {
-		tx, err := s.pool.Begin(ctx)
+		tx, err := s.begin(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
@@ -551,7 +605,7 @@ func deleteCourseItems(
 
 func (s *Store) DeleteCourse(ctx context.Context, id uuid.UUID) error {
 	return ExecCommand(ctx, func() error {
-		return s.Queries.DeleteCourse(ctx, utils.PGUUIDFromUUID(id))
+		return s.queries(ctx).DeleteCourse(ctx, utils.PGUUIDFromUUID(id))
 	})
 }
 
@@ -2,51 +2,371 @@ package store
 
 import (
 	"context"
+	"fmt"
+	"time"
 
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
+	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/services/email"
 	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
 	"github.com/supanova-rp/supanova-server/internal/utils"
 )
 
-func (s *Store) AddFailedEmail(ctx context.Context, params sqlc.AddFailedEmailParams) error {
+func (s *Store) EnqueueEmail(ctx context.Context, outboxEmail *domain.OutboxEmail) error {
 	return ExecCommand(ctx, func() error {
-		return s.Queries.AddFailedEmail(ctx, params)
+		return enqueueEmails(ctx, s.queries(ctx), outboxEmail)
 	})
 }
 
-func (s *Store) UpdateFailedEmail(ctx context.Context, params sqlc.UpdateFailedEmailParams) error {
+// enqueueEmails queues emails in the outbox, with qtx so they're only sent if the transaction
+// making the change they're about commits. Nil emails are skipped.
+func enqueueEmails(ctx context.Context, qtx *sqlc.Queries, outboxEmails ...*domain.OutboxEmail) error {
+	for _, outboxEmail := range outboxEmails {
+		if outboxEmail == nil {
+			continue
+		}
+
+		err := qtx.EnqueueEmail(ctx, sqlc.EnqueueEmailParams{
+			EmailName:      outboxEmail.EmailName,
+			TemplateName:   outboxEmail.TemplateName,
+			TemplateParams: outboxEmail.TemplateParams,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execWithEmails runs command and queues the emails about it in one transaction, so the emails are
+// only sent if the change is made and aren't lost if the server stops before sending them
+func (s *Store) execWithEmails(
+	ctx context.Context,
+	command func(qtx *sqlc.Queries) error,
+	outboxEmails ...*domain.OutboxEmail,
+) error {
 	return ExecCommand(ctx, func() error {
-		return s.Queries.UpdateFailedEmail(ctx, params)
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+
+		if err := command(qtx); err != nil {
+			return err
+		}
+
+		if err := enqueueEmails(ctx, qtx, outboxEmails...); err != nil {
+			return err
+		}
+
+		return tx.Commit(ctx)
 	})
 }
 
-func (s *Store) GetFailedEmails(ctx context.Context) ([]email.FailedEmail, error) {
-	rows, err := ExecQuery(ctx, func() ([]sqlc.GetFailedEmailsRow, error) {
-		return s.Queries.GetFailedEmails(ctx)
+// ClaimDueEmails claims up to limit emails that are due to be sent, so other servers don't send
+// them for the length of the lease
+func (s *Store) ClaimDueEmails(ctx context.Context, limit int, lease time.Duration) ([]email.QueuedEmail, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.ClaimDueEmailsRow, error) {
+		return s.queries(ctx).ClaimDueEmails(ctx, sqlc.ClaimDueEmailsParams{
+			LeaseSeconds: int32(lease.Seconds()),
+			BatchSize:    int32(limit), //nolint:gosec
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return utils.Map(rows, func(row sqlc.GetFailedEmailsRow) email.FailedEmail {
-		return failedEmailsFrom(&row)
+	return utils.Map(rows, func(row sqlc.ClaimDueEmailsRow) email.QueuedEmail {
+		return email.QueuedEmail{
+			ID:             utils.UUIDFrom(row.ID),
+			EmailName:      row.EmailName,
+			TemplateName:   row.TemplateName,
+			TemplateParams: row.TemplateParams,
+			Attempts:       int(row.Attempts),
+		}
 	}), nil
 }
 
-func failedEmailsFrom(row *sqlc.GetFailedEmailsRow) email.FailedEmail {
-	return email.FailedEmail{
-		ID:             utils.UUIDFrom(row.ID),
-		TemplateName:   row.TemplateName,
-		TemplateParams: row.TemplateParams,
-		EmailName:      row.EmailName,
-		Retries:        int(row.Retries),
+// SetEmailSent marks the email as sent, with the ID the provider gave the message so delivery
+// events can be matched to it
+func (s *Store) SetEmailSent(ctx context.Context, id uuid.UUID, providerMessageID string) error {
+	return s.recordAttempt(ctx, id, "", func(qtx *sqlc.Queries) error {
+		return qtx.SetEmailSent(ctx, sqlc.SetEmailSentParams{
+			ProviderMessageID: optionalText(providerMessageID),
+			ID:                utils.PGUUIDFromUUID(id),
+		})
+	})
+}
+
+func (s *Store) SetEmailSkipped(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).SetEmailSkipped(ctx, utils.PGUUIDFromUUID(id))
+	})
+}
+
+func (s *Store) SetEmailRetry(ctx context.Context, id uuid.UUID, sendErr string, nextAttemptAt time.Time) error {
+	return s.recordAttempt(ctx, id, sendErr, func(qtx *sqlc.Queries) error {
+		return qtx.SetEmailRetry(ctx, sqlc.SetEmailRetryParams{
+			Error:         utils.PGTextFrom(sendErr),
+			NextAttemptAt: utils.PGTimestamptzFrom(&nextAttemptAt),
+			ID:            utils.PGUUIDFromUUID(id),
+		})
+	})
+}
+
+func (s *Store) SetEmailDead(ctx context.Context, id uuid.UUID, sendErr string) error {
+	return s.recordAttempt(ctx, id, sendErr, func(qtx *sqlc.Queries) error {
+		return qtx.SetEmailDead(ctx, sqlc.SetEmailDeadParams{
+			Error: utils.PGTextFrom(sendErr),
+			ID:    utils.PGUUIDFromUUID(id),
+		})
+	})
+}
+
+// recordAttempt runs update to set the outcome of an attempt to send the email, and adds the
+// attempt to the email's history in the same transaction. sendErr is empty if the email was sent.
+func (s *Store) recordAttempt(
+	ctx context.Context,
+	id uuid.UUID,
+	sendErr string,
+	update func(qtx *sqlc.Queries) error,
+) error {
+	return ExecCommand(ctx, func() error {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+
+		if err := update(qtx); err != nil {
+			return err
+		}
+
+		err = qtx.AddEmailAttempt(ctx, sqlc.AddEmailAttemptParams{
+			EmailID: utils.PGUUIDFromUUID(id),
+			Error:   optionalText(sendErr),
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+func (s *Store) IsEmailOptedOut(ctx context.Context, userID, emailName string) (bool, error) {
+	return ExecQuery(ctx, func() (bool, error) {
+		return s.queries(ctx).IsEmailOptedOut(ctx, sqlc.IsEmailOptedOutParams{
+			UserID:    userID,
+			EmailName: emailName,
+		})
+	})
+}
+
+func (s *Store) GetEmailOptOuts(ctx context.Context, userID string) ([]string, error) {
+	return ExecQuery(ctx, func() ([]string, error) {
+		return s.queries(ctx).GetEmailOptOuts(ctx, userID)
+	})
+}
+
+// SetEmailOptOut opts the learner out of, or back in to, an email. Doing either twice is a no-op.
+func (s *Store) SetEmailOptOut(ctx context.Context, params domain.SetEmailOptOutParams) error {
+	return ExecCommand(ctx, func() error {
+		if params.OptOut {
+			return s.queries(ctx).AddEmailOptOut(ctx, sqlc.AddEmailOptOutParams{
+				UserID:    params.UserID,
+				EmailName: params.EmailName,
+			})
+		}
+
+		return s.queries(ctx).DeleteEmailOptOut(ctx, sqlc.DeleteEmailOptOutParams{
+			UserID:    params.UserID,
+			EmailName: params.EmailName,
+		})
+	})
+}
+
+func (s *Store) ListFailedEmails(
+	ctx context.Context,
+	params domain.ListFailedEmailsParams,
+) (*domain.Page[domain.FailedEmail], error) {
+	args := pageArgsFrom(params.PageParams)
+	filters := sqlc.CountFailedEmailsParams{
+		Status: optionalText(string(params.Status)),
+		Search: args.Search,
+	}
+
+	total, err := ExecQuery(ctx, func() (int64, error) {
+		return s.queries(ctx).CountFailedEmails(ctx, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ExecQuery(ctx, func() ([]sqlc.ListFailedEmailsRow, error) {
+		return s.queries(ctx).ListFailedEmails(ctx, sqlc.ListFailedEmailsParams{
+			Status:    filters.Status,
+			Search:    filters.Search,
+			CursorID:  args.CursorID,
+			SortDesc:  args.SortDesc,
+			CursorKey: args.CursorKey,
+			PageLimit: args.PageLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pageFrom(
+		rows,
+		params.PageParams,
+		total,
+		func(row sqlc.ListFailedEmailsRow) domain.Cursor {
+			return domain.Cursor{Key: row.CreatedAt.Time.Format(time.RFC3339Nano), ID: utils.UUIDFrom(row.ID).String()}
+		},
+		func(row sqlc.ListFailedEmailsRow) (domain.FailedEmail, error) {
+			failed := domain.FailedEmail{
+				ID:             utils.UUIDFrom(row.ID),
+				EmailName:      row.EmailName,
+				TemplateName:   row.TemplateName,
+				TemplateParams: row.TemplateParams,
+				Status:         domain.EmailStatus(row.Status),
+				Attempts:       int(row.Attempts),
+				Error:          row.LastError.String,
+				CreatedAt:      row.CreatedAt.Time,
+			}
+
+			// Dead emails aren't retried, so they have no next attempt
+			if failed.Status == domain.EmailStatusPending {
+				failed.NextAttemptAt = utils.TimeFrom(row.NextAttemptAt)
+			}
+
+			return failed, nil
+		},
+	)
+}
+
+// CountFailedEmails counts the failed emails waiting to be retried and the dead-lettered emails, by
+// status
+func (s *Store) CountFailedEmails(ctx context.Context) (map[domain.EmailStatus]int64, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.CountFailedEmailsByStatusRow, error) {
+		return s.queries(ctx).CountFailedEmailsByStatus(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.EmailStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[domain.EmailStatus(row.Status)] = row.Count
+	}
+
+	return counts, nil
+}
+
+// RetryFailedEmail sends a dead-lettered email with the next dispatch
+func (s *Store) RetryFailedEmail(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).RetryFailedEmail(ctx, utils.PGUUIDFromUUID(id))
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+// DiscardFailedEmail stops a failed email from being sent, it's kept as discarded
+func (s *Store) DiscardFailedEmail(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).DiscardFailedEmail(ctx, utils.PGUUIDFromUUID(id))
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) GetEmailAttempts(ctx context.Context, id uuid.UUID) ([]domain.EmailAttempt, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetEmailAttemptsRow, error) {
+		return s.queries(ctx).GetEmailAttempts(ctx, utils.PGUUIDFromUUID(id))
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return utils.Map(rows, func(row sqlc.GetEmailAttemptsRow) domain.EmailAttempt {
+		return domain.EmailAttempt{
+			AttemptedAt: row.AttemptedAt.Time,
+			Error:       row.Error.String,
+		}
+	}), nil
 }
 
-func (s *Store) DeleteFailedEmail(ctx context.Context, emailID pgtype.UUID) error {
+// RecordEmailEvent records a delivery event against the sent email it's about. Bounces flag the
+// recipient's address as bouncing until an email is delivered to it. Events that were already
+// recorded are ignored.
+func (s *Store) RecordEmailEvent(ctx context.Context, event *domain.EmailEvent) error {
 	return ExecCommand(ctx, func() error {
-		return s.Queries.DeleteFailedEmail(ctx, emailID)
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+
+		added, err := qtx.AddEmailEvent(ctx, sqlc.AddEmailEventParams{
+			ProviderEventID: event.ProviderEventID,
+			MessageID:       event.MessageID,
+			Event:           string(event.Type),
+			Recipient:       event.Recipient,
+			Reason:          optionalText(event.Reason),
+			OccurredAt:      utils.PGTimestamptzFrom(&event.OccurredAt),
+		})
+		if err != nil {
+			return err
+		}
+
+		if added == 0 {
+			return nil
+		}
+
+		// Deferred and complained events are only recorded
+		switch event.Type {
+		case domain.EmailEventBounced:
+			err = qtx.SetEmailBounced(ctx, sqlc.SetEmailBouncedParams{
+				Email:     event.Recipient,
+				BouncedAt: utils.PGTimestamptzFrom(&event.OccurredAt),
+				Reason:    optionalText(event.Reason),
+			})
+		case domain.EmailEventDelivered:
+			err = qtx.ClearEmailBounced(ctx, sqlc.ClearEmailBouncedParams{
+				Email:       event.Recipient,
+				DeliveredAt: utils.PGTimestamptzFrom(&event.OccurredAt),
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit(ctx)
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 
@@ -14,27 +15,87 @@ import (
 
 func (s *Store) GetUsersAndAssignedCourses(ctx context.Context) ([]domain.UserWithAssignedCourses, error) {
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetUsersAndAssignedCoursesRow, error) {
-		return s.Queries.GetUsersAndAssignedCourses(ctx)
+		return s.queries(ctx).GetUsersAndAssignedCourses(ctx)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return utils.MapToWithError(rows, func(row sqlc.GetUsersAndAssignedCoursesRow) (domain.UserWithAssignedCourses, error) {
-		var courseIDs []uuid.UUID
-		if row.CourseIds != nil {
-			if err := json.Unmarshal(row.CourseIds, &courseIDs); err != nil {
-				return domain.UserWithAssignedCourses{}, fmt.Errorf("failed to unmarshal course IDs: %w", err)
-			}
-		}
+	return utils.MapToWithError(rows, userWithAssignedCoursesFrom)
+}
+
+func (s *Store) ListUsersAndAssignedCourses(
+	ctx context.Context,
+	params domain.PageParams,
+) (*domain.Page[domain.UserWithAssignedCourses], error) {
+	args := pageArgsFrom(params)
 
-		return domain.UserWithAssignedCourses{
-			ID:        row.ID,
-			Name:      row.Name.String,
-			Email:     row.Email.String,
-			CourseIDs: courseIDs,
-		}, nil
+	total, err := ExecQuery(ctx, func() (int64, error) {
+		return s.queries(ctx).CountUsersAndAssignedCourses(ctx, sqlc.CountUsersAndAssignedCoursesParams{
+			Search:   args.Search,
+			CourseID: args.CourseID,
+			GroupID:  args.GroupID,
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ExecQuery(ctx, func() ([]sqlc.ListUsersAndAssignedCoursesRow, error) {
+		return s.queries(ctx).ListUsersAndAssignedCourses(ctx, sqlc.ListUsersAndAssignedCoursesParams{
+			SortBy:    params.SortBy,
+			Search:    args.Search,
+			CourseID:  args.CourseID,
+			GroupID:   args.GroupID,
+			CursorID:  args.CursorID,
+			SortDesc:  args.SortDesc,
+			CursorKey: args.CursorKey,
+			PageLimit: args.PageLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pageFrom(
+		rows,
+		params,
+		total,
+		func(row sqlc.ListUsersAndAssignedCoursesRow) domain.Cursor {
+			return domain.Cursor{Key: row.SortKey, ID: row.ID}
+		},
+		func(row sqlc.ListUsersAndAssignedCoursesRow) (domain.UserWithAssignedCourses, error) {
+			return userWithAssignedCoursesFrom(sqlc.GetUsersAndAssignedCoursesRow{
+				ID:        row.ID,
+				Name:      row.Name,
+				Email:     row.Email,
+				CourseIds: row.CourseIds,
+				Groups:    row.Groups,
+			})
+		},
+	)
+}
+
+func userWithAssignedCoursesFrom(row sqlc.GetUsersAndAssignedCoursesRow) (domain.UserWithAssignedCourses, error) {
+	var courseIDs []uuid.UUID
+	if row.CourseIds != nil {
+		if err := json.Unmarshal(row.CourseIds, &courseIDs); err != nil {
+			return domain.UserWithAssignedCourses{}, fmt.Errorf("failed to unmarshal course IDs: %w", err)
+		}
+	}
+
+	groups, err := groupSummariesFrom(row.Groups)
+	if err != nil {
+		return domain.UserWithAssignedCourses{}, err
+	}
+
+	return domain.UserWithAssignedCourses{
+		ID:        row.ID,
+		Name:      row.Name.String,
+		Email:     row.Email.String,
+		CourseIDs: courseIDs,
+		Groups:    groups,
+	}, nil
 }
 
 func (s *Store) IsEnrolled(ctx context.Context, params domain.IsEnrolledParams) (bool, error) {
@@ -44,18 +105,22 @@ func (s *Store) IsEnrolled(ctx context.Context, params domain.IsEnrolledParams)
 	}
 
 	return ExecQuery(ctx, func() (bool, error) {
-		return s.Queries.IsUserEnrolledInCourse(ctx, sqlcParams)
+		return s.queries(ctx).IsUserEnrolledInCourse(ctx, sqlcParams)
 	})
 }
 
 func (s *Store) EnrolInCourse(ctx context.Context, params domain.EnrolInCourseParams) error {
 	sqlcParams := sqlc.EnrolInCourseParams{
-		UserID:   utils.PGTextFrom(params.UserID),
-		CourseID: utils.PGUUIDFromUUID(params.CourseID),
+		UserID:     utils.PGTextFrom(params.UserID),
+		CourseID:   utils.PGUUIDFromUUID(params.CourseID),
+		EnrolledBy: utils.PGTextFrom(params.EnrolledBy),
+		DueAt:      utils.PGTimestamptzFrom(params.DueAt),
+		DueInDays:  utils.PGInt4From(params.DueInDays),
 	}
 
 	return ExecCommand(ctx, func() error {
-		return s.Queries.EnrolInCourse(ctx, sqlcParams)
+		_, err := s.queries(ctx).EnrolInCourse(ctx, sqlcParams)
+		return err
 	})
 }
 
@@ -66,6 +131,208 @@ func (s *Store) DisenrolInCourse(ctx context.Context, params domain.DisenrolInCo
 	}
 
 	return ExecCommand(ctx, func() error {
-		return s.Queries.DisenrolInCourse(ctx, sqlcParams)
+		_, err := s.queries(ctx).DisenrolInCourse(ctx, sqlcParams)
+		return err
 	})
 }
+
+// BulkUpdateEnrolments enrols or disenrols every user/course pair in a single transaction.
+// Pairs with an unknown user or course are reported in the results rather than failing the whole update.
+func (s *Store) BulkUpdateEnrolments(
+	ctx context.Context,
+	params domain.BulkUpdateEnrolmentsParams,
+) ([]domain.BulkEnrolmentResult, error) {
+	var results []domain.BulkEnrolmentResult
+
+	err := ExecCommand(ctx, func() error {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+
+		existingUserIDs, err := qtx.GetExistingUserIDs(ctx, params.UserIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get existing users: %w", err)
+		}
+
+		existingCourseIDs, err := qtx.GetExistingCourseIDs(ctx, utils.Map(params.CourseIDs, utils.PGUUIDFromUUID))
+		if err != nil {
+			return fmt.Errorf("failed to get existing courses: %w", err)
+		}
+		courseIDs := utils.Map(existingCourseIDs, utils.UUIDFrom)
+
+		results = make([]domain.BulkEnrolmentResult, 0, len(params.UserIDs)*len(params.CourseIDs))
+		for _, userID := range params.UserIDs {
+			for _, courseID := range params.CourseIDs {
+				result := domain.BulkEnrolmentResult{UserID: userID, CourseID: courseID}
+
+				switch {
+				case !slices.Contains(existingUserIDs, userID):
+					result.Status = domain.BulkEnrolmentResultUserNotFound
+				case !slices.Contains(courseIDs, courseID):
+					result.Status = domain.BulkEnrolmentResultCourseNotFound
+				default:
+					result.Status, err = updateEnrolment(ctx, qtx, params, userID, courseID)
+					if err != nil {
+						return err
+					}
+				}
+
+				results = append(results, result)
+			}
+		}
+
+		return tx.Commit(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func updateEnrolment(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	params domain.BulkUpdateEnrolmentsParams,
+	userID string,
+	courseID uuid.UUID,
+) (domain.BulkEnrolmentResultStatus, error) {
+	if params.Action == domain.BulkEnrolmentActionDisenrol {
+		deleted, err := qtx.DisenrolInCourse(ctx, sqlc.DisenrolInCourseParams{
+			UserID:   utils.PGTextFrom(userID),
+			CourseID: utils.PGUUIDFromUUID(courseID),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to disenrol user %s from course %s: %w", userID, courseID, err)
+		}
+
+		if deleted == 0 {
+			return domain.BulkEnrolmentResultNotEnrolled, nil
+		}
+
+		return domain.BulkEnrolmentResultDisenrolled, nil
+	}
+
+	inserted, err := qtx.EnrolInCourse(ctx, sqlc.EnrolInCourseParams{
+		UserID:     utils.PGTextFrom(userID),
+		CourseID:   utils.PGUUIDFromUUID(courseID),
+		EnrolledBy: utils.PGTextFrom(params.EnrolledBy),
+		DueAt:      utils.PGTimestamptzFrom(params.DueAt),
+		DueInDays:  utils.PGInt4From(params.DueInDays),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to enrol user %s in course %s: %w", userID, courseID, err)
+	}
+
+	if inserted == 0 {
+		return domain.BulkEnrolmentResultAlreadyEnrolled, nil
+	}
+
+	return domain.BulkEnrolmentResultEnrolled, nil
+}
+
+func (s *Store) SetEnrolmentDueDate(
+	ctx context.Context,
+	params domain.SetEnrolmentDueDateParams,
+) (*domain.EnrolmentDueDateChange, error) {
+	sqlcParams := sqlc.SetEnrolmentDueDateParams{
+		DueAt:     utils.PGTimestamptzFrom(params.DueAt),
+		DueInDays: utils.PGInt4From(params.DueInDays),
+		UserID:    utils.PGTextFrom(params.UserID),
+		CourseID:  utils.PGUUIDFromUUID(params.CourseID),
+	}
+
+	row, err := ExecQuery(ctx, func() (sqlc.SetEnrolmentDueDateRow, error) {
+		return s.queries(ctx).SetEnrolmentDueDate(ctx, sqlcParams)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.EnrolmentDueDateChange{
+		PreviousDueDate: utils.TimeFrom(row.PreviousDueAt),
+		DueDate:         utils.TimeFrom(row.DueAt),
+	}, nil
+}
+
+func (s *Store) GetOverdueEnrolments(ctx context.Context) ([]domain.OverdueEnrolment, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetOverdueEnrolmentsRow, error) {
+		return s.queries(ctx).GetOverdueEnrolments(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rows, func(row sqlc.GetOverdueEnrolmentsRow) domain.OverdueEnrolment {
+		return domain.OverdueEnrolment{
+			ID:          utils.UUIDFrom(row.ID),
+			UserID:      row.UserID.String,
+			UserName:    row.UserName.String,
+			UserEmail:   row.UserEmail.String,
+			CourseID:    utils.UUIDFrom(row.CourseID),
+			CourseTitle: row.CourseTitle.String,
+			DueAt:       row.DueAt.Time,
+		}
+	}), nil
+}
+
+// SetEnrolmentOverdue flags the enrolment as overdue and queues the learner's notification in the
+// same transaction, as do the other enrolment flags
+func (s *Store) SetEnrolmentOverdue(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+	return s.execWithEmails(ctx, func(qtx *sqlc.Queries) error {
+		return qtx.SetEnrolmentOverdue(ctx, utils.PGUUIDFromUUID(enrolmentID))
+	}, notification)
+}
+
+func (s *Store) GetUnnotifiedEnrolments(ctx context.Context) ([]domain.EnrolmentNotice, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetUnnotifiedEnrolmentsRow, error) {
+		return s.queries(ctx).GetUnnotifiedEnrolments(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rows, func(row sqlc.GetUnnotifiedEnrolmentsRow) domain.EnrolmentNotice {
+		return enrolmentNoticeFrom(sqlc.GetNotStartedEnrolmentsRow(row))
+	}), nil
+}
+
+func (s *Store) SetEnrolmentNotified(ctx context.Context, enrolmentID uuid.UUID, notification *domain.OutboxEmail) error {
+	return s.execWithEmails(ctx, func(qtx *sqlc.Queries) error {
+		return qtx.SetEnrolmentNotified(ctx, utils.PGUUIDFromUUID(enrolmentID))
+	}, notification)
+}
+
+func (s *Store) GetNotStartedEnrolments(ctx context.Context, enrolledDays int) ([]domain.EnrolmentNotice, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetNotStartedEnrolmentsRow, error) {
+		return s.queries(ctx).GetNotStartedEnrolments(ctx, int32(enrolledDays)) //nolint:gosec
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rows, enrolmentNoticeFrom), nil
+}
+
+func (s *Store) SetEnrolmentReminded(ctx context.Context, enrolmentID uuid.UUID, reminder *domain.OutboxEmail) error {
+	return s.execWithEmails(ctx, func(qtx *sqlc.Queries) error {
+		return qtx.SetEnrolmentReminded(ctx, utils.PGUUIDFromUUID(enrolmentID))
+	}, reminder)
+}
+
+func enrolmentNoticeFrom(row sqlc.GetNotStartedEnrolmentsRow) domain.EnrolmentNotice {
+	return domain.EnrolmentNotice{
+		ID:          utils.UUIDFrom(row.ID),
+		UserID:      row.UserID.String,
+		UserName:    row.UserName.String,
+		UserEmail:   row.UserEmail.String,
+		CourseID:    utils.UUIDFrom(row.CourseID),
+		CourseTitle: row.CourseTitle.String,
+		EnrolledAt:  row.EnrolledAt.Time,
+		DueAt:       utils.TimeFrom(row.DueAt),
+	}
+}
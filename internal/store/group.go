@@ -0,0 +1,227 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+func (s *Store) GetGroups(ctx context.Context) ([]domain.Group, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetGroupsRow, error) {
+		return s.queries(ctx).GetGroups(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MapToWithError(rows, func(row sqlc.GetGroupsRow) (domain.Group, error) {
+		var courseIDs []uuid.UUID
+		if err := json.Unmarshal(row.CourseIds, &courseIDs); err != nil {
+			return domain.Group{}, fmt.Errorf("failed to unmarshal course IDs: %w", err)
+		}
+
+		return domain.Group{
+			ID:          utils.UUIDFrom(row.ID),
+			Name:        row.Name,
+			MemberCount: row.MemberCount,
+			CourseIDs:   courseIDs,
+		}, nil
+	})
+}
+
+func (s *Store) AddGroup(ctx context.Context, name string) (*domain.Group, error) {
+	id, err := ExecQuery(ctx, func() (pgtype.UUID, error) {
+		return s.queries(ctx).AddGroup(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Group{
+		ID:        utils.UUIDFrom(id),
+		Name:      name,
+		CourseIDs: []uuid.UUID{},
+	}, nil
+}
+
+func (s *Store) UpdateGroup(ctx context.Context, params domain.UpdateGroupParams) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).UpdateGroup(ctx, sqlc.UpdateGroupParams{
+			ID:   utils.PGUUIDFromUUID(params.ID),
+			Name: params.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+// DeleteGroup removes the group and its memberships, enrolments made through the group are kept
+func (s *Store) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	return ExecCommand(ctx, func() error {
+		deleted, err := s.queries(ctx).DeleteGroup(ctx, utils.PGUUIDFromUUID(id))
+		if err != nil {
+			return err
+		}
+
+		if deleted == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+// AddGroupMembers adds the users to the group and enrols them in every course assigned to the group
+func (s *Store) AddGroupMembers(ctx context.Context, params domain.GroupMembersParams) error {
+	return ExecCommand(ctx, func() error {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+		groupID := utils.PGUUIDFromUUID(params.GroupID)
+
+		if err := groupExists(ctx, qtx, groupID); err != nil {
+			return err
+		}
+
+		err = qtx.AddGroupMembers(ctx, sqlc.AddGroupMembersParams{
+			GroupID: groupID,
+			UserIds: params.UserIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add group members: %w", err)
+		}
+
+		_, err = qtx.EnrolGroupMembers(ctx, sqlc.EnrolGroupMembersParams{
+			GroupID: groupID,
+			UserIds: params.UserIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enrol group members: %w", err)
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+// RemoveGroupMembers removes the users from the group, their existing enrolments and progress are kept
+func (s *Store) RemoveGroupMembers(ctx context.Context, params domain.GroupMembersParams) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).RemoveGroupMembers(ctx, sqlc.RemoveGroupMembersParams{
+			GroupID: utils.PGUUIDFromUUID(params.GroupID),
+			UserIds: params.UserIDs,
+		})
+	})
+}
+
+// AssignCourseToGroup assigns the course to the group and enrols all current members in it,
+// future members are enrolled when they are added to the group
+func (s *Store) AssignCourseToGroup(ctx context.Context, params domain.AssignCourseToGroupParams) error {
+	return ExecCommand(ctx, func() error {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		qtx := s.Queries.WithTx(tx)
+		groupID := utils.PGUUIDFromUUID(params.GroupID)
+		courseID := utils.PGUUIDFromUUID(params.CourseID)
+
+		if err := groupExists(ctx, qtx, groupID); err != nil {
+			return err
+		}
+
+		courseIDs, err := qtx.GetExistingCourseIDs(ctx, []pgtype.UUID{courseID})
+		if err != nil {
+			return fmt.Errorf("failed to get course: %w", err)
+		}
+
+		if len(courseIDs) == 0 {
+			return pgx.ErrNoRows
+		}
+
+		err = qtx.AssignCourseToGroup(ctx, sqlc.AssignCourseToGroupParams{
+			GroupID:    groupID,
+			CourseID:   courseID,
+			DueInDays:  utils.PGInt4From(params.DueInDays),
+			AssignedBy: utils.PGTextFrom(params.AssignedBy),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assign course to group: %w", err)
+		}
+
+		_, err = qtx.EnrolGroupMembers(ctx, sqlc.EnrolGroupMembersParams{
+			GroupID:  groupID,
+			CourseID: courseID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to enrol group members: %w", err)
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+// UnassignCourseFromGroup stops new members being enrolled in the course, existing enrolments are kept
+func (s *Store) UnassignCourseFromGroup(ctx context.Context, params domain.UnassignCourseFromGroupParams) error {
+	return ExecCommand(ctx, func() error {
+		deleted, err := s.queries(ctx).UnassignCourseFromGroup(ctx, sqlc.UnassignCourseFromGroupParams{
+			GroupID:  utils.PGUUIDFromUUID(params.GroupID),
+			CourseID: utils.PGUUIDFromUUID(params.CourseID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if deleted == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func groupExists(ctx context.Context, qtx *sqlc.Queries, groupID pgtype.UUID) error {
+	exists, err := qtx.GroupExists(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+
+	if !exists {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func groupSummariesFrom(groupsJSON []byte) ([]domain.GroupSummary, error) {
+	groups := []domain.GroupSummary{}
+	if groupsJSON == nil {
+		return groups, nil
+	}
+
+	if err := json.Unmarshal(groupsJSON, &groups); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal groups: %w", err)
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+// SetLineManager replaces the user's current line manager
+func (s *Store) SetLineManager(ctx context.Context, params domain.SetLineManagerParams) (string, error) {
+	return ExecQuery(ctx, func() (string, error) {
+		return s.queries(ctx).SetLineManager(ctx, sqlc.SetLineManagerParams{
+			UserID:    params.UserID,
+			ManagerID: params.ManagerID,
+		})
+	})
+}
+
+func (s *Store) RemoveLineManager(ctx context.Context, userID string) (string, error) {
+	managerID, err := ExecQuery(ctx, func() (string, error) {
+		return s.queries(ctx).RemoveLineManager(ctx, userID)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return managerID, err
+}
+
+func (s *Store) GetTeamEnrolments(ctx context.Context, managerID string) ([]domain.TeamEnrolment, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetTeamEnrolmentsRow, error) {
+		return s.queries(ctx).GetTeamEnrolments(ctx, managerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return utils.Map(rows, func(row sqlc.GetTeamEnrolmentsRow) domain.TeamEnrolment {
+		dueDate := utils.TimeFrom(row.DueAt)
+
+		return domain.TeamEnrolment{
+			UserID:      row.UserID,
+			UserName:    row.UserName.String,
+			Email:       row.Email.String,
+			CourseID:    utils.UUIDFrom(row.CourseID),
+			CourseTitle: row.CourseTitle.String,
+			EnrolledAt:  row.EnrolledAt.Time,
+			DueDate:     dueDate,
+			Status:      domain.EnrolmentStatusFrom(row.Started, row.CompletedCourse, dueDate, now),
+		}
+	}), nil
+}
+
+// GetManagerDigests groups the overdue and soon to be due enrolments of every manager's direct
+// reports by manager
+func (s *Store) GetManagerDigests(ctx context.Context, dueWithinDays int) ([]domain.ManagerDigest, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetManagerDigestEnrolmentsRow, error) {
+		return s.queries(ctx).GetManagerDigestEnrolments(ctx, int32(dueWithinDays)) //nolint:gosec
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var digests []domain.ManagerDigest
+	for _, row := range rows {
+		// Rows are ordered by manager so each manager's enrolments are next to each other
+		if len(digests) == 0 || digests[len(digests)-1].ManagerID != row.ManagerID {
+			digests = append(digests, domain.ManagerDigest{
+				ManagerID:    row.ManagerID,
+				ManagerName:  row.ManagerName.String,
+				ManagerEmail: row.ManagerEmail.String,
+			})
+		}
+
+		digest := &digests[len(digests)-1]
+		digest.Enrolments = append(digest.Enrolments, domain.DigestEnrolment{
+			UserName:    row.UserName.String,
+			CourseTitle: row.CourseTitle.String,
+			DueAt:       row.DueAt.Time,
+		})
+	}
+
+	return digests, nil
+}
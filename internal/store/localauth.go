@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/services/auth"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+func (s *Store) AddLocalAuthUser(ctx context.Context, user *auth.LocalUser) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).AddLocalAuthUser(ctx, sqlc.AddLocalAuthUserParams{
+			ID:           user.ID,
+			Email:        user.Email,
+			Name:         utils.PGTextFrom(user.Name),
+			PasswordHash: user.PasswordHash,
+		})
+	})
+}
+
+func (s *Store) GetLocalAuthUser(ctx context.Context, id string) (*auth.LocalUser, error) {
+	user, err := ExecQuery(ctx, func() (sqlc.LocalAuthUser, error) {
+		return s.queries(ctx).GetLocalAuthUser(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return localUserFrom(&user), nil
+}
+
+func (s *Store) GetLocalAuthUserByEmail(ctx context.Context, email string) (*auth.LocalUser, error) {
+	user, err := ExecQuery(ctx, func() (sqlc.LocalAuthUser, error) {
+		return s.queries(ctx).GetLocalAuthUserByEmail(ctx, email)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return localUserFrom(&user), nil
+}
+
+func (s *Store) UpdateLocalAuthUser(ctx context.Context, id, email, name string) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).UpdateLocalAuthUser(ctx, sqlc.UpdateLocalAuthUserParams{
+			Email: email,
+			Name:  utils.PGTextFrom(name),
+			ID:    id,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) SetLocalAuthUserDisabled(ctx context.Context, id string, disabled bool) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).SetLocalAuthUserDisabled(ctx, sqlc.SetLocalAuthUserDisabledParams{
+			Disabled: disabled,
+			ID:       id,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) SetLocalAuthUserPassword(ctx context.Context, id, passwordHash string) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).SetLocalAuthUserPassword(ctx, sqlc.SetLocalAuthUserPasswordParams{
+			PasswordHash: passwordHash,
+			ID:           id,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+// DeleteLocalAuthUser treats a user that doesn't exist as already deleted
+func (s *Store) DeleteLocalAuthUser(ctx context.Context, id string) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).DeleteLocalAuthUser(ctx, id)
+	})
+}
+
+func localUserFrom(user *sqlc.LocalAuthUser) *auth.LocalUser {
+	return &auth.LocalUser{
+		ID:           user.ID,
+		Email:        user.Email,
+		Name:         user.Name.String,
+		PasswordHash: user.PasswordHash,
+		Disabled:     user.Disabled,
+	}
+}
@@ -0,0 +1,6 @@
+ALTER TABLE usercourses
+  DROP CONSTRAINT IF EXISTS fk_enrolled_by,
+  DROP COLUMN IF EXISTS overdue_at,
+  DROP COLUMN IF EXISTS due_at,
+  DROP COLUMN IF EXISTS enrolled_by,
+  DROP COLUMN IF EXISTS enrolled_at;
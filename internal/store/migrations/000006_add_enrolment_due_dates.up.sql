@@ -0,0 +1,7 @@
+-- Existing enrolments get the migration time as their enrolment date
+ALTER TABLE usercourses
+  ADD COLUMN enrolled_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  ADD COLUMN enrolled_by TEXT,
+  ADD COLUMN due_at TIMESTAMPTZ,
+  ADD COLUMN overdue_at TIMESTAMPTZ,
+  ADD CONSTRAINT fk_enrolled_by FOREIGN KEY(enrolled_by) REFERENCES users(id) ON DELETE SET NULL;
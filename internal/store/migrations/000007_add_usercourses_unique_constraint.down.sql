@@ -0,0 +1,2 @@
+ALTER TABLE usercourses
+  DROP CONSTRAINT IF EXISTS usercourses_user_id_course_id_key;
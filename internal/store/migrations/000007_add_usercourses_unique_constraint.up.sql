@@ -0,0 +1,9 @@
+-- Remove duplicate enrolments before adding the constraint, keeping the earliest one for each user/course pair
+DELETE FROM usercourses uc
+USING usercourses dup
+WHERE uc.user_id = dup.user_id
+  AND uc.course_id = dup.course_id
+  AND (uc.enrolled_at, uc.id) > (dup.enrolled_at, dup.id);
+
+ALTER TABLE usercourses
+  ADD CONSTRAINT usercourses_user_id_course_id_key UNIQUE (user_id, course_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS group_courses;
+DROP TABLE IF EXISTS user_groups;
+DROP TABLE IF EXISTS groups;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS groups (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  name TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT groups_name_unique UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS user_groups (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  user_id TEXT NOT NULL,
+  group_id UUID NOT NULL,
+  added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_groups FOREIGN KEY(group_id) REFERENCES groups(id) ON DELETE CASCADE,
+  CONSTRAINT user_groups_user_group_unique UNIQUE (user_id, group_id)
+);
+
+-- Courses assigned to a group, every current and future member of the group is enrolled in them
+CREATE TABLE IF NOT EXISTS group_courses (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  group_id UUID NOT NULL,
+  course_id UUID NOT NULL,
+  due_in_days INT,
+  assigned_by TEXT,
+  assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_groups FOREIGN KEY(group_id) REFERENCES groups(id) ON DELETE CASCADE,
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_assigned_by FOREIGN KEY(assigned_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT group_courses_group_course_unique UNIQUE (group_id, course_id)
+);
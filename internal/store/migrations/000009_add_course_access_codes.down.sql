@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS course_access_code_redemptions;
+DROP TABLE IF EXISTS course_access_codes;
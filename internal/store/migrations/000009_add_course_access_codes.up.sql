@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS course_access_codes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  code TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  expires_at TIMESTAMPTZ,
+  max_uses INT,
+  uses INT NOT NULL DEFAULT 0,
+  created_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  revoked_at TIMESTAMPTZ,
+
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT course_access_codes_code_unique UNIQUE (code)
+);
+
+-- Audit trail of who redeemed each access code and when
+CREATE TABLE IF NOT EXISTS course_access_code_redemptions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  access_code_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  redeemed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_course_access_codes FOREIGN KEY(access_code_id) REFERENCES course_access_codes(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT course_access_code_redemptions_code_user_unique UNIQUE (access_code_id, user_id)
+);
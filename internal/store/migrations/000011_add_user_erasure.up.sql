@@ -0,0 +1,2 @@
+-- Erased users are anonymised placeholders that keep completion records for reporting
+ALTER TABLE users ADD COLUMN erased_at TIMESTAMPTZ;
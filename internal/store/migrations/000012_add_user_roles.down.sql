@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS course_instructors;
+DROP TABLE IF EXISTS group_managers;
+DROP TABLE IF EXISTS user_roles;
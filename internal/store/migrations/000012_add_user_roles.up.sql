@@ -0,0 +1,37 @@
+-- Roles granted to users on top of the default learner role. Users without a row are learners,
+-- unless the auth provider marks them as an admin. Users set back to the user role keep a row, so a
+-- stored role always overrides the admin claim and admins with the claim can be demoted.
+CREATE TABLE IF NOT EXISTS user_roles (
+  user_id TEXT PRIMARY KEY NOT NULL,
+  role TEXT NOT NULL,
+  granted_by TEXT,
+  granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_granted_by FOREIGN KEY(granted_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT user_roles_role_check CHECK (role IN ('admin', 'manager', 'instructor', 'auditor', 'user'))
+);
+
+-- Groups whose progress a manager can view
+CREATE TABLE IF NOT EXISTS group_managers (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  user_id TEXT NOT NULL,
+  group_id UUID NOT NULL,
+  added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_groups FOREIGN KEY(group_id) REFERENCES groups(id) ON DELETE CASCADE,
+  CONSTRAINT group_managers_user_group_unique UNIQUE (user_id, group_id)
+);
+
+-- Courses an instructor can edit
+CREATE TABLE IF NOT EXISTS course_instructors (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  user_id TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT course_instructors_user_course_unique UNIQUE (user_id, course_id)
+);
@@ -0,0 +1,12 @@
+-- Each user can have one line manager, who can view their direct reports' training status
+CREATE TABLE IF NOT EXISTS line_managers (
+  user_id TEXT PRIMARY KEY NOT NULL,
+  manager_id TEXT NOT NULL,
+  assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_managers FOREIGN KEY(manager_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT line_managers_not_self CHECK (user_id <> manager_id)
+);
+
+CREATE INDEX IF NOT EXISTS line_managers_manager_id_idx ON line_managers(manager_id);
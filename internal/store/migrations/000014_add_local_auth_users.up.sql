@@ -0,0 +1,12 @@
+-- Users of the local auth provider, used instead of Firebase for development and testing. Kept apart
+-- from the users table as the auth provider creates users before they are registered.
+CREATE TABLE IF NOT EXISTS local_auth_users (
+  id TEXT PRIMARY KEY,
+  email TEXT NOT NULL,
+  name TEXT,
+  password_hash TEXT NOT NULL,
+  disabled BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS local_auth_users_email_idx ON local_auth_users (lower(email));
@@ -0,0 +1,36 @@
+-- The audit log records every admin action. Each entry's hash covers the entry and the previous
+-- entry's hash, so changing or removing an entry breaks the chain. before and after are JSON rather
+-- than JSONB so they're stored exactly as they were hashed.
+CREATE TABLE IF NOT EXISTS audit_log (
+  id BIGSERIAL PRIMARY KEY,
+  actor_id TEXT,
+  action TEXT NOT NULL,
+  target_type TEXT NOT NULL,
+  target_id TEXT NOT NULL,
+  before JSON,
+  after JSON,
+  created_at TIMESTAMPTZ NOT NULL,
+  prev_hash TEXT NOT NULL,
+  hash TEXT NOT NULL,
+
+  CONSTRAINT audit_log_hash_unique UNIQUE (hash)
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_target_idx ON audit_log(target_type, target_id);
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_idx ON audit_log(actor_id);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate
+BEFORE TRUNCATE ON audit_log
+FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS archived_quiz_attempts;
+DROP TABLE IF EXISTS archived_quiz_states;
+DROP TABLE IF EXISTS archived_progress;
+DROP TABLE IF EXISTS progress_resets;
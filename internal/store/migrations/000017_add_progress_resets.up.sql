@@ -0,0 +1,58 @@
+-- Admins resetting learners' progress archive it rather than deleting it. Each reset records who
+-- made it and why, and the progress, quiz answers and quiz attempts it replaced.
+CREATE TABLE IF NOT EXISTS progress_resets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  course_id UUID NOT NULL,
+  -- Only set when a single quiz was reset rather than the whole course
+  quiz_id UUID,
+  user_ids TEXT[] NOT NULL,
+  reason TEXT NOT NULL,
+  reset_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_quizsections FOREIGN KEY(quiz_id) REFERENCES quizsections(id) ON DELETE SET NULL,
+  CONSTRAINT fk_reset_by FOREIGN KEY(reset_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS archived_progress (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  reset_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  completed_section_ids UUID[] NOT NULL,
+  completed_intro BOOLEAN,
+  completed_course BOOLEAN,
+
+  CONSTRAINT fk_progress_resets FOREIGN KEY(reset_id) REFERENCES progress_resets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS archived_quiz_states (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  reset_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  quiz_id UUID NOT NULL,
+  quiz_state JSONB NOT NULL,
+  quiz_answers JSONB NOT NULL,
+  attempts INT NOT NULL,
+
+  CONSTRAINT fk_progress_resets FOREIGN KEY(reset_id) REFERENCES progress_resets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS archived_quiz_attempts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  reset_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  quiz_id UUID NOT NULL,
+  answers JSONB NOT NULL,
+  attempt_number INT NOT NULL,
+
+  CONSTRAINT fk_progress_resets FOREIGN KEY(reset_id) REFERENCES progress_resets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS archived_progress_user_id_idx ON archived_progress(user_id);
+CREATE INDEX IF NOT EXISTS archived_quiz_states_user_id_idx ON archived_quiz_states(user_id);
+CREATE INDEX IF NOT EXISTS archived_quiz_attempts_user_id_idx ON archived_quiz_attempts(user_id);
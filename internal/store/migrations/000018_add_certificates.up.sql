@@ -0,0 +1,16 @@
+-- A certificate is issued each time a learner completes a course, so a learner whose progress was
+-- reset has one for each completion. number is the sequential certificate number printed on it.
+CREATE TABLE IF NOT EXISTS certificates (
+  id UUID PRIMARY KEY NOT NULL,
+  number BIGSERIAL NOT NULL,
+  user_id TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  storage_key TEXT NOT NULL,
+  issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT certificates_number_unique UNIQUE (number),
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS certificates_user_course_idx ON certificates(user_id, course_id);
@@ -0,0 +1,9 @@
+ALTER TABLE certificates DROP CONSTRAINT IF EXISTS fk_revoked_by;
+ALTER TABLE certificates DROP COLUMN IF EXISTS revoke_reason;
+ALTER TABLE certificates DROP COLUMN IF EXISTS revoked_by;
+ALTER TABLE certificates DROP COLUMN IF EXISTS revoked_at;
+ALTER TABLE certificates DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE certificates DROP CONSTRAINT IF EXISTS certificates_verification_id_unique;
+ALTER TABLE certificates DROP COLUMN IF EXISTS verification_id;
+
+ALTER TABLE courses DROP COLUMN IF EXISTS certificate_validity_months;
@@ -0,0 +1,16 @@
+-- Certificates from courses with a validity expire that many months after they're issued, they
+-- never expire if it isn't set
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS certificate_validity_months INT;
+
+-- verification_id is the unguessable ID certificates are publicly verified by, the certificate's
+-- own ID is used in storage keys so isn't shared
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS verification_id TEXT;
+UPDATE certificates SET verification_id = replace(gen_random_uuid()::text, '-', '') WHERE verification_id IS NULL;
+ALTER TABLE certificates ALTER COLUMN verification_id SET NOT NULL;
+ALTER TABLE certificates ADD CONSTRAINT certificates_verification_id_unique UNIQUE (verification_id);
+
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMPTZ;
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revoked_by TEXT;
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS revoke_reason TEXT;
+ALTER TABLE certificates ADD CONSTRAINT fk_revoked_by FOREIGN KEY(revoked_by) REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_email_opt_outs;
+
+ALTER TABLE certificates DROP COLUMN IF EXISTS expiry_notified_at;
+
+ALTER TABLE usercourses DROP COLUMN IF EXISTS reminded_at;
+ALTER TABLE usercourses DROP COLUMN IF EXISTS notified_at;
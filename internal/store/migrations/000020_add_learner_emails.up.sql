@@ -0,0 +1,18 @@
+-- notified_at and reminded_at record when the learner was emailed about their enrolment, and
+-- reminded to start it. Existing enrolments are marked as notified and reminded so learners
+-- aren't emailed about courses they were enrolled in before these emails existed.
+ALTER TABLE usercourses ADD COLUMN IF NOT EXISTS notified_at TIMESTAMPTZ;
+ALTER TABLE usercourses ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ;
+UPDATE usercourses SET notified_at = NOW(), reminded_at = NOW() WHERE notified_at IS NULL;
+
+ALTER TABLE certificates ADD COLUMN IF NOT EXISTS expiry_notified_at TIMESTAMPTZ;
+
+-- Learners can opt out of the emails that aren't needed for compliance, by email name
+CREATE TABLE IF NOT EXISTS user_email_opt_outs (
+  user_id TEXT NOT NULL,
+  email_name TEXT NOT NULL,
+  opted_out_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, email_name),
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
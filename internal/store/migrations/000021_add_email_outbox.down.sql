@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS email_failures (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  error TEXT NOT NULL,
+  template_name TEXT NOT NULL,
+  template_params JSONB NOT NULL DEFAULT '{}'::jsonb,
+  email_name TEXT NOT NULL,
+  retries INT NOT NULL DEFAULT 5
+);
+
+-- Unsent emails go back to being retried, sent and dead-lettered emails are dropped
+INSERT INTO email_failures (error, template_name, template_params, email_name, created_at)
+SELECT COALESCE(last_error, 'not sent'), template_name, template_params, email_name, created_at
+FROM email_outbox
+WHERE status = 'pending';
+
+DROP TABLE IF EXISTS email_outbox;
@@ -0,0 +1,25 @@
+-- Emails are queued in the outbox in the same transaction as the change they're about, then sent by
+-- the email dispatcher. Failed sends are retried with backoff until they're dead-lettered.
+CREATE TABLE IF NOT EXISTS email_outbox (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  email_name TEXT NOT NULL,
+  template_name TEXT NOT NULL,
+  template_params JSONB NOT NULL DEFAULT '{}'::jsonb,
+  status TEXT NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMPTZ,
+
+  CONSTRAINT email_outbox_status_check CHECK (status IN ('pending', 'sent', 'skipped', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS email_outbox_pending_idx ON email_outbox(next_attempt_at) WHERE status = 'pending';
+
+-- Emails waiting to be retried are moved to the outbox with their attempts so far
+INSERT INTO email_outbox (email_name, template_name, template_params, attempts, last_error, created_at)
+SELECT email_name, template_name, template_params, GREATEST(5 - retries, 1), error, created_at
+FROM email_failures;
+
+DROP TABLE IF EXISTS email_failures;
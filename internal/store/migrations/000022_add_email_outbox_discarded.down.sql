@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS email_outbox_failed_idx;
+
+-- Discarded emails were never sent, so they're kept as dead-lettered
+UPDATE email_outbox SET status = 'dead' WHERE status = 'discarded';
+
+ALTER TABLE email_outbox DROP CONSTRAINT IF EXISTS email_outbox_status_check;
+ALTER TABLE email_outbox
+  ADD CONSTRAINT email_outbox_status_check CHECK (status IN ('pending', 'sent', 'skipped', 'dead'));
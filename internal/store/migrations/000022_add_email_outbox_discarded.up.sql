@@ -0,0 +1,8 @@
+-- Failed emails can be discarded by an admin instead of being retried, they're kept so it's clear
+-- they were never sent
+ALTER TABLE email_outbox DROP CONSTRAINT IF EXISTS email_outbox_status_check;
+ALTER TABLE email_outbox
+  ADD CONSTRAINT email_outbox_status_check CHECK (status IN ('pending', 'sent', 'skipped', 'dead', 'discarded'));
+
+CREATE INDEX IF NOT EXISTS email_outbox_failed_idx ON email_outbox(created_at)
+  WHERE status IN ('pending', 'dead') AND last_error IS NOT NULL;
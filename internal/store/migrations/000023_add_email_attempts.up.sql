@@ -0,0 +1,11 @@
+-- Each attempt to send an email from the outbox, Error is NULL if it was sent
+CREATE TABLE IF NOT EXISTS email_attempts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  email_id UUID NOT NULL,
+  attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  error TEXT,
+
+  CONSTRAINT fk_email_outbox FOREIGN KEY(email_id) REFERENCES email_outbox(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS email_attempts_email_id_idx ON email_attempts(email_id, attempted_at);
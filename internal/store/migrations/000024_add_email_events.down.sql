@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS email_bounces;
+DROP TABLE IF EXISTS email_events;
+DROP INDEX IF EXISTS email_outbox_provider_message_id_idx;
+ALTER TABLE email_outbox DROP COLUMN IF EXISTS provider_message_id;
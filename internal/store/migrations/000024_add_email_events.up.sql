@@ -0,0 +1,33 @@
+-- The ID the email provider gave the message, delivery events from the provider refer to it
+ALTER TABLE email_outbox ADD COLUMN IF NOT EXISTS provider_message_id TEXT;
+
+CREATE INDEX IF NOT EXISTS email_outbox_provider_message_id_idx ON email_outbox(provider_message_id)
+  WHERE provider_message_id IS NOT NULL;
+
+-- Delivery events sent by the email provider's webhook. Events for messages that aren't in the
+-- outbox have no email_id. provider_event_id is unique so events the provider resends are ignored.
+CREATE TABLE IF NOT EXISTS email_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  provider_event_id TEXT NOT NULL,
+  email_id UUID,
+  message_id TEXT NOT NULL,
+  event TEXT NOT NULL,
+  recipient TEXT NOT NULL,
+  reason TEXT,
+  occurred_at TIMESTAMPTZ NOT NULL,
+  received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT email_events_provider_event_id_unique UNIQUE (provider_event_id),
+  CONSTRAINT fk_email_outbox FOREIGN KEY(email_id) REFERENCES email_outbox(id) ON DELETE CASCADE,
+  CONSTRAINT email_events_event_check CHECK (event IN ('delivered', 'deferred', 'bounced', 'complained'))
+);
+
+CREATE INDEX IF NOT EXISTS email_events_email_id_idx ON email_events(email_id);
+
+-- Addresses that emails bounced from, lower case. An address is no longer bouncing once an email
+-- is delivered to it after it bounced.
+CREATE TABLE IF NOT EXISTS email_bounces (
+  email TEXT PRIMARY KEY NOT NULL,
+  bounced_at TIMESTAMPTZ NOT NULL,
+  reason TEXT
+);
@@ -0,0 +1,13 @@
+-- CSV imports of users, which run in the background. report holds the result of each row, rows
+-- waiting to be imported are pending until the import completes.
+CREATE TABLE IF NOT EXISTS user_imports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  status TEXT NOT NULL DEFAULT 'running',
+  report JSON NOT NULL,
+  created_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMPTZ,
+
+  CONSTRAINT fk_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT user_imports_status_check CHECK (status IN ('running', 'completed'))
+);
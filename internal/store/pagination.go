@@ -0,0 +1,100 @@
+package store
+
+import (
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+// pageArgs holds the sqlc arguments shared by every paginated list query
+type pageArgs struct {
+	Search      pgtype.Text
+	CourseID    pgtype.UUID
+	GroupID     pgtype.UUID
+	Deactivated pgtype.Bool
+	ManagerID   pgtype.Text
+	CursorID    pgtype.Text
+	CursorKey   pgtype.Text
+	SortDesc    bool
+	// One extra row is fetched to work out whether there is a next page
+	PageLimit int32
+}
+
+// likeEscaper escapes the LIKE wildcards in a search term so it matches literally,
+// the queries use backslash as the ESCAPE character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func pageArgsFrom(params domain.PageParams) pageArgs {
+	args := pageArgs{
+		SortDesc:  params.Descending(),
+		PageLimit: int32(pageLimit(params.Limit) + 1), //nolint:gosec
+	}
+
+	if params.Search != "" {
+		args.Search = utils.PGTextFrom(likeEscaper.Replace(params.Search))
+	}
+
+	if params.CourseID != nil {
+		args.CourseID = utils.PGUUIDFromUUID(*params.CourseID)
+	}
+
+	if params.GroupID != nil {
+		args.GroupID = utils.PGUUIDFromUUID(*params.GroupID)
+	}
+
+	if params.Deactivated != nil {
+		args.Deactivated = pgtype.Bool{Bool: *params.Deactivated, Valid: true}
+	}
+
+	if params.ManagerID != nil {
+		args.ManagerID = utils.PGTextFrom(*params.ManagerID)
+	}
+
+	if params.Cursor != nil {
+		args.CursorID = utils.PGTextFrom(params.Cursor.ID)
+		args.CursorKey = utils.PGTextFrom(params.Cursor.Key)
+	}
+
+	return args
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return domain.DefaultPageLimit
+	}
+
+	return min(limit, domain.MaxPageLimit)
+}
+
+// pageFrom builds the page envelope from rows fetched with pageArgs, dropping the
+// extra row and using the last returned row as the cursor for the next page.
+func pageFrom[R, T any](
+	rows []R,
+	params domain.PageParams,
+	totalCount int64,
+	cursorFrom func(R) domain.Cursor,
+	mapFn func(R) (T, error),
+) (*domain.Page[T], error) {
+	limit := pageLimit(params.Limit)
+
+	var nextCursor *string
+	if len(rows) > limit {
+		rows = rows[:limit]
+		encoded := cursorFrom(rows[len(rows)-1]).Encode()
+		nextCursor = &encoded
+	}
+
+	items, err := utils.MapToWithError(rows, mapFn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Page[T]{
+		Items:      items,
+		TotalCount: totalCount,
+		NextCursor: nextCursor,
+	}, nil
+}
@@ -27,7 +27,7 @@ func (s *Store) GetProgress(ctx context.Context, args domain.GetProgressParams)
 
 	progress, err := ExecQuery(
 		ctx,
-		func() (sqlc.GetProgressRow, error) { return s.Queries.GetProgress(ctx, sqlcArgs) },
+		func() (sqlc.GetProgressRow, error) { return s.queries(ctx).GetProgress(ctx, sqlcArgs) },
 	)
 	if err != nil {
 		return nil, err
@@ -44,31 +44,7 @@ func (s *Store) UpdateProgress(ctx context.Context, args domain.UpdateProgressPa
 	}
 
 	return ExecCommand(ctx, func() error {
-		return s.Queries.UpdateProgress(ctx, sqlcArgs)
-	})
-}
-
-func (s *Store) HasCompletedCourse(ctx context.Context, args domain.HasCompletedCourseParams) (bool, error) {
-	sqlcArgs := sqlc.HasCompletedCourseParams{
-		UserID:   args.UserID,
-		CourseID: utils.PGUUIDFromUUID(args.CourseID),
-	}
-
-	completed, err := ExecQuery(ctx, func() (pgtype.Bool, error) {
-		return s.Queries.HasCompletedCourse(ctx, sqlcArgs)
-	})
-
-	return completed.Bool, err
-}
-
-func (s *Store) ResetProgress(ctx context.Context, args domain.ResetProgressParams) error {
-	sqlcArgs := sqlc.ResetProgressParams{
-		UserID:   args.UserID,
-		CourseID: utils.PGUUIDFromUUID(args.CourseID),
-	}
-
-	return ExecCommand(ctx, func() error {
-		return s.Queries.ResetProgress(ctx, sqlcArgs)
+		return s.queries(ctx).UpdateProgress(ctx, sqlcArgs)
 	})
 }
 
@@ -79,7 +55,7 @@ func (s *Store) SetIntroCompleted(ctx context.Context, args domain.SetIntroCompl
 	}
 
 	return ExecCommand(ctx, func() error {
-		return s.Queries.SetIntroCompleted(ctx, sqlcArgs)
+		return s.queries(ctx).SetIntroCompleted(ctx, sqlcArgs)
 	})
 }
 
@@ -89,8 +65,20 @@ func (s *Store) SetCourseCompleted(ctx context.Context, args domain.SetCourseCom
 		CourseID: utils.PGUUIDFromUUID(args.CourseID),
 	}
 
-	return ExecCommand(ctx, func() error {
-		return s.Queries.SetCourseCompleted(ctx, sqlcArgs)
+	return s.InTx(ctx, func(ctx context.Context) error {
+		qtx := s.queries(ctx)
+
+		updated, err := qtx.SetCourseCompleted(ctx, sqlcArgs)
+		if err != nil || updated == 0 {
+			return err
+		}
+
+		emails, err := args.Emails(ctx)
+		if err != nil {
+			return err
+		}
+
+		return enqueueEmails(ctx, qtx, emails...)
 	})
 }
 
@@ -109,21 +97,15 @@ func progressFrom(row sqlc.GetProgressRow) *domain.Progress {
 func (s *Store) GetAllProgress(ctx context.Context) ([]*domain.FullProgress, error) {
 	progressRows, err := ExecQuery(
 		ctx,
-		func() ([]sqlc.GetAllProgressRow, error) { return s.Queries.GetAllProgress(ctx) },
+		func() ([]sqlc.GetAllProgressRow, error) { return s.queries(ctx).GetAllProgress(ctx) },
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	courseSectionsMap := map[pgtype.UUID][]domain.CourseSectionProgress{}
-	for i := range progressRows {
-		if _, exists := courseSectionsMap[progressRows[i].CourseID]; !exists {
-			sections, err := s.GetCourseSections(ctx, progressRows[i].CourseID)
-			if err != nil {
-				return nil, err
-			}
-			courseSectionsMap[progressRows[i].CourseID] = courseSectionProgressFrom(sections)
-		}
+	courseSectionsMap, err := s.getCourseSectionsMap(ctx, progressRows)
+	if err != nil {
+		return nil, err
 	}
 
 	progressByUser := map[UserDetails][]*domain.FullUserProgress{}
@@ -157,6 +139,108 @@ func (s *Store) GetAllProgress(ctx context.Context) ([]*domain.FullProgress, err
 	return result, nil
 }
 
+func (s *Store) ListProgress(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.FullProgress], error) {
+	args := pageArgsFrom(params)
+
+	total, err := ExecQuery(ctx, func() (int64, error) {
+		return s.queries(ctx).CountProgressUsers(ctx, sqlc.CountProgressUsersParams{
+			Search:    args.Search,
+			CourseID:  args.CourseID,
+			GroupID:   args.GroupID,
+			ManagerID: args.ManagerID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := ExecQuery(ctx, func() ([]sqlc.ListProgressUsersRow, error) {
+		return s.queries(ctx).ListProgressUsers(ctx, sqlc.ListProgressUsersParams{
+			SortBy:    params.SortBy,
+			Search:    args.Search,
+			CourseID:  args.CourseID,
+			GroupID:   args.GroupID,
+			ManagerID: args.ManagerID,
+			CursorID:  args.CursorID,
+			SortDesc:  args.SortDesc,
+			CursorKey: args.CursorKey,
+			PageLimit: args.PageLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := utils.Map(users, func(u sqlc.ListProgressUsersRow) string { return u.ID })
+
+	progressRows, err := ExecQuery(ctx, func() ([]sqlc.GetProgressForUsersRow, error) {
+		return s.queries(ctx).GetProgressForUsers(ctx, sqlc.GetProgressForUsersParams{
+			UserIds:  userIDs,
+			CourseID: args.CourseID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := utils.Map(progressRows, func(row sqlc.GetProgressForUsersRow) sqlc.GetAllProgressRow {
+		return sqlc.GetAllProgressRow(row)
+	})
+
+	courseSectionsMap, err := s.getCourseSectionsMap(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	progressByUserID := map[string][]*domain.FullUserProgress{}
+	for i := range rows {
+		row := &rows[i]
+		progressByUserID[row.UserID] = append(progressByUserID[row.UserID], fullUserProgressFrom(row, courseSectionsMap[row.CourseID]))
+	}
+
+	return pageFrom(
+		users,
+		params,
+		total,
+		func(user sqlc.ListProgressUsersRow) domain.Cursor {
+			return domain.Cursor{Key: user.SortKey, ID: user.ID}
+		},
+		func(user sqlc.ListProgressUsersRow) (*domain.FullProgress, error) {
+			groups, err := groupSummariesFrom(user.Groups)
+			if err != nil {
+				return nil, err
+			}
+
+			return &domain.FullProgress{
+				UserID:   user.ID,
+				UserName: user.Name.String,
+				Email:    user.Email.String,
+				Groups:   groups,
+				Progress: progressByUserID[user.ID],
+			}, nil
+		},
+	)
+}
+
+// getCourseSectionsMap fetches the sections of every course referenced by the progress rows, once per course
+func (s *Store) getCourseSectionsMap(
+	ctx context.Context,
+	progressRows []sqlc.GetAllProgressRow,
+) (map[pgtype.UUID][]domain.CourseSectionProgress, error) {
+	courseSectionsMap := map[pgtype.UUID][]domain.CourseSectionProgress{}
+	for i := range progressRows {
+		if _, exists := courseSectionsMap[progressRows[i].CourseID]; !exists {
+			sections, err := s.GetCourseSections(ctx, progressRows[i].CourseID)
+			if err != nil {
+				return nil, err
+			}
+			courseSectionsMap[progressRows[i].CourseID] = courseSectionProgressFrom(sections)
+		}
+	}
+
+	return courseSectionsMap, nil
+}
+
 func fullUserProgressFrom(row *sqlc.GetAllProgressRow, courseSections []domain.CourseSectionProgress) *domain.FullUserProgress {
 	var completedSectionIDs []uuid.UUID
 	for _, sectionID := range row.CompletedSectionIds {
@@ -0,0 +1,223 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+// progressResetArgs are the learners and progress a reset archives and clears, quizID is only set
+// when a single quiz is reset rather than the whole course
+type progressResetArgs struct {
+	userIDs  []string
+	cohort   bool
+	courseID pgtype.UUID
+	quizID   pgtype.UUID
+	reason   string
+	resetBy  string
+	emails   func(reset *domain.ProgressReset) ([]*domain.OutboxEmail, error)
+}
+
+func (s *Store) ResetProgress(ctx context.Context, params domain.ResetProgressParams) (*domain.ProgressReset, error) {
+	return ExecQuery(ctx, func() (*domain.ProgressReset, error) {
+		return s.resetProgress(ctx, progressResetArgs{
+			userIDs:  params.UserIDs,
+			cohort:   params.Cohort,
+			courseID: utils.PGUUIDFromUUID(params.CourseID),
+			reason:   params.Reason,
+			resetBy:  params.ResetBy,
+			emails:   params.Emails,
+		})
+	})
+}
+
+func (s *Store) ResetQuizProgress(ctx context.Context, params domain.ResetQuizProgressParams) (*domain.ProgressReset, error) {
+	return ExecQuery(ctx, func() (*domain.ProgressReset, error) {
+		return s.resetProgress(ctx, progressResetArgs{
+			userIDs: params.UserIDs,
+			cohort:  params.Cohort,
+			quizID:  utils.PGUUIDFromUUID(params.QuizID),
+			reason:  params.Reason,
+			resetBy: params.ResetBy,
+			emails:  params.Emails,
+		})
+	})
+}
+
+// resetProgress archives the learners' progress, quiz answers and quiz attempts under a new reset
+// before clearing them, and queues the emails about the reset, in one transaction. Resetting a user
+// who doesn't exist returns pgx.ErrNoRows, as does a course or quiz that doesn't exist.
+func (s *Store) resetProgress(ctx context.Context, args progressResetArgs) (*domain.ProgressReset, error) {
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	qtx := s.Queries.WithTx(tx)
+
+	var course sqlc.GetResetCourseRow
+	if args.quizID.Valid {
+		row, err := qtx.GetQuizCourse(ctx, args.quizID)
+		if err != nil {
+			return nil, err
+		}
+		course = sqlc.GetResetCourseRow(row)
+	} else {
+		course, err = qtx.GetResetCourse(ctx, args.courseID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	users, err := getResetUsers(ctx, qtx, args, course.ID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := utils.Map(users, func(u domain.ResetUser) string { return u.ID })
+
+	resetID, err := qtx.AddProgressReset(ctx, sqlc.AddProgressResetParams{
+		CourseID: course.ID,
+		QuizID:   args.quizID,
+		UserIds:  userIDs,
+		Reason:   args.reason,
+		ResetBy:  optionalText(args.resetBy),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add progress reset: %w", err)
+	}
+
+	quizIDs := []pgtype.UUID{args.quizID}
+	if !args.quizID.Valid {
+		if err := resetCourseProgress(ctx, qtx, resetID, course.ID, userIDs); err != nil {
+			return nil, err
+		}
+
+		quizIDs, err = qtx.GetCourseQuizIDs(ctx, course.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get course quizzes: %w", err)
+		}
+	}
+
+	if err := resetQuizProgress(ctx, qtx, resetID, quizIDs, userIDs); err != nil {
+		return nil, err
+	}
+
+	reset := &domain.ProgressReset{
+		ID:          utils.UUIDFrom(resetID),
+		CourseID:    utils.UUIDFrom(course.ID),
+		CourseTitle: course.Title.String,
+		Users:       users,
+	}
+	if args.quizID.Valid {
+		quizID := utils.UUIDFrom(args.quizID)
+		reset.QuizID = &quizID
+	}
+
+	if args.emails != nil {
+		emails, err := args.emails(reset)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := enqueueEmails(ctx, qtx, emails...); err != nil {
+			return nil, fmt.Errorf("failed to queue emails: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return reset, nil
+}
+
+func getResetUsers(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	args progressResetArgs,
+	courseID pgtype.UUID,
+) ([]domain.ResetUser, error) {
+	if args.cohort {
+		rows, err := qtx.GetCourseCohort(ctx, courseID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get course cohort: %w", err)
+		}
+
+		return utils.Map(rows, func(row sqlc.GetCourseCohortRow) domain.ResetUser {
+			return domain.ResetUser{ID: row.ID, Name: row.Name.String, Email: row.Email.String}
+		}), nil
+	}
+
+	rows, err := qtx.GetResetUsers(ctx, args.userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	if len(rows) != len(args.userIDs) {
+		return nil, pgx.ErrNoRows
+	}
+
+	return utils.Map(rows, func(row sqlc.GetResetUsersRow) domain.ResetUser {
+		return domain.ResetUser{ID: row.ID, Name: row.Name.String, Email: row.Email.String}
+	}), nil
+}
+
+func resetCourseProgress(ctx context.Context, qtx *sqlc.Queries, resetID, courseID pgtype.UUID, userIDs []string) error {
+	if err := qtx.ArchiveProgress(ctx, sqlc.ArchiveProgressParams{
+		ResetID:  resetID,
+		CourseID: courseID,
+		UserIds:  userIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to archive progress: %w", err)
+	}
+
+	if err := qtx.ResetProgressForUsers(ctx, sqlc.ResetProgressForUsersParams{
+		CourseID: courseID,
+		UserIds:  userIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to reset progress: %w", err)
+	}
+
+	return nil
+}
+
+func resetQuizProgress(ctx context.Context, qtx *sqlc.Queries, resetID pgtype.UUID, quizIDs []pgtype.UUID, userIDs []string) error {
+	if err := qtx.ArchiveQuizStates(ctx, sqlc.ArchiveQuizStatesParams{
+		ResetID: resetID,
+		QuizIds: quizIDs,
+		UserIds: userIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to archive quiz states: %w", err)
+	}
+
+	if err := qtx.DeleteQuizStatesForUsers(ctx, sqlc.DeleteQuizStatesForUsersParams{
+		QuizIds: quizIDs,
+		UserIds: userIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to delete quiz states: %w", err)
+	}
+
+	if err := qtx.ArchiveQuizAttempts(ctx, sqlc.ArchiveQuizAttemptsParams{
+		ResetID: resetID,
+		QuizIds: quizIDs,
+		UserIds: userIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to archive quiz attempts: %w", err)
+	}
+
+	if err := qtx.DeleteQuizAttemptsForUsers(ctx, sqlc.DeleteQuizAttemptsForUsersParams{
+		QuizIds: quizIDs,
+		UserIds: userIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to delete quiz attempts: %w", err)
+	}
+
+	return nil
+}
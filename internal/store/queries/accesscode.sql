@@ -0,0 +1,36 @@
+-- name: AddAccessCode :one
+INSERT INTO course_access_codes (code, course_id, expires_at, max_uses, created_by)
+VALUES (sqlc.arg('code'), sqlc.arg('course_id'), sqlc.narg('expires_at'), sqlc.narg('max_uses'), sqlc.narg('created_by'))
+RETURNING id, code, course_id, expires_at, max_uses, uses, created_by, created_at, revoked_at;
+
+-- name: GetAccessCodes :many
+SELECT id, code, course_id, expires_at, max_uses, uses, created_by, created_at, revoked_at
+FROM course_access_codes
+WHERE sqlc.narg('course_id')::uuid IS NULL OR course_id = sqlc.narg('course_id')
+ORDER BY created_at DESC;
+
+-- Locks the code until the end of the transaction so concurrent redemptions can't exceed max_uses
+-- name: GetAccessCodeForUpdate :one
+SELECT id, code, course_id, expires_at, max_uses, uses, created_by, created_at, revoked_at
+FROM course_access_codes
+WHERE code = $1
+FOR UPDATE;
+
+-- name: HasRedeemedAccessCode :one
+SELECT EXISTS(SELECT 1 FROM course_access_code_redemptions WHERE access_code_id = $1 AND user_id = $2);
+
+-- name: AddAccessCodeRedemption :exec
+INSERT INTO course_access_code_redemptions (access_code_id, user_id) VALUES ($1, $2);
+
+-- name: IncrementAccessCodeUses :exec
+UPDATE course_access_codes SET uses = uses + 1 WHERE id = $1;
+
+-- name: RevokeAccessCode :execrows
+UPDATE course_access_codes SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1;
+
+-- name: GetAccessCodeRedemptions :many
+SELECT r.user_id, u.name AS user_name, u.email AS user_email, r.redeemed_at
+FROM course_access_code_redemptions r
+LEFT JOIN users u ON u.id = r.user_id
+WHERE r.access_code_id = $1
+ORDER BY r.redeemed_at;
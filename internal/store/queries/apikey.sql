@@ -0,0 +1,27 @@
+-- name: AddAPIKey :one
+INSERT INTO api_keys (name, key_prefix, key_hash, scopes, created_by, expires_at)
+VALUES (
+  sqlc.arg('name'),
+  sqlc.arg('key_prefix'),
+  sqlc.arg('key_hash'),
+  sqlc.arg('scopes'),
+  sqlc.narg('created_by'),
+  sqlc.narg('expires_at')
+)
+RETURNING id, name, key_prefix, key_hash, scopes, created_by, created_at, expires_at, last_used_at, revoked_at;
+
+-- name: GetAPIKeys :many
+SELECT id, name, key_prefix, key_hash, scopes, created_by, created_at, expires_at, last_used_at, revoked_at
+FROM api_keys
+ORDER BY created_at DESC;
+
+-- name: GetAPIKeyByHash :one
+SELECT id, name, key_prefix, key_hash, scopes, created_by, created_at, expires_at, last_used_at, revoked_at
+FROM api_keys
+WHERE key_hash = $1;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1;
+
+-- name: SetAPIKeyLastUsed :exec
+UPDATE api_keys SET last_used_at = NOW() WHERE id = $1;
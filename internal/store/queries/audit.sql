@@ -0,0 +1,68 @@
+-- Serialises writers until the end of the transaction so each entry chains from the one before it
+-- name: LockAuditLog :exec
+SELECT pg_advisory_xact_lock(hashtext('audit_log'));
+
+-- name: GetLastAuditHash :one
+SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1;
+
+-- name: AddAuditEntry :one
+INSERT INTO audit_log (actor_id, action, target_type, target_id, before, after, created_at, prev_hash, hash)
+VALUES (
+  sqlc.narg('actor_id'),
+  sqlc.arg('action'),
+  sqlc.arg('target_type'),
+  sqlc.arg('target_id'),
+  sqlc.narg('before'),
+  sqlc.narg('after'),
+  sqlc.arg('created_at'),
+  sqlc.arg('prev_hash'),
+  sqlc.arg('hash')
+)
+RETURNING id;
+
+-- name: ListAuditEntries :many
+SELECT id, actor_id, action, target_type, target_id, before, after, created_at, prev_hash, hash
+FROM audit_log
+WHERE (sqlc.narg('actor_id')::text IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('action')::text IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('target_type')::text IS NULL OR target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('target_id')::text IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (
+    sqlc.narg('search')::text IS NULL
+    OR target_id ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    OR actor_id ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+  )
+  AND (
+    sqlc.narg('cursor_id')::text IS NULL
+    OR (NOT sqlc.arg('sort_desc')::bool AND id > sqlc.narg('cursor_id')::bigint)
+    OR (sqlc.arg('sort_desc')::bool AND id < sqlc.narg('cursor_id')::bigint)
+  )
+ORDER BY
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE id END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN id END DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: CountAuditEntries :one
+SELECT COUNT(*)
+FROM audit_log
+WHERE (sqlc.narg('actor_id')::text IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('action')::text IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('target_type')::text IS NULL OR target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('target_id')::text IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (
+    sqlc.narg('search')::text IS NULL
+    OR target_id ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    OR actor_id ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+  );
+
+-- Returns entries in the order they were chained, a batch at a time
+-- name: GetAuditChain :many
+SELECT id, actor_id, action, target_type, target_id, before, after, created_at, prev_hash, hash
+FROM audit_log
+WHERE id > sqlc.arg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('batch_size');
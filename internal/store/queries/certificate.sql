@@ -0,0 +1,123 @@
+-- Certificates expire the course's certificate validity after they're issued, if it has one
+-- name: AddCertificate :exec
+INSERT INTO certificates (id, user_id, course_id, storage_key, verification_id, expires_at)
+SELECT
+  sqlc.arg('id'),
+  sqlc.arg('user_id'),
+  co.id,
+  sqlc.arg('storage_key'),
+  sqlc.arg('verification_id'),
+  NOW() + make_interval(months => co.certificate_validity_months)
+FROM courses co
+WHERE co.id = sqlc.arg('course_id');
+
+-- name: GetCertificate :one
+SELECT
+  c.id,
+  c.number,
+  c.user_id,
+  c.course_id,
+  c.storage_key,
+  c.issued_at,
+  c.verification_id,
+  c.expires_at,
+  c.revoked_at,
+  c.revoke_reason,
+  u.name AS user_name,
+  co.title AS course_title,
+  co.completion_title
+FROM certificates c
+JOIN users u ON u.id = c.user_id
+JOIN courses co ON co.id = c.course_id
+WHERE c.id = $1;
+
+-- A learner has a certificate for each time they completed the course, the latest is the current one
+-- name: GetLatestCertificate :one
+SELECT
+  c.id,
+  c.number,
+  c.user_id,
+  c.course_id,
+  c.storage_key,
+  c.issued_at,
+  c.verification_id,
+  c.expires_at,
+  c.revoked_at,
+  c.revoke_reason,
+  u.name AS user_name,
+  co.title AS course_title,
+  co.completion_title
+FROM certificates c
+JOIN users u ON u.id = c.user_id
+JOIN courses co ON co.id = c.course_id
+WHERE c.user_id = sqlc.arg('user_id') AND c.course_id = sqlc.arg('course_id')
+ORDER BY c.issued_at DESC, c.number DESC
+LIMIT 1;
+
+-- name: GetCertificateByVerificationID :one
+SELECT
+  c.id,
+  c.number,
+  c.user_id,
+  c.course_id,
+  c.storage_key,
+  c.issued_at,
+  c.verification_id,
+  c.expires_at,
+  c.revoked_at,
+  c.revoke_reason,
+  u.name AS user_name,
+  co.title AS course_title,
+  co.completion_title
+FROM certificates c
+JOIN users u ON u.id = c.user_id
+JOIN courses co ON co.id = c.course_id
+WHERE c.verification_id = $1;
+
+-- name: DeleteCertificate :exec
+DELETE FROM certificates WHERE id = $1;
+
+-- Revoking a certificate again keeps when, why and by whom it was first revoked
+-- name: RevokeCertificate :execrows
+UPDATE certificates
+SET
+  revoked_at = COALESCE(revoked_at, NOW()),
+  revoked_by = CASE WHEN revoked_at IS NULL THEN sqlc.narg('revoked_by') ELSE revoked_by END,
+  revoke_reason = COALESCE(revoke_reason, sqlc.arg('reason'))
+WHERE id = sqlc.arg('id');
+
+-- name: SetCertificateValidity :execrows
+UPDATE courses
+SET certificate_validity_months = sqlc.narg('validity_months')
+WHERE id = sqlc.arg('course_id');
+
+-- Current certificates expiring within the given number of days that the learner hasn't been told
+-- about yet. Certificates replaced by a later one aren't current.
+-- name: GetExpiringCertificates :many
+SELECT
+  c.id,
+  c.number,
+  c.user_id,
+  c.course_id,
+  c.expires_at,
+  u.name AS user_name,
+  u.email AS user_email,
+  co.title AS course_title
+FROM certificates c
+JOIN users u ON u.id = c.user_id
+JOIN courses co ON co.id = c.course_id
+WHERE c.expires_at BETWEEN NOW() AND NOW() + make_interval(days => sqlc.arg('expires_within_days')::int)
+  AND c.revoked_at IS NULL
+  AND c.expiry_notified_at IS NULL
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM certificates later
+    WHERE later.user_id = c.user_id
+      AND later.course_id = c.course_id
+      AND (later.issued_at, later.number) > (c.issued_at, c.number)
+  )
+ORDER BY c.expires_at;
+
+-- name: SetCertificateExpiryNotified :exec
+UPDATE certificates SET expiry_notified_at = NOW() WHERE id = $1;
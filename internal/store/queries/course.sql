@@ -57,6 +57,9 @@ SELECT
 FROM courses c
 WHERE c.id = $1;
 
+-- name: CourseExists :one
+SELECT EXISTS(SELECT 1 FROM courses WHERE id = $1);
+
 -- name: GetCoursesOverview :many
 SELECT id, title, description FROM courses ORDER BY title;
 
@@ -129,9 +132,16 @@ INSERT INTO quizanswers (answer, correct_answer, position, quiz_question_id)
 VALUES ($1, $2, $3, $4);
 
 -- name: GetAssignedCourseTitles :many
-SELECT c.id, c.title, c.description
+SELECT
+  c.id,
+  c.title,
+  c.description,
+  uc.due_at,
+  COALESCE(up.completed_course, FALSE)::bool AS completed_course,
+  (COALESCE(up.completed_intro, FALSE) OR COALESCE(cardinality(up.completed_section_ids), 0) > 0)::bool AS started
 FROM courses c
 INNER JOIN usercourses uc ON uc.course_id = c.id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = c.id
 WHERE uc.user_id = $1
 ORDER BY c.title;
 
@@ -231,3 +241,61 @@ SELECT
   ) AS materials
 FROM courses c
 ORDER BY c.title;
+
+-- name: ListCourses :many
+SELECT
+  c.id,
+  c.title,
+  c.description,
+  c.completion_title,
+  c.completion_message,
+  (
+    SELECT json_agg(json_build_object(
+      'id', v.id,
+      'title', v.title,
+      'storage_key', v.storage_key,
+      'position', v.position
+    ) ORDER BY v.position)
+    FROM videosections v WHERE v.course_id = c.id
+  ) AS video_sections,
+  (
+    SELECT json_agg(json_build_object(
+      'id', q.id,
+      'position', q.position
+    ) ORDER BY q.position)
+    FROM quizsections q WHERE q.course_id = c.id
+  ) AS quiz_sections,
+  (
+    SELECT json_agg(json_build_object(
+      'id', m.id,
+      'name', m.name,
+      'storage_key', m.storage_key,
+      'position', m.position
+    ) ORDER BY m.position)
+    FROM course_materials m WHERE m.course_id = c.id
+  ) AS materials,
+  lower(COALESCE(c.title, ''))::text AS sort_key
+FROM courses c
+WHERE (sqlc.narg('search')::text IS NULL OR c.title ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\')
+  AND (
+    sqlc.narg('cursor_id')::text IS NULL
+    OR (
+      NOT sqlc.arg('sort_desc')::bool
+      AND (lower(COALESCE(c.title, '')), c.id::text) > (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id'))
+    )
+    OR (
+      sqlc.arg('sort_desc')::bool
+      AND (lower(COALESCE(c.title, '')), c.id::text) < (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id'))
+    )
+  )
+ORDER BY
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE lower(COALESCE(c.title, '')) END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE c.id::text END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN lower(COALESCE(c.title, '')) END DESC,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN c.id::text END DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: CountCourses :one
+SELECT COUNT(*)
+FROM courses c
+WHERE sqlc.narg('search')::text IS NULL OR c.title ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\';
@@ -1,11 +1,151 @@
--- name: AddFailedEmail :exec
-INSERT INTO email_failures (error, template_params, template_name, email_name) VALUES ($1, $2, $3, $4);
+-- name: EnqueueEmail :exec
+INSERT INTO email_outbox (email_name, template_name, template_params) VALUES ($1, $2, $3);
 
--- name: GetFailedEmails :many
-SELECT id, template_params, template_name, email_name, retries FROM email_failures;
+-- Claims the pending emails that are due to be sent, oldest first, by pushing their next attempt
+-- back by the lease. Rows another server is claiming are skipped rather than waited for, so each
+-- email is only sent by one server. If the server stops before sending, the email is sent once the
+-- lease runs out.
+-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET next_attempt_at = NOW() + make_interval(secs => sqlc.arg('lease_seconds')::int)
+WHERE id IN (
+  SELECT due.id
+  FROM email_outbox due
+  WHERE due.status = 'pending' AND due.next_attempt_at <= NOW()
+  ORDER BY due.next_attempt_at
+  LIMIT sqlc.arg('batch_size')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, email_name, template_name, template_params, attempts;
 
--- name: UpdateFailedEmail :exec
-UPDATE email_failures SET retries = $1, error = $2, updated_at = NOW(); 
+-- Error is NULL for an attempt that sent the email
+-- name: AddEmailAttempt :exec
+INSERT INTO email_attempts (email_id, error) VALUES ($1, $2);
 
--- name: DeleteFailedEmail :exec
-DELETE FROM email_failures WHERE id = $1::uuid;
\ No newline at end of file
+-- name: GetEmailAttempts :many
+SELECT attempted_at, error
+FROM email_attempts
+WHERE email_id = $1
+ORDER BY attempted_at;
+
+-- Only pending emails are marked sent, so one discarded while it was being sent stays discarded
+-- name: SetEmailSent :exec
+UPDATE email_outbox
+SET
+  status = 'sent',
+  attempts = attempts + 1,
+  last_error = NULL,
+  sent_at = NOW(),
+  provider_message_id = sqlc.narg('provider_message_id')
+WHERE id = sqlc.arg('id') AND status = 'pending';
+
+-- Emails the learner opted out of after they were queued aren't sent
+-- name: SetEmailSkipped :exec
+UPDATE email_outbox SET status = 'skipped' WHERE id = $1;
+
+-- Updates only the failed email, each email backs off from its own attempts
+-- name: SetEmailRetry :exec
+UPDATE email_outbox
+SET attempts = attempts + 1, last_error = sqlc.arg('error'), next_attempt_at = sqlc.arg('next_attempt_at')
+WHERE id = sqlc.arg('id') AND status = 'pending';
+
+-- Dead-lettered emails aren't retried
+-- name: SetEmailDead :exec
+UPDATE email_outbox
+SET status = 'dead', attempts = attempts + 1, last_error = sqlc.arg('error')
+WHERE id = sqlc.arg('id') AND status = 'pending';
+
+-- Failed emails are those waiting to be retried after failing, and dead-lettered emails
+-- name: ListFailedEmails :many
+SELECT id, email_name, template_name, template_params, status, attempts, next_attempt_at, last_error, created_at
+FROM email_outbox
+WHERE status IN ('pending', 'dead')
+  AND last_error IS NOT NULL
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('search')::text IS NULL OR email_name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\')
+  AND (
+    sqlc.narg('cursor_id')::text IS NULL
+    OR (
+      NOT sqlc.arg('sort_desc')::bool
+      AND (created_at, id::text) > (sqlc.narg('cursor_key')::text::timestamptz, sqlc.narg('cursor_id'))
+    )
+    OR (
+      sqlc.arg('sort_desc')::bool
+      AND (created_at, id::text) < (sqlc.narg('cursor_key')::text::timestamptz, sqlc.narg('cursor_id'))
+    )
+  )
+ORDER BY
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE created_at END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE id::text END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN created_at END DESC,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN id::text END DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: CountFailedEmails :one
+SELECT COUNT(*)
+FROM email_outbox
+WHERE status IN ('pending', 'dead')
+  AND last_error IS NOT NULL
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('search')::text IS NULL OR email_name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\');
+
+-- Failed emails waiting to be retried and dead-lettered, for monitoring
+-- name: CountFailedEmailsByStatus :many
+SELECT status, COUNT(*) AS count
+FROM email_outbox
+WHERE status IN ('pending', 'dead') AND last_error IS NOT NULL
+GROUP BY status;
+
+-- Retried emails are sent by the next dispatch, they keep their attempts so one that fails again
+-- is dead-lettered again. Only dead-lettered emails can be retried, pending ones are already
+-- retried by the dispatcher, which may have claimed them.
+-- name: RetryFailedEmail :execrows
+UPDATE email_outbox
+SET status = 'pending', next_attempt_at = NOW()
+WHERE id = $1 AND status = 'dead';
+
+-- name: DiscardFailedEmail :execrows
+UPDATE email_outbox
+SET status = 'discarded'
+WHERE id = $1 AND status IN ('pending', 'dead') AND last_error IS NOT NULL;
+
+-- Events are recorded against the sent message they're about. Events the provider resends are
+-- ignored, so no rows are added.
+-- name: AddEmailEvent :execrows
+INSERT INTO email_events (provider_event_id, email_id, message_id, event, recipient, reason, occurred_at)
+VALUES (
+  sqlc.arg('provider_event_id'),
+  (SELECT id FROM email_outbox WHERE provider_message_id = sqlc.arg('message_id')::text LIMIT 1),
+  sqlc.arg('message_id'),
+  sqlc.arg('event'),
+  sqlc.arg('recipient'),
+  sqlc.narg('reason'),
+  sqlc.arg('occurred_at')
+)
+ON CONFLICT (provider_event_id) DO NOTHING;
+
+-- Keeps the latest bounce, events can arrive out of order
+-- name: SetEmailBounced :exec
+INSERT INTO email_bounces (email, bounced_at, reason)
+VALUES (LOWER(sqlc.arg('email')::text), sqlc.arg('bounced_at'), sqlc.narg('reason'))
+ON CONFLICT (email) DO UPDATE
+SET bounced_at = EXCLUDED.bounced_at, reason = EXCLUDED.reason
+WHERE email_bounces.bounced_at < EXCLUDED.bounced_at;
+
+-- An address stops bouncing once an email is delivered to it after it bounced
+-- name: ClearEmailBounced :exec
+DELETE FROM email_bounces
+WHERE email = LOWER(sqlc.arg('email')::text) AND bounced_at < sqlc.arg('delivered_at');
+
+-- name: IsEmailOptedOut :one
+SELECT EXISTS(SELECT 1 FROM user_email_opt_outs WHERE user_id = $1 AND email_name = $2);
+
+-- name: GetEmailOptOuts :many
+SELECT email_name FROM user_email_opt_outs WHERE user_id = $1 ORDER BY email_name;
+
+-- name: AddEmailOptOut :exec
+INSERT INTO user_email_opt_outs (user_id, email_name) VALUES ($1, $2)
+ON CONFLICT (user_id, email_name) DO NOTHING;
+
+-- name: DeleteEmailOptOut :exec
+DELETE FROM user_email_opt_outs WHERE user_id = $1 AND email_name = $2;
@@ -8,17 +8,196 @@ SELECT
     jsonb_agg(c.course_id) FILTER (WHERE c.course_id IS NOT NULL),
     '[]'::jsonb
     )
-  )::jsonb AS course_ids
+  )::jsonb AS course_ids,
+  (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('id', g.id, 'name', g.name) ORDER BY g.name), '[]'::jsonb)
+    FROM user_groups ug
+    INNER JOIN groups g ON g.id = ug.group_id
+    WHERE ug.user_id = u.id
+  )::jsonb AS groups
 FROM users u
 LEFT JOIN usercourses c ON u.id = c.user_id
+WHERE u.erased_at IS NULL
 GROUP BY u.id, u.name, u.email
 ORDER BY u.name;
 
 -- name: IsUserEnrolledInCourse :one
 SELECT EXISTS(SELECT 1 FROM usercourses WHERE user_id = $1 AND course_id = $2);
 
--- name: EnrolInCourse :exec
-INSERT INTO usercourses (user_id, course_id) VALUES ($1, $2);
+-- The due date is either absolute or a number of days after enrolment, or NULL if there isn't one.
+-- Enrolling an already enrolled user is a no-op and returns 0 rows affected
+-- name: EnrolInCourse :execrows
+INSERT INTO usercourses (user_id, course_id, enrolled_by, due_at)
+VALUES (
+  sqlc.arg('user_id'),
+  sqlc.arg('course_id'),
+  sqlc.narg('enrolled_by'),
+  COALESCE(sqlc.narg('due_at'), NOW() + make_interval(days => sqlc.narg('due_in_days')::int))
+)
+ON CONFLICT (user_id, course_id) DO NOTHING;
+
+-- name: DisenrolInCourse :execrows
+DELETE FROM usercourses WHERE user_id = $1 AND course_id = $2;
+
+-- name: GetExistingUserIDs :many
+SELECT id FROM users WHERE id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- name: GetExistingCourseIDs :many
+SELECT id FROM courses WHERE id = ANY(sqlc.arg('course_ids')::uuid[]);
+
+-- name: ListUsersAndAssignedCourses :many
+WITH filtered_users AS (
+  SELECT
+    u.id,
+    u.name,
+    u.email,
+    lower(
+      CASE WHEN sqlc.arg('sort_by')::text = 'email' THEN COALESCE(u.email, '') ELSE COALESCE(u.name, '') END
+    )::text AS sort_key
+  FROM users u
+  WHERE u.erased_at IS NULL
+    AND (
+      sqlc.narg('search')::text IS NULL
+      OR u.name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+      OR u.email ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    )
+    AND (
+      sqlc.narg('course_id')::uuid IS NULL
+      OR EXISTS (SELECT 1 FROM usercourses f WHERE f.user_id = u.id AND f.course_id = sqlc.narg('course_id'))
+    )
+    AND (
+      sqlc.narg('group_id')::uuid IS NULL
+      OR EXISTS (SELECT 1 FROM user_groups f WHERE f.user_id = u.id AND f.group_id = sqlc.narg('group_id'))
+    )
+)
+SELECT
+  fu.id,
+  fu.name,
+  fu.email,
+  (
+    COALESCE(
+    jsonb_agg(c.course_id) FILTER (WHERE c.course_id IS NOT NULL),
+    '[]'::jsonb
+    )
+  )::jsonb AS course_ids,
+  (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('id', g.id, 'name', g.name) ORDER BY g.name), '[]'::jsonb)
+    FROM user_groups ug
+    INNER JOIN groups g ON g.id = ug.group_id
+    WHERE ug.user_id = fu.id
+  )::jsonb AS groups,
+  fu.sort_key
+FROM filtered_users fu
+LEFT JOIN usercourses c ON fu.id = c.user_id
+WHERE sqlc.narg('cursor_id')::text IS NULL
+  OR (NOT sqlc.arg('sort_desc')::bool AND (fu.sort_key, fu.id) > (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+  OR (sqlc.arg('sort_desc')::bool AND (fu.sort_key, fu.id) < (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+GROUP BY fu.id, fu.name, fu.email, fu.sort_key
+ORDER BY
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fu.sort_key END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fu.id END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fu.sort_key END DESC,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fu.id END DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: CountUsersAndAssignedCourses :one
+SELECT COUNT(*)
+FROM users u
+WHERE u.erased_at IS NULL
+  AND (
+    sqlc.narg('search')::text IS NULL
+    OR u.name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    OR u.email ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+  )
+  AND (
+    sqlc.narg('course_id')::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM usercourses f WHERE f.user_id = u.id AND f.course_id = sqlc.narg('course_id'))
+  )
+  AND (
+    sqlc.narg('group_id')::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM user_groups f WHERE f.user_id = u.id AND f.group_id = sqlc.narg('group_id'))
+  );
+
+-- Changing the due date clears the overdue flag so the enrolment is re-evaluated by the overdue job.
+-- Returns the due date before and after the change.
+-- name: SetEnrolmentDueDate :one
+UPDATE usercourses uc
+SET due_at = COALESCE(sqlc.narg('due_at'), uc.enrolled_at + make_interval(days => sqlc.narg('due_in_days')::int)),
+    overdue_at = NULL
+FROM usercourses previous
+WHERE previous.id = uc.id AND uc.user_id = sqlc.arg('user_id') AND uc.course_id = sqlc.arg('course_id')
+RETURNING previous.due_at AS previous_due_at, uc.due_at;
+
+-- Enrolments past their due date that haven't been completed or flagged as overdue yet, learners
+-- who can't sign in aren't emailed
+-- name: GetOverdueEnrolments :many
+SELECT
+  uc.id,
+  uc.user_id,
+  u.name AS user_name,
+  u.email AS user_email,
+  uc.course_id,
+  c.title AS course_title,
+  uc.due_at
+FROM usercourses uc
+INNER JOIN users u ON u.id = uc.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = uc.course_id
+WHERE uc.due_at < NOW()
+  AND uc.overdue_at IS NULL
+  AND NOT COALESCE(up.completed_course, FALSE)
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+ORDER BY uc.due_at;
+
+-- name: SetEnrolmentOverdue :exec
+UPDATE usercourses SET overdue_at = NOW() WHERE id = $1;
+
+-- Enrolments the learner hasn't been emailed about yet, learners who can't sign in aren't emailed
+-- name: GetUnnotifiedEnrolments :many
+SELECT
+  uc.id,
+  uc.user_id,
+  u.name AS user_name,
+  u.email AS user_email,
+  uc.course_id,
+  c.title AS course_title,
+  uc.enrolled_at,
+  uc.due_at
+FROM usercourses uc
+INNER JOIN users u ON u.id = uc.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+WHERE uc.notified_at IS NULL
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+ORDER BY uc.enrolled_at;
+
+-- name: SetEnrolmentNotified :exec
+UPDATE usercourses SET notified_at = NOW() WHERE id = $1;
+
+-- Enrolments older than the given number of days that the learner hasn't started or been reminded
+-- about. Overdue enrolments are left to the overdue job.
+-- name: GetNotStartedEnrolments :many
+SELECT
+  uc.id,
+  uc.user_id,
+  u.name AS user_name,
+  u.email AS user_email,
+  uc.course_id,
+  c.title AS course_title,
+  uc.enrolled_at,
+  uc.due_at
+FROM usercourses uc
+INNER JOIN users u ON u.id = uc.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = uc.course_id
+WHERE uc.enrolled_at < NOW() - make_interval(days => sqlc.arg('enrolled_days')::int)
+  AND uc.reminded_at IS NULL
+  AND up.id IS NULL
+  AND (uc.due_at IS NULL OR uc.due_at >= NOW())
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+ORDER BY uc.enrolled_at;
 
--- name: DisenrolInCourse :exec
-DELETE FROM usercourses WHERE user_id = $1 AND course_id = $2;
\ No newline at end of file
+-- name: SetEnrolmentReminded :exec
+UPDATE usercourses SET reminded_at = NOW() WHERE id = $1;
@@ -0,0 +1,57 @@
+-- name: GetGroups :many
+SELECT
+  g.id,
+  g.name,
+  (SELECT COUNT(*) FROM user_groups ug WHERE ug.group_id = g.id) AS member_count,
+  (
+    SELECT COALESCE(jsonb_agg(gc.course_id ORDER BY gc.assigned_at), '[]'::jsonb)
+    FROM group_courses gc
+    WHERE gc.group_id = g.id
+  )::jsonb AS course_ids
+FROM groups g
+ORDER BY g.name;
+
+-- name: GroupExists :one
+SELECT EXISTS(SELECT 1 FROM groups WHERE id = $1);
+
+-- name: AddGroup :one
+INSERT INTO groups (name) VALUES ($1) RETURNING id;
+
+-- name: UpdateGroup :execrows
+UPDATE groups SET name = $2 WHERE id = $1;
+
+-- name: DeleteGroup :execrows
+DELETE FROM groups WHERE id = $1;
+
+-- Unknown users are skipped and users already in the group are left as they are
+-- name: AddGroupMembers :exec
+INSERT INTO user_groups (user_id, group_id)
+SELECT u.id, sqlc.arg('group_id')::uuid
+FROM users u
+WHERE u.id = ANY(sqlc.arg('user_ids')::text[])
+ON CONFLICT (user_id, group_id) DO NOTHING;
+
+-- name: RemoveGroupMembers :exec
+DELETE FROM user_groups WHERE group_id = sqlc.arg('group_id') AND user_id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- Re-assigning a course updates the due date used for future enrolments
+-- name: AssignCourseToGroup :exec
+INSERT INTO group_courses (group_id, course_id, due_in_days, assigned_by)
+VALUES (sqlc.arg('group_id'), sqlc.arg('course_id'), sqlc.narg('due_in_days'), sqlc.narg('assigned_by'))
+ON CONFLICT (group_id, course_id)
+DO UPDATE SET due_in_days = EXCLUDED.due_in_days;
+
+-- name: UnassignCourseFromGroup :execrows
+DELETE FROM group_courses WHERE group_id = $1 AND course_id = $2;
+
+-- Enrols members of a group in the courses assigned to the group, optionally limited to some
+-- members or a single course. Existing enrolments are left as they are.
+-- name: EnrolGroupMembers :execrows
+INSERT INTO usercourses (user_id, course_id, enrolled_by, due_at)
+SELECT ug.user_id, gc.course_id, gc.assigned_by, NOW() + make_interval(days => gc.due_in_days)
+FROM user_groups ug
+INNER JOIN group_courses gc ON gc.group_id = ug.group_id
+WHERE ug.group_id = sqlc.arg('group_id')
+  AND (sqlc.narg('user_ids')::text[] IS NULL OR ug.user_id = ANY(sqlc.narg('user_ids')::text[]))
+  AND (sqlc.narg('course_id')::uuid IS NULL OR gc.course_id = sqlc.narg('course_id'))
+ON CONFLICT (user_id, course_id) DO NOTHING;
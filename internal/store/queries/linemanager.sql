@@ -0,0 +1,62 @@
+-- Replaces the user's current line manager and returns the previous one, empty if they had none.
+-- Returns no rows if either user doesn't exist.
+-- name: SetLineManager :one
+WITH previous AS (
+  SELECT manager_id FROM line_managers WHERE user_id = sqlc.arg('user_id')
+)
+INSERT INTO line_managers (user_id, manager_id)
+SELECT u.id, m.id
+FROM users u
+INNER JOIN users m ON m.id = sqlc.arg('manager_id')
+WHERE u.id = sqlc.arg('user_id')
+ON CONFLICT (user_id)
+DO UPDATE SET manager_id = EXCLUDED.manager_id, assigned_at = NOW()
+RETURNING COALESCE((SELECT manager_id FROM previous), '')::text AS previous_manager_id;
+
+-- Returns no rows if the user has no line manager
+-- name: RemoveLineManager :one
+DELETE FROM line_managers WHERE user_id = $1 RETURNING manager_id;
+
+-- Enrolments of the manager's direct reports, with enough progress to work out their status
+-- name: GetTeamEnrolments :many
+SELECT
+  u.id AS user_id,
+  u.name AS user_name,
+  u.email,
+  c.id AS course_id,
+  c.title AS course_title,
+  uc.enrolled_at,
+  uc.due_at,
+  COALESCE(up.completed_course, FALSE)::bool AS completed_course,
+  (COALESCE(up.completed_intro, FALSE) OR COALESCE(cardinality(up.completed_section_ids), 0) > 0)::bool AS started
+FROM line_managers lm
+INNER JOIN users u ON u.id = lm.user_id
+INNER JOIN usercourses uc ON uc.user_id = lm.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = uc.course_id
+WHERE lm.manager_id = $1
+  AND u.erased_at IS NULL
+ORDER BY lower(COALESCE(u.name, '')), u.id, c.title;
+
+-- Incomplete enrolments of every manager's direct reports that are overdue or due within the
+-- given number of days, ordered by manager
+-- name: GetManagerDigestEnrolments :many
+SELECT
+  lm.manager_id,
+  m.name AS manager_name,
+  m.email AS manager_email,
+  u.name AS user_name,
+  c.title AS course_title,
+  uc.due_at
+FROM line_managers lm
+INNER JOIN users m ON m.id = lm.manager_id
+INNER JOIN users u ON u.id = lm.user_id
+INNER JOIN usercourses uc ON uc.user_id = lm.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = uc.course_id
+WHERE uc.due_at < NOW() + make_interval(days => sqlc.arg('due_within_days')::int)
+  AND NOT COALESCE(up.completed_course, FALSE)
+  AND m.deactivated_at IS NULL
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+ORDER BY lm.manager_id, uc.due_at, lower(COALESCE(u.name, ''));
@@ -0,0 +1,25 @@
+-- name: AddLocalAuthUser :exec
+INSERT INTO local_auth_users (id, email, name, password_hash)
+VALUES (sqlc.arg('id'), sqlc.arg('email'), sqlc.arg('name'), sqlc.arg('password_hash'));
+
+-- name: GetLocalAuthUser :one
+SELECT id, email, name, password_hash, disabled, created_at FROM local_auth_users WHERE id = $1;
+
+-- name: GetLocalAuthUserByEmail :one
+SELECT id, email, name, password_hash, disabled, created_at FROM local_auth_users WHERE lower(email) = lower(sqlc.arg('email'));
+
+-- name: UpdateLocalAuthUser :execrows
+UPDATE local_auth_users
+SET email = sqlc.arg('email'), name = sqlc.arg('name')
+WHERE id = sqlc.arg('id');
+
+-- name: SetLocalAuthUserDisabled :execrows
+UPDATE local_auth_users SET disabled = sqlc.arg('disabled') WHERE id = sqlc.arg('id');
+
+-- name: SetLocalAuthUserPassword :execrows
+UPDATE local_auth_users
+SET password_hash = sqlc.arg('password_hash')
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteLocalAuthUser :exec
+DELETE FROM local_auth_users WHERE id = $1;
@@ -10,27 +10,19 @@ ON CONFLICT (user_id, course_id)
 DO UPDATE SET completed_section_ids = array_append(userprogress.completed_section_ids, sqlc.arg('section_id')::uuid)
 WHERE NOT (sqlc.arg('section_id') = ANY(userprogress.completed_section_ids));
 
--- name: HasCompletedCourse :one
-SELECT completed_course FROM userprogress WHERE user_id = $1 AND course_id = $2;
-
 -- If there is no existing userprogress (should not happen since user should have some progress already)
 -- then insert new row with empty completed_section_ids */
--- name: SetCourseCompleted :exec
+-- Returns 0 rows affected if the course was already completed.
+-- name: SetCourseCompleted :execrows
 INSERT INTO userprogress (user_id, course_id, completed_section_ids, completed_course)
 VALUES ($1, $2, ARRAY[]::uuid[], TRUE)
 ON CONFLICT (user_id, course_id)
-DO UPDATE SET completed_course = TRUE;
+DO UPDATE SET completed_course = TRUE
+WHERE userprogress.completed_course IS NOT TRUE;
 
 -- name: GetCompletedSectionIDsByUserID :many
 SELECT completed_section_ids FROM userprogress WHERE user_id = $1;
 
--- name: ResetProgress :exec
-UPDATE userprogress
-SET completed_section_ids = ARRAY[]::uuid[],
-    completed_intro = FALSE,
-    completed_course = FALSE
-WHERE user_id = $1 AND course_id = $2;
-
 -- name: SetIntroCompleted :exec
 INSERT INTO userprogress (user_id, course_id, completed_section_ids, completed_intro)
 VALUES ($1, $2, ARRAY[]::uuid[], TRUE)
@@ -55,3 +47,109 @@ LEFT JOIN users u
   ON u.id = COALESCE(uc.user_id, up.user_id)
 LEFT JOIN courses c
   ON c.id = COALESCE(uc.course_id, up.course_id);
+
+-- Users that have an enrolment or progress (optionally for a single course, group or line manager), paginated
+-- name: ListProgressUsers :many
+WITH filtered_users AS (
+  SELECT
+    u.id,
+    u.name,
+    u.email,
+    lower(
+      CASE WHEN sqlc.arg('sort_by')::text = 'email' THEN COALESCE(u.email, '') ELSE COALESCE(u.name, '') END
+    )::text AS sort_key
+  FROM users u
+  WHERE (
+      sqlc.narg('search')::text IS NULL
+      OR u.name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+      OR u.email ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    )
+    AND (
+      EXISTS (
+        SELECT 1 FROM usercourses uc
+        WHERE uc.user_id = u.id AND (sqlc.narg('course_id')::uuid IS NULL OR uc.course_id = sqlc.narg('course_id'))
+      )
+      OR EXISTS (
+        SELECT 1 FROM userprogress up
+        WHERE up.user_id = u.id AND (sqlc.narg('course_id')::uuid IS NULL OR up.course_id = sqlc.narg('course_id'))
+      )
+    )
+    AND (
+      sqlc.narg('group_id')::uuid IS NULL
+      OR EXISTS (SELECT 1 FROM user_groups ug WHERE ug.user_id = u.id AND ug.group_id = sqlc.narg('group_id'))
+    )
+    AND (
+      sqlc.narg('manager_id')::text IS NULL
+      OR EXISTS (SELECT 1 FROM line_managers lm WHERE lm.user_id = u.id AND lm.manager_id = sqlc.narg('manager_id'))
+    )
+)
+SELECT
+  fu.id,
+  fu.name,
+  fu.email,
+  (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('id', g.id, 'name', g.name) ORDER BY g.name), '[]'::jsonb)
+    FROM user_groups ug
+    INNER JOIN groups g ON g.id = ug.group_id
+    WHERE ug.user_id = fu.id
+  )::jsonb AS groups,
+  fu.sort_key
+FROM filtered_users fu
+WHERE sqlc.narg('cursor_id')::text IS NULL
+  OR (NOT sqlc.arg('sort_desc')::bool AND (fu.sort_key, fu.id) > (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+  OR (sqlc.arg('sort_desc')::bool AND (fu.sort_key, fu.id) < (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+ORDER BY
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fu.sort_key END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fu.id END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fu.sort_key END DESC,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fu.id END DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: CountProgressUsers :one
+SELECT COUNT(*)
+FROM users u
+WHERE (
+    sqlc.narg('search')::text IS NULL
+    OR u.name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    OR u.email ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+  )
+  AND (
+    EXISTS (
+      SELECT 1 FROM usercourses uc
+      WHERE uc.user_id = u.id AND (sqlc.narg('course_id')::uuid IS NULL OR uc.course_id = sqlc.narg('course_id'))
+    )
+    OR EXISTS (
+      SELECT 1 FROM userprogress up
+      WHERE up.user_id = u.id AND (sqlc.narg('course_id')::uuid IS NULL OR up.course_id = sqlc.narg('course_id'))
+    )
+  )
+  AND (
+    sqlc.narg('group_id')::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM user_groups ug WHERE ug.user_id = u.id AND ug.group_id = sqlc.narg('group_id'))
+  )
+  AND (
+    sqlc.narg('manager_id')::text IS NULL
+    OR EXISTS (SELECT 1 FROM line_managers lm WHERE lm.user_id = u.id AND lm.manager_id = sqlc.narg('manager_id'))
+  );
+
+-- name: GetProgressForUsers :many
+SELECT
+  COALESCE(uc.user_id, up.user_id) AS user_id,
+  COALESCE(uc.course_id, up.course_id) AS course_id,
+  u.name as user_name,
+  u.email,
+  c.title as course_title,
+  up.completed_intro,
+  up.completed_section_ids,
+  up.completed_course
+FROM usercourses uc
+FULL OUTER JOIN userprogress up
+  ON uc.user_id = up.user_id
+  AND uc.course_id = up.course_id
+LEFT JOIN users u
+  ON u.id = COALESCE(uc.user_id, up.user_id)
+LEFT JOIN courses c
+  ON c.id = COALESCE(uc.course_id, up.course_id)
+WHERE COALESCE(uc.user_id, up.user_id) = ANY(sqlc.arg('user_ids')::text[])
+  AND (sqlc.narg('course_id')::uuid IS NULL OR COALESCE(uc.course_id, up.course_id) = sqlc.narg('course_id'))
+ORDER BY c.title;
@@ -0,0 +1,69 @@
+-- name: GetResetCourse :one
+SELECT id, title FROM courses WHERE id = $1;
+
+-- name: GetQuizCourse :one
+SELECT c.id, c.title
+FROM quizsections q
+JOIN courses c ON c.id = q.course_id
+WHERE q.id = $1;
+
+-- name: GetResetUsers :many
+SELECT id, name, email
+FROM users
+WHERE id = ANY(sqlc.arg('user_ids')::text[]) AND erased_at IS NULL
+ORDER BY id;
+
+-- The cohort of a course is everyone enrolled on it
+-- name: GetCourseCohort :many
+SELECT u.id, u.name, u.email
+FROM usercourses uc
+JOIN users u ON u.id = uc.user_id
+WHERE uc.course_id = $1 AND u.erased_at IS NULL
+ORDER BY u.id;
+
+-- name: GetCourseQuizIDs :many
+SELECT id FROM quizsections WHERE course_id = $1;
+
+-- name: AddProgressReset :one
+INSERT INTO progress_resets (course_id, quiz_id, user_ids, reason, reset_by)
+VALUES (
+  sqlc.arg('course_id'),
+  sqlc.narg('quiz_id'),
+  sqlc.arg('user_ids'),
+  sqlc.arg('reason'),
+  sqlc.narg('reset_by')
+)
+RETURNING id;
+
+-- name: ArchiveProgress :exec
+INSERT INTO archived_progress (reset_id, user_id, course_id, completed_section_ids, completed_intro, completed_course)
+SELECT sqlc.arg('reset_id'), user_id, course_id, completed_section_ids, completed_intro, completed_course
+FROM userprogress
+WHERE course_id = sqlc.arg('course_id') AND user_id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- name: ResetProgressForUsers :exec
+UPDATE userprogress
+SET completed_section_ids = ARRAY[]::uuid[],
+    completed_intro = FALSE,
+    completed_course = FALSE
+WHERE course_id = sqlc.arg('course_id') AND user_id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- name: ArchiveQuizStates :exec
+INSERT INTO archived_quiz_states (reset_id, user_id, quiz_id, quiz_state, quiz_answers, attempts)
+SELECT sqlc.arg('reset_id'), user_id, quiz_id, quiz_state, quiz_answers, attempts
+FROM user_quiz_state
+WHERE quiz_id = ANY(sqlc.arg('quiz_ids')::uuid[]) AND user_id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- name: DeleteQuizStatesForUsers :exec
+DELETE FROM user_quiz_state
+WHERE quiz_id = ANY(sqlc.arg('quiz_ids')::uuid[]) AND user_id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- name: ArchiveQuizAttempts :exec
+INSERT INTO archived_quiz_attempts (reset_id, user_id, quiz_id, answers, attempt_number)
+SELECT sqlc.arg('reset_id'), user_id, quiz_id, answers, attempt_number
+FROM quiz_attempts
+WHERE quiz_id = ANY(sqlc.arg('quiz_ids')::uuid[]) AND user_id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- name: DeleteQuizAttemptsForUsers :exec
+DELETE FROM quiz_attempts
+WHERE quiz_id = ANY(sqlc.arg('quiz_ids')::uuid[]) AND user_id = ANY(sqlc.arg('user_ids')::text[]);
@@ -63,12 +63,6 @@ LEFT JOIN quizquestions qq ON qq.quiz_section_id = qs.id
 GROUP BY qs.id, qs.position, qs.course_id
 ORDER BY qs.course_id, qs.position;
 
--- name: DeleteUserQuizState :exec
-DELETE FROM user_quiz_state WHERE user_id = $1 AND quiz_id = $2;
-
--- name: DeleteQuizAttempts :exec
-DELETE FROM quiz_attempts WHERE user_id = $1 AND quiz_id = $2;
-
 -- name: UpsertQuizState :exec
 INSERT INTO user_quiz_state (user_id, quiz_id, quiz_answers)
 VALUES ($1, $2, $3)
@@ -112,3 +106,61 @@ SELECT
 FROM quizquestions qq
 WHERE qq.quiz_section_id = ANY($1::uuid[])
 ORDER BY qq.position;
+
+-- Quiz sections are sorted by course title and then by their position within the course
+-- name: ListQuizSections :many
+WITH filtered_sections AS (
+  SELECT
+    qs.id,
+    qs.position,
+    qs.course_id,
+    (lower(COALESCE(c.title, '')) || '#' || lpad(COALESCE(qs.position, 0)::text, 10, '0'))::text AS sort_key
+  FROM quizsections qs
+  LEFT JOIN courses c ON c.id = qs.course_id
+  WHERE (sqlc.narg('search')::text IS NULL OR c.title ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\')
+    AND (sqlc.narg('course_id')::uuid IS NULL OR qs.course_id = sqlc.narg('course_id'))
+)
+SELECT
+  fs.id,
+  fs.position,
+  fs.course_id,
+  json_agg(
+    json_build_object(
+      'id', qq.id,
+      'question', qq.question,
+      'position', qq.position,
+      'is_multi_answer', qq.is_multi_answer,
+      'answers', (
+        SELECT json_agg(
+          json_build_object(
+            'id', qa.id,
+            'answer', qa.answer,
+            'correct_answer', qa.correct_answer,
+            'position', qa.position
+          ) ORDER BY qa.position
+        )
+        FROM quizanswers qa
+        WHERE qa.quiz_question_id = qq.id
+      )
+    ) ORDER BY qq.position
+  ) AS questions,
+  fs.sort_key
+FROM filtered_sections fs
+LEFT JOIN quizquestions qq ON qq.quiz_section_id = fs.id
+WHERE sqlc.narg('cursor_id')::text IS NULL
+  OR (NOT sqlc.arg('sort_desc')::bool AND (fs.sort_key, fs.id::text) > (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+  OR (sqlc.arg('sort_desc')::bool AND (fs.sort_key, fs.id::text) < (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+GROUP BY fs.id, fs.position, fs.course_id, fs.sort_key
+ORDER BY
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fs.sort_key END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fs.id::text END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fs.sort_key END DESC,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fs.id::text END DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: CountQuizSections :one
+SELECT COUNT(*)
+FROM quizsections qs
+LEFT JOIN courses c ON c.id = qs.course_id
+WHERE (sqlc.narg('search')::text IS NULL OR c.title ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\')
+  AND (sqlc.narg('course_id')::uuid IS NULL OR qs.course_id = sqlc.narg('course_id'));
@@ -0,0 +1,68 @@
+-- name: GetUserRole :one
+SELECT role FROM user_roles WHERE user_id = $1;
+
+-- Users set back to the user role are left out, they have no granted role
+-- name: GetUserRoles :many
+SELECT
+  u.id,
+  u.name,
+  u.email,
+  ur.role,
+  ur.granted_by,
+  ur.granted_at,
+  (
+    SELECT COALESCE(jsonb_agg(gm.group_id ORDER BY gm.added_at), '[]'::jsonb)
+    FROM group_managers gm
+    WHERE gm.user_id = u.id
+  )::jsonb AS group_ids,
+  (
+    SELECT COALESCE(jsonb_agg(ci.course_id ORDER BY ci.assigned_at), '[]'::jsonb)
+    FROM course_instructors ci
+    WHERE ci.user_id = u.id
+  )::jsonb AS course_ids
+FROM user_roles ur
+INNER JOIN users u ON u.id = ur.user_id
+WHERE ur.role <> 'user'
+ORDER BY ur.role, lower(COALESCE(u.name, '')), u.id;
+
+-- name: SetUserRole :execrows
+INSERT INTO user_roles (user_id, role, granted_by)
+SELECT u.id, sqlc.arg('role'), sqlc.narg('granted_by')
+FROM users u
+WHERE u.id = sqlc.arg('user_id')
+ON CONFLICT (user_id)
+DO UPDATE SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by, granted_at = NOW();
+
+-- name: GetManagedGroupIDs :many
+SELECT group_id FROM group_managers WHERE user_id = $1 ORDER BY added_at;
+
+-- name: IsGroupManager :one
+SELECT EXISTS(SELECT 1 FROM group_managers WHERE user_id = $1 AND group_id = $2);
+
+-- Unknown users are skipped and existing managers are left as they are
+-- name: AddGroupManagers :exec
+INSERT INTO group_managers (user_id, group_id)
+SELECT u.id, sqlc.arg('group_id')::uuid
+FROM users u
+WHERE u.id = ANY(sqlc.arg('user_ids')::text[])
+ON CONFLICT (user_id, group_id) DO NOTHING;
+
+-- name: RemoveGroupManagers :exec
+DELETE FROM group_managers WHERE group_id = sqlc.arg('group_id') AND user_id = ANY(sqlc.arg('user_ids')::text[]);
+
+-- name: GetInstructedCourseIDs :many
+SELECT course_id FROM course_instructors WHERE user_id = $1 ORDER BY assigned_at;
+
+-- name: IsCourseInstructor :one
+SELECT EXISTS(SELECT 1 FROM course_instructors WHERE user_id = $1 AND course_id = $2);
+
+-- Unknown users are skipped and existing instructors are left as they are
+-- name: AssignCourseInstructors :exec
+INSERT INTO course_instructors (user_id, course_id)
+SELECT u.id, sqlc.arg('course_id')::uuid
+FROM users u
+WHERE u.id = ANY(sqlc.arg('user_ids')::text[])
+ON CONFLICT (user_id, course_id) DO NOTHING;
+
+-- name: UnassignCourseInstructors :exec
+DELETE FROM course_instructors WHERE course_id = sqlc.arg('course_id') AND user_id = ANY(sqlc.arg('user_ids')::text[]);
@@ -1,3 +1,70 @@
-
 -- name: GetUser :one
-SELECT id, name, email FROM users WHERE id = $1;
+SELECT id, name, email, deactivated_at, erased_at FROM users WHERE id = $1;
+
+-- name: GetUserByEmail :one
+SELECT id, name, email, deactivated_at, erased_at FROM users WHERE LOWER(email) = LOWER($1::text);
+
+-- name: ListUsers :many
+WITH filtered_users AS (
+  SELECT
+    u.id,
+    u.name,
+    u.email,
+    u.deactivated_at,
+    eb.bounced_at AS email_bounced_at,
+    lower(
+      CASE WHEN sqlc.arg('sort_by')::text = 'email' THEN COALESCE(u.email, '') ELSE COALESCE(u.name, '') END
+    )::text AS sort_key
+  FROM users u
+  LEFT JOIN email_bounces eb ON eb.email = lower(u.email)
+  WHERE u.erased_at IS NULL
+    AND (
+      sqlc.narg('search')::text IS NULL
+      OR u.name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+      OR u.email ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    )
+    AND (
+      sqlc.narg('deactivated')::bool IS NULL
+      OR (u.deactivated_at IS NOT NULL) = sqlc.narg('deactivated')
+    )
+)
+SELECT fu.id, fu.name, fu.email, fu.deactivated_at, fu.email_bounced_at, fu.sort_key
+FROM filtered_users fu
+WHERE sqlc.narg('cursor_id')::text IS NULL
+  OR (NOT sqlc.arg('sort_desc')::bool AND (fu.sort_key, fu.id) > (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+  OR (sqlc.arg('sort_desc')::bool AND (fu.sort_key, fu.id) < (sqlc.narg('cursor_key')::text, sqlc.narg('cursor_id')))
+ORDER BY
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fu.sort_key END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN NULL ELSE fu.id END,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fu.sort_key END DESC,
+  CASE WHEN sqlc.arg('sort_desc')::bool THEN fu.id END DESC
+LIMIT sqlc.arg('page_limit');
+
+-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users u
+WHERE u.erased_at IS NULL
+  AND (
+    sqlc.narg('search')::text IS NULL
+    OR u.name ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+    OR u.email ILIKE '%' || sqlc.narg('search') || '%' ESCAPE '\'
+  )
+  AND (
+    sqlc.narg('deactivated')::bool IS NULL
+    OR (u.deactivated_at IS NOT NULL) = sqlc.narg('deactivated')
+  );
+
+-- name: UpdateUser :one
+UPDATE users
+SET name = sqlc.arg('name'), email = sqlc.arg('email')
+WHERE id = sqlc.arg('id')
+RETURNING id, name, email, deactivated_at, erased_at;
+
+-- Deactivating an already deactivated user keeps the original deactivation time
+-- name: SetUserDeactivated :execrows
+UPDATE users
+SET deactivated_at = CASE WHEN sqlc.arg('deactivated')::bool THEN COALESCE(deactivated_at, NOW()) END
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteUser :execrows
+DELETE FROM users WHERE id = $1;
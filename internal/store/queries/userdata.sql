@@ -0,0 +1,75 @@
+-- name: ExportUserEnrolments :many
+SELECT uc.course_id, c.title AS course_title, uc.enrolled_at, uc.enrolled_by, uc.due_at, uc.overdue_at
+FROM usercourses uc
+INNER JOIN courses c ON c.id = uc.course_id
+WHERE uc.user_id = $1
+ORDER BY uc.enrolled_at;
+
+-- name: ExportUserProgress :many
+SELECT up.course_id, c.title AS course_title, up.completed_section_ids, up.completed_intro, up.completed_course
+FROM userprogress up
+INNER JOIN courses c ON c.id = up.course_id
+WHERE up.user_id = $1
+ORDER BY c.title;
+
+-- name: ExportUserQuizStates :many
+SELECT quiz_id, quiz_state, quiz_answers, attempts
+FROM user_quiz_state
+WHERE user_id = $1
+ORDER BY quiz_id;
+
+-- name: ExportUserQuizAttempts :many
+SELECT quiz_id, attempt_number, answers
+FROM quiz_attempts
+WHERE user_id = $1
+ORDER BY quiz_id, attempt_number;
+
+-- name: ExportUserGroups :many
+SELECT g.id AS group_id, g.name AS group_name, ug.added_at
+FROM user_groups ug
+INNER JOIN groups g ON g.id = ug.group_id
+WHERE ug.user_id = $1
+ORDER BY g.name;
+
+-- name: ExportUserAccessCodeRedemptions :many
+SELECT ac.code, ac.course_id, r.redeemed_at
+FROM course_access_code_redemptions r
+INNER JOIN course_access_codes ac ON ac.id = r.access_code_id
+WHERE r.user_id = $1
+ORDER BY r.redeemed_at;
+
+-- Emails are matched on the learner email stored in their template params
+-- name: ExportUserEmails :many
+SELECT id, created_at, email_name, template_params, status, last_error, sent_at
+FROM email_outbox
+WHERE LOWER(template_params->>'user_email') = LOWER(sqlc.arg('email')::text)
+ORDER BY created_at;
+
+-- name: InsertErasedUser :exec
+INSERT INTO users (id, deactivated_at, erased_at) VALUES ($1, NOW(), NOW());
+
+-- Only completed courses are kept for an erased user, with the enrolment they were completed under
+-- name: MoveCompletedProgress :execrows
+UPDATE userprogress
+SET user_id = sqlc.arg('erased_user_id')
+WHERE user_id = sqlc.arg('user_id') AND completed_course = TRUE;
+
+-- name: MoveCompletedEnrolments :exec
+UPDATE usercourses uc
+SET user_id = sqlc.arg('erased_user_id')
+WHERE uc.user_id = sqlc.arg('user_id')
+  AND EXISTS (
+    SELECT 1 FROM userprogress up
+    WHERE up.user_id = sqlc.arg('erased_user_id') AND up.course_id = uc.course_id
+  );
+
+-- name: DeleteUserEmails :exec
+DELETE FROM email_outbox
+WHERE LOWER(template_params->>'user_email') = LOWER(sqlc.arg('email')::text);
+
+-- Events for emails still in the outbox are deleted with them
+-- name: DeleteUserEmailEvents :exec
+DELETE FROM email_events WHERE LOWER(recipient) = LOWER(sqlc.arg('email')::text);
+
+-- name: DeleteUserEmailBounce :exec
+DELETE FROM email_bounces WHERE email = LOWER(sqlc.arg('email')::text);
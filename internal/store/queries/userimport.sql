@@ -0,0 +1,14 @@
+-- name: AddUserImport :one
+INSERT INTO user_imports (report, created_by)
+VALUES (sqlc.arg('report'), sqlc.narg('created_by'))
+RETURNING id;
+
+-- name: CompleteUserImport :execrows
+UPDATE user_imports
+SET status = 'completed', report = sqlc.arg('report'), completed_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: GetUserImport :one
+SELECT id, status, report, created_by, created_at, completed_at
+FROM user_imports
+WHERE id = $1;
@@ -33,7 +33,7 @@ type SqlcQuizAnswer struct {
 
 func (s *Store) GetQuizSections(ctx context.Context, courseID pgtype.UUID) ([]*domain.QuizSection, error) {
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetCourseQuizSectionsRow, error) {
-		return s.Queries.GetCourseQuizSections(ctx, courseID)
+		return s.queries(ctx).GetCourseQuizSections(ctx, courseID)
 	})
 	if err != nil {
 		return nil, err
@@ -111,7 +111,7 @@ func (s *Store) SaveQuizAttempt(ctx context.Context, params domain.SaveQuizAttem
 	}
 
 	return ExecCommand(ctx, func() error {
-		tx, err := s.pool.Begin(ctx)
+		tx, err := s.begin(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
@@ -136,7 +136,7 @@ func (s *Store) SaveQuizAttempt(ctx context.Context, params domain.SaveQuizAttem
 
 func (s *Store) GetQuizAttemptsByUserID(ctx context.Context, userID string) ([]*domain.QuizAttempts, error) {
 	attemptRows, err := ExecQuery(ctx, func() ([]sqlc.GetQuizAttemptsByUserIDRow, error) {
-		return s.Queries.GetQuizAttemptsByUserID(ctx, userID)
+		return s.queries(ctx).GetQuizAttemptsByUserID(ctx, userID)
 	})
 	if err != nil {
 		return nil, err
@@ -179,7 +179,7 @@ func (s *Store) GetQuizAttemptsByUserID(ctx context.Context, userID string) ([]*
 
 func (s *Store) GetAllQuizSections(ctx context.Context) ([]*domain.QuizSection, error) {
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetAllQuizSectionsRow, error) {
-		return s.Queries.GetAllQuizSections(ctx)
+		return s.queries(ctx).GetAllQuizSections(ctx)
 	})
 	if err != nil {
 		return nil, err
@@ -195,33 +195,49 @@ func (s *Store) GetAllQuizSections(ctx context.Context) ([]*domain.QuizSection,
 	return sections, nil
 }
 
-func (s *Store) ResetQuizProgress(ctx context.Context, userID string, quizID uuid.UUID) error {
-	pgQuizID := utils.PGUUIDFromUUID(quizID)
-	return ExecCommand(ctx, func() error {
-		tx, err := s.pool.Begin(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to begin transaction: %w", err)
-		}
-		defer tx.Rollback(ctx) //nolint:errcheck
-
-		qtx := s.Queries.WithTx(tx)
+func (s *Store) ListQuizSections(ctx context.Context, params domain.PageParams) (*domain.Page[*domain.QuizSection], error) {
+	args := pageArgsFrom(params)
 
-		if err := qtx.DeleteUserQuizState(ctx, sqlc.DeleteUserQuizStateParams{
-			UserID: userID,
-			QuizID: pgQuizID,
-		}); err != nil {
-			return fmt.Errorf("failed to delete user quiz state: %w", err)
-		}
-
-		if err := qtx.DeleteQuizAttempts(ctx, sqlc.DeleteQuizAttemptsParams{
-			UserID: userID,
-			QuizID: pgQuizID,
-		}); err != nil {
-			return fmt.Errorf("failed to delete quiz attempts: %w", err)
-		}
+	total, err := ExecQuery(ctx, func() (int64, error) {
+		return s.queries(ctx).CountQuizSections(ctx, sqlc.CountQuizSectionsParams{
+			Search:   args.Search,
+			CourseID: args.CourseID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		return tx.Commit(ctx)
+	rows, err := ExecQuery(ctx, func() ([]sqlc.ListQuizSectionsRow, error) {
+		return s.queries(ctx).ListQuizSections(ctx, sqlc.ListQuizSectionsParams{
+			Search:    args.Search,
+			CourseID:  args.CourseID,
+			CursorID:  args.CursorID,
+			SortDesc:  args.SortDesc,
+			CursorKey: args.CursorKey,
+			PageLimit: args.PageLimit,
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pageFrom(
+		rows,
+		params,
+		total,
+		func(row sqlc.ListQuizSectionsRow) domain.Cursor {
+			return domain.Cursor{Key: row.SortKey, ID: utils.UUIDFrom(row.ID).String()}
+		},
+		func(row sqlc.ListQuizSectionsRow) (*domain.QuizSection, error) {
+			return quizSectionFrom(sqlc.GetCourseQuizSectionsRow{
+				ID:        row.ID,
+				Position:  row.Position,
+				CourseID:  row.CourseID,
+				Questions: row.Questions,
+			})
+		},
+	)
 }
 
 func (s *Store) SetQuizState(ctx context.Context, params domain.SetQuizStateParams) error {
@@ -232,7 +248,7 @@ func (s *Store) SetQuizState(ctx context.Context, params domain.SetQuizStatePara
 	}
 
 	return ExecCommand(ctx, func() error {
-		return s.Queries.SetQuizState(ctx, sqlcParams)
+		return s.queries(ctx).SetQuizState(ctx, sqlcParams)
 	})
 }
 
@@ -244,7 +260,7 @@ func (s *Store) UpsertQuizState(ctx context.Context, params domain.UpsertQuizSta
 	}
 
 	return ExecCommand(ctx, func() error {
-		return s.Queries.UpsertQuizState(ctx, sqlcParams)
+		return s.queries(ctx).UpsertQuizState(ctx, sqlcParams)
 	})
 }
 
@@ -262,7 +278,7 @@ func (s *Store) GetCurrentQuizAnswersByUserID(ctx context.Context, userID string
 	}
 
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetCurrentQuizAnswersByUserIDRow, error) {
-		return s.Queries.GetCurrentQuizAnswersByUserID(ctx, userID)
+		return s.queries(ctx).GetCurrentQuizAnswersByUserID(ctx, userID)
 	})
 	if err != nil {
 		return nil, err
@@ -287,7 +303,7 @@ func (s *Store) GetCurrentQuizAnswersByUserID(ctx context.Context, userID string
 
 func (s *Store) GetQuizState(ctx context.Context, userID string, quizID uuid.UUID) (*domain.QuizState, error) {
 	row, err := ExecQuery(ctx, func() (sqlc.GetQuizStateRow, error) {
-		return s.Queries.GetQuizState(ctx, sqlc.GetQuizStateParams{
+		return s.queries(ctx).GetQuizState(ctx, sqlc.GetQuizStateParams{
 			UserID: userID,
 			QuizID: utils.PGUUIDFromUUID(quizID),
 		})
@@ -315,7 +331,7 @@ func (s *Store) GetQuizQuestions(ctx context.Context, sectionIDs []uuid.UUID) ([
 	pgSectionIDs := utils.Map(sectionIDs, utils.PGUUIDFromUUID)
 
 	rows, err := ExecQuery(ctx, func() ([]sqlc.GetQuizQuestionsBySectionIDsRow, error) {
-		return s.Queries.GetQuizQuestionsBySectionIDs(ctx, pgSectionIDs)
+		return s.queries(ctx).GetQuizQuestionsBySectionIDs(ctx, pgSectionIDs)
 	})
 	if err != nil {
 		return nil, err
@@ -350,7 +366,7 @@ func quizQuestionLegacyFrom(row *sqlc.GetQuizQuestionsBySectionIDsRow) (*domain.
 
 func (s *Store) getCompletedSectionIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
 	rows, err := ExecQuery(ctx, func() ([][]pgtype.UUID, error) {
-		return s.Queries.GetCompletedSectionIDsByUserID(ctx, userID)
+		return s.queries(ctx).GetCompletedSectionIDsByUserID(ctx, userID)
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/supanova-rp/supanova-server/internal/config"
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+func (s *Store) GetUserRole(ctx context.Context, userID string) (config.Role, error) {
+	role, err := ExecQuery(ctx, func() (string, error) {
+		return s.queries(ctx).GetUserRole(ctx, userID)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return config.Role(role), nil
+}
+
+func (s *Store) GetUserRoles(ctx context.Context) ([]domain.UserRole, error) {
+	rows, err := ExecQuery(ctx, func() ([]sqlc.GetUserRolesRow, error) {
+		return s.queries(ctx).GetUserRoles(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MapToWithError(rows, func(row sqlc.GetUserRolesRow) (domain.UserRole, error) {
+		var scope domain.RoleScope
+		if err := json.Unmarshal(row.GroupIds, &scope.ManagedGroupIDs); err != nil {
+			return domain.UserRole{}, fmt.Errorf("failed to unmarshal group IDs: %w", err)
+		}
+
+		if err := json.Unmarshal(row.CourseIds, &scope.InstructedCourseIDs); err != nil {
+			return domain.UserRole{}, fmt.Errorf("failed to unmarshal course IDs: %w", err)
+		}
+
+		return domain.UserRole{
+			UserID:    row.ID,
+			Name:      row.Name.String,
+			Email:     row.Email.String,
+			Role:      config.Role(row.Role),
+			GrantedBy: row.GrantedBy.String,
+			GrantedAt: row.GrantedAt.Time,
+			RoleScope: scope,
+		}, nil
+	})
+}
+
+// SetUserRole grants the role to the user, replacing any role granted before. The user role is
+// stored too, so it overrides the admin claim from the auth provider.
+func (s *Store) SetUserRole(ctx context.Context, params domain.SetUserRoleParams) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).SetUserRole(ctx, sqlc.SetUserRoleParams{
+			Role:      string(params.Role),
+			GrantedBy: utils.PGTextFrom(params.GrantedBy),
+			UserID:    params.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) GetRoleScope(ctx context.Context, userID string) (*domain.RoleScope, error) {
+	groupIDs, err := ExecQuery(ctx, func() ([]uuid.UUID, error) {
+		ids, err := s.queries(ctx).GetManagedGroupIDs(ctx, userID)
+		return utils.Map(ids, utils.UUIDFrom), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	courseIDs, err := ExecQuery(ctx, func() ([]uuid.UUID, error) {
+		ids, err := s.queries(ctx).GetInstructedCourseIDs(ctx, userID)
+		return utils.Map(ids, utils.UUIDFrom), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RoleScope{
+		ManagedGroupIDs:     groupIDs,
+		InstructedCourseIDs: courseIDs,
+	}, nil
+}
+
+func (s *Store) IsGroupManager(ctx context.Context, params domain.IsGroupManagerParams) (bool, error) {
+	return ExecQuery(ctx, func() (bool, error) {
+		return s.queries(ctx).IsGroupManager(ctx, sqlc.IsGroupManagerParams{
+			UserID:  params.UserID,
+			GroupID: utils.PGUUIDFromUUID(params.GroupID),
+		})
+	})
+}
+
+// AddGroupManagers lets the users view progress for the group. Unknown users are skipped.
+func (s *Store) AddGroupManagers(ctx context.Context, params domain.GroupMembersParams) error {
+	return ExecCommand(ctx, func() error {
+		groupID := utils.PGUUIDFromUUID(params.GroupID)
+
+		if err := groupExists(ctx, s.queries(ctx), groupID); err != nil {
+			return err
+		}
+
+		return s.queries(ctx).AddGroupManagers(ctx, sqlc.AddGroupManagersParams{
+			GroupID: groupID,
+			UserIds: params.UserIDs,
+		})
+	})
+}
+
+func (s *Store) RemoveGroupManagers(ctx context.Context, params domain.GroupMembersParams) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).RemoveGroupManagers(ctx, sqlc.RemoveGroupManagersParams{
+			GroupID: utils.PGUUIDFromUUID(params.GroupID),
+			UserIds: params.UserIDs,
+		})
+	})
+}
+
+func (s *Store) IsCourseInstructor(ctx context.Context, params domain.IsCourseInstructorParams) (bool, error) {
+	return ExecQuery(ctx, func() (bool, error) {
+		return s.queries(ctx).IsCourseInstructor(ctx, sqlc.IsCourseInstructorParams{
+			UserID:   params.UserID,
+			CourseID: utils.PGUUIDFromUUID(params.CourseID),
+		})
+	})
+}
+
+// AssignCourseInstructors lets the users edit the course. Unknown users are skipped.
+func (s *Store) AssignCourseInstructors(ctx context.Context, params domain.CourseInstructorsParams) error {
+	return ExecCommand(ctx, func() error {
+		courseID := utils.PGUUIDFromUUID(params.CourseID)
+
+		exists, err := s.queries(ctx).CourseExists(ctx, courseID)
+		if err != nil {
+			return fmt.Errorf("failed to get course: %w", err)
+		}
+
+		if !exists {
+			return pgx.ErrNoRows
+		}
+
+		return s.queries(ctx).AssignCourseInstructors(ctx, sqlc.AssignCourseInstructorsParams{
+			CourseID: courseID,
+			UserIds:  params.UserIDs,
+		})
+	})
+}
+
+func (s *Store) UnassignCourseInstructors(ctx context.Context, params domain.CourseInstructorsParams) error {
+	return ExecCommand(ctx, func() error {
+		return s.queries(ctx).UnassignCourseInstructors(ctx, sqlc.UnassignCourseInstructorsParams{
+			CourseID: utils.PGUUIDFromUUID(params.CourseID),
+			UserIds:  params.UserIDs,
+		})
+	})
+}
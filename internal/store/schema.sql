@@ -1,7 +1,9 @@
 CREATE TABLE users (
   id TEXT PRIMARY KEY,
   name TEXT,
-  email TEXT
+  email TEXT,
+  deactivated_at TIMESTAMPTZ,
+  erased_at TIMESTAMPTZ
 );
 
 CREATE TABLE courses (
@@ -9,7 +11,8 @@ CREATE TABLE courses (
   title TEXT,
   description TEXT,
   completion_title TEXT,
-  completion_message TEXT
+  completion_message TEXT,
+  certificate_validity_months INT
 );
 
 CREATE TABLE course_materials (
@@ -64,9 +67,17 @@ CREATE TABLE usercourses (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
   user_id TEXT,
   course_id UUID NOT NULL,
+  enrolled_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  enrolled_by TEXT,
+  due_at TIMESTAMPTZ,
+  overdue_at TIMESTAMPTZ,
+  notified_at TIMESTAMPTZ,
+  reminded_at TIMESTAMPTZ,
 
   CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
-  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_enrolled_by FOREIGN KEY(enrolled_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT usercourses_user_id_course_id_key UNIQUE (user_id, course_id)
 );
 
 CREATE TABLE userprogress (
@@ -95,15 +106,60 @@ CREATE TABLE user_quiz_state (
   CONSTRAINT userquizstate_user_quiz_unique UNIQUE (user_id, quiz_id)
 );
 
-CREATE TABLE email_failures (
+CREATE TABLE email_outbox (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
-  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-  error TEXT NOT NULL,
+  email_name TEXT NOT NULL,
   template_name TEXT NOT NULL,
   template_params JSONB NOT NULL DEFAULT '{}'::jsonb,
-  email_name TEXT NOT NULL,
-  retries INT NOT NULL DEFAULT 5 
+  status TEXT NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMPTZ,
+  provider_message_id TEXT,
+
+  CONSTRAINT email_outbox_status_check CHECK (status IN ('pending', 'sent', 'skipped', 'dead', 'discarded'))
+);
+
+CREATE INDEX email_outbox_pending_idx ON email_outbox(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX email_outbox_failed_idx ON email_outbox(created_at) WHERE status IN ('pending', 'dead') AND last_error IS NOT NULL;
+
+CREATE TABLE email_attempts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  email_id UUID NOT NULL,
+  attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  error TEXT,
+
+  CONSTRAINT fk_email_outbox FOREIGN KEY(email_id) REFERENCES email_outbox(id) ON DELETE CASCADE
+);
+
+CREATE INDEX email_attempts_email_id_idx ON email_attempts(email_id, attempted_at);
+
+CREATE INDEX email_outbox_provider_message_id_idx ON email_outbox(provider_message_id) WHERE provider_message_id IS NOT NULL;
+
+CREATE TABLE email_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  provider_event_id TEXT NOT NULL,
+  email_id UUID,
+  message_id TEXT NOT NULL,
+  event TEXT NOT NULL,
+  recipient TEXT NOT NULL,
+  reason TEXT,
+  occurred_at TIMESTAMPTZ NOT NULL,
+  received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT email_events_provider_event_id_unique UNIQUE (provider_event_id),
+  CONSTRAINT fk_email_outbox FOREIGN KEY(email_id) REFERENCES email_outbox(id) ON DELETE CASCADE,
+  CONSTRAINT email_events_event_check CHECK (event IN ('delivered', 'deferred', 'bounced', 'complained'))
+);
+
+CREATE INDEX email_events_email_id_idx ON email_events(email_id);
+
+CREATE TABLE email_bounces (
+  email TEXT PRIMARY KEY NOT NULL,
+  bounced_at TIMESTAMPTZ NOT NULL,
+  reason TEXT
 );
 
 CREATE TABLE quiz_attempts (
@@ -117,3 +173,254 @@ CREATE TABLE quiz_attempts (
   CONSTRAINT fk_quizsections FOREIGN KEY(quiz_id) REFERENCES quizsections(id) ON DELETE CASCADE,
   CONSTRAINT quiz_attempts_user_quiz_attempt_unique UNIQUE (user_id, quiz_id, attempt_number)
 );
+
+CREATE TABLE groups (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  name TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT groups_name_unique UNIQUE (name)
+);
+
+CREATE TABLE user_groups (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  user_id TEXT NOT NULL,
+  group_id UUID NOT NULL,
+  added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_groups FOREIGN KEY(group_id) REFERENCES groups(id) ON DELETE CASCADE,
+  CONSTRAINT user_groups_user_group_unique UNIQUE (user_id, group_id)
+);
+
+CREATE TABLE group_courses (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  group_id UUID NOT NULL,
+  course_id UUID NOT NULL,
+  due_in_days INT,
+  assigned_by TEXT,
+  assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_groups FOREIGN KEY(group_id) REFERENCES groups(id) ON DELETE CASCADE,
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_assigned_by FOREIGN KEY(assigned_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT group_courses_group_course_unique UNIQUE (group_id, course_id)
+);
+
+CREATE TABLE course_access_codes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  code TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  expires_at TIMESTAMPTZ,
+  max_uses INT,
+  uses INT NOT NULL DEFAULT 0,
+  created_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  revoked_at TIMESTAMPTZ,
+
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT course_access_codes_code_unique UNIQUE (code)
+);
+
+CREATE TABLE course_access_code_redemptions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  access_code_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  redeemed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_course_access_codes FOREIGN KEY(access_code_id) REFERENCES course_access_codes(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT course_access_code_redemptions_code_user_unique UNIQUE (access_code_id, user_id)
+);
+
+CREATE TABLE user_roles (
+  user_id TEXT PRIMARY KEY NOT NULL,
+  role TEXT NOT NULL,
+  granted_by TEXT,
+  granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_granted_by FOREIGN KEY(granted_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT user_roles_role_check CHECK (role IN ('admin', 'manager', 'instructor', 'auditor', 'user'))
+);
+
+CREATE TABLE group_managers (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  user_id TEXT NOT NULL,
+  group_id UUID NOT NULL,
+  added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_groups FOREIGN KEY(group_id) REFERENCES groups(id) ON DELETE CASCADE,
+  CONSTRAINT group_managers_user_group_unique UNIQUE (user_id, group_id)
+);
+
+CREATE TABLE course_instructors (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  user_id TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT course_instructors_user_course_unique UNIQUE (user_id, course_id)
+);
+
+CREATE TABLE line_managers (
+  user_id TEXT PRIMARY KEY NOT NULL,
+  manager_id TEXT NOT NULL,
+  assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_managers FOREIGN KEY(manager_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT line_managers_not_self CHECK (user_id <> manager_id)
+);
+
+CREATE INDEX line_managers_manager_id_idx ON line_managers(manager_id);
+
+CREATE TABLE local_auth_users (
+  id TEXT PRIMARY KEY,
+  email TEXT NOT NULL,
+  name TEXT,
+  password_hash TEXT NOT NULL,
+  disabled BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX local_auth_users_email_idx ON local_auth_users (lower(email));
+
+CREATE TABLE api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  name TEXT NOT NULL,
+  key_prefix TEXT NOT NULL,
+  key_hash TEXT NOT NULL,
+  scopes TEXT[] NOT NULL,
+  created_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+
+  CONSTRAINT fk_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT api_keys_key_hash_unique UNIQUE (key_hash)
+);
+
+CREATE TABLE audit_log (
+  id BIGSERIAL PRIMARY KEY,
+  actor_id TEXT,
+  action TEXT NOT NULL,
+  target_type TEXT NOT NULL,
+  target_id TEXT NOT NULL,
+  before JSON,
+  after JSON,
+  created_at TIMESTAMPTZ NOT NULL,
+  prev_hash TEXT NOT NULL,
+  hash TEXT NOT NULL,
+
+  CONSTRAINT audit_log_hash_unique UNIQUE (hash)
+);
+
+CREATE INDEX audit_log_target_idx ON audit_log(target_type, target_id);
+CREATE INDEX audit_log_actor_id_idx ON audit_log(actor_id);
+
+CREATE TABLE progress_resets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  course_id UUID NOT NULL,
+  -- Only set when a single quiz was reset rather than the whole course
+  quiz_id UUID,
+  user_ids TEXT[] NOT NULL,
+  reason TEXT NOT NULL,
+  reset_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_quizsections FOREIGN KEY(quiz_id) REFERENCES quizsections(id) ON DELETE SET NULL,
+  CONSTRAINT fk_reset_by FOREIGN KEY(reset_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TABLE archived_progress (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  reset_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  completed_section_ids UUID[] NOT NULL,
+  completed_intro BOOLEAN,
+  completed_course BOOLEAN,
+
+  CONSTRAINT fk_progress_resets FOREIGN KEY(reset_id) REFERENCES progress_resets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE archived_quiz_states (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  reset_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  quiz_id UUID NOT NULL,
+  quiz_state JSONB NOT NULL,
+  quiz_answers JSONB NOT NULL,
+  attempts INT NOT NULL,
+
+  CONSTRAINT fk_progress_resets FOREIGN KEY(reset_id) REFERENCES progress_resets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE archived_quiz_attempts (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  reset_id UUID NOT NULL,
+  user_id TEXT NOT NULL,
+  quiz_id UUID NOT NULL,
+  answers JSONB NOT NULL,
+  attempt_number INT NOT NULL,
+
+  CONSTRAINT fk_progress_resets FOREIGN KEY(reset_id) REFERENCES progress_resets(id) ON DELETE CASCADE,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX archived_progress_user_id_idx ON archived_progress(user_id);
+CREATE INDEX archived_quiz_states_user_id_idx ON archived_quiz_states(user_id);
+CREATE INDEX archived_quiz_attempts_user_id_idx ON archived_quiz_attempts(user_id);
+
+CREATE TABLE certificates (
+  id UUID PRIMARY KEY NOT NULL,
+  number BIGSERIAL NOT NULL,
+  user_id TEXT NOT NULL,
+  course_id UUID NOT NULL,
+  storage_key TEXT NOT NULL,
+  issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  verification_id TEXT NOT NULL,
+  expires_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  revoked_by TEXT,
+  revoke_reason TEXT,
+  expiry_notified_at TIMESTAMPTZ,
+
+  CONSTRAINT certificates_number_unique UNIQUE (number),
+  CONSTRAINT certificates_verification_id_unique UNIQUE (verification_id),
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_courses FOREIGN KEY(course_id) REFERENCES courses(id) ON DELETE CASCADE,
+  CONSTRAINT fk_revoked_by FOREIGN KEY(revoked_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX certificates_user_course_idx ON certificates(user_id, course_id);
+
+CREATE TABLE user_email_opt_outs (
+  user_id TEXT NOT NULL,
+  email_name TEXT NOT NULL,
+  opted_out_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (user_id, email_name),
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_imports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid() NOT NULL,
+  status TEXT NOT NULL DEFAULT 'running',
+  report JSON NOT NULL,
+  created_by TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMPTZ,
+
+  CONSTRAINT fk_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL,
+  CONSTRAINT user_imports_status_check CHECK (status IN ('running', 'completed'))
+);
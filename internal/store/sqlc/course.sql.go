@@ -35,6 +35,30 @@ func (q *Queries) AddCourse(ctx context.Context, arg AddCourseParams) (pgtype.UU
 	return id, err
 }
 
+const countCourses = `-- name: CountCourses :one
+SELECT COUNT(*)
+FROM courses c
+WHERE $1::text IS NULL OR c.title ILIKE '%' || $1 || '%' ESCAPE '\'
+`
+
+func (q *Queries) CountCourses(ctx context.Context, search pgtype.Text) (int64, error) {
+	row := q.db.QueryRow(ctx, countCourses, search)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const courseExists = `-- name: CourseExists :one
+SELECT EXISTS(SELECT 1 FROM courses WHERE id = $1)
+`
+
+func (q *Queries) CourseExists(ctx context.Context, id pgtype.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, courseExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const deleteCourse = `-- name: DeleteCourse :exec
 DELETE FROM courses WHERE id = $1
 `
@@ -166,17 +190,27 @@ func (q *Queries) GetAllCourses(ctx context.Context) ([]GetAllCoursesRow, error)
 }
 
 const getAssignedCourseTitles = `-- name: GetAssignedCourseTitles :many
-SELECT c.id, c.title, c.description
+SELECT
+  c.id,
+  c.title,
+  c.description,
+  uc.due_at,
+  COALESCE(up.completed_course, FALSE)::bool AS completed_course,
+  (COALESCE(up.completed_intro, FALSE) OR COALESCE(cardinality(up.completed_section_ids), 0) > 0)::bool AS started
 FROM courses c
 INNER JOIN usercourses uc ON uc.course_id = c.id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = c.id
 WHERE uc.user_id = $1
 ORDER BY c.title
 `
 
 type GetAssignedCourseTitlesRow struct {
-	ID          pgtype.UUID
-	Title       pgtype.Text
-	Description pgtype.Text
+	ID              pgtype.UUID
+	Title           pgtype.Text
+	Description     pgtype.Text
+	DueAt           pgtype.Timestamptz
+	CompletedCourse bool
+	Started         bool
 }
 
 func (q *Queries) GetAssignedCourseTitles(ctx context.Context, userID pgtype.Text) ([]GetAssignedCourseTitlesRow, error) {
@@ -188,7 +222,14 @@ func (q *Queries) GetAssignedCourseTitles(ctx context.Context, userID pgtype.Tex
 	var items []GetAssignedCourseTitlesRow
 	for rows.Next() {
 		var i GetAssignedCourseTitlesRow
-		if err := rows.Scan(&i.ID, &i.Title, &i.Description); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.DueAt,
+			&i.CompletedCourse,
+			&i.Started,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -568,6 +609,116 @@ func (q *Queries) InsertVideoSection(ctx context.Context, arg InsertVideoSection
 	return err
 }
 
+const listCourses = `-- name: ListCourses :many
+SELECT
+  c.id,
+  c.title,
+  c.description,
+  c.completion_title,
+  c.completion_message,
+  (
+    SELECT json_agg(json_build_object(
+      'id', v.id,
+      'title', v.title,
+      'storage_key', v.storage_key,
+      'position', v.position
+    ) ORDER BY v.position)
+    FROM videosections v WHERE v.course_id = c.id
+  ) AS video_sections,
+  (
+    SELECT json_agg(json_build_object(
+      'id', q.id,
+      'position', q.position
+    ) ORDER BY q.position)
+    FROM quizsections q WHERE q.course_id = c.id
+  ) AS quiz_sections,
+  (
+    SELECT json_agg(json_build_object(
+      'id', m.id,
+      'name', m.name,
+      'storage_key', m.storage_key,
+      'position', m.position
+    ) ORDER BY m.position)
+    FROM course_materials m WHERE m.course_id = c.id
+  ) AS materials,
+  lower(COALESCE(c.title, ''))::text AS sort_key
+FROM courses c
+WHERE ($1::text IS NULL OR c.title ILIKE '%' || $1 || '%' ESCAPE '\')
+  AND (
+    $2::text IS NULL
+    OR (
+      NOT $3::bool
+      AND (lower(COALESCE(c.title, '')), c.id::text) > ($4::text, $2)
+    )
+    OR (
+      $3::bool
+      AND (lower(COALESCE(c.title, '')), c.id::text) < ($4::text, $2)
+    )
+  )
+ORDER BY
+  CASE WHEN $3::bool THEN NULL ELSE lower(COALESCE(c.title, '')) END,
+  CASE WHEN $3::bool THEN NULL ELSE c.id::text END,
+  CASE WHEN $3::bool THEN lower(COALESCE(c.title, '')) END DESC,
+  CASE WHEN $3::bool THEN c.id::text END DESC
+LIMIT $5
+`
+
+type ListCoursesParams struct {
+	Search    pgtype.Text
+	CursorID  pgtype.Text
+	SortDesc  bool
+	CursorKey pgtype.Text
+	PageLimit int32
+}
+
+type ListCoursesRow struct {
+	ID                pgtype.UUID
+	Title             pgtype.Text
+	Description       pgtype.Text
+	CompletionTitle   pgtype.Text
+	CompletionMessage pgtype.Text
+	VideoSections     []byte
+	QuizSections      []byte
+	Materials         []byte
+	SortKey           string
+}
+
+func (q *Queries) ListCourses(ctx context.Context, arg ListCoursesParams) ([]ListCoursesRow, error) {
+	rows, err := q.db.Query(ctx, listCourses,
+		arg.Search,
+		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorKey,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCoursesRow
+	for rows.Next() {
+		var i ListCoursesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CompletionTitle,
+			&i.CompletionMessage,
+			&i.VideoSections,
+			&i.QuizSections,
+			&i.Materials,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const removeDeletedSectionsFromProgress = `-- name: RemoveDeletedSectionsFromProgress :exec
 UPDATE userprogress
 SET completed_section_ids = (
@@ -11,63 +11,392 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const addFailedEmail = `-- name: AddFailedEmail :exec
-INSERT INTO email_failures (error, template_params, template_name, email_name) VALUES ($1, $2, $3, $4)
+const addEmailAttempt = `-- name: AddEmailAttempt :exec
+INSERT INTO email_attempts (email_id, error) VALUES ($1, $2)
 `
 
-type AddFailedEmailParams struct {
-	Error          string
-	TemplateParams []byte
-	TemplateName   string
-	EmailName      string
+type AddEmailAttemptParams struct {
+	EmailID pgtype.UUID
+	Error   pgtype.Text
 }
 
-func (q *Queries) AddFailedEmail(ctx context.Context, arg AddFailedEmailParams) error {
-	_, err := q.db.Exec(ctx, addFailedEmail,
-		arg.Error,
-		arg.TemplateParams,
-		arg.TemplateName,
-		arg.EmailName,
-	)
+// Error is NULL for an attempt that sent the email
+func (q *Queries) AddEmailAttempt(ctx context.Context, arg AddEmailAttemptParams) error {
+	_, err := q.db.Exec(ctx, addEmailAttempt, arg.EmailID, arg.Error)
 	return err
 }
 
-const deleteFailedEmail = `-- name: DeleteFailedEmail :exec
-DELETE FROM email_failures WHERE id = $1::uuid
+const addEmailEvent = `-- name: AddEmailEvent :execrows
+INSERT INTO email_events (provider_event_id, email_id, message_id, event, recipient, reason, occurred_at)
+VALUES (
+  $1,
+  (SELECT id FROM email_outbox WHERE provider_message_id = $2::text LIMIT 1),
+  $2,
+  $3,
+  $4,
+  $5,
+  $6
+)
+ON CONFLICT (provider_event_id) DO NOTHING
 `
 
-func (q *Queries) DeleteFailedEmail(ctx context.Context, dollar_1 pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteFailedEmail, dollar_1)
+type AddEmailEventParams struct {
+	ProviderEventID string
+	MessageID       string
+	Event           string
+	Recipient       string
+	Reason          pgtype.Text
+	OccurredAt      pgtype.Timestamptz
+}
+
+// Events are recorded against the sent message they're about. Events the provider resends are
+// ignored, so no rows are added.
+func (q *Queries) AddEmailEvent(ctx context.Context, arg AddEmailEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addEmailEvent,
+		arg.ProviderEventID,
+		arg.MessageID,
+		arg.Event,
+		arg.Recipient,
+		arg.Reason,
+		arg.OccurredAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addEmailOptOut = `-- name: AddEmailOptOut :exec
+INSERT INTO user_email_opt_outs (user_id, email_name) VALUES ($1, $2)
+ON CONFLICT (user_id, email_name) DO NOTHING
+`
+
+type AddEmailOptOutParams struct {
+	UserID    string
+	EmailName string
+}
+
+func (q *Queries) AddEmailOptOut(ctx context.Context, arg AddEmailOptOutParams) error {
+	_, err := q.db.Exec(ctx, addEmailOptOut, arg.UserID, arg.EmailName)
 	return err
 }
 
-const getFailedEmails = `-- name: GetFailedEmails :many
-SELECT id, template_params, template_name, email_name, retries FROM email_failures
+const claimDueEmails = `-- name: ClaimDueEmails :many
+UPDATE email_outbox
+SET next_attempt_at = NOW() + make_interval(secs => $1::int)
+WHERE id IN (
+  SELECT due.id
+  FROM email_outbox due
+  WHERE due.status = 'pending' AND due.next_attempt_at <= NOW()
+  ORDER BY due.next_attempt_at
+  LIMIT $2
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, email_name, template_name, template_params, attempts
 `
 
-type GetFailedEmailsRow struct {
+type ClaimDueEmailsParams struct {
+	LeaseSeconds int32
+	BatchSize    int32
+}
+
+type ClaimDueEmailsRow struct {
 	ID             pgtype.UUID
+	EmailName      string
+	TemplateName   string
 	TemplateParams []byte
+	Attempts       int32
+}
+
+// Claims the pending emails that are due to be sent, oldest first, by pushing their next attempt
+// back by the lease. Rows another server is claiming are skipped rather than waited for, so each
+// email is only sent by one server. If the server stops before sending, the email is sent once the
+// lease runs out.
+func (q *Queries) ClaimDueEmails(ctx context.Context, arg ClaimDueEmailsParams) ([]ClaimDueEmailsRow, error) {
+	rows, err := q.db.Query(ctx, claimDueEmails, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimDueEmailsRow
+	for rows.Next() {
+		var i ClaimDueEmailsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmailName,
+			&i.TemplateName,
+			&i.TemplateParams,
+			&i.Attempts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const clearEmailBounced = `-- name: ClearEmailBounced :exec
+DELETE FROM email_bounces
+WHERE email = LOWER($1::text) AND bounced_at < $2
+`
+
+type ClearEmailBouncedParams struct {
+	Email       string
+	DeliveredAt pgtype.Timestamptz
+}
+
+// An address stops bouncing once an email is delivered to it after it bounced
+func (q *Queries) ClearEmailBounced(ctx context.Context, arg ClearEmailBouncedParams) error {
+	_, err := q.db.Exec(ctx, clearEmailBounced, arg.Email, arg.DeliveredAt)
+	return err
+}
+
+const countFailedEmails = `-- name: CountFailedEmails :one
+SELECT COUNT(*)
+FROM email_outbox
+WHERE status IN ('pending', 'dead')
+  AND last_error IS NOT NULL
+  AND ($1::text IS NULL OR status = $1)
+  AND ($2::text IS NULL OR email_name ILIKE '%' || $2 || '%' ESCAPE '\')
+`
+
+type CountFailedEmailsParams struct {
+	Status pgtype.Text
+	Search pgtype.Text
+}
+
+func (q *Queries) CountFailedEmails(ctx context.Context, arg CountFailedEmailsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countFailedEmails, arg.Status, arg.Search)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFailedEmailsByStatus = `-- name: CountFailedEmailsByStatus :many
+SELECT status, COUNT(*) AS count
+FROM email_outbox
+WHERE status IN ('pending', 'dead') AND last_error IS NOT NULL
+GROUP BY status
+`
+
+type CountFailedEmailsByStatusRow struct {
+	Status string
+	Count  int64
+}
+
+// Failed emails waiting to be retried and dead-lettered, for monitoring
+func (q *Queries) CountFailedEmailsByStatus(ctx context.Context) ([]CountFailedEmailsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countFailedEmailsByStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountFailedEmailsByStatusRow
+	for rows.Next() {
+		var i CountFailedEmailsByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteEmailOptOut = `-- name: DeleteEmailOptOut :exec
+DELETE FROM user_email_opt_outs WHERE user_id = $1 AND email_name = $2
+`
+
+type DeleteEmailOptOutParams struct {
+	UserID    string
+	EmailName string
+}
+
+func (q *Queries) DeleteEmailOptOut(ctx context.Context, arg DeleteEmailOptOutParams) error {
+	_, err := q.db.Exec(ctx, deleteEmailOptOut, arg.UserID, arg.EmailName)
+	return err
+}
+
+const discardFailedEmail = `-- name: DiscardFailedEmail :execrows
+UPDATE email_outbox
+SET status = 'discarded'
+WHERE id = $1 AND status IN ('pending', 'dead') AND last_error IS NOT NULL
+`
+
+func (q *Queries) DiscardFailedEmail(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, discardFailedEmail, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const enqueueEmail = `-- name: EnqueueEmail :exec
+INSERT INTO email_outbox (email_name, template_name, template_params) VALUES ($1, $2, $3)
+`
+
+type EnqueueEmailParams struct {
+	EmailName      string
 	TemplateName   string
+	TemplateParams []byte
+}
+
+func (q *Queries) EnqueueEmail(ctx context.Context, arg EnqueueEmailParams) error {
+	_, err := q.db.Exec(ctx, enqueueEmail, arg.EmailName, arg.TemplateName, arg.TemplateParams)
+	return err
+}
+
+const getEmailAttempts = `-- name: GetEmailAttempts :many
+SELECT attempted_at, error
+FROM email_attempts
+WHERE email_id = $1
+ORDER BY attempted_at
+`
+
+type GetEmailAttemptsRow struct {
+	AttemptedAt pgtype.Timestamptz
+	Error       pgtype.Text
+}
+
+func (q *Queries) GetEmailAttempts(ctx context.Context, emailID pgtype.UUID) ([]GetEmailAttemptsRow, error) {
+	rows, err := q.db.Query(ctx, getEmailAttempts, emailID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEmailAttemptsRow
+	for rows.Next() {
+		var i GetEmailAttemptsRow
+		if err := rows.Scan(&i.AttemptedAt, &i.Error); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEmailOptOuts = `-- name: GetEmailOptOuts :many
+SELECT email_name FROM user_email_opt_outs WHERE user_id = $1 ORDER BY email_name
+`
+
+func (q *Queries) GetEmailOptOuts(ctx context.Context, userID string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getEmailOptOuts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var email_name string
+		if err := rows.Scan(&email_name); err != nil {
+			return nil, err
+		}
+		items = append(items, email_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isEmailOptedOut = `-- name: IsEmailOptedOut :one
+SELECT EXISTS(SELECT 1 FROM user_email_opt_outs WHERE user_id = $1 AND email_name = $2)
+`
+
+type IsEmailOptedOutParams struct {
+	UserID    string
+	EmailName string
+}
+
+func (q *Queries) IsEmailOptedOut(ctx context.Context, arg IsEmailOptedOutParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isEmailOptedOut, arg.UserID, arg.EmailName)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listFailedEmails = `-- name: ListFailedEmails :many
+SELECT id, email_name, template_name, template_params, status, attempts, next_attempt_at, last_error, created_at
+FROM email_outbox
+WHERE status IN ('pending', 'dead')
+  AND last_error IS NOT NULL
+  AND ($1::text IS NULL OR status = $1)
+  AND ($2::text IS NULL OR email_name ILIKE '%' || $2 || '%' ESCAPE '\')
+  AND (
+    $3::text IS NULL
+    OR (
+      NOT $4::bool
+      AND (created_at, id::text) > ($5::text::timestamptz, $3)
+    )
+    OR (
+      $4::bool
+      AND (created_at, id::text) < ($5::text::timestamptz, $3)
+    )
+  )
+ORDER BY
+  CASE WHEN $4::bool THEN NULL ELSE created_at END,
+  CASE WHEN $4::bool THEN NULL ELSE id::text END,
+  CASE WHEN $4::bool THEN created_at END DESC,
+  CASE WHEN $4::bool THEN id::text END DESC
+LIMIT $6
+`
+
+type ListFailedEmailsParams struct {
+	Status    pgtype.Text
+	Search    pgtype.Text
+	CursorID  pgtype.Text
+	SortDesc  bool
+	CursorKey pgtype.Text
+	PageLimit int32
+}
+
+type ListFailedEmailsRow struct {
+	ID             pgtype.UUID
 	EmailName      string
-	Retries        int32
+	TemplateName   string
+	TemplateParams []byte
+	Status         string
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamptz
+	LastError      pgtype.Text
+	CreatedAt      pgtype.Timestamptz
 }
 
-func (q *Queries) GetFailedEmails(ctx context.Context) ([]GetFailedEmailsRow, error) {
-	rows, err := q.db.Query(ctx, getFailedEmails)
+// Failed emails are those waiting to be retried after failing, and dead-lettered emails
+func (q *Queries) ListFailedEmails(ctx context.Context, arg ListFailedEmailsParams) ([]ListFailedEmailsRow, error) {
+	rows, err := q.db.Query(ctx, listFailedEmails,
+		arg.Status,
+		arg.Search,
+		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorKey,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetFailedEmailsRow
+	var items []ListFailedEmailsRow
 	for rows.Next() {
-		var i GetFailedEmailsRow
+		var i ListFailedEmailsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.TemplateParams,
-			&i.TemplateName,
 			&i.EmailName,
-			&i.Retries,
+			&i.TemplateName,
+			&i.TemplateParams,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -79,16 +408,106 @@ func (q *Queries) GetFailedEmails(ctx context.Context) ([]GetFailedEmailsRow, er
 	return items, nil
 }
 
-const updateFailedEmail = `-- name: UpdateFailedEmail :exec
-UPDATE email_failures SET retries = $1, error = $2, updated_at = NOW()
+const retryFailedEmail = `-- name: RetryFailedEmail :execrows
+UPDATE email_outbox
+SET status = 'pending', next_attempt_at = NOW()
+WHERE id = $1 AND status = 'dead'
 `
 
-type UpdateFailedEmailParams struct {
-	Retries int32
-	Error   string
+// Retried emails are sent by the next dispatch, they keep their attempts so one that fails again
+// is dead-lettered again. Only dead-lettered emails can be retried, pending ones are already
+// retried by the dispatcher, which may have claimed them.
+func (q *Queries) RetryFailedEmail(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, retryFailedEmail, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) UpdateFailedEmail(ctx context.Context, arg UpdateFailedEmailParams) error {
-	_, err := q.db.Exec(ctx, updateFailedEmail, arg.Retries, arg.Error)
+const setEmailBounced = `-- name: SetEmailBounced :exec
+INSERT INTO email_bounces (email, bounced_at, reason)
+VALUES (LOWER($1::text), $2, $3)
+ON CONFLICT (email) DO UPDATE
+SET bounced_at = EXCLUDED.bounced_at, reason = EXCLUDED.reason
+WHERE email_bounces.bounced_at < EXCLUDED.bounced_at
+`
+
+type SetEmailBouncedParams struct {
+	Email     string
+	BouncedAt pgtype.Timestamptz
+	Reason    pgtype.Text
+}
+
+// Keeps the latest bounce, events can arrive out of order
+func (q *Queries) SetEmailBounced(ctx context.Context, arg SetEmailBouncedParams) error {
+	_, err := q.db.Exec(ctx, setEmailBounced, arg.Email, arg.BouncedAt, arg.Reason)
+	return err
+}
+
+const setEmailDead = `-- name: SetEmailDead :exec
+UPDATE email_outbox
+SET status = 'dead', attempts = attempts + 1, last_error = $1
+WHERE id = $2 AND status = 'pending'
+`
+
+type SetEmailDeadParams struct {
+	Error pgtype.Text
+	ID    pgtype.UUID
+}
+
+// Dead-lettered emails aren't retried
+func (q *Queries) SetEmailDead(ctx context.Context, arg SetEmailDeadParams) error {
+	_, err := q.db.Exec(ctx, setEmailDead, arg.Error, arg.ID)
+	return err
+}
+
+const setEmailRetry = `-- name: SetEmailRetry :exec
+UPDATE email_outbox
+SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2
+WHERE id = $3 AND status = 'pending'
+`
+
+type SetEmailRetryParams struct {
+	Error         pgtype.Text
+	NextAttemptAt pgtype.Timestamptz
+	ID            pgtype.UUID
+}
+
+// Updates only the failed email, each email backs off from its own attempts
+func (q *Queries) SetEmailRetry(ctx context.Context, arg SetEmailRetryParams) error {
+	_, err := q.db.Exec(ctx, setEmailRetry, arg.Error, arg.NextAttemptAt, arg.ID)
+	return err
+}
+
+const setEmailSent = `-- name: SetEmailSent :exec
+UPDATE email_outbox
+SET
+  status = 'sent',
+  attempts = attempts + 1,
+  last_error = NULL,
+  sent_at = NOW(),
+  provider_message_id = $1
+WHERE id = $2 AND status = 'pending'
+`
+
+type SetEmailSentParams struct {
+	ProviderMessageID pgtype.Text
+	ID                pgtype.UUID
+}
+
+// Only pending emails are marked sent, so one discarded while it was being sent stays discarded
+func (q *Queries) SetEmailSent(ctx context.Context, arg SetEmailSentParams) error {
+	_, err := q.db.Exec(ctx, setEmailSent, arg.ProviderMessageID, arg.ID)
+	return err
+}
+
+const setEmailSkipped = `-- name: SetEmailSkipped :exec
+UPDATE email_outbox SET status = 'skipped' WHERE id = $1
+`
+
+// Emails the learner opted out of after they were queued aren't sent
+func (q *Queries) SetEmailSkipped(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, setEmailSkipped, id)
 	return err
 }
@@ -11,7 +11,39 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const disenrolInCourse = `-- name: DisenrolInCourse :exec
+const countUsersAndAssignedCourses = `-- name: CountUsersAndAssignedCourses :one
+SELECT COUNT(*)
+FROM users u
+WHERE u.erased_at IS NULL
+  AND (
+    $1::text IS NULL
+    OR u.name ILIKE '%' || $1 || '%' ESCAPE '\'
+    OR u.email ILIKE '%' || $1 || '%' ESCAPE '\'
+  )
+  AND (
+    $2::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM usercourses f WHERE f.user_id = u.id AND f.course_id = $2)
+  )
+  AND (
+    $3::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM user_groups f WHERE f.user_id = u.id AND f.group_id = $3)
+  )
+`
+
+type CountUsersAndAssignedCoursesParams struct {
+	Search   pgtype.Text
+	CourseID pgtype.UUID
+	GroupID  pgtype.UUID
+}
+
+func (q *Queries) CountUsersAndAssignedCourses(ctx context.Context, arg CountUsersAndAssignedCoursesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsersAndAssignedCourses, arg.Search, arg.CourseID, arg.GroupID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const disenrolInCourse = `-- name: DisenrolInCourse :execrows
 DELETE FROM usercourses WHERE user_id = $1 AND course_id = $2
 `
 
@@ -20,23 +52,281 @@ type DisenrolInCourseParams struct {
 	CourseID pgtype.UUID
 }
 
-func (q *Queries) DisenrolInCourse(ctx context.Context, arg DisenrolInCourseParams) error {
-	_, err := q.db.Exec(ctx, disenrolInCourse, arg.UserID, arg.CourseID)
-	return err
+func (q *Queries) DisenrolInCourse(ctx context.Context, arg DisenrolInCourseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, disenrolInCourse, arg.UserID, arg.CourseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const enrolInCourse = `-- name: EnrolInCourse :exec
-INSERT INTO usercourses (user_id, course_id) VALUES ($1, $2)
+const enrolInCourse = `-- name: EnrolInCourse :execrows
+INSERT INTO usercourses (user_id, course_id, enrolled_by, due_at)
+VALUES (
+  $1,
+  $2,
+  $3,
+  COALESCE($4, NOW() + make_interval(days => $5::int))
+)
+ON CONFLICT (user_id, course_id) DO NOTHING
 `
 
 type EnrolInCourseParams struct {
-	UserID   pgtype.Text
-	CourseID pgtype.UUID
+	UserID     pgtype.Text
+	CourseID   pgtype.UUID
+	EnrolledBy pgtype.Text
+	DueAt      pgtype.Timestamptz
+	DueInDays  pgtype.Int4
 }
 
-func (q *Queries) EnrolInCourse(ctx context.Context, arg EnrolInCourseParams) error {
-	_, err := q.db.Exec(ctx, enrolInCourse, arg.UserID, arg.CourseID)
-	return err
+// The due date is either absolute or a number of days after enrolment, or NULL if there isn't one.
+// Enrolling an already enrolled user is a no-op and returns 0 rows affected
+func (q *Queries) EnrolInCourse(ctx context.Context, arg EnrolInCourseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enrolInCourse,
+		arg.UserID,
+		arg.CourseID,
+		arg.EnrolledBy,
+		arg.DueAt,
+		arg.DueInDays,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getExistingCourseIDs = `-- name: GetExistingCourseIDs :many
+SELECT id FROM courses WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) GetExistingCourseIDs(ctx context.Context, courseIds []pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getExistingCourseIDs, courseIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []pgtype.UUID
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExistingUserIDs = `-- name: GetExistingUserIDs :many
+SELECT id FROM users WHERE id = ANY($1::text[])
+`
+
+func (q *Queries) GetExistingUserIDs(ctx context.Context, userIds []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getExistingUserIDs, userIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotStartedEnrolments = `-- name: GetNotStartedEnrolments :many
+SELECT
+  uc.id,
+  uc.user_id,
+  u.name AS user_name,
+  u.email AS user_email,
+  uc.course_id,
+  c.title AS course_title,
+  uc.enrolled_at,
+  uc.due_at
+FROM usercourses uc
+INNER JOIN users u ON u.id = uc.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = uc.course_id
+WHERE uc.enrolled_at < NOW() - make_interval(days => $1::int)
+  AND uc.reminded_at IS NULL
+  AND up.id IS NULL
+  AND (uc.due_at IS NULL OR uc.due_at >= NOW())
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+ORDER BY uc.enrolled_at
+`
+
+type GetNotStartedEnrolmentsRow struct {
+	ID          pgtype.UUID
+	UserID      pgtype.Text
+	UserName    pgtype.Text
+	UserEmail   pgtype.Text
+	CourseID    pgtype.UUID
+	CourseTitle pgtype.Text
+	EnrolledAt  pgtype.Timestamptz
+	DueAt       pgtype.Timestamptz
+}
+
+// Enrolments older than the given number of days that the learner hasn't started or been reminded
+// about. Overdue enrolments are left to the overdue job.
+func (q *Queries) GetNotStartedEnrolments(ctx context.Context, enrolledDays int32) ([]GetNotStartedEnrolmentsRow, error) {
+	rows, err := q.db.Query(ctx, getNotStartedEnrolments, enrolledDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotStartedEnrolmentsRow
+	for rows.Next() {
+		var i GetNotStartedEnrolmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UserName,
+			&i.UserEmail,
+			&i.CourseID,
+			&i.CourseTitle,
+			&i.EnrolledAt,
+			&i.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOverdueEnrolments = `-- name: GetOverdueEnrolments :many
+SELECT
+  uc.id,
+  uc.user_id,
+  u.name AS user_name,
+  u.email AS user_email,
+  uc.course_id,
+  c.title AS course_title,
+  uc.due_at
+FROM usercourses uc
+INNER JOIN users u ON u.id = uc.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+LEFT JOIN userprogress up ON up.user_id = uc.user_id AND up.course_id = uc.course_id
+WHERE uc.due_at < NOW()
+  AND uc.overdue_at IS NULL
+  AND NOT COALESCE(up.completed_course, FALSE)
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+ORDER BY uc.due_at
+`
+
+type GetOverdueEnrolmentsRow struct {
+	ID          pgtype.UUID
+	UserID      pgtype.Text
+	UserName    pgtype.Text
+	UserEmail   pgtype.Text
+	CourseID    pgtype.UUID
+	CourseTitle pgtype.Text
+	DueAt       pgtype.Timestamptz
+}
+
+// Enrolments past their due date that haven't been completed or flagged as overdue yet, learners
+// who can't sign in aren't emailed
+func (q *Queries) GetOverdueEnrolments(ctx context.Context) ([]GetOverdueEnrolmentsRow, error) {
+	rows, err := q.db.Query(ctx, getOverdueEnrolments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOverdueEnrolmentsRow
+	for rows.Next() {
+		var i GetOverdueEnrolmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UserName,
+			&i.UserEmail,
+			&i.CourseID,
+			&i.CourseTitle,
+			&i.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnnotifiedEnrolments = `-- name: GetUnnotifiedEnrolments :many
+SELECT
+  uc.id,
+  uc.user_id,
+  u.name AS user_name,
+  u.email AS user_email,
+  uc.course_id,
+  c.title AS course_title,
+  uc.enrolled_at,
+  uc.due_at
+FROM usercourses uc
+INNER JOIN users u ON u.id = uc.user_id
+INNER JOIN courses c ON c.id = uc.course_id
+WHERE uc.notified_at IS NULL
+  AND u.deactivated_at IS NULL
+  AND u.erased_at IS NULL
+ORDER BY uc.enrolled_at
+`
+
+type GetUnnotifiedEnrolmentsRow struct {
+	ID          pgtype.UUID
+	UserID      pgtype.Text
+	UserName    pgtype.Text
+	UserEmail   pgtype.Text
+	CourseID    pgtype.UUID
+	CourseTitle pgtype.Text
+	EnrolledAt  pgtype.Timestamptz
+	DueAt       pgtype.Timestamptz
+}
+
+// Enrolments the learner hasn't been emailed about yet, learners who can't sign in aren't emailed
+func (q *Queries) GetUnnotifiedEnrolments(ctx context.Context) ([]GetUnnotifiedEnrolmentsRow, error) {
+	rows, err := q.db.Query(ctx, getUnnotifiedEnrolments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUnnotifiedEnrolmentsRow
+	for rows.Next() {
+		var i GetUnnotifiedEnrolmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UserName,
+			&i.UserEmail,
+			&i.CourseID,
+			&i.CourseTitle,
+			&i.EnrolledAt,
+			&i.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getUsersAndAssignedCourses = `-- name: GetUsersAndAssignedCourses :many
@@ -49,9 +339,16 @@ SELECT
     jsonb_agg(c.course_id) FILTER (WHERE c.course_id IS NOT NULL),
     '[]'::jsonb
     )
-  )::jsonb AS course_ids
+  )::jsonb AS course_ids,
+  (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('id', g.id, 'name', g.name) ORDER BY g.name), '[]'::jsonb)
+    FROM user_groups ug
+    INNER JOIN groups g ON g.id = ug.group_id
+    WHERE ug.user_id = u.id
+  )::jsonb AS groups
 FROM users u
 LEFT JOIN usercourses c ON u.id = c.user_id
+WHERE u.erased_at IS NULL
 GROUP BY u.id, u.name, u.email
 ORDER BY u.name
 `
@@ -61,6 +358,7 @@ type GetUsersAndAssignedCoursesRow struct {
 	Name      pgtype.Text
 	Email     pgtype.Text
 	CourseIds []byte
+	Groups    []byte
 }
 
 func (q *Queries) GetUsersAndAssignedCourses(ctx context.Context) ([]GetUsersAndAssignedCoursesRow, error) {
@@ -77,6 +375,7 @@ func (q *Queries) GetUsersAndAssignedCourses(ctx context.Context) ([]GetUsersAnd
 			&i.Name,
 			&i.Email,
 			&i.CourseIds,
+			&i.Groups,
 		); err != nil {
 			return nil, err
 		}
@@ -103,3 +402,177 @@ func (q *Queries) IsUserEnrolledInCourse(ctx context.Context, arg IsUserEnrolled
 	err := row.Scan(&exists)
 	return exists, err
 }
+
+const listUsersAndAssignedCourses = `-- name: ListUsersAndAssignedCourses :many
+WITH filtered_users AS (
+  SELECT
+    u.id,
+    u.name,
+    u.email,
+    lower(
+      CASE WHEN $1::text = 'email' THEN COALESCE(u.email, '') ELSE COALESCE(u.name, '') END
+    )::text AS sort_key
+  FROM users u
+  WHERE u.erased_at IS NULL
+    AND (
+      $2::text IS NULL
+      OR u.name ILIKE '%' || $2 || '%' ESCAPE '\'
+      OR u.email ILIKE '%' || $2 || '%' ESCAPE '\'
+    )
+    AND (
+      $3::uuid IS NULL
+      OR EXISTS (SELECT 1 FROM usercourses f WHERE f.user_id = u.id AND f.course_id = $3)
+    )
+    AND (
+      $4::uuid IS NULL
+      OR EXISTS (SELECT 1 FROM user_groups f WHERE f.user_id = u.id AND f.group_id = $4)
+    )
+)
+SELECT
+  fu.id,
+  fu.name,
+  fu.email,
+  (
+    COALESCE(
+    jsonb_agg(c.course_id) FILTER (WHERE c.course_id IS NOT NULL),
+    '[]'::jsonb
+    )
+  )::jsonb AS course_ids,
+  (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('id', g.id, 'name', g.name) ORDER BY g.name), '[]'::jsonb)
+    FROM user_groups ug
+    INNER JOIN groups g ON g.id = ug.group_id
+    WHERE ug.user_id = fu.id
+  )::jsonb AS groups,
+  fu.sort_key
+FROM filtered_users fu
+LEFT JOIN usercourses c ON fu.id = c.user_id
+WHERE $5::text IS NULL
+  OR (NOT $6::bool AND (fu.sort_key, fu.id) > ($7::text, $5))
+  OR ($6::bool AND (fu.sort_key, fu.id) < ($7::text, $5))
+GROUP BY fu.id, fu.name, fu.email, fu.sort_key
+ORDER BY
+  CASE WHEN $6::bool THEN NULL ELSE fu.sort_key END,
+  CASE WHEN $6::bool THEN NULL ELSE fu.id END,
+  CASE WHEN $6::bool THEN fu.sort_key END DESC,
+  CASE WHEN $6::bool THEN fu.id END DESC
+LIMIT $8
+`
+
+type ListUsersAndAssignedCoursesParams struct {
+	SortBy    string
+	Search    pgtype.Text
+	CourseID  pgtype.UUID
+	GroupID   pgtype.UUID
+	CursorID  pgtype.Text
+	SortDesc  bool
+	CursorKey pgtype.Text
+	PageLimit int32
+}
+
+type ListUsersAndAssignedCoursesRow struct {
+	ID        string
+	Name      pgtype.Text
+	Email     pgtype.Text
+	CourseIds []byte
+	Groups    []byte
+	SortKey   string
+}
+
+func (q *Queries) ListUsersAndAssignedCourses(ctx context.Context, arg ListUsersAndAssignedCoursesParams) ([]ListUsersAndAssignedCoursesRow, error) {
+	rows, err := q.db.Query(ctx, listUsersAndAssignedCourses,
+		arg.SortBy,
+		arg.Search,
+		arg.CourseID,
+		arg.GroupID,
+		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorKey,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersAndAssignedCoursesRow
+	for rows.Next() {
+		var i ListUsersAndAssignedCoursesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CourseIds,
+			&i.Groups,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setEnrolmentDueDate = `-- name: SetEnrolmentDueDate :one
+UPDATE usercourses uc
+SET due_at = COALESCE($1, uc.enrolled_at + make_interval(days => $2::int)),
+    overdue_at = NULL
+FROM usercourses previous
+WHERE previous.id = uc.id AND uc.user_id = $3 AND uc.course_id = $4
+RETURNING previous.due_at AS previous_due_at, uc.due_at
+`
+
+type SetEnrolmentDueDateParams struct {
+	DueAt     pgtype.Timestamptz
+	DueInDays pgtype.Int4
+	UserID    pgtype.Text
+	CourseID  pgtype.UUID
+}
+
+type SetEnrolmentDueDateRow struct {
+	PreviousDueAt pgtype.Timestamptz
+	DueAt         pgtype.Timestamptz
+}
+
+// Changing the due date clears the overdue flag so the enrolment is re-evaluated by the overdue job.
+// Returns the due date before and after the change.
+func (q *Queries) SetEnrolmentDueDate(ctx context.Context, arg SetEnrolmentDueDateParams) (SetEnrolmentDueDateRow, error) {
+	row := q.db.QueryRow(ctx, setEnrolmentDueDate,
+		arg.DueAt,
+		arg.DueInDays,
+		arg.UserID,
+		arg.CourseID,
+	)
+	var i SetEnrolmentDueDateRow
+	err := row.Scan(&i.PreviousDueAt, &i.DueAt)
+	return i, err
+}
+
+const setEnrolmentNotified = `-- name: SetEnrolmentNotified :exec
+UPDATE usercourses SET notified_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) SetEnrolmentNotified(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, setEnrolmentNotified, id)
+	return err
+}
+
+const setEnrolmentOverdue = `-- name: SetEnrolmentOverdue :exec
+UPDATE usercourses SET overdue_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) SetEnrolmentOverdue(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, setEnrolmentOverdue, id)
+	return err
+}
+
+const setEnrolmentReminded = `-- name: SetEnrolmentReminded :exec
+UPDATE usercourses SET reminded_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) SetEnrolmentReminded(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, setEnrolmentReminded, id)
+	return err
+}
@@ -8,12 +8,80 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiKey struct {
+	ID         pgtype.UUID
+	Name       string
+	KeyPrefix  string
+	KeyHash    string
+	Scopes     []string
+	CreatedBy  pgtype.Text
+	CreatedAt  pgtype.Timestamptz
+	ExpiresAt  pgtype.Timestamptz
+	LastUsedAt pgtype.Timestamptz
+	RevokedAt  pgtype.Timestamptz
+}
+
+type AuditLog struct {
+	ID         int64
+	ActorID    pgtype.Text
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     []byte
+	After      []byte
+	CreatedAt  pgtype.Timestamptz
+	PrevHash   string
+	Hash       string
+}
+
+type Certificate struct {
+	ID               pgtype.UUID
+	Number           int64
+	UserID           string
+	CourseID         pgtype.UUID
+	StorageKey       string
+	IssuedAt         pgtype.Timestamptz
+	VerificationID   string
+	ExpiresAt        pgtype.Timestamptz
+	RevokedAt        pgtype.Timestamptz
+	RevokedBy        pgtype.Text
+	RevokeReason     pgtype.Text
+	ExpiryNotifiedAt pgtype.Timestamptz
+}
+
 type Course struct {
-	ID                pgtype.UUID
-	Title             pgtype.Text
-	Description       pgtype.Text
-	CompletionTitle   pgtype.Text
-	CompletionMessage pgtype.Text
+	ID                        pgtype.UUID
+	Title                     pgtype.Text
+	Description               pgtype.Text
+	CompletionTitle           pgtype.Text
+	CompletionMessage         pgtype.Text
+	CertificateValidityMonths pgtype.Int4
+}
+
+type CourseAccessCode struct {
+	ID        pgtype.UUID
+	Code      string
+	CourseID  pgtype.UUID
+	ExpiresAt pgtype.Timestamptz
+	MaxUses   pgtype.Int4
+	Uses      int32
+	CreatedBy pgtype.Text
+	CreatedAt pgtype.Timestamptz
+	RevokedAt pgtype.Timestamptz
+}
+
+type CourseAccessCodeRedemption struct {
+	ID           pgtype.UUID
+	AccessCodeID pgtype.UUID
+	UserID       string
+	RedeemedAt   pgtype.Timestamptz
+}
+
+type CourseInstructor struct {
+	ID         pgtype.UUID
+	UserID     string
+	CourseID   pgtype.UUID
+	AssignedAt pgtype.Timestamptz
 }
 
 type CourseMaterial struct {
@@ -24,15 +92,80 @@ type CourseMaterial struct {
 	Position   pgtype.Int4
 }
 
-type EmailFailure struct {
-	ID             pgtype.UUID
-	CreatedAt      pgtype.Timestamptz
-	UpdatedAt      pgtype.Timestamptz
-	Error          string
-	TemplateName   string
-	TemplateParams []byte
-	EmailName      string
-	Retries        int32
+type EmailAttempt struct {
+	ID          pgtype.UUID
+	EmailID     pgtype.UUID
+	AttemptedAt pgtype.Timestamptz
+	Error       pgtype.Text
+}
+
+type EmailBounce struct {
+	Email     string
+	BouncedAt pgtype.Timestamptz
+	Reason    pgtype.Text
+}
+
+type EmailEvent struct {
+	ID              pgtype.UUID
+	ProviderEventID string
+	EmailID         pgtype.UUID
+	MessageID       string
+	Event           string
+	Recipient       string
+	Reason          pgtype.Text
+	OccurredAt      pgtype.Timestamptz
+	ReceivedAt      pgtype.Timestamptz
+}
+
+type EmailOutbox struct {
+	ID                pgtype.UUID
+	EmailName         string
+	TemplateName      string
+	TemplateParams    []byte
+	Status            string
+	Attempts          int32
+	NextAttemptAt     pgtype.Timestamptz
+	LastError         pgtype.Text
+	CreatedAt         pgtype.Timestamptz
+	SentAt            pgtype.Timestamptz
+	ProviderMessageID pgtype.Text
+}
+
+type Group struct {
+	ID        pgtype.UUID
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type GroupCourse struct {
+	ID         pgtype.UUID
+	GroupID    pgtype.UUID
+	CourseID   pgtype.UUID
+	DueInDays  pgtype.Int4
+	AssignedBy pgtype.Text
+	AssignedAt pgtype.Timestamptz
+}
+
+type GroupManager struct {
+	ID      pgtype.UUID
+	UserID  string
+	GroupID pgtype.UUID
+	AddedAt pgtype.Timestamptz
+}
+
+type LineManager struct {
+	UserID     string
+	ManagerID  string
+	AssignedAt pgtype.Timestamptz
+}
+
+type LocalAuthUser struct {
+	ID           string
+	Email        string
+	Name         pgtype.Text
+	PasswordHash string
+	Disabled     bool
+	CreatedAt    pgtype.Timestamptz
 }
 
 type QuizAttempt struct {
@@ -66,9 +199,40 @@ type Quizsection struct {
 }
 
 type User struct {
-	ID    string
-	Name  pgtype.Text
-	Email pgtype.Text
+	ID            string
+	Name          pgtype.Text
+	Email         pgtype.Text
+	DeactivatedAt pgtype.Timestamptz
+	ErasedAt      pgtype.Timestamptz
+}
+
+type UserEmailOptOut struct {
+	UserID     string
+	EmailName  string
+	OptedOutAt pgtype.Timestamptz
+}
+
+type UserGroup struct {
+	ID      pgtype.UUID
+	UserID  string
+	GroupID pgtype.UUID
+	AddedAt pgtype.Timestamptz
+}
+
+type UserImport struct {
+	ID          pgtype.UUID
+	Status      string
+	Report      []byte
+	CreatedBy   pgtype.Text
+	CreatedAt   pgtype.Timestamptz
+	CompletedAt pgtype.Timestamptz
+}
+
+type UserRole struct {
+	UserID    string
+	Role      string
+	GrantedBy pgtype.Text
+	GrantedAt pgtype.Timestamptz
 }
 
 type UserQuizState struct {
@@ -81,9 +245,15 @@ type UserQuizState struct {
 }
 
 type Usercourse struct {
-	ID       pgtype.UUID
-	UserID   pgtype.Text
-	CourseID pgtype.UUID
+	ID         pgtype.UUID
+	UserID     pgtype.Text
+	CourseID   pgtype.UUID
+	EnrolledAt pgtype.Timestamptz
+	EnrolledBy pgtype.Text
+	DueAt      pgtype.Timestamptz
+	OverdueAt  pgtype.Timestamptz
+	NotifiedAt pgtype.Timestamptz
+	RemindedAt pgtype.Timestamptz
 }
 
 type Userprogress struct {
@@ -11,6 +11,53 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countProgressUsers = `-- name: CountProgressUsers :one
+SELECT COUNT(*)
+FROM users u
+WHERE (
+    $1::text IS NULL
+    OR u.name ILIKE '%' || $1 || '%' ESCAPE '\'
+    OR u.email ILIKE '%' || $1 || '%' ESCAPE '\'
+  )
+  AND (
+    EXISTS (
+      SELECT 1 FROM usercourses uc
+      WHERE uc.user_id = u.id AND ($2::uuid IS NULL OR uc.course_id = $2)
+    )
+    OR EXISTS (
+      SELECT 1 FROM userprogress up
+      WHERE up.user_id = u.id AND ($2::uuid IS NULL OR up.course_id = $2)
+    )
+  )
+  AND (
+    $3::uuid IS NULL
+    OR EXISTS (SELECT 1 FROM user_groups ug WHERE ug.user_id = u.id AND ug.group_id = $3)
+  )
+  AND (
+    $4::text IS NULL
+    OR EXISTS (SELECT 1 FROM line_managers lm WHERE lm.user_id = u.id AND lm.manager_id = $4)
+  )
+`
+
+type CountProgressUsersParams struct {
+	Search    pgtype.Text
+	CourseID  pgtype.UUID
+	GroupID   pgtype.UUID
+	ManagerID pgtype.Text
+}
+
+func (q *Queries) CountProgressUsers(ctx context.Context, arg CountProgressUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProgressUsers,
+		arg.Search,
+		arg.CourseID,
+		arg.GroupID,
+		arg.ManagerID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getAllProgress = `-- name: GetAllProgress :many
 SELECT
   COALESCE(uc.user_id, up.user_id) AS user_id,
@@ -116,45 +163,194 @@ func (q *Queries) GetProgress(ctx context.Context, arg GetProgressParams) (GetPr
 	return i, err
 }
 
-const hasCompletedCourse = `-- name: HasCompletedCourse :one
-SELECT completed_course FROM userprogress WHERE user_id = $1 AND course_id = $2
+const getProgressForUsers = `-- name: GetProgressForUsers :many
+SELECT
+  COALESCE(uc.user_id, up.user_id) AS user_id,
+  COALESCE(uc.course_id, up.course_id) AS course_id,
+  u.name as user_name,
+  u.email,
+  c.title as course_title,
+  up.completed_intro,
+  up.completed_section_ids,
+  up.completed_course
+FROM usercourses uc
+FULL OUTER JOIN userprogress up
+  ON uc.user_id = up.user_id
+  AND uc.course_id = up.course_id
+LEFT JOIN users u
+  ON u.id = COALESCE(uc.user_id, up.user_id)
+LEFT JOIN courses c
+  ON c.id = COALESCE(uc.course_id, up.course_id)
+WHERE COALESCE(uc.user_id, up.user_id) = ANY($1::text[])
+  AND ($2::uuid IS NULL OR COALESCE(uc.course_id, up.course_id) = $2)
+ORDER BY c.title
 `
 
-type HasCompletedCourseParams struct {
-	UserID   string
+type GetProgressForUsersParams struct {
+	UserIds  []string
 	CourseID pgtype.UUID
 }
 
-func (q *Queries) HasCompletedCourse(ctx context.Context, arg HasCompletedCourseParams) (pgtype.Bool, error) {
-	row := q.db.QueryRow(ctx, hasCompletedCourse, arg.UserID, arg.CourseID)
-	var completed_course pgtype.Bool
-	err := row.Scan(&completed_course)
-	return completed_course, err
+type GetProgressForUsersRow struct {
+	UserID              string
+	CourseID            pgtype.UUID
+	UserName            pgtype.Text
+	Email               pgtype.Text
+	CourseTitle         pgtype.Text
+	CompletedIntro      pgtype.Bool
+	CompletedSectionIds []pgtype.UUID
+	CompletedCourse     pgtype.Bool
 }
 
-const resetProgress = `-- name: ResetProgress :exec
-UPDATE userprogress
-SET completed_section_ids = ARRAY[]::uuid[],
-    completed_intro = FALSE,
-    completed_course = FALSE
-WHERE user_id = $1 AND course_id = $2
+func (q *Queries) GetProgressForUsers(ctx context.Context, arg GetProgressForUsersParams) ([]GetProgressForUsersRow, error) {
+	rows, err := q.db.Query(ctx, getProgressForUsers, arg.UserIds, arg.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetProgressForUsersRow
+	for rows.Next() {
+		var i GetProgressForUsersRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.CourseID,
+			&i.UserName,
+			&i.Email,
+			&i.CourseTitle,
+			&i.CompletedIntro,
+			&i.CompletedSectionIds,
+			&i.CompletedCourse,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProgressUsers = `-- name: ListProgressUsers :many
+WITH filtered_users AS (
+  SELECT
+    u.id,
+    u.name,
+    u.email,
+    lower(
+      CASE WHEN $1::text = 'email' THEN COALESCE(u.email, '') ELSE COALESCE(u.name, '') END
+    )::text AS sort_key
+  FROM users u
+  WHERE (
+      $2::text IS NULL
+      OR u.name ILIKE '%' || $2 || '%' ESCAPE '\'
+      OR u.email ILIKE '%' || $2 || '%' ESCAPE '\'
+    )
+    AND (
+      EXISTS (
+        SELECT 1 FROM usercourses uc
+        WHERE uc.user_id = u.id AND ($3::uuid IS NULL OR uc.course_id = $3)
+      )
+      OR EXISTS (
+        SELECT 1 FROM userprogress up
+        WHERE up.user_id = u.id AND ($3::uuid IS NULL OR up.course_id = $3)
+      )
+    )
+    AND (
+      $4::uuid IS NULL
+      OR EXISTS (SELECT 1 FROM user_groups ug WHERE ug.user_id = u.id AND ug.group_id = $4)
+    )
+    AND (
+      $5::text IS NULL
+      OR EXISTS (SELECT 1 FROM line_managers lm WHERE lm.user_id = u.id AND lm.manager_id = $5)
+    )
+)
+SELECT
+  fu.id,
+  fu.name,
+  fu.email,
+  (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('id', g.id, 'name', g.name) ORDER BY g.name), '[]'::jsonb)
+    FROM user_groups ug
+    INNER JOIN groups g ON g.id = ug.group_id
+    WHERE ug.user_id = fu.id
+  )::jsonb AS groups,
+  fu.sort_key
+FROM filtered_users fu
+WHERE $6::text IS NULL
+  OR (NOT $7::bool AND (fu.sort_key, fu.id) > ($8::text, $6))
+  OR ($7::bool AND (fu.sort_key, fu.id) < ($8::text, $6))
+ORDER BY
+  CASE WHEN $7::bool THEN NULL ELSE fu.sort_key END,
+  CASE WHEN $7::bool THEN NULL ELSE fu.id END,
+  CASE WHEN $7::bool THEN fu.sort_key END DESC,
+  CASE WHEN $7::bool THEN fu.id END DESC
+LIMIT $9
 `
 
-type ResetProgressParams struct {
-	UserID   string
-	CourseID pgtype.UUID
+type ListProgressUsersParams struct {
+	SortBy    string
+	Search    pgtype.Text
+	CourseID  pgtype.UUID
+	GroupID   pgtype.UUID
+	ManagerID pgtype.Text
+	CursorID  pgtype.Text
+	SortDesc  bool
+	CursorKey pgtype.Text
+	PageLimit int32
 }
 
-func (q *Queries) ResetProgress(ctx context.Context, arg ResetProgressParams) error {
-	_, err := q.db.Exec(ctx, resetProgress, arg.UserID, arg.CourseID)
-	return err
+type ListProgressUsersRow struct {
+	ID      string
+	Name    pgtype.Text
+	Email   pgtype.Text
+	Groups  []byte
+	SortKey string
 }
 
-const setCourseCompleted = `-- name: SetCourseCompleted :exec
+// Users that have an enrolment or progress (optionally for a single course, group or line manager), paginated
+func (q *Queries) ListProgressUsers(ctx context.Context, arg ListProgressUsersParams) ([]ListProgressUsersRow, error) {
+	rows, err := q.db.Query(ctx, listProgressUsers,
+		arg.SortBy,
+		arg.Search,
+		arg.CourseID,
+		arg.GroupID,
+		arg.ManagerID,
+		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorKey,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListProgressUsersRow
+	for rows.Next() {
+		var i ListProgressUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.Groups,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setCourseCompleted = `-- name: SetCourseCompleted :execrows
 INSERT INTO userprogress (user_id, course_id, completed_section_ids, completed_course)
 VALUES ($1, $2, ARRAY[]::uuid[], TRUE)
 ON CONFLICT (user_id, course_id)
 DO UPDATE SET completed_course = TRUE
+WHERE userprogress.completed_course IS NOT TRUE
 `
 
 type SetCourseCompletedParams struct {
@@ -164,9 +360,13 @@ type SetCourseCompletedParams struct {
 
 // If there is no existing userprogress (should not happen since user should have some progress already)
 // then insert new row with empty completed_section_ids */
-func (q *Queries) SetCourseCompleted(ctx context.Context, arg SetCourseCompletedParams) error {
-	_, err := q.db.Exec(ctx, setCourseCompleted, arg.UserID, arg.CourseID)
-	return err
+// Returns 0 rows affected if the course was already completed.
+func (q *Queries) SetCourseCompleted(ctx context.Context, arg SetCourseCompletedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setCourseCompleted, arg.UserID, arg.CourseID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const setIntroCompleted = `-- name: SetIntroCompleted :exec
@@ -11,32 +11,24 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const deleteQuizAttempts = `-- name: DeleteQuizAttempts :exec
-DELETE FROM quiz_attempts WHERE user_id = $1 AND quiz_id = $2
-`
-
-type DeleteQuizAttemptsParams struct {
-	UserID string
-	QuizID pgtype.UUID
-}
-
-func (q *Queries) DeleteQuizAttempts(ctx context.Context, arg DeleteQuizAttemptsParams) error {
-	_, err := q.db.Exec(ctx, deleteQuizAttempts, arg.UserID, arg.QuizID)
-	return err
-}
-
-const deleteUserQuizState = `-- name: DeleteUserQuizState :exec
-DELETE FROM user_quiz_state WHERE user_id = $1 AND quiz_id = $2
+const countQuizSections = `-- name: CountQuizSections :one
+SELECT COUNT(*)
+FROM quizsections qs
+LEFT JOIN courses c ON c.id = qs.course_id
+WHERE ($1::text IS NULL OR c.title ILIKE '%' || $1 || '%' ESCAPE '\')
+  AND ($2::uuid IS NULL OR qs.course_id = $2)
 `
 
-type DeleteUserQuizStateParams struct {
-	UserID string
-	QuizID pgtype.UUID
+type CountQuizSectionsParams struct {
+	Search   pgtype.Text
+	CourseID pgtype.UUID
 }
 
-func (q *Queries) DeleteUserQuizState(ctx context.Context, arg DeleteUserQuizStateParams) error {
-	_, err := q.db.Exec(ctx, deleteUserQuizState, arg.UserID, arg.QuizID)
-	return err
+func (q *Queries) CountQuizSections(ctx context.Context, arg CountQuizSectionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countQuizSections, arg.Search, arg.CourseID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
 const getAllQuizSections = `-- name: GetAllQuizSections :many
@@ -289,6 +281,108 @@ func (q *Queries) IncrementAttempts(ctx context.Context, arg IncrementAttemptsPa
 	return err
 }
 
+const listQuizSections = `-- name: ListQuizSections :many
+WITH filtered_sections AS (
+  SELECT
+    qs.id,
+    qs.position,
+    qs.course_id,
+    (lower(COALESCE(c.title, '')) || '#' || lpad(COALESCE(qs.position, 0)::text, 10, '0'))::text AS sort_key
+  FROM quizsections qs
+  LEFT JOIN courses c ON c.id = qs.course_id
+  WHERE ($1::text IS NULL OR c.title ILIKE '%' || $1 || '%' ESCAPE '\')
+    AND ($2::uuid IS NULL OR qs.course_id = $2)
+)
+SELECT
+  fs.id,
+  fs.position,
+  fs.course_id,
+  json_agg(
+    json_build_object(
+      'id', qq.id,
+      'question', qq.question,
+      'position', qq.position,
+      'is_multi_answer', qq.is_multi_answer,
+      'answers', (
+        SELECT json_agg(
+          json_build_object(
+            'id', qa.id,
+            'answer', qa.answer,
+            'correct_answer', qa.correct_answer,
+            'position', qa.position
+          ) ORDER BY qa.position
+        )
+        FROM quizanswers qa
+        WHERE qa.quiz_question_id = qq.id
+      )
+    ) ORDER BY qq.position
+  ) AS questions,
+  fs.sort_key
+FROM filtered_sections fs
+LEFT JOIN quizquestions qq ON qq.quiz_section_id = fs.id
+WHERE $3::text IS NULL
+  OR (NOT $4::bool AND (fs.sort_key, fs.id::text) > ($5::text, $3))
+  OR ($4::bool AND (fs.sort_key, fs.id::text) < ($5::text, $3))
+GROUP BY fs.id, fs.position, fs.course_id, fs.sort_key
+ORDER BY
+  CASE WHEN $4::bool THEN NULL ELSE fs.sort_key END,
+  CASE WHEN $4::bool THEN NULL ELSE fs.id::text END,
+  CASE WHEN $4::bool THEN fs.sort_key END DESC,
+  CASE WHEN $4::bool THEN fs.id::text END DESC
+LIMIT $6
+`
+
+type ListQuizSectionsParams struct {
+	Search    pgtype.Text
+	CourseID  pgtype.UUID
+	CursorID  pgtype.Text
+	SortDesc  bool
+	CursorKey pgtype.Text
+	PageLimit int32
+}
+
+type ListQuizSectionsRow struct {
+	ID        pgtype.UUID
+	Position  pgtype.Int4
+	CourseID  pgtype.UUID
+	Questions []byte
+	SortKey   string
+}
+
+// Quiz sections are sorted by course title and then by their position within the course
+func (q *Queries) ListQuizSections(ctx context.Context, arg ListQuizSectionsParams) ([]ListQuizSectionsRow, error) {
+	rows, err := q.db.Query(ctx, listQuizSections,
+		arg.Search,
+		arg.CourseID,
+		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorKey,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListQuizSectionsRow
+	for rows.Next() {
+		var i ListQuizSectionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Position,
+			&i.CourseID,
+			&i.Questions,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const saveQuizAttempt = `-- name: SaveQuizAttempt :exec
 INSERT INTO quiz_attempts (user_id, quiz_id, answers, attempt_number)
 VALUES (
@@ -7,15 +7,216 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*)
+FROM users u
+WHERE u.erased_at IS NULL
+  AND (
+    $1::text IS NULL
+    OR u.name ILIKE '%' || $1 || '%' ESCAPE '\'
+    OR u.email ILIKE '%' || $1 || '%' ESCAPE '\'
+  )
+  AND (
+    $2::bool IS NULL
+    OR (u.deactivated_at IS NOT NULL) = $2
+  )
+`
+
+type CountUsersParams struct {
+	Search      pgtype.Text
+	Deactivated pgtype.Bool
+}
+
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers, arg.Search, arg.Deactivated)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteUser = `-- name: DeleteUser :execrows
+DELETE FROM users WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getUser = `-- name: GetUser :one
-SELECT id, name, email FROM users WHERE id = $1
+SELECT id, name, email, deactivated_at, erased_at FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUser(ctx context.Context, id string) (User, error) {
 	row := q.db.QueryRow(ctx, getUser, id)
 	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeactivatedAt,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, name, email, deactivated_at, erased_at FROM users WHERE LOWER(email) = LOWER($1::text)
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeactivatedAt,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+WITH filtered_users AS (
+  SELECT
+    u.id,
+    u.name,
+    u.email,
+    u.deactivated_at,
+    eb.bounced_at AS email_bounced_at,
+    lower(
+      CASE WHEN $1::text = 'email' THEN COALESCE(u.email, '') ELSE COALESCE(u.name, '') END
+    )::text AS sort_key
+  FROM users u
+  LEFT JOIN email_bounces eb ON eb.email = lower(u.email)
+  WHERE u.erased_at IS NULL
+    AND (
+      $2::text IS NULL
+      OR u.name ILIKE '%' || $2 || '%' ESCAPE '\'
+      OR u.email ILIKE '%' || $2 || '%' ESCAPE '\'
+    )
+    AND (
+      $3::bool IS NULL
+      OR (u.deactivated_at IS NOT NULL) = $3
+    )
+)
+SELECT fu.id, fu.name, fu.email, fu.deactivated_at, fu.email_bounced_at, fu.sort_key
+FROM filtered_users fu
+WHERE $4::text IS NULL
+  OR (NOT $5::bool AND (fu.sort_key, fu.id) > ($6::text, $4))
+  OR ($5::bool AND (fu.sort_key, fu.id) < ($6::text, $4))
+ORDER BY
+  CASE WHEN $5::bool THEN NULL ELSE fu.sort_key END,
+  CASE WHEN $5::bool THEN NULL ELSE fu.id END,
+  CASE WHEN $5::bool THEN fu.sort_key END DESC,
+  CASE WHEN $5::bool THEN fu.id END DESC
+LIMIT $7
+`
+
+type ListUsersParams struct {
+	SortBy      string
+	Search      pgtype.Text
+	Deactivated pgtype.Bool
+	CursorID    pgtype.Text
+	SortDesc    bool
+	CursorKey   pgtype.Text
+	PageLimit   int32
+}
+
+type ListUsersRow struct {
+	ID             string
+	Name           pgtype.Text
+	Email          pgtype.Text
+	DeactivatedAt  pgtype.Timestamptz
+	EmailBouncedAt pgtype.Timestamptz
+	SortKey        string
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.SortBy,
+		arg.Search,
+		arg.Deactivated,
+		arg.CursorID,
+		arg.SortDesc,
+		arg.CursorKey,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersRow
+	for rows.Next() {
+		var i ListUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.DeactivatedAt,
+			&i.EmailBouncedAt,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserDeactivated = `-- name: SetUserDeactivated :execrows
+UPDATE users
+SET deactivated_at = CASE WHEN $1::bool THEN COALESCE(deactivated_at, NOW()) END
+WHERE id = $2
+`
+
+type SetUserDeactivatedParams struct {
+	Deactivated bool
+	ID          string
+}
+
+// Deactivating an already deactivated user keeps the original deactivation time
+func (q *Queries) SetUserDeactivated(ctx context.Context, arg SetUserDeactivatedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserDeactivated, arg.Deactivated, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUser = `-- name: UpdateUser :one
+UPDATE users
+SET name = $1, email = $2
+WHERE id = $3
+RETURNING id, name, email, deactivated_at, erased_at
+`
+
+type UpdateUserParams struct {
+	Name  pgtype.Text
+	Email pgtype.Text
+	ID    string
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUser, arg.Name, arg.Email, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeactivatedAt,
+		&i.ErasedAt,
+	)
 	return i, err
 }
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -60,7 +61,51 @@ func (s *Store) PingDB(ctx context.Context) error {
 	return s.pool.Ping(ctx)
 }
 
+type txContextKey struct{}
+
+// InTx runs fn in a transaction. Store calls made with the context fn is given join the
+// transaction, so their changes are committed together or not at all.
+func (s *Store) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return ExecCommand(ctx, func() error {
+		tx, err := s.begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+			return err
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+// begin starts a transaction, or a savepoint if the context is already in one, so a change that
+// needs its own transaction can still be made as part of a larger one
+func (s *Store) begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txContextKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+
+	return s.pool.Begin(ctx)
+}
+
+// queries runs queries in the context's transaction if it's in one
+func (s *Store) queries(ctx context.Context) *sqlc.Queries {
+	if tx, ok := ctx.Value(txContextKey{}).(pgx.Tx); ok {
+		return s.Queries.WithTx(tx)
+	}
+
+	return s.Queries
+}
+
 func ExecQuery[T any](ctx context.Context, query func() (T, error)) (T, error) {
+	// A failed statement aborts the transaction it's in, so only the whole transaction is retried
+	if _, ok := ctx.Value(txContextKey{}).(pgx.Tx); ok {
+		return query()
+	}
+
 	return utils.RetryWithExponentialBackoff(ctx, query, DbMaxRetries, DbBaseDelay, isRetryableDbError)
 }
 
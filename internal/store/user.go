@@ -3,21 +3,138 @@ package store
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
+
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
 )
 
 func (s *Store) GetUser(ctx context.Context, id string) (*domain.User, error) {
 	user, err := ExecQuery(ctx, func() (sqlc.User, error) {
-		return s.Queries.GetUser(ctx, id)
+		return s.queries(ctx).GetUser(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userFrom(user), nil
+}
+
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	user, err := ExecQuery(ctx, func() (sqlc.User, error) {
+		return s.queries(ctx).GetUserByEmail(ctx, email)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userFrom(user), nil
+}
+
+func (s *Store) ListUsers(ctx context.Context, params domain.PageParams) (*domain.Page[domain.User], error) {
+	args := pageArgsFrom(params)
+
+	total, err := ExecQuery(ctx, func() (int64, error) {
+		return s.queries(ctx).CountUsers(ctx, sqlc.CountUsersParams{
+			Search:      args.Search,
+			Deactivated: args.Deactivated,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ExecQuery(ctx, func() ([]sqlc.ListUsersRow, error) {
+		return s.queries(ctx).ListUsers(ctx, sqlc.ListUsersParams{
+			SortBy:      params.SortBy,
+			Search:      args.Search,
+			Deactivated: args.Deactivated,
+			CursorID:    args.CursorID,
+			SortDesc:    args.SortDesc,
+			CursorKey:   args.CursorKey,
+			PageLimit:   args.PageLimit,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pageFrom(
+		rows,
+		params,
+		total,
+		func(row sqlc.ListUsersRow) domain.Cursor {
+			return domain.Cursor{Key: row.SortKey, ID: row.ID}
+		},
+		func(row sqlc.ListUsersRow) (domain.User, error) {
+			user := userFrom(sqlc.User{
+				ID:            row.ID,
+				Name:          row.Name,
+				Email:         row.Email,
+				DeactivatedAt: row.DeactivatedAt,
+			})
+			user.EmailBouncedAt = utils.TimeFrom(row.EmailBouncedAt)
+
+			return *user, nil
+		},
+	)
+}
+
+func (s *Store) UpdateUser(ctx context.Context, params domain.UpdateUserParams) (*domain.User, error) {
+	user, err := ExecQuery(ctx, func() (sqlc.User, error) {
+		return s.queries(ctx).UpdateUser(ctx, sqlc.UpdateUserParams{
+			ID:    params.ID,
+			Name:  utils.PGTextFrom(params.Name),
+			Email: utils.PGTextFrom(params.Email),
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	return userFrom(user), nil
+}
+
+func (s *Store) SetUserDeactivated(ctx context.Context, params domain.SetUserDeactivatedParams) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).SetUserDeactivated(ctx, sqlc.SetUserDeactivatedParams{
+			ID:          params.ID,
+			Deactivated: params.Deactivated,
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+// DeleteUser removes the user along with their enrolments, progress and group memberships
+func (s *Store) DeleteUser(ctx context.Context, id string) error {
+	return ExecCommand(ctx, func() error {
+		deleted, err := s.queries(ctx).DeleteUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if deleted == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func userFrom(user sqlc.User) *domain.User {
 	return &domain.User{
-		ID:    user.ID,
-		Name:  user.Name.String,
-		Email: user.Email.String,
-	}, nil
+		ID:            user.ID,
+		Name:          user.Name.String,
+		Email:         user.Email.String,
+		DeactivatedAt: utils.TimeFrom(user.DeactivatedAt),
+	}
 }
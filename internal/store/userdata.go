@@ -0,0 +1,214 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+// erasedUserIDPrefix marks the anonymised placeholders left by erasing a user
+const erasedUserIDPrefix = "erased-"
+
+func (s *Store) ExportUserData(ctx context.Context, id string) (*domain.UserDataExport, error) {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolments, err := ExecQuery(ctx, func() ([]sqlc.ExportUserEnrolmentsRow, error) {
+		return s.queries(ctx).ExportUserEnrolments(ctx, utils.PGTextFrom(id))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := ExecQuery(ctx, func() ([]sqlc.ExportUserProgressRow, error) {
+		return s.queries(ctx).ExportUserProgress(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	quizStates, err := ExecQuery(ctx, func() ([]sqlc.ExportUserQuizStatesRow, error) {
+		return s.queries(ctx).ExportUserQuizStates(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	quizAttempts, err := ExecQuery(ctx, func() ([]sqlc.ExportUserQuizAttemptsRow, error) {
+		return s.queries(ctx).ExportUserQuizAttempts(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := ExecQuery(ctx, func() ([]sqlc.ExportUserGroupsRow, error) {
+		return s.queries(ctx).ExportUserGroups(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	redemptions, err := ExecQuery(ctx, func() ([]sqlc.ExportUserAccessCodeRedemptionsRow, error) {
+		return s.queries(ctx).ExportUserAccessCodeRedemptions(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	emails, err := ExecQuery(ctx, func() ([]sqlc.ExportUserEmailsRow, error) {
+		return s.queries(ctx).ExportUserEmails(ctx, user.Email)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserDataExport{
+		ExportedAt:            time.Now().UTC(),
+		User:                  *user,
+		Enrolments:            utils.Map(enrolments, userDataEnrolmentFrom),
+		Progress:              utils.Map(progress, userDataProgressFrom),
+		QuizStates:            utils.Map(quizStates, userDataQuizStateFrom),
+		QuizAttempts:          utils.Map(quizAttempts, userDataQuizAttemptFrom),
+		Groups:                utils.Map(groups, userDataGroupFrom),
+		AccessCodeRedemptions: utils.Map(redemptions, userDataAccessCodeRedemptionFrom),
+		Emails:                utils.Map(emails, userDataEmailFrom),
+	}, nil
+}
+
+// EraseUser deletes the user and everything held about them. Their completed courses are moved to
+// an anonymised placeholder user first, so completion statistics are unchanged.
+func (s *Store) EraseUser(ctx context.Context, id string) (*domain.ErasedUser, error) {
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	qtx := s.Queries.WithTx(tx)
+
+	user, err := qtx.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	erasedID := erasedUserIDPrefix + uuid.New().String()
+	if err := qtx.InsertErasedUser(ctx, erasedID); err != nil {
+		return nil, err
+	}
+
+	retained, err := qtx.MoveCompletedProgress(ctx, sqlc.MoveCompletedProgressParams{
+		ErasedUserID: erasedID,
+		UserID:       id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = qtx.MoveCompletedEnrolments(ctx, sqlc.MoveCompletedEnrolmentsParams{
+		ErasedUserID: utils.PGTextFrom(erasedID),
+		UserID:       utils.PGTextFrom(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email.Valid {
+		if err := qtx.DeleteUserEmails(ctx, user.Email.String); err != nil {
+			return nil, err
+		}
+
+		if err := qtx.DeleteUserEmailEvents(ctx, user.Email.String); err != nil {
+			return nil, err
+		}
+
+		if err := qtx.DeleteUserEmailBounce(ctx, user.Email.String); err != nil {
+			return nil, err
+		}
+	}
+
+	// Deleting the user cascades to their remaining enrolments, progress, quiz answers,
+	// group memberships and access code redemptions
+	if _, err := qtx.DeleteUser(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &domain.ErasedUser{ID: erasedID, RetainedCompletions: retained}, nil
+}
+
+func userDataEnrolmentFrom(row sqlc.ExportUserEnrolmentsRow) domain.UserDataEnrolment {
+	return domain.UserDataEnrolment{
+		CourseID:    utils.UUIDFrom(row.CourseID),
+		CourseTitle: row.CourseTitle.String,
+		EnrolledAt:  row.EnrolledAt.Time,
+		EnrolledBy:  row.EnrolledBy.String,
+		DueAt:       utils.TimeFrom(row.DueAt),
+		OverdueAt:   utils.TimeFrom(row.OverdueAt),
+	}
+}
+
+func userDataProgressFrom(row sqlc.ExportUserProgressRow) domain.UserDataProgress {
+	return domain.UserDataProgress{
+		CourseID:            utils.UUIDFrom(row.CourseID),
+		CourseTitle:         row.CourseTitle.String,
+		CompletedSectionIDs: utils.Map(row.CompletedSectionIds, utils.UUIDFrom),
+		CompletedIntro:      row.CompletedIntro.Bool,
+		CompletedCourse:     row.CompletedCourse.Bool,
+	}
+}
+
+func userDataQuizStateFrom(row sqlc.ExportUserQuizStatesRow) domain.UserDataQuizState {
+	return domain.UserDataQuizState{
+		QuizID:      utils.UUIDFrom(row.QuizID),
+		QuizState:   row.QuizState,
+		QuizAnswers: row.QuizAnswers,
+		Attempts:    int(row.Attempts),
+	}
+}
+
+func userDataQuizAttemptFrom(row sqlc.ExportUserQuizAttemptsRow) domain.UserDataQuizAttempt {
+	return domain.UserDataQuizAttempt{
+		QuizID:        utils.UUIDFrom(row.QuizID),
+		AttemptNumber: int(row.AttemptNumber),
+		Answers:       row.Answers,
+	}
+}
+
+func userDataGroupFrom(row sqlc.ExportUserGroupsRow) domain.UserDataGroup {
+	return domain.UserDataGroup{
+		GroupID:   utils.UUIDFrom(row.GroupID),
+		GroupName: row.GroupName,
+		AddedAt:   row.AddedAt.Time,
+	}
+}
+
+func userDataAccessCodeRedemptionFrom(row sqlc.ExportUserAccessCodeRedemptionsRow) domain.UserDataAccessCodeRedemption {
+	return domain.UserDataAccessCodeRedemption{
+		Code:       row.Code,
+		CourseID:   utils.UUIDFrom(row.CourseID),
+		RedeemedAt: row.RedeemedAt.Time,
+	}
+}
+
+func userDataEmailFrom(row sqlc.ExportUserEmailsRow) domain.UserDataEmail {
+	return domain.UserDataEmail{
+		ID:             utils.UUIDFrom(row.ID),
+		CreatedAt:      row.CreatedAt.Time,
+		EmailName:      row.EmailName,
+		TemplateParams: row.TemplateParams,
+		Status:         row.Status,
+		Error:          row.LastError.String,
+		SentAt:         utils.TimeFrom(row.SentAt),
+	}
+}
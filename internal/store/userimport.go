@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/supanova-rp/supanova-server/internal/domain"
+	"github.com/supanova-rp/supanova-server/internal/store/sqlc"
+	"github.com/supanova-rp/supanova-server/internal/utils"
+)
+
+func (s *Store) AddUserImport(ctx context.Context, params domain.AddUserImportParams) (uuid.UUID, error) {
+	id, err := ExecQuery(ctx, func() (pgtype.UUID, error) {
+		return s.queries(ctx).AddUserImport(ctx, sqlc.AddUserImportParams{
+			Report:    params.Report,
+			CreatedBy: optionalText(params.CreatedBy),
+		})
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return utils.UUIDFrom(id), nil
+}
+
+func (s *Store) CompleteUserImport(ctx context.Context, params domain.CompleteUserImportParams) error {
+	return ExecCommand(ctx, func() error {
+		updated, err := s.queries(ctx).CompleteUserImport(ctx, sqlc.CompleteUserImportParams{
+			Report: params.Report,
+			ID:     utils.PGUUIDFromUUID(params.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			return pgx.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+func (s *Store) GetUserImport(ctx context.Context, id uuid.UUID) (*domain.UserImport, error) {
+	row, err := ExecQuery(ctx, func() (sqlc.UserImport, error) {
+		return s.queries(ctx).GetUserImport(ctx, utils.PGUUIDFromUUID(id))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserImport{
+		ID:          utils.UUIDFrom(row.ID),
+		Status:      domain.UserImportStatus(row.Status),
+		Report:      row.Report,
+		CreatedBy:   row.CreatedBy.String,
+		CreatedAt:   row.CreatedAt.Time.UTC(),
+		CompletedAt: utils.TimeFrom(row.CompletedAt),
+	}, nil
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -15,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 
+	"github.com/supanova-rp/supanova-server/internal/config"
 	"github.com/supanova-rp/supanova-server/internal/domain"
 	"github.com/supanova-rp/supanova-server/internal/handlers"
 )
@@ -57,6 +60,72 @@ func TestAuth(t *testing.T) {
 		}
 	})
 
+	t.Run("sign in and use the access token as a bearer token", func(t *testing.T) {
+		register(t, testResources.AppURL, &handlers.RegisterParams{
+			Name:     "Signed In User",
+			Email:    "signedin@example.com",
+			Password: "password123",
+		})
+
+		signIn(t, "signedin@example.com", "wrong-password", http.StatusUnauthorized)
+		token := signIn(t, "signedin@example.com", "password123", http.StatusOK)
+
+		resp := makeRequestWithToken(t, http.MethodGet, "roles/me", nil, token)
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		own := parseJSONResponse[handlers.OwnRoleResponse](t, resp)
+		if own.Role != config.UserRole {
+			t.Errorf("expected role %s, got %s", config.UserRole, own.Role)
+		}
+	})
+
+	t.Run("invalid access token", func(t *testing.T) {
+		resp := makeRequestWithToken(t, http.MethodGet, "roles/me", nil, "not-a-token")
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+		}
+	})
+
+	t.Run("set password from a password setup link", func(t *testing.T) {
+		register(t, testResources.AppURL, &handlers.RegisterParams{
+			Name:     "Invited User",
+			Email:    "invited@example.com",
+			Password: "password123",
+		})
+
+		link, err := testResources.Auth.GetPasswordSetupLink(t.Context(), "invited@example.com")
+		if err != nil {
+			t.Fatalf("failed to get password setup link: %v", err)
+		}
+
+		parsed, err := url.Parse(link)
+		if err != nil {
+			t.Fatalf("failed to parse password setup link: %v", err)
+		}
+
+		params := &handlers.SetPasswordParams{Token: parsed.Query().Get("token"), Password: "new-password123"}
+
+		resp := makeRequestWithToken(t, http.MethodPost, "auth/set-password", params, "")
+		resp.Body.Close() //nolint:errcheck,gosec
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("expected status %d, got %d", http.StatusNoContent, resp.StatusCode)
+		}
+
+		signIn(t, "invited@example.com", "password123", http.StatusUnauthorized)
+		signIn(t, "invited@example.com", "new-password123", http.StatusOK)
+
+		// The link can only be used once
+		resp = makeRequestWithToken(t, http.MethodPost, "auth/set-password", params, "")
+		resp.Body.Close() //nolint:errcheck,gosec
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+		}
+	})
+
 	t.Run("register - missing required fields", func(t *testing.T) {
 		resp := makePOSTRequest(t, testResources.AppURL, "register", &handlers.RegisterParams{
 			Name: "New User",
@@ -212,6 +281,73 @@ func TestMaterials(t *testing.T) {
 	})
 }
 
+func TestCertificate(t *testing.T) {
+	t.Run("issues a certificate when a course is completed", func(t *testing.T) {
+		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		})
+
+		enrolUserInCourse(t, testResources.AppURL, created.ID)
+		setCourseCompleted(t, testResources.AppURL, created.ID)
+
+		cert := waitForCertificate(t, testResources.AppURL, created.ID)
+
+		if cert.UserID != TestUserID || cert.CourseTitle != courseTitle || cert.CompletionTitle != courseCompletionTitle {
+			t.Errorf("unexpected certificate %+v", cert.Certificate)
+		}
+
+		expectedURL := fmt.Sprintf("https://cdn.example.com/%s/certificates/%s.pdf", created.ID, cert.ID)
+		if cert.URL != expectedURL {
+			t.Errorf("expected certificate URL %s, got %s", expectedURL, cert.URL)
+		}
+	})
+
+	t.Run("certificates can be verified publicly until they're revoked", func(t *testing.T) {
+		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		})
+
+		validityMonths := 12
+		postOnly(t, testResources.AppURL, "courses/certificate-validity", &handlers.SetCertificateValidityParams{
+			CourseID:       created.ID.String(),
+			ValidityMonths: &validityMonths,
+		}, http.StatusNoContent)
+
+		enrolUserInCourse(t, testResources.AppURL, created.ID)
+		setCourseCompleted(t, testResources.AppURL, created.ID)
+
+		cert := waitForCertificate(t, testResources.AppURL, created.ID)
+
+		verification := verifyCertificate(t, cert.VerificationID, http.StatusOK)
+		if verification.Number != cert.Number || verification.CourseTitle != courseTitle ||
+			verification.Status != domain.CertificateStatusValid {
+			t.Errorf("unexpected verification %+v", verification)
+		}
+
+		expectedExpiry := cert.IssuedAt.AddDate(0, validityMonths, 0)
+		if verification.ExpiresAt == nil || !verification.ExpiresAt.Equal(expectedExpiry) {
+			t.Errorf("expected certificate to expire at %s, got %v", expectedExpiry, verification.ExpiresAt)
+		}
+
+		revoke := &handlers.RevokeCertificateParams{CertificateID: cert.ID.String(), Reason: "Issued in error"}
+		postOnlyAs(t, testResources.AppURL, "certificates/revoke", revoke, TestUserID, config.UserRole, http.StatusForbidden)
+		postOnly(t, testResources.AppURL, "certificates/revoke", revoke, http.StatusNoContent)
+
+		verification = verifyCertificate(t, cert.VerificationID, http.StatusOK)
+		if !verification.Revoked || verification.Status != domain.CertificateStatusRevoked {
+			t.Errorf("expected certificate to be revoked, got %+v", verification)
+		}
+
+		verifyCertificate(t, "unknown", http.StatusNotFound)
+	})
+}
+
 func TestProgress(t *testing.T) {
 	t.Run("user progress - happy path", func(t *testing.T) {
 		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
@@ -245,7 +381,33 @@ func TestProgress(t *testing.T) {
 			t.Errorf("progress mismatch (-want +got):\n%s", diff)
 		}
 
-		resetProgress(t, testResources.AppURL, created.ID)
+		resetProgress(t, testResources.AppURL, &handlers.ResetProgressParams{
+			ResetTargetParams: handlers.ResetTargetParams{UserID: "unknown-user", Reason: "Wrong user"},
+			CourseID:          created.ID.String(),
+		}, http.StatusNotFound)
+
+		reset := resetProgress(t, testResources.AppURL, &handlers.ResetProgressParams{
+			ResetTargetParams: handlers.ResetTargetParams{Cohort: true, Reason: "Course content changed"},
+			CourseID:          created.ID.String(),
+		}, http.StatusOK)
+
+		if len(reset.Users) != 1 || reset.Users[0].ID != TestUserID || reset.CourseTitle != courseTitle {
+			t.Errorf("expected the cohort reset to cover only %s, got %+v", TestUserID, reset)
+		}
+
+		var archived int
+		err := testResources.DB.QueryRowContext(
+			t.Context(),
+			"SELECT count(*) FROM archived_progress WHERE reset_id = $1 AND user_id = $2",
+			reset.ID,
+			TestUserID,
+		).Scan(&archived)
+		if err != nil {
+			t.Fatalf("failed to count archived progress: %v", err)
+		}
+		if archived != 1 {
+			t.Errorf("expected the previous progress to be archived, got %d rows", archived)
+		}
 
 		afterReset := getProgress(t, testResources.AppURL, created.ID)
 
@@ -271,6 +433,139 @@ func TestProgress(t *testing.T) {
 	})
 }
 
+func TestEnrolment(t *testing.T) {
+	t.Run("bulk enrolment - idempotent with per item results", func(t *testing.T) {
+		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		})
+		unknownUserID := "unknown-user"
+
+		params := &handlers.BulkUpdateEnrolmentsParams{
+			UserIDs:   []string{TestUserID, unknownUserID},
+			CourseIDs: []string{created.ID.String()},
+			Action:    string(domain.BulkEnrolmentActionEnrol),
+		}
+
+		steps := []struct {
+			action         domain.BulkEnrolmentAction
+			expectedStatus domain.BulkEnrolmentResultStatus
+		}{
+			{domain.BulkEnrolmentActionEnrol, domain.BulkEnrolmentResultEnrolled},
+			{domain.BulkEnrolmentActionEnrol, domain.BulkEnrolmentResultAlreadyEnrolled},
+			{domain.BulkEnrolmentActionDisenrol, domain.BulkEnrolmentResultDisenrolled},
+			{domain.BulkEnrolmentActionDisenrol, domain.BulkEnrolmentResultNotEnrolled},
+		}
+
+		for _, step := range steps {
+			params.Action = string(step.action)
+
+			expected := []domain.BulkEnrolmentResult{
+				{UserID: TestUserID, CourseID: created.ID, Status: step.expectedStatus},
+				{UserID: unknownUserID, CourseID: created.ID, Status: domain.BulkEnrolmentResultUserNotFound},
+			}
+
+			actual := bulkUpdateEnrolments(t, testResources.AppURL, params)
+
+			if diff := cmp.Diff(expected, actual); diff != "" {
+				t.Errorf("%s results mismatch (-want +got):\n%s", step.action, diff)
+			}
+		}
+
+		deleteCourse(t, testResources.AppURL, created.ID)
+	})
+}
+
+func TestGroups(t *testing.T) {
+	t.Run("group members are enrolled in group courses", func(t *testing.T) {
+		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		})
+
+		group := addGroup(t, testResources.AppURL, "Site B")
+
+		postOnly(t, testResources.AppURL, "groups/courses/assign", &handlers.AssignCourseToGroupParams{
+			GroupID:  group.ID.String(),
+			CourseID: created.ID.String(),
+		}, http.StatusNoContent)
+
+		// Members added after the course was assigned are enrolled too
+		postOnly(t, testResources.AppURL, "groups/members/add", &handlers.GroupMembersParams{
+			GroupID: group.ID.String(),
+			UserIDs: []string{TestUserID},
+		}, http.StatusNoContent)
+
+		page := listUsersAndAssignedCourses(t, testResources.AppURL, &handlers.ListUsersAndAssignedCoursesParams{
+			GroupID: group.ID.String(),
+		})
+
+		expected := []domain.UserWithAssignedCourses{
+			{
+				ID:        TestUserID,
+				CourseIDs: []uuid.UUID{created.ID},
+				Groups:    []domain.GroupSummary{{ID: group.ID, Name: group.Name}},
+			},
+		}
+
+		opts := cmpopts.IgnoreFields(domain.UserWithAssignedCourses{}, "Name", "Email", "CourseIDs")
+		if diff := cmp.Diff(expected, page.Items, opts); diff != "" {
+			t.Fatalf("group members mismatch (-want +got):\n%s", diff)
+		}
+
+		if !slices.Contains(page.Items[0].CourseIDs, created.ID) {
+			t.Errorf("expected course %s to be in test user's courses, got %v", created.ID, page.Items[0].CourseIDs)
+		}
+
+		postOnly(t, testResources.AppURL, "groups/delete", &handlers.DeleteGroupParams{GroupID: group.ID.String()}, http.StatusNoContent)
+		deleteCourse(t, testResources.AppURL, created.ID)
+	})
+}
+
+func TestAccessCodes(t *testing.T) {
+	t.Run("redeeming an access code enrols the user once", func(t *testing.T) {
+		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		})
+
+		maxUses := 1
+		accessCode := postAndParse[domain.AccessCode](t, testResources.AppURL, "access-codes/add", &handlers.AddAccessCodeParams{
+			CourseID: created.ID.String(),
+			MaxUses:  &maxUses,
+		}, http.StatusCreated)
+
+		redeem := &handlers.RedeemAccessCodeParams{Code: accessCode.Code}
+
+		first := postAndParse[domain.RedeemAccessCodeResult](t, testResources.AppURL, "access-codes/redeem", redeem, http.StatusOK)
+		if diff := cmp.Diff(&domain.RedeemAccessCodeResult{CourseID: created.ID}, first); diff != "" {
+			t.Errorf("first redemption mismatch (-want +got):\n%s", diff)
+		}
+
+		// Redeeming again doesn't count towards max uses
+		second := postAndParse[domain.RedeemAccessCodeResult](t, testResources.AppURL, "access-codes/redeem", redeem, http.StatusOK)
+		if diff := cmp.Diff(&domain.RedeemAccessCodeResult{CourseID: created.ID, AlreadyRedeemed: true}, second); diff != "" {
+			t.Errorf("second redemption mismatch (-want +got):\n%s", diff)
+		}
+
+		redemptionsParams := &handlers.AccessCodeParams{ID: accessCode.ID.String()}
+		redemptions := postAndParse[[]domain.AccessCodeRedemption](
+			t, testResources.AppURL, "access-codes/redemptions", redemptionsParams, http.StatusOK,
+		)
+		if len(*redemptions) != 1 || (*redemptions)[0].UserID != TestUserID {
+			t.Errorf("expected a single redemption by %s, got %+v", TestUserID, *redemptions)
+		}
+
+		deleteCourse(t, testResources.AppURL, created.ID)
+	})
+}
+
 func TestEditCourse(t *testing.T) {
 	t.Run("updates course fields, sections, and materials", func(t *testing.T) {
 		videoStorageKey := uuid.New()
@@ -773,3 +1068,339 @@ func TestQuiz(t *testing.T) {
 		}
 	})
 }
+
+func TestUsers(t *testing.T) {
+	t.Run("user happy path - update, deactivate, reactivate, delete", func(t *testing.T) {
+		result := register(t, testResources.AppURL, &handlers.RegisterParams{
+			Name:     "Leaver",
+			Email:    "leaver@example.com",
+			Password: "password123",
+		})
+		userID := result["newUserId"]
+
+		updated := postAndParse[domain.User](t, testResources.AppURL, "users/update", &handlers.UpdateUserParams{
+			UserID: userID,
+			Name:   "Leaving User",
+			Email:  "leaving@example.com",
+		}, http.StatusOK)
+		if updated.Name != "Leaving User" || updated.Email != "leaving@example.com" {
+			t.Errorf("expected updated name and email, got %+v", updated)
+		}
+
+		postOnly(t, testResources.AppURL, "users/deactivate", &handlers.UserIDParams{UserID: userID}, http.StatusNoContent)
+
+		listParams := &handlers.ListUsersParams{
+			PaginationParams: handlers.PaginationParams{Search: "leaving@"},
+			Status:           string(domain.UserStatusDeactivated),
+		}
+		page := postAndParse[domain.Page[domain.User]](t, testResources.AppURL, "users/list", listParams, http.StatusOK)
+		if len(page.Items) != 1 || page.Items[0].ID != userID || page.Items[0].DeactivatedAt == nil {
+			t.Fatalf("expected deactivated user %s, got %+v", userID, page.Items)
+		}
+
+		postOnly(t, testResources.AppURL, "users/reactivate", &handlers.UserIDParams{UserID: userID}, http.StatusNoContent)
+
+		page = postAndParse[domain.Page[domain.User]](t, testResources.AppURL, "users/list", listParams, http.StatusOK)
+		if page.TotalCount != 0 {
+			t.Errorf("expected no deactivated users after reactivating, got %d", page.TotalCount)
+		}
+
+		postOnly(t, testResources.AppURL, "users/delete", &handlers.UserIDParams{UserID: userID}, http.StatusNoContent)
+		postOnly(t, testResources.AppURL, "users/delete", &handlers.UserIDParams{UserID: userID}, http.StatusNotFound)
+	})
+}
+
+func TestUserData(t *testing.T) {
+	t.Run("export and erase user data", func(t *testing.T) {
+		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		})
+
+		result := register(t, testResources.AppURL, &handlers.RegisterParams{
+			Name:     "Data Subject",
+			Email:    "subject@example.com",
+			Password: "password123",
+		})
+		userID := result["newUserId"]
+
+		bulkUpdateEnrolments(t, testResources.AppURL, &handlers.BulkUpdateEnrolmentsParams{
+			UserIDs:   []string{userID},
+			CourseIDs: []string{created.ID.String()},
+			Action:    string(domain.BulkEnrolmentActionEnrol),
+		})
+
+		export := postAndParse[domain.UserDataExport](t, testResources.AppURL, "users/export", &handlers.ExportUserDataParams{
+			UserID: userID,
+		}, http.StatusOK)
+		if export.User.Email != "subject@example.com" {
+			t.Errorf("expected exported user email, got %q", export.User.Email)
+		}
+		if len(export.Enrolments) != 1 || export.Enrolments[0].CourseID != created.ID {
+			t.Errorf("expected enrolment in course %s, got %+v", created.ID, export.Enrolments)
+		}
+
+		erased := postAndParse[domain.ErasedUser](t, testResources.AppURL, "users/erase", &handlers.UserIDParams{
+			UserID: userID,
+		}, http.StatusOK)
+		if erased.RetainedCompletions != 0 {
+			t.Errorf("expected no retained completions, got %d", erased.RetainedCompletions)
+		}
+
+		postOnly(t, testResources.AppURL, "users/export", &handlers.ExportUserDataParams{UserID: userID}, http.StatusNotFound)
+
+		page := postAndParse[domain.Page[domain.User]](t, testResources.AppURL, "users/list", &handlers.ListUsersParams{
+			PaginationParams: handlers.PaginationParams{Search: "subject@"},
+		}, http.StatusOK)
+		if page.TotalCount != 0 {
+			t.Errorf("expected erased user to be removed from users list, got %d", page.TotalCount)
+		}
+
+		deleteCourse(t, testResources.AppURL, created.ID)
+	})
+}
+
+func TestRoles(t *testing.T) {
+	t.Run("manager can only view progress for the groups they manage", func(t *testing.T) {
+		result := register(t, testResources.AppURL, &handlers.RegisterParams{
+			Name:     "Ward Manager",
+			Email:    "ward.manager@example.com",
+			Password: "password123",
+		})
+		managerID := result["newUserId"]
+
+		postOnly(t, testResources.AppURL, "roles/set", &handlers.SetUserRoleParams{
+			UserID: managerID,
+			Role:   string(config.ManagerRole),
+		}, http.StatusNoContent)
+
+		roles := postAndParse[[]domain.UserRole](t, testResources.AppURL, "roles", nil, http.StatusOK)
+		idx := slices.IndexFunc(*roles, func(r domain.UserRole) bool { return r.UserID == managerID })
+		if idx == -1 || (*roles)[idx].Role != config.ManagerRole {
+			t.Fatalf("expected %s to be a manager, got %+v", managerID, *roles)
+		}
+
+		group := addGroup(t, testResources.AppURL, "Ward 1")
+		postOnly(t, testResources.AppURL, "groups/managers/add", &handlers.GroupMembersParams{
+			GroupID: group.ID.String(),
+			UserIDs: []string{managerID},
+		}, http.StatusNoContent)
+
+		own := postAndParseAs[handlers.OwnRoleResponse](t, testResources.AppURL, "roles/me", nil, managerID, config.ManagerRole, http.StatusOK)
+		if !slices.Contains(own.ManagedGroupIDs, group.ID) {
+			t.Errorf("expected manager of group %s, got %v", group.ID, own.ManagedGroupIDs)
+		}
+
+		managedProgress := &handlers.ListProgressParams{GroupID: group.ID.String()}
+		postOnlyAs(t, testResources.AppURL, "admin/progress/list", managedProgress, managerID, config.ManagerRole, http.StatusOK)
+
+		otherGroup := addGroup(t, testResources.AppURL, "Ward 2")
+		otherProgress := &handlers.ListProgressParams{GroupID: otherGroup.ID.String()}
+		postOnlyAs(t, testResources.AppURL, "admin/progress/list", otherProgress, managerID, config.ManagerRole, http.StatusForbidden)
+		postOnlyAs(t, testResources.AppURL, "admin/progress/list", nil, managerID, config.ManagerRole, http.StatusForbidden)
+		postOnlyAs(t, testResources.AppURL, "users/list", nil, managerID, config.ManagerRole, http.StatusForbidden)
+
+		postOnly(t, testResources.AppURL, "roles/set", &handlers.SetUserRoleParams{
+			UserID: managerID,
+			Role:   string(config.UserRole),
+		}, http.StatusNoContent)
+
+		roles = postAndParse[[]domain.UserRole](t, testResources.AppURL, "roles", nil, http.StatusOK)
+		if slices.ContainsFunc(*roles, func(r domain.UserRole) bool { return r.UserID == managerID }) {
+			t.Errorf("expected role for %s to be removed, got %+v", managerID, *roles)
+		}
+	})
+
+	t.Run("auditor can view reports but not change anything", func(t *testing.T) {
+		postOnlyAs(t, testResources.AppURL, "users/list", nil, "auditor-id", config.AuditorRole, http.StatusOK)
+		postOnlyAs(t, testResources.AppURL, "admin/progress/list", nil, "auditor-id", config.AuditorRole, http.StatusOK)
+		postOnlyAs(t, testResources.AppURL, "add-course", &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		}, "auditor-id", config.AuditorRole, http.StatusForbidden)
+		// Access codes can be redeemed, so they're not part of the reports
+		postOnlyAs(t, testResources.AppURL, "access-codes", &handlers.GetAccessCodesParams{
+			CourseID: uuid.NewString(),
+		}, "auditor-id", config.AuditorRole, http.StatusForbidden)
+	})
+
+	t.Run("learners can't call admin routes", func(t *testing.T) {
+		postOnlyAs(t, testResources.AppURL, "users/list", nil, "learner-id", config.UserRole, http.StatusForbidden)
+		postOnlyAs(t, testResources.AppURL, "roles/set", &handlers.SetUserRoleParams{
+			UserID: "learner-id",
+			Role:   string(config.AdminRole),
+		}, "learner-id", config.UserRole, http.StatusForbidden)
+	})
+}
+
+func TestLineManagers(t *testing.T) {
+	t.Run("line manager can only view their direct reports", func(t *testing.T) {
+		manager := register(t, testResources.AppURL, &handlers.RegisterParams{
+			Name:     "Line Manager",
+			Email:    "line.manager@example.com",
+			Password: "password123",
+		})
+		managerID := manager["newUserId"]
+
+		report := register(t, testResources.AppURL, &handlers.RegisterParams{
+			Name:     "Direct Report",
+			Email:    "direct.report@example.com",
+			Password: "password123",
+		})
+		reportID := report["newUserId"]
+
+		postOnly(t, testResources.AppURL, "users/manager/set", &handlers.SetLineManagerParams{
+			UserID:    reportID,
+			ManagerID: managerID,
+		}, http.StatusNoContent)
+
+		progress := postAndParseAs[domain.Page[*domain.FullProgress]](
+			t, testResources.AppURL, "team/progress", nil, managerID, config.UserRole, http.StatusOK,
+		)
+		if len(progress.Items) != 1 || progress.Items[0].UserID != reportID {
+			t.Errorf("expected only %s in team progress, got %+v", reportID, progress.Items)
+		}
+
+		compliance := postAndParseAs[domain.TeamCompliance](
+			t, testResources.AppURL, "team/compliance", nil, managerID, config.UserRole, http.StatusOK,
+		)
+		for _, enrolment := range compliance.Enrolments {
+			if enrolment.UserID != reportID {
+				t.Errorf("expected only enrolments of %s, got %+v", reportID, enrolment)
+			}
+		}
+
+		// The report has no direct reports of their own
+		reportProgress := postAndParseAs[domain.Page[*domain.FullProgress]](
+			t, testResources.AppURL, "team/progress", nil, reportID, config.UserRole, http.StatusOK,
+		)
+		if len(reportProgress.Items) != 0 {
+			t.Errorf("expected empty team progress, got %+v", reportProgress.Items)
+		}
+
+		postOnlyAs(t, testResources.AppURL, "users/manager/set", &handlers.SetLineManagerParams{
+			UserID: reportID,
+		}, managerID, config.UserRole, http.StatusForbidden)
+
+		postOnly(t, testResources.AppURL, "users/manager/set", &handlers.SetLineManagerParams{
+			UserID: reportID,
+		}, http.StatusNoContent)
+
+		progress = postAndParseAs[domain.Page[*domain.FullProgress]](
+			t, testResources.AppURL, "team/progress", nil, managerID, config.UserRole, http.StatusOK,
+		)
+		if len(progress.Items) != 0 {
+			t.Errorf("expected empty team progress after removing line manager, got %+v", progress.Items)
+		}
+	})
+
+	t.Run("unknown manager", func(t *testing.T) {
+		postOnly(t, testResources.AppURL, "users/manager/set", &handlers.SetLineManagerParams{
+			UserID:    TestUserID,
+			ManagerID: "unknown-user",
+		}, http.StatusNotFound)
+	})
+}
+
+func TestAPIKeys(t *testing.T) {
+	t.Run("API key can read reports until it's revoked", func(t *testing.T) {
+		created := postAndParse[handlers.AddAPIKeyResponse](t, testResources.AppURL, "api-keys/add", &handlers.AddAPIKeyParams{
+			Name: "HR system",
+		}, http.StatusCreated)
+
+		resp := makeRequestWithToken(t, http.MethodPost, "users/list", nil, created.Key)
+		resp.Body.Close() //nolint:errcheck,gosec
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		// The default progress scope covers every group's progress
+		resp = makeRequestWithToken(t, http.MethodPost, "admin/progress/list", nil, created.Key)
+		resp.Body.Close() //nolint:errcheck,gosec
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d listing progress, got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		// Keys are read-only by default
+		resp = makeRequestWithToken(t, http.MethodPost, "users/import", nil, created.Key)
+		resp.Body.Close() //nolint:errcheck,gosec
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected status %d, got %d", http.StatusForbidden, resp.StatusCode)
+		}
+
+		keys := postAndParse[[]domain.APIKey](t, testResources.AppURL, "api-keys", nil, http.StatusOK)
+		idx := slices.IndexFunc(*keys, func(k domain.APIKey) bool { return k.ID == created.ID })
+		if idx == -1 {
+			t.Fatalf("expected key %s to be listed", created.ID)
+		}
+		if (*keys)[idx].LastUsedAt == nil {
+			t.Error("expected key to have a last used time")
+		}
+
+		postOnly(t, testResources.AppURL, "api-keys/revoke", &handlers.RevokeAPIKeyParams{
+			ID: created.ID.String(),
+		}, http.StatusNoContent)
+
+		resp = makeRequestWithToken(t, http.MethodPost, "users/list", nil, created.Key)
+		resp.Body.Close() //nolint:errcheck,gosec
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+		}
+	})
+
+	t.Run("only admins can create API keys", func(t *testing.T) {
+		postOnlyAs(t, testResources.AppURL, "api-keys/add", &handlers.AddAPIKeyParams{
+			Name: "HR system",
+		}, "auditor-id", config.AuditorRole, http.StatusForbidden)
+	})
+}
+
+func TestAuditLog(t *testing.T) {
+	t.Run("admin actions are recorded in a verifiable chain", func(t *testing.T) {
+		created := addCourse(t, testResources.AppURL, &handlers.AddCourseParams{
+			Title:             courseTitle,
+			Description:       courseDescription,
+			CompletionTitle:   courseCompletionTitle,
+			CompletionMessage: courseCompletionMessage,
+		})
+		deleteCourse(t, testResources.AppURL, created.ID)
+
+		entries := postAndParse[domain.Page[domain.AuditEntry]](t, testResources.AppURL, "audit-log", &handlers.ListAuditLogParams{
+			TargetType: domain.AuditTargetCourse,
+			TargetID:   created.ID.String(),
+		}, http.StatusOK)
+
+		actions := make([]domain.AuditAction, 0, len(entries.Items))
+		for _, entry := range entries.Items {
+			actions = append(actions, entry.Action)
+		}
+		expected := []domain.AuditAction{domain.AuditActionAddCourse, domain.AuditActionDeleteCourse}
+		if diff := cmp.Diff(expected, actions); diff != "" {
+			t.Fatalf("actions mismatch (-want +got):\n%s", diff)
+		}
+
+		deleted := entries.Items[1]
+		if deleted.ActorID != TestUserID {
+			t.Errorf("expected actor %s, got %s", TestUserID, deleted.ActorID)
+		}
+		if deleted.Before == nil || string(deleted.After) != "null" {
+			t.Errorf("expected state before and no state after, got %s and %s", deleted.Before, deleted.After)
+		}
+		if deleted.PrevHash == "" || deleted.PrevHash == deleted.Hash {
+			t.Errorf("expected entry to be chained, got previous hash %q", deleted.PrevHash)
+		}
+
+		verification := postAndParse[domain.AuditVerification](t, testResources.AppURL, "audit-log/verify", nil, http.StatusOK)
+		if !verification.Valid {
+			t.Errorf("expected audit log to be valid, first invalid entry %v", verification.FirstInvalidID)
+		}
+	})
+
+	t.Run("only admins can read the audit log", func(t *testing.T) {
+		postOnlyAs(t, testResources.AppURL, "audit-log", nil, "auditor-id", config.AuditorRole, http.StatusForbidden)
+	})
+}
@@ -10,8 +10,10 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 
 	"github.com/supanova-rp/supanova-server/internal/config"
 	"github.com/supanova-rp/supanova-server/internal/domain"
@@ -81,9 +83,62 @@ func getMaterials(t *testing.T, baseURL string, courseID uuid.UUID) []domain.Cou
 	return *postAndParse[[]domain.CourseMaterialWithURL](t, baseURL, "materials", map[string]string{"courseId": courseID.String()}, http.StatusOK)
 }
 
-func resetProgress(t *testing.T, baseURL string, courseID uuid.UUID) {
+func resetProgress(t *testing.T, baseURL string, params *handlers.ResetProgressParams, expectedStatus int) *domain.ProgressReset {
 	t.Helper()
-	postOnly(t, baseURL, "reset-progress", &handlers.ResetProgressParams{CourseID: courseID.String()}, http.StatusNoContent)
+	resp := makePOSTRequest(t, baseURL, "reset-progress", params)
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("expected status %d, got %d", expectedStatus, resp.StatusCode)
+	}
+	if expectedStatus != http.StatusOK {
+		return nil
+	}
+	return parseJSONResponse[domain.ProgressReset](t, resp)
+}
+
+func setCourseCompleted(t *testing.T, baseURL string, courseID uuid.UUID) {
+	t.Helper()
+	postOnly(t, baseURL, "set-course-completed", &handlers.SetCourseCompletedParams{CourseID: courseID.String(), CourseName: courseTitle}, http.StatusNoContent)
+}
+
+const (
+	certificateTimeout      = 5 * time.Second
+	certificatePollInterval = 100 * time.Millisecond
+)
+
+// waitForCertificate polls for the certificate of a completed course, which is issued after the
+// completion request returns
+func waitForCertificate(t *testing.T, baseURL string, courseID uuid.UUID) *handlers.CertificateResponse {
+	t.Helper()
+
+	deadline := time.Now().Add(certificateTimeout)
+	for {
+		resp := makePOSTRequest(t, baseURL, "certificate", &handlers.GetCertificateParams{CourseID: courseID.String()})
+		if resp.StatusCode == http.StatusOK {
+			defer resp.Body.Close() //nolint:errcheck
+			return parseJSONResponse[handlers.CertificateResponse](t, resp)
+		}
+		resp.Body.Close() //nolint:errcheck
+
+		if time.Now().After(deadline) {
+			t.Fatalf("certificate not issued, last status %d", resp.StatusCode)
+		}
+		time.Sleep(certificatePollInterval)
+	}
+}
+
+// verifyCertificate checks a certificate without signing in, as anyone scanning its QR code would
+func verifyCertificate(t *testing.T, verificationID string, expectedStatus int) *domain.CertificateVerification {
+	t.Helper()
+	resp := makeRequestWithToken(t, http.MethodGet, "certificates/verify/"+verificationID, nil, "")
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("expected status %d, got %d", expectedStatus, resp.StatusCode)
+	}
+	if expectedStatus != http.StatusOK {
+		return nil
+	}
+	return parseJSONResponse[domain.CertificateVerification](t, resp)
 }
 
 func setIntroCompleted(t *testing.T, baseURL string, courseID uuid.UUID) *handlers.SetIntroCompletedResponse {
@@ -96,6 +151,25 @@ func enrolUserInCourse(t *testing.T, baseURL string, courseID uuid.UUID) {
 	postOnly(t, baseURL, "update-users-to-courses", &handlers.UpdateCourseEnrolmentParams{UserID: TestUserID, CourseID: courseID.String(), IsEnrolled: false}, http.StatusNoContent)
 }
 
+func bulkUpdateEnrolments(t *testing.T, baseURL string, params *handlers.BulkUpdateEnrolmentsParams) []domain.BulkEnrolmentResult {
+	t.Helper()
+	return *postAndParse[[]domain.BulkEnrolmentResult](t, baseURL, "enrolments/bulk", params, http.StatusOK)
+}
+
+func addGroup(t *testing.T, baseURL, name string) *domain.Group {
+	t.Helper()
+	return postAndParse[domain.Group](t, baseURL, "groups/add", &handlers.AddGroupParams{Name: name}, http.StatusCreated)
+}
+
+func listUsersAndAssignedCourses(
+	t *testing.T,
+	baseURL string,
+	params *handlers.ListUsersAndAssignedCoursesParams,
+) *domain.Page[domain.UserWithAssignedCourses] {
+	t.Helper()
+	return postAndParse[domain.Page[domain.UserWithAssignedCourses]](t, baseURL, "users-to-courses/list", params, http.StatusOK)
+}
+
 func postAndParse[T any](t *testing.T, baseURL, endpoint string, body any, expectedStatus int) *T {
 	t.Helper()
 	resp := makePOSTRequest(t, baseURL, endpoint, body)
@@ -127,8 +201,32 @@ func register(t *testing.T, baseURL string, params *handlers.RegisterParams) map
 	return *parseJSONResponse[map[string]string](t, resp)
 }
 
+func postOnlyAs(t *testing.T, baseURL, endpoint string, body any, userID string, role config.Role, expectedStatus int) {
+	t.Helper()
+	resp := makePOSTRequestAs(t, baseURL, endpoint, body, userID, role)
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("expected status %d, got %d", expectedStatus, resp.StatusCode)
+	}
+}
+
+func postAndParseAs[T any](t *testing.T, baseURL, endpoint string, body any, userID string, role config.Role, expectedStatus int) *T {
+	t.Helper()
+	resp := makePOSTRequestAs(t, baseURL, endpoint, body, userID, role)
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("expected status %d, got %d", expectedStatus, resp.StatusCode)
+	}
+	return parseJSONResponse[T](t, resp)
+}
+
 func makePOSTRequest(t *testing.T, baseURL, endpoint string, resource any) *http.Response {
 	t.Helper()
+	return makePOSTRequestAs(t, baseURL, endpoint, resource, TestUserID, config.AdminRole)
+}
+
+func makePOSTRequestAs(t *testing.T, baseURL, endpoint string, resource any, userID string, role config.Role) *http.Response {
+	t.Helper()
 
 	parsedURL, err := url.Parse(fmt.Sprintf("%s/%s/%s", baseURL, config.APIVersion, endpoint))
 	if err != nil {
@@ -146,8 +244,7 @@ func makePOSTRequest(t *testing.T, baseURL, endpoint string, resource any) *http
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Test-User-ID", TestUserID)
-	req.Header.Set("X-Test-User-Role", string(config.AdminRole))
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+accessTokenFor(t, userID, role))
 
 	client := &http.Client{}
 	res, err := client.Do(req)
@@ -158,6 +255,97 @@ func makePOSTRequest(t *testing.T, baseURL, endpoint string, resource any) *http
 	return res
 }
 
+// makeRequestWithToken sends the request with the access token as a bearer token, a nil body sends
+// no body
+func makeRequestWithToken(t *testing.T, method, endpoint string, body any, token string) *http.Response {
+	t.Helper()
+
+	reqBody := io.Reader(http.NoBody)
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to parse request body: %v", err)
+		}
+		reqBody = bytes.NewBuffer(b)
+	}
+
+	reqURL := fmt.Sprintf("%s/%s/%s", testResources.AppURL, config.APIVersion, endpoint)
+	req, err := http.NewRequestWithContext(t.Context(), method, reqURL, reqBody)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to make request: %v", err)
+	}
+
+	return res
+}
+
+func signIn(t *testing.T, email, password string, expectedStatus int) string {
+	t.Helper()
+	resp := makeRequestWithToken(t, http.MethodPost, "auth/sign-in", &handlers.SignInParams{Email: email, Password: password}, "")
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("expected status %d, got %d", expectedStatus, resp.StatusCode)
+	}
+
+	if expectedStatus != http.StatusOK {
+		return ""
+	}
+
+	return parseJSONResponse[handlers.SignInResponse](t, resp).AccessToken
+}
+
+// accessTokenFor returns an access token from the local auth provider for the user, creating the
+// user with the role first so tests can make requests as any user
+func accessTokenFor(t *testing.T, userID string, role config.Role) string {
+	t.Helper()
+
+	ctx := t.Context()
+	db := testResources.DB
+	email := userID + "@example.com"
+
+	_, err := db.ExecContext(ctx, "INSERT INTO users (id, name, email) VALUES ($1, $1, $2) ON CONFLICT DO NOTHING", userID, email)
+	if err != nil {
+		t.Fatalf("failed to insert user: %v", err)
+	}
+
+	// Users created here have no password, so they can't sign in with /auth/sign-in
+	_, err = db.ExecContext(
+		ctx,
+		"INSERT INTO local_auth_users (id, email, password_hash) VALUES ($1, $2, '') ON CONFLICT DO NOTHING",
+		userID,
+		email,
+	)
+	if err != nil {
+		t.Fatalf("failed to insert auth user: %v", err)
+	}
+
+	_, err = db.ExecContext(
+		ctx,
+		"INSERT INTO user_roles (user_id, role) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET role = EXCLUDED.role",
+		userID,
+		string(role),
+	)
+	if err != nil {
+		t.Fatalf("failed to set user role: %v", err)
+	}
+
+	token, err := testResources.Auth.IssueToken(userID)
+	if err != nil {
+		t.Fatalf("failed to issue access token: %v", err)
+	}
+
+	return token
+}
+
 func parseJSONResponse[T any](t *testing.T, resp *http.Response) *T {
 	t.Helper()
 
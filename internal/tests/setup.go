@@ -11,12 +11,14 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/testcontainers/testcontainers-go/modules/compose"
 
 	"github.com/supanova-rp/supanova-server/internal/app"
 	"github.com/supanova-rp/supanova-server/internal/config"
 	"github.com/supanova-rp/supanova-server/internal/handlers/mocks"
+	"github.com/supanova-rp/supanova-server/internal/services/auth"
+	"github.com/supanova-rp/supanova-server/internal/services/certificate"
+	"github.com/supanova-rp/supanova-server/internal/services/email"
 	"github.com/supanova-rp/supanova-server/internal/store"
 )
 
@@ -24,10 +26,13 @@ const (
 	testPort                = "3007"
 	appStartTimeout         = 3 * time.Second
 	readyCheckRetryInterval = 250 * time.Millisecond
+	testClientURL           = "http://localhost:3000"
+	testSigningKey          = "e2e-test-signing-key-at-least-32-chars"
 )
 
 type TestResources struct {
 	Store        *store.Store
+	Auth         *auth.Local
 	ComposeStack *compose.DockerCompose
 	DB           *sql.DB
 	AppURL       string
@@ -63,8 +68,15 @@ func setupTestResources(ctx context.Context) (*TestResources, error) {
 	}
 
 	mockEmailService := &mocks.EmailServiceMock{
-		SetupRetryFunc: func() (context.CancelFunc, error) {
-			return nil, nil
+		StopDispatcherFunc: func(_ context.Context) {},
+		SendFunc: func(_ context.Context, _ email.EmailParams, _, _ string) error {
+			return nil
+		},
+		GetTemplateNamesFunc: func() *email.TemplateNames {
+			return &email.TemplateNames{}
+		},
+		GetEmailNamesFunc: func() *email.EmailNames {
+			return &email.EmailNames{}
 		},
 	}
 
@@ -72,6 +84,9 @@ func setupTestResources(ctx context.Context) (*TestResources, error) {
 		GetCDNURLFunc: func(_ context.Context, key string) (string, error) {
 			return fmt.Sprintf("https://cdn.example.com/%s", key), nil
 		},
+		UploadFunc: func(_ context.Context, _ string, _ []byte, _ string) error {
+			return nil
+		},
 	}
 
 	cfg := &config.App{
@@ -79,6 +94,7 @@ func setupTestResources(ctx context.Context) (*TestResources, error) {
 		DatabaseURL: postgresURL,
 		Environment: config.EnvironmentTest,
 		AWS:         nil, // Not needed for tests with mock object storage
+		ClientURLs:  []string{testClientURL},
 	}
 	appURL := fmt.Sprintf("http://localhost:%s", testPort)
 
@@ -87,19 +103,20 @@ func setupTestResources(ctx context.Context) (*TestResources, error) {
 		return nil, fmt.Errorf("failed to connect to database: %v", err)
 	}
 
-	// Start the app in a goroutine
-	mockAuthProvider := &mocks.AuthProviderMock{
-		CreateUserFunc: func(_ context.Context, _, _, _ string) (string, error) {
-			return uuid.New().String(), nil
-		},
-	}
+	// Tests sign in through the local auth provider, so requests go through the real auth middleware
+	authProvider := auth.NewLocal(&config.Auth{
+		Provider:        config.LocalAuthProvider,
+		LocalSigningKey: testSigningKey,
+	}, testClientURL, st)
 
+	// Start the app in a goroutine
 	go func() {
 		err := app.Run(ctx, cfg, app.Dependencies{
-			Store:         st,
-			EmailService:  mockEmailService,
-			ObjectStorage: mockObjectStorage,
-			AuthProvider:  mockAuthProvider,
+			Store:               st,
+			EmailService:        mockEmailService,
+			ObjectStorage:       mockObjectStorage,
+			AuthProvider:        authProvider,
+			CertificateRenderer: certificate.New(testClientURL + "/certificates/verify"),
 		})
 		if err != nil {
 			slog.Error("app error", slog.Any("error", err))
@@ -118,6 +135,7 @@ func setupTestResources(ctx context.Context) (*TestResources, error) {
 
 	return &TestResources{
 		Store:        st,
+		Auth:         authProvider,
 		ComposeStack: composeStack,
 		DB:           db,
 		AppURL:       appURL,
@@ -26,3 +26,19 @@ func MapToWithError[S, T any](items []S, f func(S) (T, error)) ([]T, error) {
 
 	return mapped, nil
 }
+
+// Unique returns a new slice without duplicates, keeping the order of first occurrence
+func Unique[T comparable](items []T) []T {
+	seen := make(map[T]struct{}, len(items))
+	unique := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		unique = append(unique, item)
+	}
+
+	return unique
+}
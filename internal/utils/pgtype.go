@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -25,3 +27,42 @@ func PGTextFrom(text string) pgtype.Text {
 		Valid:  true,
 	}
 }
+
+func PGTimestamptzFrom(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+
+	return pgtype.Timestamptz{
+		Time:  *t,
+		Valid: true,
+	}
+}
+
+func TimeFrom(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+
+	return &ts.Time
+}
+
+func PGInt4From(i *int) pgtype.Int4 {
+	if i == nil {
+		return pgtype.Int4{}
+	}
+
+	return pgtype.Int4{
+		Int32: int32(*i), //nolint:gosec
+		Valid: true,
+	}
+}
+
+func IntFrom(i pgtype.Int4) *int {
+	if !i.Valid {
+		return nil
+	}
+
+	value := int(i.Int32)
+	return &value
+}
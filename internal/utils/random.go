@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+// codeAlphabet leaves out characters that are easily confused when read or typed (0/O, 1/I/L)
+const codeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// RandomCode returns a cryptographically random, human friendly code of the given length
+func RandomCode(length int) (string, error) {
+	code := make([]byte, length)
+	maxIndex := big.NewInt(int64(len(codeAlphabet)))
+
+	for i := range code {
+		n, err := rand.Int(rand.Reader, maxIndex)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random code: %w", err)
+		}
+		code[i] = codeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// RandomToken returns a cryptographically random, URL safe token made from the given number of
+// random bytes
+func RandomToken(numBytes int) (string, error) {
+	b := make([]byte, numBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
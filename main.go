@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -16,9 +17,11 @@ import (
 	"github.com/supanova-rp/supanova-server/internal/app"
 	"github.com/supanova-rp/supanova-server/internal/config"
 	"github.com/supanova-rp/supanova-server/internal/services/auth"
+	"github.com/supanova-rp/supanova-server/internal/services/certificate"
 	"github.com/supanova-rp/supanova-server/internal/services/email"
 	"github.com/supanova-rp/supanova-server/internal/services/metrics"
 	"github.com/supanova-rp/supanova-server/internal/services/objectstorage"
+	"github.com/supanova-rp/supanova-server/internal/services/reminders"
 	"github.com/supanova-rp/supanova-server/internal/services/secrets"
 	"github.com/supanova-rp/supanova-server/internal/store"
 )
@@ -70,7 +73,7 @@ func run() error {
 		return fmt.Errorf("failed to create object store: %v", err)
 	}
 
-	authProvider, err := auth.New(ctx, cfg.AuthProviderCredentials)
+	authProvider, err := newAuthProvider(ctx, cfg, st)
 	if err != nil {
 		return fmt.Errorf("failed to initialise auth provider: %v", err)
 	}
@@ -80,13 +83,24 @@ func run() error {
 		return fmt.Errorf("failed to initialise email service: %v", err)
 	}
 
+	if len(cfg.ClientURLs) == 0 {
+		return errors.New("reminder service needs a client URL for course links")
+	}
+
+	reminderService, err := reminders.New(cfg.Reminders, cfg.ClientURLs[0], st, emailService)
+	if err != nil {
+		return fmt.Errorf("failed to initialise reminder service: %v", err)
+	}
+	defer reminderService.Stop()
+
 	errGroup, errCtx := errgroup.WithContext(ctx)
 	errGroup.Go(func() error {
 		return app.Run(errCtx, cfg, app.Dependencies{
-			Store:         st,
-			ObjectStorage: objectStore,
-			AuthProvider:  authProvider,
-			EmailService:  emailService,
+			Store:               st,
+			ObjectStorage:       objectStore,
+			AuthProvider:        authProvider,
+			EmailService:        emailService,
+			CertificateRenderer: certificate.New(cfg.Certificates.VerifyURL),
 		})
 	})
 
@@ -99,6 +113,24 @@ func run() error {
 	return errGroup.Wait()
 }
 
+func newAuthProvider(ctx context.Context, cfg *config.App, st *store.Store) (auth.AuthProvider, error) {
+	if cfg.Auth.Provider == config.LocalAuthProvider {
+		if len(cfg.ClientURLs) == 0 {
+			return nil, errors.New("local auth provider needs a client URL for password setup links")
+		}
+
+		slog.Warn("using local auth provider, not for production use")
+		return auth.NewLocal(cfg.Auth, cfg.ClientURLs[0], st), nil
+	}
+
+	firebaseAuth, err := auth.New(ctx, cfg.Auth.FirebaseCredentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewTokenCache(firebaseAuth, cfg.Auth), nil
+}
+
 func newAWSConfig(ctx context.Context, cfg *config.AWS) (*aws.Config, error) {
 	awsCfg, err := awsConfig.LoadDefaultConfig(
 		ctx,